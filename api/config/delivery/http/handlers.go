@@ -2,22 +2,33 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
+	"sync"
 
 	"github.com/monitoror/monitoror/api/config"
 	"github.com/monitoror/monitoror/api/config/models"
 	coreModels "github.com/monitoror/monitoror/models"
 
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
 )
 
 type ConfigDelivery struct {
 	configUsecase config.Usecase
+
+	// Used to reload config loaded from path. See WatchConfigFiles
+	configWatcher  config.Watcher
+	onConfigChange func(requestURI string, revision string)
+
+	watchMutex sync.Mutex
+	// watchedFiles contains params of configs using each watched file, by request uri
+	watchedFiles map[string]map[string]models.ConfigParams
 }
 
 func NewConfigDelivery(cu config.Usecase) *ConfigDelivery {
-	return &ConfigDelivery{cu}
+	return &ConfigDelivery{configUsecase: cu}
 }
 
 func (h *ConfigDelivery) GetConfig(c echo.Context) error {
@@ -28,20 +39,125 @@ func (h *ConfigDelivery) GetConfig(c echo.Context) error {
 		return coreModels.ParamsError
 	}
 
+	configBag := h.LoadConfig(c.Request().Context(), c.Request().RequestURI, params)
+
+	c.Response().Header().Set(models.ConfigRevisionHeader, configBag.Revision)
+
+	// By default, Marshall function escape <, > and & according https://golang.org/src/encoding/json/encode.go?s=6456:6499#L48
+	// In Chromium on arm the UI code do not parse escaping character correctly
+	encoded, _ := JSONMarshal(configBag) // Ignoring error, assuming there is no function or channel inside this struct
+
+	return c.Blob(http.StatusOK, echo.MIMEApplicationJSONCharsetUTF8, encoded)
+}
+
+func (h *ConfigDelivery) GetNamedConfigs(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.configUsecase.GetNamedConfigs())
+}
+
+func (h *ConfigDelivery) GetConfigSchema(c echo.Context) error {
+	encoded, _ := JSONMarshal(h.configUsecase.GetSchema())
+	return c.Blob(http.StatusOK, echo.MIMEApplicationJSONCharsetUTF8, encoded)
+}
+
+// LoadConfig get, verify, select requested page and hydrate config. ctx is given to tile generators.
+// Files of config are watched to notify requestURI when they change (see WatchConfigFiles).
+// Also used by stream delivery to push the same config as /config
+func (h *ConfigDelivery) LoadConfig(ctx context.Context, requestURI string, params *models.ConfigParams) *models.ConfigBag {
+	configBag := h.verifyConfig(requestURI, params)
+
+	if len(configBag.Errors) == 0 {
+		h.configUsecase.Hydrate(ctx, configBag)
+	}
+
+	return configBag
+}
+
+// WatchConfig watch files of config to notify requestURI when they change, config isn't hydrated.
+// Used by clients which don't load config from this server (ex: websocket)
+func (h *ConfigDelivery) WatchConfig(requestURI string, params *models.ConfigParams) {
+	h.verifyConfig(requestURI, params)
+}
+
+// verifyConfig get, verify and select requested page of config, then watch its files for requestURI (unless empty).
+// Revision is computed before hydration, it only change with content of config and its includes
+func (h *ConfigDelivery) verifyConfig(requestURI string, params *models.ConfigParams) *models.ConfigBag {
+	// Keeping request params, GetConfig resolve named config into params
+	requestParams := *params
 	configBag := h.configUsecase.GetConfig(params)
 
 	if len(configBag.Errors) == 0 {
 		h.configUsecase.Verify(configBag)
 	}
-	if len(configBag.Errors) == 0 {
-		h.configUsecase.Hydrate(configBag)
+	if len(configBag.Errors) == 0 && params.Page != 0 {
+		h.configUsecase.SelectPage(configBag, params.Page)
 	}
 
-	// By default, Marshall function escape <, > and & according https://golang.org/src/encoding/json/encode.go?s=6456:6499#L48
-	// In Chromium on arm the UI code do not parse escaping character correctly
-	encoded, _ := JSONMarshal(configBag) // Ignoring error, assuming there is no function or channel inside this struct
+	configBag.UpdateRevision()
 
-	return c.Blob(http.StatusOK, echo.MIMEApplicationJSONCharsetUTF8, encoded)
+	// Watching config files even in error, to notify client when config is fixed
+	if h.configWatcher != nil && configBag.Config != nil && requestURI != "" {
+		h.watchConfigFiles(requestURI, requestParams, configBag.Config.Files)
+	}
+
+	return configBag
+}
+
+// WatchConfigFiles enable hot reload of config loaded from path.
+// onConfigChange is called with request uri and new revision of config each time one of its files change
+func (h *ConfigDelivery) WatchConfigFiles(watcher config.Watcher, onConfigChange func(requestURI string, revision string)) {
+	h.configWatcher = watcher
+	h.onConfigChange = onConfigChange
+	h.watchedFiles = make(map[string]map[string]models.ConfigParams)
+}
+
+// watchConfigFiles watch files (resolved path of config and its includes) used by config of requestURI
+func (h *ConfigDelivery) watchConfigFiles(requestURI string, params models.ConfigParams, files []string) {
+	h.watchMutex.Lock()
+	defer h.watchMutex.Unlock()
+
+	for _, file := range files {
+		configs, exists := h.watchedFiles[file]
+		if !exists {
+			file := file
+			if err := h.configWatcher.Watch(file, func() { h.configFileChanged(file) }); err != nil {
+				log.Warnf("unable to watch config %s, %v", file, err)
+				continue
+			}
+
+			configs = make(map[string]models.ConfigParams)
+			h.watchedFiles[file] = configs
+		}
+		configs[requestURI] = params
+	}
+}
+
+// configFileChanged verify every configs using file and notify them with their new revision.
+// Configs aren't hydrated here (no generator call), clients reload them.
+// Configs are no longer watched after notification, they are watched again when clients reload them
+func (h *ConfigDelivery) configFileChanged(file string) {
+	h.watchMutex.Lock()
+	configs := make(map[string]models.ConfigParams)
+	for requestURI, params := range h.watchedFiles[file] {
+		configs[requestURI] = params
+	}
+
+	for watchedFile, watchedConfigs := range h.watchedFiles {
+		for requestURI := range configs {
+			delete(watchedConfigs, requestURI)
+		}
+
+		if len(watchedConfigs) == 0 {
+			delete(h.watchedFiles, watchedFile)
+			h.configWatcher.Unwatch(watchedFile)
+		}
+	}
+	h.watchMutex.Unlock()
+
+	for requestURI, params := range configs {
+		params := params
+		configBag := h.verifyConfig("", &params)
+		h.onConfigChange(requestURI, configBag.Revision)
+	}
 }
 
 // JSONMarshal same as JSON.Marshall but with SetEscapeHTML(false)
@@ -38,14 +38,16 @@ func TestDelivery_ConfigHandler_Success(t *testing.T) {
 	mockUsecase.On("Hydrate", Anything, Anything)
 	handler := NewConfigDelivery(mockUsecase)
 
-	// Expected
-	json, err := json.Marshal(config)
-	assert.NoError(t, err, "unable to marshal config")
-
 	// Test
 	if assert.NoError(t, handler.GetConfig(ctx)) {
+		// Expected
+		json, err := json.Marshal(config)
+		assert.NoError(t, err, "unable to marshal config")
+
 		assert.Equal(t, http.StatusOK, res.Code)
 		assert.Equal(t, string(json), strings.TrimSpace(res.Body.String()))
+		assert.NotEmpty(t, config.Revision)
+		assert.Equal(t, config.Revision, res.Header().Get(models.ConfigRevisionHeader))
 		mockUsecase.AssertNumberOfCalls(t, "GetConfig", 1)
 		mockUsecase.AssertNumberOfCalls(t, "Verify", 1)
 		mockUsecase.AssertNumberOfCalls(t, "Hydrate", 1)
@@ -53,6 +55,29 @@ func TestDelivery_ConfigHandler_Success(t *testing.T) {
 	}
 }
 
+func TestDelivery_ConfigHandler_WithPage(t *testing.T) {
+	// Init
+	ctx, _ := initEcho()
+	ctx.QueryParams().Set("url", "monitoror.example.com")
+	ctx.QueryParams().Set("page", "2")
+
+	config := &models.ConfigBag{}
+
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("GetConfig", Anything).Return(config)
+	mockUsecase.On("Verify", Anything)
+	mockUsecase.On("SelectPage", Anything, 2)
+	mockUsecase.On("Hydrate", Anything, Anything)
+	handler := NewConfigDelivery(mockUsecase)
+
+	// Test
+	if assert.NoError(t, handler.GetConfig(ctx)) {
+		mockUsecase.AssertNumberOfCalls(t, "SelectPage", 1)
+		mockUsecase.AssertNumberOfCalls(t, "Hydrate", 1)
+		mockUsecase.AssertExpectations(t)
+	}
+}
+
 func TestDelivery_ConfigHandler_QueryParamsError(t *testing.T) {
 	// Init
 	ctx, _ := initEcho()
@@ -120,3 +145,102 @@ func TestDelivery_ConfigHandler_ErrorHydrate(t *testing.T) {
 		mockUsecase.AssertExpectations(t)
 	}
 }
+
+func TestDelivery_ConfigHandler_WatchConfigFiles(t *testing.T) {
+	// Init
+	ctx, _ := initEcho()
+	ctx.Request().RequestURI = "/api/v1/config?name=default"
+	ctx.QueryParams().Set("name", "default")
+
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("GetConfig", &models.ConfigParams{Name: "default"}).
+		Return(&models.ConfigBag{Config: &models.Config{Files: []string{"/config/default.json", "/config/include.json"}}})
+	mockUsecase.On("Verify", Anything)
+	mockUsecase.On("Hydrate", Anything, Anything)
+
+	var onChange func()
+	mockWatcher := new(mocks.Watcher)
+	mockWatcher.On("Watch", "/config/default.json", Anything).Return(nil)
+	mockWatcher.On("Watch", "/config/include.json", Anything).
+		Run(func(args Arguments) { onChange = args.Get(1).(func()) }).
+		Return(nil)
+	mockWatcher.On("Unwatch", Anything)
+
+	var changedRequestURI, changedRevision string
+	handler := NewConfigDelivery(mockUsecase)
+	handler.WatchConfigFiles(mockWatcher, func(requestURI string, revision string) {
+		changedRequestURI, changedRevision = requestURI, revision
+	})
+
+	// Test
+	if assert.NoError(t, handler.GetConfig(ctx)) && assert.NotNil(t, onChange) {
+		// Second load of same config doesn't watch files again
+		assert.NoError(t, handler.GetConfig(ctx))
+		mockWatcher.AssertNumberOfCalls(t, "Watch", 2)
+
+		onChange()
+		assert.Equal(t, "/api/v1/config?name=default", changedRequestURI)
+		assert.NotEmpty(t, changedRevision)
+		mockUsecase.AssertNumberOfCalls(t, "GetConfig", 3)
+		// Changed config is only verified, clients hydrate it when they reload it
+		mockUsecase.AssertNumberOfCalls(t, "Hydrate", 2)
+
+		// Files are no longer watched until config is loaded again
+		mockWatcher.AssertCalled(t, "Unwatch", "/config/default.json")
+		mockWatcher.AssertCalled(t, "Unwatch", "/config/include.json")
+		assert.Empty(t, handler.watchedFiles)
+	}
+}
+
+func TestDelivery_ConfigHandler_WatchConfigFiles_LoadingError(t *testing.T) {
+	// Init
+	ctx, _ := initEcho()
+	ctx.QueryParams().Set("path", "/etc/shadow")
+
+	configBag := &models.ConfigBag{}
+	configBag.AddErrors(models.ConfigError{ID: models.ConfigErrorUnauthorizedConfigPath})
+
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("GetConfig", Anything).Return(configBag)
+	mockWatcher := new(mocks.Watcher)
+
+	handler := NewConfigDelivery(mockUsecase)
+	handler.WatchConfigFiles(mockWatcher, func(string, string) {})
+
+	// Test
+	if assert.NoError(t, handler.GetConfig(ctx)) {
+		mockWatcher.AssertNotCalled(t, "Watch", Anything, Anything)
+	}
+}
+
+func TestDelivery_GetNamedConfigs(t *testing.T) {
+	// Init
+	ctx, res := initEcho()
+
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("GetNamedConfigs").Return([]models.NamedConfig{{Name: "lobby"}})
+	handler := NewConfigDelivery(mockUsecase)
+
+	// Test
+	if assert.NoError(t, handler.GetNamedConfigs(ctx)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, `[{"name":"lobby"}]`, strings.TrimSpace(res.Body.String()))
+		mockUsecase.AssertExpectations(t)
+	}
+}
+
+func TestDelivery_GetConfigSchema(t *testing.T) {
+	// Init
+	ctx, res := initEcho()
+
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("GetSchema").Return(&models.JSONSchema{Schema: models.JSONSchemaDraft, Type: "object"})
+	handler := NewConfigDelivery(mockUsecase)
+
+	// Test
+	if assert.NoError(t, handler.GetConfigSchema(ctx)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, `{"$schema":"http://json-schema.org/draft-07/schema#","type":"object"}`, strings.TrimSpace(res.Body.String()))
+		mockUsecase.AssertExpectations(t)
+	}
+}
@@ -13,15 +13,15 @@ type ParamsValidator struct {
 }
 
 // Validate provides a mock function with given fields: currentVersion
-func (_m *ParamsValidator) Validate(currentVersion *models.ConfigVersion) *models.ConfigError {
+func (_m *ParamsValidator) Validate(currentVersion *models.ConfigVersion) []models.ConfigError {
 	ret := _m.Called(currentVersion)
 
-	var r0 *models.ConfigError
-	if rf, ok := ret.Get(0).(func(*models.ConfigVersion) *models.ConfigError); ok {
+	var r0 []models.ConfigError
+	if rf, ok := ret.Get(0).(func(*models.ConfigVersion) []models.ConfigError); ok {
 		r0 = rf(currentVersion)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.ConfigError)
+			r0 = ret.Get(0).([]models.ConfigError)
 		}
 	}
 
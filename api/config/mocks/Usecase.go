@@ -3,8 +3,11 @@
 package mocks
 
 import (
-	models "github.com/monitoror/monitoror/api/config/models"
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/monitoror/monitoror/api/config/models"
 )
 
 // Usecase is an autogenerated mock type for the Usecase type
@@ -28,12 +31,49 @@ func (_m *Usecase) GetConfig(params *models.ConfigParams) *models.ConfigBag {
 	return r0
 }
 
-// Hydrate provides a mock function with given fields: _a0
-func (_m *Usecase) Hydrate(_a0 *models.ConfigBag) {
-	_m.Called(_a0)
+// GetNamedConfigs provides a mock function with given fields:
+func (_m *Usecase) GetNamedConfigs() []models.NamedConfig {
+	ret := _m.Called()
+
+	var r0 []models.NamedConfig
+	if rf, ok := ret.Get(0).(func() []models.NamedConfig); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.NamedConfig)
+		}
+	}
+
+	return r0
+}
+
+// GetSchema provides a mock function with given fields:
+func (_m *Usecase) GetSchema() *models.JSONSchema {
+	ret := _m.Called()
+
+	var r0 *models.JSONSchema
+	if rf, ok := ret.Get(0).(func() *models.JSONSchema); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JSONSchema)
+		}
+	}
+
+	return r0
+}
+
+// Hydrate provides a mock function with given fields: ctx, config
+func (_m *Usecase) Hydrate(ctx context.Context, config *models.ConfigBag) {
+	_m.Called(ctx, config)
+}
+
+// SelectPage provides a mock function with given fields: config, page
+func (_m *Usecase) SelectPage(config *models.ConfigBag, page int) {
+	_m.Called(config, page)
 }
 
-// Verify provides a mock function with given fields: _a0
-func (_m *Usecase) Verify(_a0 *models.ConfigBag) {
-	_m.Called(_a0)
+// Verify provides a mock function with given fields: config
+func (_m *Usecase) Verify(config *models.ConfigBag) {
+	_m.Called(config)
 }
@@ -1,11 +1,21 @@
 package models
 
-import coreModels "github.com/monitoror/monitoror/models"
+import (
+	"encoding/json"
+	"strings"
+
+	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/pkg/hash"
+)
+
+// ConfigRevisionHeader is used to return config revision in response header
+const ConfigRevisionHeader = "Config-Revision"
 
 type (
 	ConfigBag struct {
-		Config *Config       `json:"config,omitempty"`
-		Errors []ConfigError `json:"errors,omitempty"`
+		Config   *Config       `json:"config,omitempty"`
+		Errors   []ConfigError `json:"errors,omitempty"`
+		Revision string        `json:"revision,omitempty"`
 	}
 
 	Config struct {
@@ -13,11 +23,37 @@ type (
 		Columns *int           `json:"columns"`
 		Zoom    *float32       `json:"zoom,omitempty"`
 		Tiles   []TileConfig   `json:"tiles"`
+
+		// Pages displayed in rotation. Replace tiles, columns and zoom are used as default for pages
+		Pages []PageConfig `json:"pages,omitempty"`
+
+		// Variables used in tiles label and params with ${name}. Removed after hydration
+		Variables map[string]interface{} `json:"variables,omitempty"`
+		// Templates used as tile defaults with "template" tile field. Removed after hydration
+		Templates map[string]TileConfig `json:"templates,omitempty"`
+
+		// Path or URL of config followed by path or URL of configs including it. Used to resolve includes and detect cycles
+		Sources []string `json:"-"`
+		// Resolved paths of config files loaded from disk (config and its includes). Used to watch config changes
+		Files []string `json:"-"`
+
+		// Positions of values in config source (Sources[0]), used to locate errors
+		Positions ConfigPositions `json:"-"`
+	}
+
+	PageConfig struct {
+		Columns  *int         `json:"columns,omitempty"`
+		Zoom     *float32     `json:"zoom,omitempty"`
+		Duration *int         `json:"duration,omitempty"` // Display duration in seconds before showing next page
+		Tiles    []TileConfig `json:"tiles"`
 	}
 
 	TileConfig struct {
 		Type coreModels.TileType `json:"type"`
 
+		Template string `json:"template,omitempty"`
+		Include  string `json:"include,omitempty"` // Path or URL of config, replaced by its tiles after hydration
+
 		Label      string `json:"label,omitempty"`
 		RowSpan    *int   `json:"rowSpan,omitempty"`
 		ColumnSpan *int   `json:"columnSpan,omitempty"`
@@ -25,6 +61,7 @@ type (
 		Tiles           []TileConfig `json:"tiles,omitempty"`
 		URL             string       `json:"url,omitempty"`
 		InitialMaxDelay *int         `json:"initialMaxDelay,omitempty"`
+		RefreshInterval *int         `json:"refreshInterval,omitempty"` // In second
 
 		// Used to validate config and to create API URLs
 		// Will be removed before being returned to the UI
@@ -44,6 +81,12 @@ type (
 		Value                  string `json:"value,omitempty"`
 		FieldName              string `json:"fieldName,omitempty"`
 		Expected               string `json:"expected,omitempty"`
+
+		// Location of error in config source
+		Line    int    `json:"line,omitempty"`
+		Column  int    `json:"column,omitempty"`
+		Pointer string `json:"pointer,omitempty"` // JSON pointer (RFC 6901). Ex: /tiles/12/params/url
+		Source  string `json:"source,omitempty"`  // Path or URL of included config. Empty for main config
 	}
 
 	ConfigErrorID string
@@ -52,7 +95,9 @@ type (
 const (
 	ConfigErrorConfigNotFound                     ConfigErrorID = "ERROR_CONFIG_NOT_FOUND"
 	ConfigErrorDisabledVariant                    ConfigErrorID = "ERROR_DISABLED_VARIANT"
+	ConfigErrorFieldNotSupportedInThisVersion     ConfigErrorID = "ERROR_FIELD_NOT_SUPPORTED_IN_THIS_VERSION"
 	ConfigErrorFieldTypeMismatch                  ConfigErrorID = "ERROR_FIELD_TYPE_MISMATCH"
+	ConfigErrorIncludeCycle                       ConfigErrorID = "ERROR_INCLUDE_CYCLE"
 	ConfigErrorInvalidEscapedCharacter            ConfigErrorID = "ERROR_INVALID_ESCAPED_CHARACTER"
 	ConfigErrorInvalidFieldValue                  ConfigErrorID = "ERROR_INVALID_FIELD_VALUE"
 	ConfigErrorMissingRequiredField               ConfigErrorID = "ERROR_MISSING_REQUIRED_FIELD"
@@ -60,11 +105,15 @@ const (
 	ConfigErrorTileParamNotSupportedInThisVersion ConfigErrorID = "ERROR_TILE_PARAM_NOT_SUPPORTED_IN_THIS_VERSION"
 	ConfigErrorUnauthorizedField                  ConfigErrorID = "ERROR_UNAUTHORIZED_FIELD"
 	ConfigErrorUnauthorizedSubtileType            ConfigErrorID = "ERROR_UNAUTHORIZED_SUBTILE_TYPE"
+	ConfigErrorUndefinedVariable                  ConfigErrorID = "ERROR_UNDEFINED_VARIABLE"
 	ConfigErrorUnableToHydrate                    ConfigErrorID = "ERROR_UNABLE_TO_HYDRATE"
 	ConfigErrorUnableToParseConfig                ConfigErrorID = "ERROR_UNABLE_TO_PARSE_CONFIG"
+	ConfigErrorUnauthorizedConfigParam            ConfigErrorID = "ERROR_UNAUTHORIZED_CONFIG_PARAM"
+	ConfigErrorUnauthorizedConfigPath             ConfigErrorID = "ERROR_UNAUTHORIZED_CONFIG_PATH"
 	ConfigErrorUnexpectedError                    ConfigErrorID = "ERROR_UNEXPECTED"
 	ConfigErrorUnknownField                       ConfigErrorID = "ERROR_UNKNOWN_FIELD"
 	ConfigErrorUnknownGeneratorTileType           ConfigErrorID = "ERROR_UNKNOWN_GENERATOR_TILE_TYPE"
+	ConfigErrorUnknownTemplate                    ConfigErrorID = "ERROR_UNKNOWN_TEMPLATE"
 	ConfigErrorUnknownTileType                    ConfigErrorID = "ERROR_UNKNOWN_TILE_TYPE"
 	ConfigErrorUnknownVariant                     ConfigErrorID = "ERROR_UNKNOWN_VARIANT"
 	ConfigErrorUnsupportedVersion                 ConfigErrorID = "ERROR_UNSUPPORTED_VERSION"
@@ -73,3 +122,43 @@ const (
 func (c *ConfigBag) AddErrors(errors ...ConfigError) {
 	c.Errors = append(c.Errors, errors...)
 }
+
+// LocateErrors set line and column of errors from their JSON pointer.
+// Pointer of missing field doesn't exist in source, in this case the nearest parent is used.
+// Errors of other sources (included configs) are not located, their pointer doesn't target this source
+func (c *ConfigBag) LocateErrors() {
+	if c.Config == nil || c.Config.Positions == nil {
+		return
+	}
+
+	var source string
+	if len(c.Config.Sources) > 0 {
+		source = c.Config.Sources[0]
+	}
+
+	for i := range c.Errors {
+		data := &c.Errors[i].Data
+		if data.Pointer == "" || data.Line != 0 || (data.Source != "" && data.Source != source) {
+			continue
+		}
+
+		pointer := data.Pointer
+		for {
+			if position, found := c.Config.Positions.Find(pointer); found {
+				data.Line, data.Column = position.Line, position.Column
+				break
+			}
+			if pointer == "" {
+				break
+			}
+			pointer = pointer[:strings.LastIndex(pointer, "/")]
+		}
+	}
+}
+
+// UpdateRevision compute hash of the config bag. Used by clients to detect config changes
+func (c *ConfigBag) UpdateRevision() {
+	c.Revision = ""
+	bytes, _ := json.Marshal(c) // Ignoring error, assuming there is no function or channel inside this struct
+	c.Revision = hash.GetMD5Hash(string(bytes))
+}
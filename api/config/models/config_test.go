@@ -12,3 +12,36 @@ func TestConfig_AddErrors(t *testing.T) {
 
 	assert.Len(t, config.Errors, 1)
 }
+
+func TestConfig_UpdateRevision(t *testing.T) {
+	config := &ConfigBag{}
+	config.UpdateRevision()
+	revision := config.Revision
+	assert.NotEmpty(t, revision)
+
+	// Same content, same revision
+	config.UpdateRevision()
+	assert.Equal(t, revision, config.Revision)
+
+	config.AddErrors(ConfigError{})
+	config.UpdateRevision()
+	assert.NotEqual(t, revision, config.Revision)
+}
+
+func TestConfig_LocateErrors_OtherSource(t *testing.T) {
+	configBag := &ConfigBag{Config: &Config{
+		Sources:   []string{"main.json"},
+		Positions: ParseConfigPositions([]byte(`{"tiles": [{"type": "EMPTY"}]}`)),
+	}}
+	configBag.AddErrors(
+		ConfigError{Data: ConfigErrorData{Pointer: "/tiles/0/type"}},
+		ConfigError{Data: ConfigErrorData{Pointer: "/tiles/0/type", Source: "main.json"}},
+		ConfigError{Data: ConfigErrorData{Pointer: "/tiles/0/type", Source: "included.json"}},
+	)
+	configBag.LocateErrors()
+
+	assert.Equal(t, [2]int{1, 13}, [2]int{configBag.Errors[0].Data.Line, configBag.Errors[0].Data.Column})
+	assert.Equal(t, [2]int{1, 13}, [2]int{configBag.Errors[1].Data.Line, configBag.Errors[1].Data.Column})
+	// Pointer of included config error doesn't target main config source
+	assert.Equal(t, [2]int{0, 0}, [2]int{configBag.Errors[2].Data.Line, configBag.Errors[2].Data.Column})
+}
@@ -0,0 +1,251 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// ConfigPosition locate a value in config source. For object fields, Offset target the key
+	ConfigPosition struct {
+		Offset int
+		End    int
+		Line   int
+		Column int
+	}
+
+	// ConfigPositions index config source positions by JSON pointer (RFC 6901). Ex: /tiles/12/params/url
+	ConfigPositions map[string]ConfigPosition
+
+	positionScanner struct {
+		data       []byte
+		offset     int
+		lineStarts []int
+		positions  ConfigPositions
+	}
+)
+
+// ParseConfigPositions scan JSON source to find position of every value. Return nil if source isn't valid JSON
+func ParseConfigPositions(data []byte) ConfigPositions {
+	if !json.Valid(data) {
+		return nil
+	}
+
+	s := &positionScanner{data: data, lineStarts: []int{0}, positions: make(ConfigPositions)}
+	for i, b := range data {
+		if b == '\n' {
+			s.lineStarts = append(s.lineStarts, i+1)
+		}
+	}
+
+	s.scanValue("", -1)
+
+	return s.positions
+}
+
+// ParseYAMLConfigPositions find position of every value of YAML source from yaml.v3 nodes. Return nil if source isn't valid YAML.
+// Only start of values is known, End is equal to Offset
+func ParseYAMLConfigPositions(data []byte) ConfigPositions {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil || len(document.Content) == 0 {
+		return nil
+	}
+
+	lineStarts := []int{0}
+	for i, b := range data {
+		if b == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+
+	positions := make(ConfigPositions)
+	addYAMLPositions(positions, lineStarts, "", document.Content[0], document.Content[0])
+
+	return positions
+}
+
+// addYAMLPositions set position of node and its children. Like JSON positions, start is the key for object fields
+func addYAMLPositions(positions ConfigPositions, lineStarts []int, pointer string, start *yaml.Node, node *yaml.Node) {
+	offset := 0
+	if start.Line > 0 && start.Line <= len(lineStarts) {
+		offset = lineStarts[start.Line-1] + start.Column - 1
+	}
+	positions[pointer] = ConfigPosition{Offset: offset, End: offset, Line: start.Line, Column: start.Column}
+
+	addYAMLChildrenPositions(positions, lineStarts, pointer, node)
+}
+
+func addYAMLChildrenPositions(positions ConfigPositions, lineStarts []int, pointer string, node *yaml.Node) {
+	// Aliases are expanded in JSON, children are located in anchor definition
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		// Merged fields ("<<: *anchor") are overridden by explicit fields, whatever their order
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if key, value := node.Content[i], node.Content[i+1]; key.Tag == "!!merge" {
+				if value.Kind == yaml.SequenceNode {
+					for _, merged := range value.Content {
+						addYAMLChildrenPositions(positions, lineStarts, pointer, merged)
+					}
+				} else {
+					addYAMLChildrenPositions(positions, lineStarts, pointer, value)
+				}
+			}
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if key, value := node.Content[i], node.Content[i+1]; key.Tag != "!!merge" {
+				addYAMLPositions(positions, lineStarts, JSONPointer(pointer, key.Value), key, value)
+			}
+		}
+	case yaml.SequenceNode:
+		for index, value := range node.Content {
+			addYAMLPositions(positions, lineStarts, JSONPointer(pointer, index), value, value)
+		}
+	}
+}
+
+// Find return position of JSON pointer
+func (p ConfigPositions) Find(pointer string) (ConfigPosition, bool) {
+	position, exists := p[pointer]
+	return position, exists
+}
+
+// FindByOffset return the deepest value containing offset
+func (p ConfigPositions) FindByOffset(offset int) (pointer string, position ConfigPosition, found bool) {
+	for currentPointer, currentPosition := range p {
+		if currentPosition.Offset < offset && offset <= currentPosition.End &&
+			(!found || currentPosition.End-currentPosition.Offset < position.End-position.Offset) {
+			pointer, position, found = currentPointer, currentPosition, true
+		}
+	}
+	return
+}
+
+// FindByField return the first field (in source order) named field outside of tiles params
+func (p ConfigPositions) FindByField(field string) (pointer string, position ConfigPosition, found bool) {
+	var pointers []string
+	for currentPointer := range p {
+		if strings.HasSuffix(currentPointer, "/"+EscapeJSONPointer(field)) && !strings.Contains(currentPointer, "/params/") {
+			pointers = append(pointers, currentPointer)
+		}
+	}
+
+	if len(pointers) == 0 {
+		return
+	}
+
+	sort.Slice(pointers, func(i, j int) bool { return p[pointers[i]].Offset < p[pointers[j]].Offset })
+	return pointers[0], p[pointers[0]], true
+}
+
+// EscapeJSONPointer escape JSON pointer reference token according to RFC 6901
+func EscapeJSONPointer(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// JSONPointer join reference tokens into JSON pointer
+func JSONPointer(pointer string, tokens ...interface{}) string {
+	for _, token := range tokens {
+		pointer = fmt.Sprintf("%s/%s", pointer, EscapeJSONPointer(fmt.Sprint(token)))
+	}
+	return pointer
+}
+
+// OffsetToLineAndColumn return 1-based line and column of offset in data
+func OffsetToLineAndColumn(data []byte, offset int) (line int, column int) {
+	if offset > len(data) {
+		offset = len(data)
+	} else if offset < 0 {
+		offset = 0
+	}
+
+	line = 1 + bytes.Count(data[:offset], []byte("\n"))
+	column = offset - bytes.LastIndexByte(data[:offset], '\n')
+	return
+}
+
+// --- Scanner (data is already validated by json.Valid) ---
+func (s *positionScanner) scanValue(pointer string, start int) {
+	s.skipSpaces()
+	if start < 0 {
+		start = s.offset
+	}
+
+	switch s.data[s.offset] {
+	case '{':
+		s.offset++
+		for {
+			s.skipSpaces()
+			if s.data[s.offset] == '}' {
+				s.offset++
+				break
+			}
+			if s.data[s.offset] == ',' {
+				s.offset++
+				s.skipSpaces()
+			}
+
+			keyStart := s.offset
+			key := s.scanString()
+			s.skipSpaces()
+			s.offset++ // ':'
+			s.scanValue(JSONPointer(pointer, key), keyStart)
+		}
+	case '[':
+		s.offset++
+		for index := 0; ; index++ {
+			s.skipSpaces()
+			if s.data[s.offset] == ']' {
+				s.offset++
+				break
+			}
+			if s.data[s.offset] == ',' {
+				s.offset++
+			}
+			s.scanValue(JSONPointer(pointer, index), -1)
+		}
+	case '"':
+		s.scanString()
+	default:
+		for s.offset < len(s.data) && !strings.ContainsRune(",}] \t\r\n", rune(s.data[s.offset])) {
+			s.offset++
+		}
+	}
+
+	line, column := s.lineAndColumn(start)
+	s.positions[pointer] = ConfigPosition{Offset: start, End: s.offset, Line: line, Column: column}
+}
+
+func (s *positionScanner) scanString() string {
+	start := s.offset
+	for s.offset++; s.data[s.offset] != '"'; s.offset++ {
+		if s.data[s.offset] == '\\' {
+			s.offset++
+		}
+	}
+	s.offset++
+
+	var str string
+	_ = json.Unmarshal(s.data[start:s.offset], &str)
+	return str
+}
+
+func (s *positionScanner) skipSpaces() {
+	for s.offset < len(s.data) && strings.ContainsRune(" \t\r\n", rune(s.data[s.offset])) {
+		s.offset++
+	}
+}
+
+// lineAndColumn return 1-based line and column of offset
+func (s *positionScanner) lineAndColumn(offset int) (int, int) {
+	line := sort.Search(len(s.lineStarts), func(i int) bool { return s.lineStarts[i] > offset })
+	return line, offset - s.lineStarts[line-1] + 1
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConfigPositions(t *testing.T) {
+	input := `{
+  "version": "2.0",
+  "tiles": [
+    { "type": "EMPTY" },
+    { "type": "PING", "params": { "a/b": "~", "c": [1, 2] } }
+  ]
+}`
+
+	positions := ParseConfigPositions([]byte(input))
+
+	for pointer, expected := range map[string][2]int{
+		"":                     {1, 1},
+		"/version":             {2, 3},
+		"/tiles":               {3, 3},
+		"/tiles/0":             {4, 5},
+		"/tiles/0/type":        {4, 7},
+		"/tiles/1/params":      {5, 23},
+		"/tiles/1/params/a~1b": {5, 35},
+		"/tiles/1/params/c/1":  {5, 56},
+	} {
+		position, found := positions.Find(pointer)
+		if assert.True(t, found, pointer) {
+			assert.Equal(t, expected, [2]int{position.Line, position.Column}, pointer)
+		}
+	}
+
+	pointer, _, found := positions.FindByOffset(50)
+	assert.True(t, found)
+	assert.Equal(t, "/tiles/0/type", pointer)
+
+	pointer, _, found = positions.FindByField("type")
+	assert.True(t, found)
+	assert.Equal(t, "/tiles/0/type", pointer)
+
+	_, _, found = positions.FindByField("c")
+	assert.False(t, found)
+}
+
+func TestParseConfigPositions_InvalidJSON(t *testing.T) {
+	assert.Nil(t, ParseConfigPositions([]byte(`{"version": `)))
+}
+
+func TestParseYAMLConfigPositions(t *testing.T) {
+	input := `version: "2.0"
+defaults: &defaults
+  columnSpan: 2
+tiles:
+  - type: EMPTY
+  - <<: *defaults
+    type: PING
+    params: { a/b: "~", c: [1, 2] }
+`
+
+	positions := ParseYAMLConfigPositions([]byte(input))
+
+	for pointer, expected := range map[string][2]int{
+		"":                     {1, 1},
+		"/version":             {1, 1},
+		"/tiles":               {4, 1},
+		"/tiles/0":             {5, 5},
+		"/tiles/0/type":        {5, 5},
+		"/tiles/1/columnSpan":  {3, 3},
+		"/tiles/1/type":        {7, 5},
+		"/tiles/1/params":      {8, 5},
+		"/tiles/1/params/a~1b": {8, 15},
+		"/tiles/1/params/c/1":  {8, 32},
+	} {
+		position, found := positions.Find(pointer)
+		if assert.True(t, found, pointer) {
+			assert.Equal(t, expected, [2]int{position.Line, position.Column}, pointer)
+		}
+	}
+
+	pointer, _, found := positions.FindByField("type")
+	assert.True(t, found)
+	assert.Equal(t, "/tiles/0/type", pointer)
+}
+
+func TestParseYAMLConfigPositions_InvalidYAML(t *testing.T) {
+	assert.Nil(t, ParseYAMLConfigPositions([]byte(`version: 2.0: 3`)))
+	assert.Nil(t, ParseYAMLConfigPositions([]byte(``)))
+}
+
+func TestJSONPointer(t *testing.T) {
+	assert.Equal(t, "/tiles/12/params/a~1b~0c", JSONPointer("/tiles", 12, "params", "a/b~c"))
+}
+
+func TestOffsetToLineAndColumn(t *testing.T) {
+	line, column := OffsetToLineAndColumn([]byte("{\n  \"a\": x\n}"), 9)
+	assert.Equal(t, 2, line)
+	assert.Equal(t, 8, column)
+}
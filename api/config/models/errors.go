@@ -1,11 +1,18 @@
 package models
 
 import (
+	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 )
 
+// Based on: https://github.com/golang/go/blob/release-branch.go1.14/src/encoding/json/decode.go#L755
+const unknownFieldErrorPrefix = "json: unknown field "
+
 // ConfigFileNotFoundError
 type ConfigFileNotFoundError struct {
 	PathOrURL string
@@ -20,6 +27,16 @@ func (e *ConfigFileNotFoundError) Error() string {
 }
 func (e *ConfigFileNotFoundError) Unwrap() error { return e.Err }
 
+// ConfigPathNotAllowedError
+type ConfigPathNotAllowedError struct {
+	Path   string
+	Reason string
+}
+
+func (e *ConfigPathNotAllowedError) Error() string {
+	return fmt.Sprintf(`Config path not allowed: %s, %s`, e.Path, e.Reason)
+}
+
 // ConfigVersionFormatError
 type ConfigVersionFormatError struct {
 	WrongVersion string
@@ -33,6 +50,43 @@ func (e *ConfigVersionFormatError) Error() string {
 type ConfigUnmarshalError struct {
 	Err       error
 	RawConfig string
+
+	// Location of error in RawConfig (0 / empty if unknown)
+	Line    int
+	Column  int
+	Pointer string
+}
+
+// NewConfigUnmarshalError locate json error in raw config
+func NewConfigUnmarshalError(err error, rawConfig []byte) *ConfigUnmarshalError {
+	cue := &ConfigUnmarshalError{Err: err, RawConfig: string(rawConfig)}
+	positions := ParseConfigPositions(rawConfig)
+
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+	var versionError *ConfigVersionFormatError
+
+	if errors.As(err, &syntaxError) {
+		// Offset is given after reading invalid character
+		cue.Line, cue.Column = OffsetToLineAndColumn(rawConfig, int(syntaxError.Offset)-1)
+	} else if errors.As(err, &typeError) {
+		// Config is decoded by his own UnmarshalJSON, offset is relative to trimmed config
+		offset := int(typeError.Offset) + len(rawConfig) - len(bytes.TrimLeft(rawConfig, " \t\r\n"))
+		if pointer, position, found := positions.FindByOffset(offset); found {
+			cue.Pointer, cue.Line, cue.Column = pointer, position.Line, position.Column
+		}
+	} else if errors.As(err, &versionError) {
+		if position, found := positions.Find("/version"); found {
+			cue.Pointer, cue.Line, cue.Column = "/version", position.Line, position.Column
+		}
+	} else if strings.HasPrefix(err.Error(), unknownFieldErrorPrefix) {
+		field, _ := strconv.Unquote(strings.TrimPrefix(err.Error(), unknownFieldErrorPrefix))
+		if pointer, position, found := positions.FindByField(field); found {
+			cue.Pointer, cue.Line, cue.Column = pointer, position.Line, position.Column
+		}
+	}
+
+	return cue
 }
 
 func (e *ConfigUnmarshalError) Error() string {
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -27,3 +28,27 @@ func TestConfigUnmarshalError(t *testing.T) {
 	assert.Equal(t, "boom", err.Error())
 	assert.Equal(t, "boom", err.Unwrap().Error())
 }
+
+func TestNewConfigUnmarshalError(t *testing.T) {
+	for _, testcase := range []struct {
+		rawConfig string
+		line      int
+		column    int
+		pointer   string
+	}{
+		{rawConfig: "{\n  \"columns\": 4,\n  xxx\n}", line: 3, column: 3},
+		{rawConfig: "\n{\n  \"columns\": \"4\"\n}", line: 3, column: 3, pointer: "/columns"},
+		{rawConfig: "{\n  \"version\": 2\n}", line: 2, column: 3, pointer: "/version"},
+		{rawConfig: "{\n  \"tiles\": [\n    { \"type\": \"EMPTY\", \"unknown\": 1 }\n  ]\n}", line: 3, column: 24, pointer: "/tiles/0/unknown"},
+	} {
+		var config *Config
+		err := json.Unmarshal([]byte(testcase.rawConfig), &config)
+		if assert.Error(t, err) {
+			cue := NewConfigUnmarshalError(err, []byte(testcase.rawConfig))
+			assert.Equal(t, testcase.rawConfig, cue.RawConfig)
+			assert.Equal(t, testcase.line, cue.Line, testcase.rawConfig)
+			assert.Equal(t, testcase.column, cue.Column, testcase.rawConfig)
+			assert.Equal(t, testcase.pointer, cue.Pointer, testcase.rawConfig)
+		}
+	}
+}
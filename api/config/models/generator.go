@@ -1,7 +1,10 @@
 package models
 
+import "context"
+
 type (
-	TileGeneratorFunction func(params interface{}) ([]GeneratedTile, error)
+	// TileGeneratorFunction is called with request context, canceled when client is gone or on shutdown
+	TileGeneratorFunction func(ctx context.Context, params interface{}) ([]GeneratedTile, error)
 
 	GeneratedTile struct {
 		Label  string
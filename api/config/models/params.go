@@ -4,6 +4,15 @@ type (
 	ConfigParams struct {
 		URL  string `json:"url" query:"url"`
 		Path string `json:"path" query:"path"`
+		Name string `json:"name" query:"name"`
+
+		// Page number (starting at 1) of multi-page config to return. 0 means every pages
+		Page int `json:"page" query:"page"`
+	}
+
+	// NamedConfig is a config declared on server side, referenced by his name
+	NamedConfig struct {
+		Name string `json:"name"`
 	}
 )
 
@@ -15,5 +24,8 @@ func (p *ConfigParams) IsValid() bool {
 	if p.Path != "" {
 		count++
 	}
-	return count == 1
+	if p.Name != "" {
+		count++
+	}
+	return count == 1 && p.Page >= 0
 }
@@ -12,6 +12,12 @@ func TestConfigParams_IsValid_Success(t *testing.T) {
 
 	configParams = &ConfigParams{URL: "URL"}
 	assert.True(t, configParams.IsValid())
+
+	configParams = &ConfigParams{Name: "Name"}
+	assert.True(t, configParams.IsValid())
+
+	configParams = &ConfigParams{Name: "Name", Page: 2}
+	assert.True(t, configParams.IsValid())
 }
 
 func TestConfigParams_IsValid_Error(t *testing.T) {
@@ -20,4 +26,10 @@ func TestConfigParams_IsValid_Error(t *testing.T) {
 
 	configParams = &ConfigParams{Path: "Path", URL: "URL"}
 	assert.False(t, configParams.IsValid())
+
+	configParams = &ConfigParams{Path: "Path", Name: "Name"}
+	assert.False(t, configParams.IsValid())
+
+	configParams = &ConfigParams{Path: "Path", Page: -1}
+	assert.False(t, configParams.IsValid())
 }
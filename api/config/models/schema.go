@@ -0,0 +1,37 @@
+package models
+
+// JSONSchemaDraft is the JSON Schema specification used by config schema
+const JSONSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+type (
+	// JSONSchema is a subset of JSON Schema (draft-07) used to describe dashboard config
+	JSONSchema struct {
+		Schema      string `json:"$schema,omitempty"`
+		Ref         string `json:"$ref,omitempty"`
+		Title       string `json:"title,omitempty"`
+		Description string `json:"description,omitempty"`
+
+		Type    string        `json:"type,omitempty"`
+		Enum    []interface{} `json:"enum,omitempty"`
+		Const   interface{}   `json:"const,omitempty"`
+		Format  string        `json:"format,omitempty"`
+		Pattern string        `json:"pattern,omitempty"`
+
+		Minimum          *float64 `json:"minimum,omitempty"`
+		Maximum          *float64 `json:"maximum,omitempty"`
+		ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+
+		Items                *JSONSchema            `json:"items,omitempty"`
+		MinItems             *int                   `json:"minItems,omitempty"`
+		Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+		AdditionalProperties interface{}            `json:"additionalProperties,omitempty"` // bool or *JSONSchema
+		Required             []string               `json:"required,omitempty"`
+
+		AnyOf []*JSONSchema `json:"anyOf,omitempty"`
+		AllOf []*JSONSchema `json:"allOf,omitempty"`
+		If    *JSONSchema   `json:"if,omitempty"`
+		Then  *JSONSchema   `json:"then,omitempty"`
+
+		Definitions map[string]*JSONSchema `json:"definitions,omitempty"`
+	}
+)
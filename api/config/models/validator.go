@@ -2,6 +2,105 @@
 
 package models
 
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// AvailableTag define minimal config version of param field. Ex: `available:"since=2.1"`
+const AvailableTag = "available"
+
 type ParamsValidator interface {
-	Validate(currentVersion *ConfigVersion) *ConfigError
+	// Validate return every invalid params. FieldName of errors must be the json name of param.
+	// Pointer and ConfigExtract are set by config verify
+	Validate(currentVersion *ConfigVersion) []ConfigError
+}
+
+// NewMissingParamError is used by ParamsValidator when required param is missing
+func NewMissingParamError(fieldName string) ConfigError {
+	return ConfigError{
+		ID:      ConfigErrorMissingRequiredField,
+		Message: fmt.Sprintf(`Required %q param is missing.`, fieldName),
+		Data:    ConfigErrorData{FieldName: fieldName},
+	}
+}
+
+// NewInvalidParamError is used by ParamsValidator when param value is invalid
+func NewInvalidParamError(fieldName string, value interface{}, expected string) ConfigError {
+	bytes, _ := json.Marshal(value)
+
+	return ConfigError{
+		ID:      ConfigErrorInvalidFieldValue,
+		Message: fmt.Sprintf(`Invalid %q param. Must be %s.`, fieldName, expected),
+		Data: ConfigErrorData{
+			FieldName: fieldName,
+			Value:     string(bytes),
+			Expected:  expected,
+		},
+	}
+}
+
+// NewInvalidRegexParamError is used by ParamsValidator when param isn't a valid regex
+func NewInvalidRegexParamError(fieldName string, regex string, err error) ConfigError {
+	bytes, _ := json.Marshal(regex)
+
+	return ConfigError{
+		ID:      ConfigErrorInvalidFieldValue,
+		Message: fmt.Sprintf(`Invalid %q param. Must be a valid regular expression: %v.`, fieldName, err),
+		Data: ConfigErrorData{
+			FieldName: fieldName,
+			Value:     string(bytes),
+			Expected:  "valid regular expression",
+		},
+	}
+}
+
+// NewParamNotSupportedInThisVersionError is used by ParamsValidator when param is used with a config version lower than param minimal version
+func NewParamNotSupportedInThisVersionError(fieldName string, currentVersion *ConfigVersion, minimalVersion RawVersion) ConfigError {
+	return ConfigError{
+		ID:      ConfigErrorTileParamNotSupportedInThisVersion,
+		Message: fmt.Sprintf(`%q param is not supported in version %q. Minimal supported version is %q`, fieldName, currentVersion.ToVersion(), minimalVersion),
+		Data: ConfigErrorData{
+			FieldName: fieldName,
+			Expected:  fmt.Sprintf(`%q <= version`, minimalVersion),
+		},
+	}
+}
+
+// ValidateParamsVersion return an error for every param set with a config version lower than its minimal version.
+// Minimal version of param is defined by AvailableTag, fields of embedded structs are also checked
+func ValidateParamsVersion(params interface{}, currentVersion *ConfigVersion) []ConfigError {
+	return validateParamsVersion(reflect.Indirect(reflect.ValueOf(params)), currentVersion)
+}
+
+func validateParamsVersion(value reflect.Value, currentVersion *ConfigVersion) []ConfigError {
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var errors []ConfigError
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+
+		if field.Anonymous {
+			errors = append(errors, validateParamsVersion(reflect.Indirect(value.Field(i)), currentVersion)...)
+			continue
+		}
+
+		minimalVersion := strings.TrimPrefix(field.Tag.Get(AvailableTag), "since=")
+		if minimalVersion == "" || value.Field(i).IsZero() || !currentVersion.IsLessThan(RawVersion(minimalVersion)) {
+			continue
+		}
+
+		fieldName := strings.Split(field.Tag.Get("json"), ",")[0]
+		if fieldName == "" {
+			fieldName = field.Name
+		}
+
+		errors = append(errors, NewParamNotSupportedInThisVersionError(fieldName, currentVersion, RawVersion(minimalVersion)))
+	}
+
+	return errors
 }
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMissingParamError(t *testing.T) {
+	err := NewMissingParamError("hostname")
+	assert.Equal(t, ConfigErrorMissingRequiredField, err.ID)
+	assert.Equal(t, `Required "hostname" param is missing.`, err.Message)
+	assert.Equal(t, ConfigErrorData{FieldName: "hostname"}, err.Data)
+}
+
+func TestNewInvalidParamError(t *testing.T) {
+	err := NewInvalidParamError("sortBy", "id", `"name"`)
+	assert.Equal(t, ConfigErrorInvalidFieldValue, err.ID)
+	assert.Equal(t, `Invalid "sortBy" param. Must be "name".`, err.Message)
+	assert.Equal(t, ConfigErrorData{FieldName: "sortBy", Value: `"id"`, Expected: `"name"`}, err.Data)
+}
+
+func TestNewInvalidRegexParamError(t *testing.T) {
+	err := NewInvalidRegexParamError("regex", "(", errors.New("boom"))
+	assert.Equal(t, ConfigErrorInvalidFieldValue, err.ID)
+	assert.Equal(t, `Invalid "regex" param. Must be a valid regular expression: boom.`, err.Message)
+	assert.Equal(t, ConfigErrorData{FieldName: "regex", Value: `"("`, Expected: "valid regular expression"}, err.Data)
+}
+
+func TestNewParamNotSupportedInThisVersionError(t *testing.T) {
+	err := NewParamNotSupportedInThisVersionError("newParam", ParseVersion("2.0"), "2.1")
+	assert.Equal(t, ConfigErrorTileParamNotSupportedInThisVersion, err.ID)
+	assert.Equal(t, `"newParam" param is not supported in version "2.0". Minimal supported version is "2.1"`, err.Message)
+	assert.Equal(t, ConfigErrorData{FieldName: "newParam", Expected: `"2.1" <= version`}, err.Data)
+}
+
+type (
+	baseParams struct {
+		Hostname string `json:"hostname"`
+		Since21  string `json:"since21,omitempty" available:"since=2.1"`
+	}
+
+	versionedParams struct {
+		baseParams
+		Since22 *int `json:"since22,omitempty" available:"since=2.2"`
+		NoTag   int
+	}
+)
+
+func TestValidateParamsVersion(t *testing.T) {
+	value := 10
+	params := &versionedParams{baseParams: baseParams{Hostname: "server.com", Since21: "value"}, Since22: &value}
+
+	assert.Len(t, ValidateParamsVersion(params, ParseVersion("2.2")), 0)
+	assert.Len(t, ValidateParamsVersion(&versionedParams{}, ParseVersion("2.0")), 0)
+
+	errors := ValidateParamsVersion(params, ParseVersion("2.0"))
+	if assert.Len(t, errors, 2) {
+		assert.Equal(t, ConfigErrorTileParamNotSupportedInThisVersion, errors[0].ID)
+		assert.Equal(t, "since21", errors[0].Data.FieldName)
+		assert.Equal(t, `"2.1" <= version`, errors[0].Data.Expected)
+		assert.Equal(t, "since22", errors[1].Data.FieldName)
+	}
+
+	errors = ValidateParamsVersion(params, ParseVersion("2.1"))
+	if assert.Len(t, errors, 1) {
+		assert.Equal(t, "since22", errors[0].Data.FieldName)
+	}
+
+	assert.Nil(t, ValidateParamsVersion("not a struct", ParseVersion("2.0")))
+}
@@ -2,27 +2,98 @@ package repository
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/monitoror/monitoror/api/config/models"
 )
 
 func (cr *configRepository) GetConfigFromPath(path string) (config *models.Config, err error) {
-	file, err := os.Open(path)
+	resolvedPath, err := cr.resolvePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(resolvedPath)
 	if err != nil {
 		return nil, &models.ConfigFileNotFoundError{Err: err, PathOrURL: path}
 	}
 	defer file.Close()
 
-	config, err = ReadConfig(file)
+	if isYAMLPath(resolvedPath) {
+		config, err = ReadYAMLConfig(file)
+	} else {
+		config, err = ReadConfig(file)
+	}
 
-	// Remove RawConfig by security on GetConfigFromPath.
+	if config != nil {
+		absPath, _ := filepath.Abs(resolvedPath) // Ignoring error, resolved path was already opened
+		config.Files = []string{absPath}
+	}
+
+	// Remove RawConfig by security on GetConfigFromPath when no config root is defined.
 	// This can be leak files if monitoror as to high right on system.
-	// TODO: Remove this when directory traversal will be fix: https://github.com/monitoror/monitoror/issues/222
 	var cue *models.ConfigUnmarshalError
-	if errors.As(err, &cue) {
+	if cr.configRoot == "" && errors.As(err, &cue) {
 		cue.RawConfig = ""
 	}
 
 	return
 }
+
+// resolvePath check that path is allowed by sandbox (config root and extensions) and return it with symlinks evaluated.
+// Relative paths are resolved from config root
+func (cr *configRepository) resolvePath(path string) (string, error) {
+	resolvedPath := path
+
+	if cr.configRoot != "" {
+		root, err := filepath.Abs(cr.configRoot)
+		if err == nil {
+			root, err = filepath.EvalSymlinks(root)
+		}
+		if err != nil {
+			return "", &models.ConfigPathNotAllowedError{Path: path, Reason: fmt.Sprintf("unable to resolve config root, %v", err)}
+		}
+
+		absPath := path
+		if !filepath.IsAbs(absPath) {
+			absPath = filepath.Join(root, absPath)
+		}
+
+		// Symlinks are evaluated before checking root to avoid escaping it with a link.
+		// Same error is returned for missing files to not reveal which files exist outside of config root
+		resolvedPath, err = filepath.EvalSymlinks(absPath)
+		if err == nil {
+			relativePath, relErr := filepath.Rel(root, resolvedPath)
+			if relErr != nil || relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(filepath.Separator)) {
+				err = errors.New("outside of config root")
+			}
+		}
+		if err != nil {
+			return "", &models.ConfigPathNotAllowedError{Path: path, Reason: "path not found in config root"}
+		}
+	}
+
+	if len(cr.allowedExtensions) > 0 {
+		extension := strings.ToLower(filepath.Ext(resolvedPath))
+
+		allowed := false
+		for _, allowedExtension := range cr.allowedExtensions {
+			if extension == allowedExtension {
+				allowed = true
+				break
+			}
+		}
+
+		if !allowed {
+			return "", &models.ConfigPathNotAllowedError{
+				Path:   path,
+				Reason: fmt.Sprintf("extension must be %s", strings.Join(cr.allowedExtensions, ", ")),
+			}
+		}
+	}
+
+	return resolvedPath, nil
+}
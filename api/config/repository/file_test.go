@@ -3,6 +3,7 @@ package repository
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/monitoror/monitoror/api/config/models"
@@ -20,19 +21,33 @@ func TestConfigRepository_GetConfigFromPath(t *testing.T) {
 		defer os.Remove(tmpFile.Name())
 		_, _ = tmpFile.WriteString("{}")
 
-		repository := NewConfigRepository()
+		repository := NewConfigRepository("", nil)
 		_, err := repository.GetConfigFromPath(tmpFile.Name())
 		assert.NoError(t, err)
 	}
 }
 
+func TestConfigRepository_GetConfigFromPath_YAML(t *testing.T) {
+	tmpFile, err := ioutil.TempFile(os.TempDir(), "test-config-GetConfigFromPath-*.yaml")
+	if assert.NoError(t, err) {
+		defer os.Remove(tmpFile.Name())
+		_, _ = tmpFile.WriteString("columns: 4")
+
+		repository := NewConfigRepository("", nil)
+		config, err := repository.GetConfigFromPath(tmpFile.Name())
+		if assert.NoError(t, err) {
+			assert.Equal(t, 4, *config.Columns)
+		}
+	}
+}
+
 func TestConfigRepository_UnableToParse(t *testing.T) {
 	tmpFile, err := ioutil.TempFile(os.TempDir(), "monitoror-wrong-file")
 	if assert.NoError(t, err) {
 		defer os.Remove(tmpFile.Name())
 		_, _ = tmpFile.WriteString("xxxxxx")
 
-		repository := NewConfigRepository()
+		repository := NewConfigRepository("", nil)
 		_, err := repository.GetConfigFromPath(tmpFile.Name())
 		assert.Error(t, err)
 		assert.Equal(t, "", err.(*models.ConfigUnmarshalError).RawConfig)
@@ -40,7 +55,65 @@ func TestConfigRepository_UnableToParse(t *testing.T) {
 }
 
 func TestConfigRepository_GetConfigFromPath_MissingFile(t *testing.T) {
-	repository := NewConfigRepository()
+	repository := NewConfigRepository("", nil)
 	_, err := repository.GetConfigFromPath("monitoror-missing-file")
 	assert.Error(t, err)
 }
+
+func TestConfigRepository_GetConfigFromPath_WithConfigRoot(t *testing.T) {
+	root, err := ioutil.TempDir(os.TempDir(), "monitoror-config-root")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(root)
+
+	outside, err := ioutil.TempDir(os.TempDir(), "monitoror-config-outside")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(outside)
+
+	_ = ioutil.WriteFile(filepath.Join(root, "config.json"), []byte("{}"), 0644)
+	_ = ioutil.WriteFile(filepath.Join(root, "wrong.json"), []byte("xxxxxx"), 0644)
+	_ = ioutil.WriteFile(filepath.Join(root, "config.txt"), []byte("{}"), 0644)
+	_ = ioutil.WriteFile(filepath.Join(outside, "secret.json"), []byte("{}"), 0644)
+	_ = os.Symlink(filepath.Join(outside, "secret.json"), filepath.Join(root, "link.json"))
+
+	repository := NewConfigRepository(root, []string{"JSON"})
+
+	config, err := repository.GetConfigFromPath(filepath.Join(root, "config.json"))
+	if assert.NoError(t, err) {
+		resolvedRoot, _ := filepath.EvalSymlinks(root)
+		assert.Equal(t, []string{filepath.Join(resolvedRoot, "config.json")}, config.Files)
+	}
+
+	// RawConfig is kept when config root is defined
+	_, err = repository.GetConfigFromPath(filepath.Join(root, "wrong.json"))
+	if assert.IsType(t, &models.ConfigUnmarshalError{}, err) {
+		assert.Equal(t, "xxxxxx", err.(*models.ConfigUnmarshalError).RawConfig)
+	}
+
+	for _, path := range []string{
+		filepath.Join(outside, "secret.json"),
+		filepath.Join(root, "..", filepath.Base(outside), "secret.json"),
+		filepath.Join(root, "link.json"),
+		filepath.Join(root, "config.txt"),
+	} {
+		_, err = repository.GetConfigFromPath(path)
+		assert.IsType(t, &models.ConfigPathNotAllowedError{}, err, path)
+	}
+
+	// Relative paths are resolved from config root
+	_, err = repository.GetConfigFromPath("config.json")
+	assert.NoError(t, err)
+
+	// Missing files return same error inside and outside of config root
+	for _, path := range []string{filepath.Join(root, "missing.json"), filepath.Join(outside, "missing.json"), "missing.json"} {
+		_, err = repository.GetConfigFromPath(path)
+		if assert.IsType(t, &models.ConfigPathNotAllowedError{}, err, path) {
+			assert.Equal(t, "path not found in config root", err.(*models.ConfigPathNotAllowedError).Reason)
+		}
+	}
+	_, err = repository.GetConfigFromPath(filepath.Join(outside, "secret.json"))
+	assert.Equal(t, "path not found in config root", err.(*models.ConfigPathNotAllowedError).Reason)
+}
@@ -1,11 +1,20 @@
 package repository
 
 import (
+	"mime"
 	"net/http"
+	"net/url"
 
 	"github.com/monitoror/monitoror/api/config/models"
 )
 
+var yamlMimeTypes = map[string]bool{
+	"application/yaml":   true,
+	"application/x-yaml": true,
+	"text/yaml":          true,
+	"text/x-yaml":        true,
+}
+
 func (cr *configRepository) GetConfigFromURL(url string) (config *models.Config, err error) {
 	resp, err := cr.httpClient.Get(url)
 	if err != nil || resp.StatusCode != http.StatusOK {
@@ -13,7 +22,21 @@ func (cr *configRepository) GetConfigFromURL(url string) (config *models.Config,
 	}
 	defer resp.Body.Close()
 
-	config, err = ReadConfig(resp.Body)
+	if isYAMLResponse(url, resp) {
+		config, err = ReadYAMLConfig(resp.Body)
+	} else {
+		config, err = ReadConfig(resp.Body)
+	}
 
 	return
 }
+
+// isYAMLResponse use content type or url extension (raw file hosting often respond with text/plain)
+func isYAMLResponse(rawURL string, resp *http.Response) bool {
+	if mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err == nil && yamlMimeTypes[mediaType] {
+		return true
+	}
+
+	parsedURL, err := url.Parse(rawURL)
+	return err == nil && isYAMLPath(parsedURL.Path)
+}
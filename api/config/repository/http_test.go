@@ -19,14 +19,32 @@ func TestConfigRepository_GetConfigFromURL(t *testing.T) {
 	}))
 	defer ts.Close()
 
-	repository := NewConfigRepository()
+	repository := NewConfigRepository("", nil)
 	_, err := repository.GetConfigFromURL(ts.URL)
 	assert.NoError(t, err)
 }
 
+func TestConfigRepository_GetConfigFromURL_YAML(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/config" {
+			w.Header().Set("Content-Type", "application/yaml; charset=utf-8")
+		}
+		_, _ = fmt.Fprintln(w, "columns: 4")
+	}))
+	defer ts.Close()
+
+	repository := NewConfigRepository("", nil)
+	for _, url := range []string{ts.URL + "/config", ts.URL + "/config.yml"} {
+		config, err := repository.GetConfigFromURL(url)
+		if assert.NoError(t, err) {
+			assert.Equal(t, 4, *config.Columns)
+		}
+	}
+}
+
 // TestConfigRepository_GetConfigFromURL test if http get works
 func TestConfigRepository_GetConfigFromURL_Error(t *testing.T) {
-	repository := NewConfigRepository()
+	repository := NewConfigRepository("", nil)
 	_, err := repository.GetConfigFromURL("http://monitoror.example.com")
 	assert.Error(t, err)
 }
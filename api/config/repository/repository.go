@@ -5,20 +5,51 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/monitoror/monitoror/api/config"
 	"github.com/monitoror/monitoror/api/config/models"
+
+	"github.com/ghodss/yaml"
+	yamlv2 "gopkg.in/yaml.v2"
 )
 
+// Based on: https://github.com/go-yaml/yaml/blob/v2.2.2/yaml.go#L262
+var yamlLineRegex = regexp.MustCompile(`^yaml: line ([0-9]+):`)
+
 type (
 	configRepository struct {
 		httpClient *http.Client
+
+		// sandbox of configs loaded by path (empty means no restriction)
+		configRoot        string
+		allowedExtensions []string
 	}
 )
 
-func NewConfigRepository() config.Repository {
+func NewConfigRepository(configRoot string, allowedExtensions []string) config.Repository {
+	// Extensions are compared in lower case with leading dot
+	var extensions []string
+	for _, extension := range allowedExtensions {
+		extension = strings.ToLower(strings.TrimSpace(extension))
+		if extension == "" {
+			continue
+		}
+		if !strings.HasPrefix(extension, ".") {
+			extension = "." + extension
+		}
+		extensions = append(extensions, extension)
+	}
+
 	//TODO: Add possibility to disable SSL check?
-	return &configRepository{httpClient: http.DefaultClient}
+	return &configRepository{
+		httpClient:        http.DefaultClient,
+		configRoot:        configRoot,
+		allowedExtensions: extensions,
+	}
 }
 
 func ReadConfig(reader io.Reader) (config *models.Config, err error) {
@@ -28,8 +59,82 @@ func ReadConfig(reader io.Reader) (config *models.Config, err error) {
 	}
 
 	if err = json.Unmarshal(bytes, &config); err != nil {
-		err = &models.ConfigUnmarshalError{Err: err, RawConfig: string(bytes)}
+		return nil, models.NewConfigUnmarshalError(err, bytes)
+	}
+
+	if config != nil {
+		config.Positions = models.ParseConfigPositions(bytes)
 	}
 
 	return
 }
+
+// ReadYAMLConfig convert YAML into JSON before unmarshalling it like ReadConfig (unknown fields are still forbidden)
+// RawConfig of errors contains original YAML. Positions are read from YAML nodes, so verify errors are located in YAML source
+func ReadYAMLConfig(reader io.Reader) (config *models.Config, err error) {
+	bytes, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return
+	}
+
+	jsonBytes, err := yaml.YAMLToJSON(bytes)
+	if err != nil {
+		cue := &models.ConfigUnmarshalError{Err: err, RawConfig: string(bytes)}
+		if subMatch := yamlLineRegex.FindStringSubmatch(err.Error()); subMatch != nil {
+			cue.Line, _ = strconv.Atoi(subMatch[1])
+		}
+		return nil, cue
+	}
+
+	if jsonBytes, err = keepYAMLVersionAsString(bytes, jsonBytes); err == nil {
+		err = json.Unmarshal(jsonBytes, &config)
+	}
+
+	if err != nil {
+		// Line and column are located in converted JSON, locate JSON pointer in YAML source instead
+		cue := models.NewConfigUnmarshalError(err, jsonBytes)
+		cue.RawConfig, cue.Line, cue.Column = string(bytes), 0, 0
+		if position, found := models.ParseYAMLConfigPositions(bytes).Find(cue.Pointer); found && cue.Pointer != "" {
+			cue.Line, cue.Column = position.Line, position.Column
+		}
+		return nil, cue
+	}
+
+	if config != nil {
+		config.Positions = models.ParseYAMLConfigPositions(bytes)
+	}
+
+	return
+}
+
+// keepYAMLVersionAsString replace version in json by his YAML raw value.
+// Unquoted version (ex: version: 2.10) is parsed as number by YAML and would be converted to 2.1
+func keepYAMLVersionAsString(yamlBytes, jsonBytes []byte) ([]byte, error) {
+	var yamlVersion struct {
+		Version *string `yaml:"version"`
+	}
+	if err := yamlv2.Unmarshal(yamlBytes, &yamlVersion); err != nil || yamlVersion.Version == nil {
+		// Let json.Unmarshal return the error
+		return jsonBytes, nil
+	}
+
+	var rawConfig map[string]json.RawMessage
+	if err := json.Unmarshal(jsonBytes, &rawConfig); err != nil {
+		// Let json.Unmarshal return the error
+		return jsonBytes, nil
+	}
+
+	rawConfig["version"], _ = json.Marshal(*yamlVersion.Version)
+
+	return json.Marshal(rawConfig)
+}
+
+// isYAMLPath return true if path (or url path) ends with YAML extension
+func isYAMLPath(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	default:
+		return false
+	}
+}
@@ -11,7 +11,7 @@ import (
 )
 
 func TestNewConfigRepository(t *testing.T) {
-	assert.NotNil(t, NewConfigRepository())
+	assert.NotNil(t, NewConfigRepository("", nil))
 }
 
 func TestRepository_ReadConfig_Success(t *testing.T) {
@@ -71,6 +71,8 @@ func TestRepository_ReadConfig_Error_WrongJson(t *testing.T) {
 
 	assert.Error(t, err)
 	assert.EqualError(t, err, "invalid character 'x' looking for beginning of value")
+	assert.Equal(t, 7, err.(*models.ConfigUnmarshalError).Line)
+	assert.Equal(t, 5, err.(*models.ConfigUnmarshalError).Column)
 }
 
 func TestRepository_ReadConfig_Error_WrongJson2(t *testing.T) {
@@ -95,3 +97,88 @@ func TestRepository_ReadConfig_Error_WrongReader(t *testing.T) {
 	assert.Error(t, err)
 	assert.EqualError(t, err, "timeout")
 }
+
+func TestRepository_ReadYAMLConfig_Success(t *testing.T) {
+	input := `
+version: 2.10
+columns: 4
+tiles:
+  - type: EMPTY
+  - type: PING
+    label: "..."
+    params: { hostname: server.com }
+  - type: GROUP
+    label: "..."
+    tiles:
+      - { type: PING, params: { hostname: aserver.com } }
+      - { type: PORT, params: { hostname: bserver.com, port: 22 } }
+`
+	config, err := ReadYAMLConfig(strings.NewReader(input))
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, models.RawVersion("2.10"), config.Version.ToVersion())
+		assert.Equal(t, 4, *config.Columns)
+		assert.Len(t, config.Tiles, 3)
+		assert.Equal(t, float64(22), config.Tiles[2].Tiles[1].Params["port"])
+	}
+}
+
+func TestRepository_ReadYAMLConfig_WithPositions(t *testing.T) {
+	input := `
+version: "2.0"
+columns: 4
+tiles:
+  - type: EMPTY
+  - type: PING
+    params: { hostname: server.com }
+`
+	config, err := ReadYAMLConfig(strings.NewReader(input))
+
+	if assert.NoError(t, err) {
+		assert.NotNil(t, config.Positions)
+
+		// Errors are located in YAML source, missing field is located on its parent
+		configBag := &models.ConfigBag{Config: config}
+		configBag.AddErrors(
+			models.ConfigError{Data: models.ConfigErrorData{Pointer: "/tiles/0"}},
+			models.ConfigError{Data: models.ConfigErrorData{Pointer: "/tiles/1/params/hostname"}},
+			models.ConfigError{Data: models.ConfigErrorData{Pointer: "/tiles/1/params/port"}},
+		)
+		configBag.LocateErrors()
+		assert.Equal(t, [2]int{5, 5}, [2]int{configBag.Errors[0].Data.Line, configBag.Errors[0].Data.Column})
+		assert.Equal(t, [2]int{7, 15}, [2]int{configBag.Errors[1].Data.Line, configBag.Errors[1].Data.Column})
+		assert.Equal(t, [2]int{7, 5}, [2]int{configBag.Errors[2].Data.Line, configBag.Errors[2].Data.Column})
+	}
+}
+
+func TestRepository_ReadYAMLConfig_Error_UnknownField(t *testing.T) {
+	input := `
+version: "2.0"
+columns: 4
+tiles:
+  - type: EMPTY
+    unknown: true
+`
+	_, err := ReadYAMLConfig(strings.NewReader(input))
+
+	if assert.IsType(t, &models.ConfigUnmarshalError{}, err) {
+		assert.EqualError(t, err, `json: unknown field "unknown"`)
+		assert.Equal(t, input, err.(*models.ConfigUnmarshalError).RawConfig)
+		assert.Equal(t, "/tiles/0/unknown", err.(*models.ConfigUnmarshalError).Pointer)
+		assert.Equal(t, 6, err.(*models.ConfigUnmarshalError).Line)
+		assert.Equal(t, 5, err.(*models.ConfigUnmarshalError).Column)
+	}
+}
+
+func TestRepository_ReadYAMLConfig_Error_WrongYaml(t *testing.T) {
+	input := `
+version: "2.0"
+columns: 4: 5
+`
+	_, err := ReadYAMLConfig(strings.NewReader(input))
+
+	if assert.IsType(t, &models.ConfigUnmarshalError{}, err) {
+		assert.EqualError(t, err, "yaml: line 3: mapping values are not allowed in this context")
+		assert.Equal(t, 3, err.(*models.ConfigUnmarshalError).Line)
+	}
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/monitoror/monitoror/api/config"
+	"github.com/monitoror/monitoror/pkg/hash"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/labstack/gommon/log"
+)
+
+// DebounceDelay is used to wait for the end of file writing before reading it (editors often write file in many steps)
+var DebounceDelay = 100 * time.Millisecond
+
+type (
+	fileWatcher struct {
+		mutex sync.Mutex
+
+		// watcher is created on first Watch call
+		watcher     *fsnotify.Watcher
+		watchedDirs map[string]int // number of watched files by directory
+		files       map[string]*watchedFile
+	}
+
+	watchedFile struct {
+		hash     string
+		onChange func()
+		debounce *time.Timer
+	}
+)
+
+func NewFileWatcher() config.Watcher {
+	return &fileWatcher{
+		watchedDirs: make(map[string]int),
+		files:       make(map[string]*watchedFile),
+	}
+}
+
+func (fw *fileWatcher) Watch(path string, onChange func()) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	fw.mutex.Lock()
+	defer fw.mutex.Unlock()
+
+	if fw.watcher == nil {
+		if fw.watcher, err = fsnotify.NewWatcher(); err != nil {
+			return err
+		}
+		go fw.run(fw.watcher)
+	}
+
+	if file, exists := fw.files[absPath]; exists {
+		file.onChange = onChange
+		return nil
+	}
+
+	// Watching parent directory instead of file to handle editors replacing file on save
+	dir := filepath.Dir(absPath)
+	if fw.watchedDirs[dir] == 0 {
+		if err := fw.watcher.Add(dir); err != nil {
+			return err
+		}
+	}
+	fw.watchedDirs[dir]++
+
+	fw.files[absPath] = &watchedFile{
+		hash:     fileHash(absPath),
+		onChange: onChange,
+	}
+
+	return nil
+}
+
+func (fw *fileWatcher) Unwatch(path string) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return
+	}
+
+	fw.mutex.Lock()
+	defer fw.mutex.Unlock()
+
+	file, exists := fw.files[absPath]
+	if !exists {
+		return
+	}
+
+	if file.debounce != nil {
+		file.debounce.Stop()
+	}
+	delete(fw.files, absPath)
+
+	// Stop watching directory when it doesn't contain watched file anymore
+	dir := filepath.Dir(absPath)
+	fw.watchedDirs[dir]--
+	if fw.watchedDirs[dir] == 0 {
+		delete(fw.watchedDirs, dir)
+		_ = fw.watcher.Remove(dir)
+	}
+}
+
+func (fw *fileWatcher) run(watcher *fsnotify.Watcher) {
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			path := filepath.Clean(event.Name)
+
+			fw.mutex.Lock()
+			if file, exists := fw.files[path]; exists {
+				if file.debounce != nil {
+					file.debounce.Stop()
+				}
+				file.debounce = time.AfterFunc(DebounceDelay, func() { fw.fileChanged(path) })
+			}
+			fw.mutex.Unlock()
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Warnf("config watcher error, %v", err)
+		}
+	}
+}
+
+// fileChanged call onChange of file only if content changed
+func (fw *fileWatcher) fileChanged(path string) {
+	fw.mutex.Lock()
+	file, exists := fw.files[path]
+	if !exists {
+		// Unwatched during debounce
+		fw.mutex.Unlock()
+		return
+	}
+
+	newHash := fileHash(path)
+	if newHash == file.hash {
+		fw.mutex.Unlock()
+		return
+	}
+	file.hash = newHash
+	onChange := file.onChange
+	fw.mutex.Unlock()
+
+	onChange()
+}
+
+// fileHash return hash of file content or empty string if file can't be read
+func fileHash(path string) string {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return hash.GetMD5Hash(string(bytes))
+}
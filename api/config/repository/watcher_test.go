@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// /!\ this is an integration test /!\
+// Note : It may be necessary to separate them from unit tests
+
+func TestFileWatcher_Watch(t *testing.T) {
+	DebounceDelay = time.Millisecond * 10
+
+	tmpFile, err := ioutil.TempFile(os.TempDir(), "test-config-Watch-")
+	if assert.NoError(t, err) {
+		defer os.Remove(tmpFile.Name())
+		_, _ = tmpFile.WriteString("{}")
+		_ = tmpFile.Close()
+
+		changes := make(chan string, 10)
+		watcher := NewFileWatcher()
+		assert.NoError(t, watcher.Watch(tmpFile.Name(), func() { changes <- "replaced" }))
+		assert.NoError(t, watcher.Watch(tmpFile.Name(), func() { changes <- "change" }))
+
+		// Same content, no change
+		assert.NoError(t, ioutil.WriteFile(tmpFile.Name(), []byte("{}"), 0644))
+		select {
+		case change := <-changes:
+			assert.Fail(t, "unexpected change: "+change)
+		case <-time.After(time.Millisecond * 100):
+		}
+
+		// New content
+		assert.NoError(t, ioutil.WriteFile(tmpFile.Name(), []byte(`{"columns": 4}`), 0644))
+		select {
+		case change := <-changes:
+			assert.Equal(t, "change", change)
+		case <-time.After(time.Second):
+			assert.FailNow(t, "timeout waiting for changes")
+		}
+
+		// Unwatched file
+		watcher.Unwatch(tmpFile.Name())
+		assert.NoError(t, ioutil.WriteFile(tmpFile.Name(), []byte(`{"columns": 2}`), 0644))
+		select {
+		case change := <-changes:
+			assert.Fail(t, "unexpected change: "+change)
+		case <-time.After(time.Millisecond * 100):
+		}
+		assert.Empty(t, watcher.(*fileWatcher).files)
+		assert.Empty(t, watcher.(*fileWatcher).watchedDirs)
+	}
+}
+
+func TestFileWatcher_Watch_MissingDirectory(t *testing.T) {
+	watcher := NewFileWatcher()
+	assert.Error(t, watcher.Watch("/monitoror-missing-directory/config.json", func() {}))
+}
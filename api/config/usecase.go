@@ -3,13 +3,18 @@
 package config
 
 import (
+	"context"
+
 	"github.com/monitoror/monitoror/api/config/models"
 )
 
 type (
 	Usecase interface {
 		GetConfig(params *models.ConfigParams) *models.ConfigBag
+		GetNamedConfigs() []models.NamedConfig
 		Verify(config *models.ConfigBag)
-		Hydrate(config *models.ConfigBag)
+		SelectPage(config *models.ConfigBag, page int)
+		Hydrate(ctx context.Context, config *models.ConfigBag)
+		GetSchema() *models.JSONSchema
 	}
 )
@@ -3,6 +3,7 @@ package usecase
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/fatih/structs"
@@ -26,10 +27,59 @@ func init() {
 	invalidEscapedCharacterRegex = regexp.MustCompile(`'(.*)' in string escape code`)
 }
 
+// GetNamedConfigs return every named configs sorted by name
+func (cu *configUsecase) GetNamedConfigs() []models.NamedConfig {
+	namedConfigs := []models.NamedConfig{}
+	for name := range cu.namedConfigs {
+		namedConfigs = append(namedConfigs, models.NamedConfig{Name: name})
+	}
+
+	sort.Slice(namedConfigs, func(i, j int) bool { return namedConfigs[i].Name < namedConfigs[j].Name })
+
+	return namedConfigs
+}
+
 // GetConfig and set default value for Config from repository
+// Named config are resolved into params Path or URL
 func (cu *configUsecase) GetConfig(params *models.ConfigParams) *models.ConfigBag {
 	configBag := &models.ConfigBag{}
 
+	if params.Name != "" {
+		pathOrURL, exists := cu.namedConfigs[strings.ToLower(params.Name)]
+		if !exists {
+			var names []string
+			for _, namedConfig := range cu.GetNamedConfigs() {
+				names = append(names, namedConfig.Name)
+			}
+
+			configBag.AddErrors(models.ConfigError{
+				ID:      models.ConfigErrorConfigNotFound,
+				Message: fmt.Sprintf(`Unknown %q config name. Must be %s`, params.Name, strings.Join(names, ", ")),
+				Data: models.ConfigErrorData{
+					FieldName: "name",
+					Value:     params.Name,
+					Expected:  strings.Join(names, ", "),
+				},
+			})
+			return configBag
+		}
+
+		if isURL(pathOrURL) {
+			params.URL = pathOrURL
+		} else {
+			params.Path = pathOrURL
+		}
+	} else if cu.disableFreeFormConfig {
+		configBag.AddErrors(models.ConfigError{
+			ID:      models.ConfigErrorUnauthorizedConfigParam,
+			Message: `"path" and "url" params are disabled on this server. Use "name" param instead.`,
+			Data: models.ConfigErrorData{
+				FieldName: "name",
+			},
+		})
+		return configBag
+	}
+
 	var err error
 	if params.URL != "" {
 		configBag.Config, err = cu.repository.GetConfigFromURL(params.URL)
@@ -37,10 +87,17 @@ func (cu *configUsecase) GetConfig(params *models.ConfigParams) *models.ConfigBa
 		configBag.Config, err = cu.repository.GetConfigFromPath(params.Path)
 	}
 
-	if err == nil {
-		return configBag
+	if err != nil {
+		addRepositoryError(configBag, err)
+	} else if configBag.Config != nil {
+		configBag.Config.Sources = []string{params.URL + params.Path}
 	}
 
+	return configBag
+}
+
+// addRepositoryError convert error returned by config repository into config error
+func addRepositoryError(configBag *models.ConfigBag, err error) {
 	switch e := err.(type) {
 	case *models.ConfigFileNotFoundError:
 		configBag.AddErrors(models.ConfigError{
@@ -48,6 +105,15 @@ func (cu *configUsecase) GetConfig(params *models.ConfigParams) *models.ConfigBa
 			Message: e.Error(),
 			Data:    models.ConfigErrorData{Value: e.PathOrURL},
 		})
+	case *models.ConfigPathNotAllowedError:
+		configBag.AddErrors(models.ConfigError{
+			ID:      models.ConfigErrorUnauthorizedConfigPath,
+			Message: e.Error(),
+			Data: models.ConfigErrorData{
+				FieldName: "path",
+				Value:     e.Path,
+			},
+		})
 	case *models.ConfigVersionFormatError:
 		configBag.AddErrors(models.ConfigError{
 			ID:      models.ConfigErrorUnsupportedVersion,
@@ -135,12 +201,14 @@ func (cu *configUsecase) GetConfig(params *models.ConfigParams) *models.ConfigBa
 				},
 			})
 		}
+
+		// Locate error in config source
+		data := &configBag.Errors[len(configBag.Errors)-1].Data
+		data.Line, data.Column, data.Pointer = e.Line, e.Column, e.Pointer
 	default:
 		configBag.AddErrors(models.ConfigError{
 			ID:      models.ConfigErrorUnexpectedError,
 			Message: err.Error(),
 		})
 	}
-
-	return configBag
 }
@@ -54,6 +54,11 @@ func TestUsecase_GetConfig_WithError(t *testing.T) {
 			errorID:   models.ConfigErrorConfigNotFound,
 			errorData: models.ConfigErrorData{Value: "path"},
 		},
+		{
+			err:       &models.ConfigPathNotAllowedError{Path: "path", Reason: "boom"},
+			errorID:   models.ConfigErrorUnauthorizedConfigPath,
+			errorData: models.ConfigErrorData{FieldName: "path", Value: "path"},
+		},
 		{
 			err:     &models.ConfigVersionFormatError{WrongVersion: "18"},
 			errorID: models.ConfigErrorUnsupportedVersion,
@@ -68,15 +73,20 @@ func TestUsecase_GetConfig_WithError(t *testing.T) {
 			errorID:   models.ConfigErrorUnableToParseConfig,
 			errorData: models.ConfigErrorData{ConfigExtract: "test json"},
 		},
+		{
+			err:       &models.ConfigUnmarshalError{Err: errors.New("yaml: line 3: mapping values are not allowed in this context"), RawConfig: "test yaml"},
+			errorID:   models.ConfigErrorUnableToParseConfig,
+			errorData: models.ConfigErrorData{ConfigExtract: "test yaml"},
+		},
 		{
 			err:       &models.ConfigUnmarshalError{Err: errors.New(`json: unknown field "test"`), RawConfig: "test json"},
 			errorID:   models.ConfigErrorUnknownField,
-			errorData: models.ConfigErrorData{FieldName: "test", ConfigExtract: "test json", Expected: "version, columns, zoom, tiles, type, label, rowSpan, columnSpan, tiles, url, initialMaxDelay, params, configVariant"},
+			errorData: models.ConfigErrorData{FieldName: "test", ConfigExtract: "test json", Expected: "version, columns, zoom, tiles, pages, variables, templates, type, template, include, label, rowSpan, columnSpan, tiles, url, initialMaxDelay, refreshInterval, params, configVariant"},
 		},
 		{
-			err:       &models.ConfigUnmarshalError{Err: errors.New(`json: cannot unmarshal string into Go struct field TileConfig.tiles.test of type int`), RawConfig: "test json"},
+			err:       &models.ConfigUnmarshalError{Err: errors.New(`json: cannot unmarshal string into Go struct field TileConfig.tiles.test of type int`), RawConfig: "test json", Line: 3, Column: 5, Pointer: "/tiles/0/test"},
 			errorID:   models.ConfigErrorFieldTypeMismatch,
-			errorData: models.ConfigErrorData{FieldName: "test", ConfigExtract: "test json", Expected: "int"},
+			errorData: models.ConfigErrorData{FieldName: "test", ConfigExtract: "test json", Expected: "int", Line: 3, Column: 5, Pointer: "/tiles/0/test"},
 		},
 		{
 			err:       &models.ConfigUnmarshalError{Err: errors.New(`'\s' in string escape code`), RawConfig: "test json"},
@@ -98,3 +108,56 @@ func TestUsecase_GetConfig_WithError(t *testing.T) {
 		}
 	}
 }
+
+func TestUsecase_GetConfig_WithName_Success(t *testing.T) {
+	mockRepo := new(mocks.Repository)
+	mockRepo.On("GetConfigFromPath", "./config.json").Return(&models.Config{}, nil)
+	mockRepo.On("GetConfigFromURL", "https://monitoror.example.com/config.json").Return(&models.Config{}, nil)
+
+	usecase := initConfigUsecase(mockRepo)
+	usecase.namedConfigs = map[string]string{
+		"team_a": "./config.json",
+		"lobby":  "https://monitoror.example.com/config.json",
+	}
+
+	params := &models.ConfigParams{Name: "TEAM_A"}
+	configBag := usecase.GetConfig(params)
+	assert.Len(t, configBag.Errors, 0)
+	assert.Equal(t, "./config.json", params.Path)
+
+	params = &models.ConfigParams{Name: "lobby"}
+	configBag = usecase.GetConfig(params)
+	assert.Len(t, configBag.Errors, 0)
+	assert.Equal(t, "https://monitoror.example.com/config.json", params.URL)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUsecase_GetConfig_WithName_Unknown(t *testing.T) {
+	usecase := initConfigUsecase(nil)
+	usecase.namedConfigs = map[string]string{"team_b": "./b.json", "team_a": "./a.json"}
+
+	configBag := usecase.GetConfig(&models.ConfigParams{Name: "team_c"})
+	if assert.Len(t, configBag.Errors, 1) {
+		assert.Equal(t, models.ConfigErrorConfigNotFound, configBag.Errors[0].ID)
+		assert.Equal(t, "team_a, team_b", configBag.Errors[0].Data.Expected)
+	}
+}
+
+func TestUsecase_GetConfig_FreeFormDisabled(t *testing.T) {
+	usecase := initConfigUsecase(nil)
+	usecase.disableFreeFormConfig = true
+
+	configBag := usecase.GetConfig(&models.ConfigParams{Path: "/etc/passwd"})
+	if assert.Len(t, configBag.Errors, 1) {
+		assert.Equal(t, models.ConfigErrorUnauthorizedConfigParam, configBag.Errors[0].ID)
+	}
+}
+
+func TestUsecase_GetNamedConfigs(t *testing.T) {
+	usecase := initConfigUsecase(nil)
+	assert.Equal(t, []models.NamedConfig{}, usecase.GetNamedConfigs())
+
+	usecase.namedConfigs = map[string]string{"team_b": "./b.json", "team_a": "./a.json"}
+	assert.Equal(t, []models.NamedConfig{{Name: "team_a"}, {Name: "team_b"}}, usecase.GetNamedConfigs())
+}
@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"context"
+	"encoding/gob"
 	"encoding/json"
 	"fmt"
 	"net/url"
@@ -10,15 +12,59 @@ import (
 	"github.com/monitoror/monitoror/api/config/models"
 	coreModels "github.com/monitoror/monitoror/models"
 	"github.com/monitoror/monitoror/pkg/humanize"
+	"github.com/monitoror/monitoror/pkg/singleflight"
 )
 
-func (cu *configUsecase) Hydrate(configBag *models.ConfigBag) {
-	cu.hydrateTiles(configBag, &configBag.Config.Tiles)
+func init() {
+	// Generated tile params are stored as raw JSON in cache, gob need to know this type to use network cache store
+	gob.Register(json.RawMessage{})
 }
 
-func (cu *configUsecase) hydrateTiles(configBag *models.ConfigBag, tiles *[]models.TileConfig) {
+func (cu *configUsecase) Hydrate(ctx context.Context, configBag *models.ConfigBag) {
+	if configBag.Config.Pages != nil {
+		cu.hydratePages(ctx, configBag)
+	} else {
+		cu.hydrateTiles(ctx, configBag, &configBag.Config.Tiles)
+	}
+
+	// Variables and templates are already applied during Verify
+	configBag.Config.Variables = nil
+	configBag.Config.Templates = nil
+}
+
+// hydratePages set default columns, zoom and duration of pages and hydrate their tiles
+func (cu *configUsecase) hydratePages(ctx context.Context, configBag *models.ConfigBag) {
+	for i := range configBag.Config.Pages {
+		page := &configBag.Config.Pages[i]
+
+		if page.Columns == nil {
+			page.Columns = configBag.Config.Columns
+		}
+		if page.Zoom == nil {
+			page.Zoom = configBag.Config.Zoom
+		}
+		if page.Duration == nil {
+			duration := DefaultPageDuration
+			page.Duration = &duration
+		}
+
+		cu.hydrateTiles(ctx, configBag, &page.Tiles)
+	}
+}
+
+func (cu *configUsecase) hydrateTiles(ctx context.Context, configBag *models.ConfigBag, tiles *[]models.TileConfig) {
 	for i := 0; i < len(*tiles); i++ {
 		tile := &((*tiles)[i])
+
+		// Replace include tile by included tiles (already verified)
+		if tile.Include != "" {
+			remainingTiles := append([]models.TileConfig{}, (*tiles)[i+1:]...)
+			*tiles = append(append((*tiles)[:i], tile.Tiles...), remainingTiles...)
+
+			i--
+			continue
+		}
+
 		if tile.Type != EmptyTileType && tile.Type != GroupTileType {
 			// Set ConfigVariant to DefaultVariant if empty
 			if tile.ConfigVariant == "" {
@@ -27,14 +73,14 @@ func (cu *configUsecase) hydrateTiles(configBag *models.ConfigBag, tiles *[]mode
 		}
 
 		if _, exists := cu.registry.GeneratorMetadata[tile.Type]; !exists {
-			cu.hydrateTile(configBag, tile)
+			cu.hydrateTile(ctx, configBag, tile)
 
 			if tile.Type == GroupTileType && len(tile.Tiles) == 0 {
 				*tiles = append((*tiles)[:i], (*tiles)[i+1:]...)
 				i--
 			}
 		} else {
-			generatorTiles := cu.hydrateGeneratorTile(configBag, tile)
+			generatorTiles := cu.hydrateGeneratorTile(ctx, configBag, tile)
 
 			// Remove Generator tile config and add real generated tiles in array
 			temp := append((*tiles)[:i], generatorTiles...)
@@ -45,14 +91,14 @@ func (cu *configUsecase) hydrateTiles(configBag *models.ConfigBag, tiles *[]mode
 	}
 }
 
-func (cu *configUsecase) hydrateTile(configBag *models.ConfigBag, tile *models.TileConfig) {
+func (cu *configUsecase) hydrateTile(ctx context.Context, configBag *models.ConfigBag, tile *models.TileConfig) {
 	// Empty tile, skip
 	if tile.Type == EmptyTileType {
 		return
 	}
 
 	if tile.Type == GroupTileType {
-		cu.hydrateTiles(configBag, &tile.Tiles)
+		cu.hydrateTiles(ctx, configBag, &tile.Tiles)
 		return
 	}
 
@@ -70,6 +116,10 @@ func (cu *configUsecase) hydrateTile(configBag *models.ConfigBag, tile *models.T
 			urlParams.Add(key, humanize.Interface(value))
 		}
 	}
+	// Add refresh interval in URL to use it as upstream cache expiration of this tile
+	if tile.RefreshInterval != nil {
+		urlParams.Set(coreModels.RefreshIntervalQueryParam, fmt.Sprint(*tile.RefreshInterval))
+	}
 	tile.URL = fmt.Sprintf("%s?%s", *tileVariantMetadata.RoutePath, urlParams.Encode())
 
 	// Add initial max delay from config
@@ -80,7 +130,7 @@ func (cu *configUsecase) hydrateTile(configBag *models.ConfigBag, tile *models.T
 	tile.ConfigVariant = ""
 }
 
-func (cu *configUsecase) hydrateGeneratorTile(configBag *models.ConfigBag, tile *models.TileConfig) []models.TileConfig {
+func (cu *configUsecase) hydrateGeneratorTile(ctx context.Context, configBag *models.ConfigBag, tile *models.TileConfig) []models.TileConfig {
 	generatorMetadata := cu.registry.GeneratorMetadata[tile.Type]
 	generatorVariantMetadata := generatorMetadata.VariantsMetadata[tile.ConfigVariant]
 
@@ -92,13 +142,19 @@ func (cu *configUsecase) hydrateGeneratorTile(configBag *models.ConfigBag, tile
 	bParams, _ := json.Marshal(tile.Params)
 	_ = json.Unmarshal(bParams, &rInstance)
 
-	// Call builder and add inherited value from generator tile
+	// Call builder and add inherited value from generator tile.
+	// Call is shared with concurrent hydrations, it isn't canceled with ctx (generators apply timeout of their monitorable)
 	cacheKey := fmt.Sprintf("%s:%s_%s_%s", TileGeneratorStoreKeyPrefix, tile.Type, tile.ConfigVariant, string(bParams))
-	results, err := generatorVariantMetadata.GeneratorFunction(rInstance)
+	value, err, _ := cu.generatorCalls.Do(cacheKey, func() (interface{}, error) {
+		return generatorVariantMetadata.GeneratorFunction(singleflight.Detach(ctx, cu.baseContext), rInstance)
+	})
+	results, _ := value.([]models.GeneratedTile)
 	if err != nil {
 		if os.IsTimeout(err) {
 			// Get previous value in cache
-			if err := cu.generatorTileStore.Get(cacheKey, &results); err != nil {
+			cacheErr := cu.generatorTileStore.Get(cacheKey, &results)
+			cu.metrics.ObserveGenerator(tile.Type, tile.ConfigVariant, err, cacheErr == nil)
+			if cacheErr != nil {
 				configBag.AddErrors(models.ConfigError{
 					ID:      models.ConfigErrorUnableToHydrate,
 					Message: fmt.Sprintf(`Error while generating %s tiles (params: %s). Timeout or host unreachable`, tile.Type, string(bParams)),
@@ -108,6 +164,7 @@ func (cu *configUsecase) hydrateGeneratorTile(configBag *models.ConfigBag, tile
 				})
 			}
 		} else {
+			cu.metrics.ObserveGenerator(tile.Type, tile.ConfigVariant, err, false)
 			configBag.AddErrors(models.ConfigError{
 				ID:      models.ConfigErrorUnableToHydrate,
 				Message: fmt.Sprintf(`Error while generating %s tiles (params: %s). %v`, tile.Type, string(bParams), err),
@@ -117,19 +174,22 @@ func (cu *configUsecase) hydrateGeneratorTile(configBag *models.ConfigBag, tile
 			})
 		}
 	} else {
-		// Add result in cache
-		_ = cu.generatorTileStore.Set(cacheKey, results, cu.cacheExpiration)
+		cu.metrics.ObserveGenerator(tile.Type, tile.ConfigVariant, nil, false)
+
+		// Add result in cache, params are stored as raw JSON to be serializable by every cache store
+		_ = cu.generatorTileStore.Set(cacheKey, cacheableGeneratedTiles(results), cu.cacheExpiration)
 	}
 
 	var tiles []models.TileConfig
 	for _, result := range results {
 		newTile := models.TileConfig{
-			Type:          generatorMetadata.GeneratedTileType,
-			Label:         result.Label,
-			Params:        make(map[string]interface{}),
-			ConfigVariant: tile.ConfigVariant,
-			ColumnSpan:    tile.ColumnSpan,
-			RowSpan:       tile.RowSpan,
+			Type:            generatorMetadata.GeneratedTileType,
+			Label:           result.Label,
+			Params:          make(map[string]interface{}),
+			ConfigVariant:   tile.ConfigVariant,
+			ColumnSpan:      tile.ColumnSpan,
+			RowSpan:         tile.RowSpan,
+			RefreshInterval: tile.RefreshInterval,
 		}
 
 		// Transform Tile params struct in map[string]interface{}
@@ -146,3 +206,13 @@ func (cu *configUsecase) hydrateGeneratorTile(configBag *models.ConfigBag, tile
 
 	return tiles
 }
+
+// cacheableGeneratedTiles replace params of generated tiles by their JSON. Params are only used as JSON by hydration
+func cacheableGeneratedTiles(results []models.GeneratedTile) []models.GeneratedTile {
+	cacheable := make([]models.GeneratedTile, len(results))
+	for i, result := range results {
+		bParams, _ := json.Marshal(result.Params)
+		cacheable[i] = models.GeneratedTile{Label: result.Label, Params: json.RawMessage(bParams)}
+	}
+	return cacheable
+}
@@ -2,9 +2,13 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/monitoror/monitoror/api/config/models"
 	"github.com/monitoror/monitoror/api/config/versions"
@@ -35,15 +39,15 @@ func TestUsecase_Hydrate(t *testing.T) {
 `
 
 	usecase := initConfigUsecase(nil)
-	jenkinsTileEnabler := usecase.registry.RegisterTile(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant, "variant1"})
-	jenkinsTileEnabler.Enable(coreModels.DefaultVariant, &jenkinsModels.BuildParams{}, "/jenkins/default/build")
-	jenkinsTileEnabler.Enable("variant1", &jenkinsModels.BuildParams{}, "/jenkins/variant1/build")
+	jenkinsTileEnabler := usecase.registry.RegisterTile(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant, "variant1"}, jenkinsApi.JenkinsBuildMinimalRefreshInterval, &jenkinsModels.BuildParams{})
+	jenkinsTileEnabler.Enable(coreModels.DefaultVariant, "/jenkins/default/build")
+	jenkinsTileEnabler.Enable("variant1", "/jenkins/variant1/build")
 
 	config, err := readConfig(input)
 
 	assert.NoError(t, err)
 
-	usecase.Hydrate(config)
+	usecase.Hydrate(context.Background(), config)
 	assert.Len(t, config.Errors, 0)
 
 	assert.Equal(t, "/ping/default/ping?hostname=aserver.com&values=123&values=456", config.Config.Tiles[1].URL)
@@ -65,6 +69,31 @@ func TestUsecase_Hydrate(t *testing.T) {
 	assert.Equal(t, 1000, *config.Config.Tiles[6].InitialMaxDelay)
 }
 
+func TestUsecase_Hydrate_WithRefreshInterval(t *testing.T) {
+	input := `
+{
+  "columns": 4,
+  "tiles": [
+    { "type": "PING", "params": { "hostname": "aserver.com" } },
+    { "type": "PINGDOM-CHECK", "refreshInterval": 60, "params": { "id": 10000000 } }
+  ]
+}
+`
+
+	usecase := initConfigUsecase(nil)
+
+	config, err := readConfig(input)
+	assert.NoError(t, err)
+
+	usecase.Hydrate(context.Background(), config)
+	assert.Len(t, config.Errors, 0)
+
+	assert.Equal(t, "/ping/default/ping?hostname=aserver.com", config.Config.Tiles[0].URL)
+	assert.Nil(t, config.Config.Tiles[0].RefreshInterval)
+	assert.Equal(t, "/pingdom/default/check?id=10000000&refreshInterval=60", config.Config.Tiles[1].URL)
+	assert.Equal(t, 60, *config.Config.Tiles[1].RefreshInterval)
+}
+
 func TestUsecase_Hydrate_WithGenerator(t *testing.T) {
 	input := `
 {
@@ -85,18 +114,18 @@ func TestUsecase_Hydrate_WithGenerator(t *testing.T) {
 }
 `
 	params := &jenkinsModels.BuildParams{Job: "test"}
-	mockBuilder := func(_ interface{}) ([]models.GeneratedTile, error) {
+	mockBuilder := func(_ context.Context, _ interface{}) ([]models.GeneratedTile, error) {
 		return []models.GeneratedTile{{Params: params}}, nil
 	}
 
 	usecase := initConfigUsecase(nil)
-	usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}).
-		Enable(coreModels.DefaultVariant, &jenkinsModels.BuildGeneratorParams{}, mockBuilder)
+	usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}, &jenkinsModels.BuildGeneratorParams{}).
+		Enable(coreModels.DefaultVariant, mockBuilder)
 
 	config, err := readConfig(input)
 	assert.NoError(t, err)
 
-	usecase.Hydrate(config)
+	usecase.Hydrate(context.Background(), config)
 	assert.Len(t, config.Errors, 0)
 
 	assert.Equal(t, 4, len(config.Config.Tiles))
@@ -131,16 +160,18 @@ func TestUsecase_Hydrate_WithGeneratorEmpty(t *testing.T) {
   ]
 }
 `
-	mockBuilder := func(_ interface{}) ([]models.GeneratedTile, error) { return []models.GeneratedTile{}, nil }
+	mockBuilder := func(_ context.Context, _ interface{}) ([]models.GeneratedTile, error) {
+		return []models.GeneratedTile{}, nil
+	}
 
 	usecase := initConfigUsecase(nil)
-	usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}).
-		Enable(coreModels.DefaultVariant, &jenkinsModels.BuildGeneratorParams{}, mockBuilder)
+	usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}, &jenkinsModels.BuildGeneratorParams{}).
+		Enable(coreModels.DefaultVariant, mockBuilder)
 
 	config, err := readConfig(input)
 	assert.NoError(t, err)
 
-	usecase.Hydrate(config)
+	usecase.Hydrate(context.Background(), config)
 	assert.Len(t, config.Errors, 0)
 
 	assert.Equal(t, 2, len(config.Config.Tiles))
@@ -163,20 +194,22 @@ func TestUsecase_Hydrate_WithGenerator_WithError(t *testing.T) {
 }
 `
 	params := &jenkinsModels.BuildParams{Job: "test"}
-	mockBuilder := func(_ interface{}) ([]models.GeneratedTile, error) {
+	mockBuilder := func(_ context.Context, _ interface{}) ([]models.GeneratedTile, error) {
 		return []models.GeneratedTile{{Params: params}}, nil
 	}
-	mockBuilder2 := func(_ interface{}) ([]models.GeneratedTile, error) { return nil, errors.New("unable to find job") }
+	mockBuilder2 := func(_ context.Context, _ interface{}) ([]models.GeneratedTile, error) {
+		return nil, errors.New("unable to find job")
+	}
 
 	usecase := initConfigUsecase(nil)
-	tileGeneratorEnabler := usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant, "variant1"})
-	tileGeneratorEnabler.Enable(coreModels.DefaultVariant, &jenkinsModels.BuildGeneratorParams{}, mockBuilder)
-	tileGeneratorEnabler.Enable("variant1", &jenkinsModels.BuildGeneratorParams{}, mockBuilder2)
+	tileGeneratorEnabler := usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant, "variant1"}, &jenkinsModels.BuildGeneratorParams{})
+	tileGeneratorEnabler.Enable(coreModels.DefaultVariant, mockBuilder)
+	tileGeneratorEnabler.Enable("variant1", mockBuilder2)
 
 	config, err := readConfig(input)
 	assert.NoError(t, err)
 
-	usecase.Hydrate(config)
+	usecase.Hydrate(context.Background(), config)
 	assert.Len(t, config.Errors, 1)
 	assert.Equal(t, config.Errors[0].ID, models.ConfigErrorUnableToHydrate)
 	assert.Contains(t, config.Errors[0].Data.ConfigExtract, `GENERATE:JENKINS-BUILD`)
@@ -199,19 +232,21 @@ func TestUsecase_Hydrate_WithGenerator_WithTimeoutError(t *testing.T) {
 }
 `
 	params := &jenkinsModels.BuildParams{Job: "test"}
-	mockBuilder := func(_ interface{}) ([]models.GeneratedTile, error) {
+	mockBuilder := func(_ context.Context, _ interface{}) ([]models.GeneratedTile, error) {
 		return []models.GeneratedTile{{Params: params}}, nil
 	}
-	mockBuilder2 := func(_ interface{}) ([]models.GeneratedTile, error) { return nil, context.DeadlineExceeded }
+	mockBuilder2 := func(_ context.Context, _ interface{}) ([]models.GeneratedTile, error) {
+		return nil, context.DeadlineExceeded
+	}
 
 	usecase := initConfigUsecase(nil)
-	tileGeneratorEnabler := usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant, "variant1"})
-	tileGeneratorEnabler.Enable(coreModels.DefaultVariant, &jenkinsModels.BuildGeneratorParams{}, mockBuilder)
-	tileGeneratorEnabler.Enable("variant1", &jenkinsModels.BuildGeneratorParams{}, mockBuilder2)
+	tileGeneratorEnabler := usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant, "variant1"}, &jenkinsModels.BuildGeneratorParams{})
+	tileGeneratorEnabler.Enable(coreModels.DefaultVariant, mockBuilder)
+	tileGeneratorEnabler.Enable("variant1", mockBuilder2)
 	config, err := readConfig(input)
 	assert.NoError(t, err)
 
-	usecase.Hydrate(config)
+	usecase.Hydrate(context.Background(), config)
 	assert.Len(t, config.Errors, 1)
 	assert.Equal(t, config.Errors[0].ID, models.ConfigErrorUnableToHydrate)
 	assert.Contains(t, config.Errors[0].Data.ConfigExtract, `GENERATE:JENKINS-BUILD`)
@@ -233,15 +268,163 @@ func TestUsecase_Hydrate_WithGenerator_WithTimeoutCache(t *testing.T) {
 	cacheKey := fmt.Sprintf("%s:%s_%s_%s", TileGeneratorStoreKeyPrefix, "GENERATE:JENKINS-BUILD", "default", `{"job":"test"}`)
 	_ = usecase.generatorTileStore.Add(cacheKey, cachedResult, 0)
 
-	mockBuilder := func(_ interface{}) ([]models.GeneratedTile, error) { return nil, context.DeadlineExceeded }
-	usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}).
-		Enable(coreModels.DefaultVariant, &jenkinsModels.BuildGeneratorParams{}, mockBuilder)
+	mockBuilder := func(_ context.Context, _ interface{}) ([]models.GeneratedTile, error) {
+		return nil, context.DeadlineExceeded
+	}
+	usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}, &jenkinsModels.BuildGeneratorParams{}).
+		Enable(coreModels.DefaultVariant, mockBuilder)
 
 	config, err := readConfig(input)
 	if assert.NoError(t, err) {
-		usecase.Hydrate(config)
+		usecase.Hydrate(context.Background(), config)
 		assert.Len(t, config.Errors, 0)
 		assert.Equal(t, jenkinsApi.JenkinsBuildTileType, config.Config.Tiles[0].Type)
 		assert.Equal(t, "/jenkins/default/build?job=test", config.Config.Tiles[0].URL)
 	}
 }
+
+func TestUsecase_Hydrate_WithGenerator_CacheableResult(t *testing.T) {
+	input := `
+{
+  "columns": 4,
+  "tiles": [
+    { "type": "GENERATE:JENKINS-BUILD", "params": {"job": "test"}}
+	]
+}
+`
+	usecase := initConfigUsecase(nil)
+
+	mockBuilder := func(_ context.Context, _ interface{}) ([]models.GeneratedTile, error) {
+		return []models.GeneratedTile{{Label: "test", Params: &jenkinsModels.BuildParams{Job: "test"}}}, nil
+	}
+	usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}, &jenkinsModels.BuildGeneratorParams{}).
+		Enable(coreModels.DefaultVariant, mockBuilder)
+
+	config, err := readConfig(input)
+	if assert.NoError(t, err) {
+		usecase.Hydrate(context.Background(), config)
+		assert.Len(t, config.Errors, 0)
+	}
+
+	// Params are stored as JSON (serializable by network cache stores)
+	var cachedResult []models.GeneratedTile
+	cacheKey := fmt.Sprintf("%s:%s_%s_%s", TileGeneratorStoreKeyPrefix, "GENERATE:JENKINS-BUILD", "default", `{"job":"test"}`)
+	if assert.NoError(t, usecase.generatorTileStore.Get(cacheKey, &cachedResult)) && assert.Len(t, cachedResult, 1) {
+		assert.Equal(t, "test", cachedResult[0].Label)
+		assert.Equal(t, json.RawMessage(`{"job":"test"}`), cachedResult[0].Params)
+	}
+}
+
+func TestUsecase_Hydrate_WithGenerator_Coalesced(t *testing.T) {
+	input := `
+{
+  "columns": 4,
+  "tiles": [
+    { "type": "GENERATE:JENKINS-BUILD", "params": {"job": "test"}}
+	]
+}
+`
+	usecase := initConfigUsecase(nil)
+
+	var calls int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mockBuilder := func(_ context.Context, _ interface{}) ([]models.GeneratedTile, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			close(started)
+		}
+		<-release
+		return []models.GeneratedTile{{Label: "test", Params: &jenkinsModels.BuildParams{Job: "test"}}}, nil
+	}
+	usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}, &jenkinsModels.BuildGeneratorParams{}).
+		Enable(coreModels.DefaultVariant, mockBuilder)
+
+	// Many walls loading the same config at the same time
+	configs := make([]*models.ConfigBag, 5)
+	var wg sync.WaitGroup
+	for i := range configs {
+		config, err := readConfig(input)
+		if !assert.NoError(t, err) {
+			return
+		}
+		configs[i] = config
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			usecase.Hydrate(context.Background(), config)
+		}()
+
+		// First config call generator, others wait it
+		if i == 0 {
+			<-started
+		}
+	}
+	time.Sleep(time.Millisecond * 100)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for _, config := range configs {
+		assert.Len(t, config.Errors, 0)
+		if assert.Len(t, config.Config.Tiles, 1) {
+			assert.Equal(t, "/jenkins/default/build?job=test", config.Config.Tiles[0].URL)
+		}
+	}
+}
+
+func TestUsecase_Hydrate_WithGenerator_Coalesced_FirstContextCanceled(t *testing.T) {
+	input := `
+{
+  "columns": 4,
+  "tiles": [
+    { "type": "GENERATE:JENKINS-BUILD", "params": {"job": "test"}}
+	]
+}
+`
+	usecase := initConfigUsecase(nil)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mockBuilder := func(ctx context.Context, _ interface{}) ([]models.GeneratedTile, error) {
+		close(started)
+		<-release
+
+		// Upstream call fail when its context is canceled
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return []models.GeneratedTile{{Label: "test", Params: &jenkinsModels.BuildParams{Job: "test"}}}, nil
+	}
+	usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}, &jenkinsModels.BuildGeneratorParams{}).
+		Enable(coreModels.DefaultVariant, mockBuilder)
+
+	first, err := readConfig(input)
+	assert.NoError(t, err)
+	second, err := readConfig(input)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		usecase.Hydrate(ctx, first)
+	}()
+	<-started
+	go func() {
+		defer wg.Done()
+		usecase.Hydrate(context.Background(), second)
+	}()
+
+	// Client of first config disconnect while second config wait
+	time.Sleep(time.Millisecond * 100)
+	cancel()
+	close(release)
+	wg.Wait()
+
+	assert.Len(t, second.Errors, 0)
+	if assert.Len(t, second.Config.Tiles, 1) {
+		assert.Equal(t, "/jenkins/default/build?job=test", second.Config.Tiles[0].URL)
+	}
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/config/versions"
+)
+
+// verifyInclude load and verify included config. Tiles of included config are stored in include tile
+// and replace it during hydration. Errors of included config are located in its own source
+func (cu *configUsecase) verifyInclude(configBag *models.ConfigBag, tile *models.TileConfig, groupTile *models.TileConfig, pointer string) {
+	if configBag.Config.Version.IsLessThan(versions.Version2002) {
+		err := newFieldNotSupportedInThisVersionError(configBag, "include", models.JSONPointer(pointer, "include"), versions.Version2002)
+		err.Data.ConfigExtract = stringify(tile)
+		configBag.AddErrors(err)
+		return
+	}
+
+	if groupTile != nil {
+		configBag.AddErrors(models.ConfigError{
+			ID:      models.ConfigErrorUnauthorizedField,
+			Message: fmt.Sprintf(`Unauthorized "include" key in %s tile.`, GroupTileType),
+			Data: models.ConfigErrorData{
+				FieldName:              "include",
+				Pointer:                models.JSONPointer(pointer, "include"),
+				ConfigExtract:          stringify(groupTile),
+				ConfigExtractHighlight: stringify(tile),
+			},
+		})
+		return
+	}
+
+	if !reflect.DeepEqual(*tile, models.TileConfig{Include: tile.Include}) {
+		configBag.AddErrors(models.ConfigError{
+			ID:      models.ConfigErrorUnauthorizedField,
+			Message: `Unauthorized keys with "include" key in tile definition. Include tile must only contain "include" key.`,
+			Data: models.ConfigErrorData{
+				FieldName:     "include",
+				Pointer:       pointer,
+				ConfigExtract: stringify(tile),
+			},
+		})
+		return
+	}
+
+	source, err := resolveIncludeSource(configBag.Config.Sources, tile.Include)
+	if err != nil {
+		configBag.AddErrors(models.ConfigError{
+			ID:      models.ConfigErrorInvalidFieldValue,
+			Message: fmt.Sprintf(`Invalid "include" field. %v`, err),
+			Data: models.ConfigErrorData{
+				FieldName:     "include",
+				Pointer:       models.JSONPointer(pointer, "include"),
+				Value:         stringify(tile.Include),
+				ConfigExtract: stringify(tile),
+			},
+		})
+		return
+	}
+
+	for _, parentSource := range configBag.Config.Sources {
+		if parentSource == source {
+			chain := []string{source}
+			for _, s := range configBag.Config.Sources {
+				chain = append([]string{s}, chain...)
+			}
+
+			configBag.AddErrors(models.ConfigError{
+				ID:      models.ConfigErrorIncludeCycle,
+				Message: fmt.Sprintf(`Include cycle detected: %s`, strings.Join(chain, " -> ")),
+				Data: models.ConfigErrorData{
+					FieldName:     "include",
+					Pointer:       models.JSONPointer(pointer, "include"),
+					Value:         stringify(tile.Include),
+					ConfigExtract: stringify(tile),
+				},
+			})
+			return
+		}
+	}
+
+	includedBag := &models.ConfigBag{}
+
+	if isURL(source) {
+		includedBag.Config, err = cu.repository.GetConfigFromURL(source)
+	} else {
+		includedBag.Config, err = cu.repository.GetConfigFromPath(source)
+	}
+
+	if err != nil {
+		addRepositoryError(includedBag, err)
+	} else {
+		includedBag.Config.Sources = append([]string{source}, configBag.Config.Sources...)
+		cu.verifyIncludedConfig(includedBag)
+	}
+
+	// Nested includes errors already have their source
+	for _, includedError := range includedBag.Errors {
+		if includedError.Data.Source == "" {
+			includedError.Data.Source = source
+		}
+		configBag.AddErrors(includedError)
+	}
+
+	if includedBag.Config != nil {
+		tile.Tiles = includedBag.Config.Tiles
+		configBag.Config.Files = append(configBag.Config.Files, includedBag.Config.Files...)
+	}
+}
+
+// verifyIncludedConfig check included config. Unlike main config, only "version" and "tiles" are required
+func (cu *configUsecase) verifyIncludedConfig(configBag *models.ConfigBag) {
+	defer configBag.LocateErrors()
+
+	if !verifyVersion(configBag) {
+		return
+	}
+
+	cu.verifyVariables(configBag)
+	cu.verifyTemplates(configBag)
+	cu.verifyTiles(configBag, configBag.Config.Tiles, "")
+}
+
+// resolveIncludeSource resolve include relatively to the config including it.
+// Include of config loaded by URL is always resolved as URL, so it can't be used to read server files
+func resolveIncludeSource(sources []string, include string) (string, error) {
+	if len(sources) == 0 || sources[0] == "" {
+		return include, nil
+	}
+
+	parent := sources[0]
+	if isURL(parent) {
+		parentURL, err := url.Parse(parent)
+		if err != nil {
+			return "", err
+		}
+
+		includeURL, err := url.Parse(include)
+		if err != nil {
+			return "", err
+		}
+
+		resolvedURL := parentURL.ResolveReference(includeURL).String()
+		if !isURL(resolvedURL) {
+			return "", fmt.Errorf("config loaded by URL can only include http or https URLs")
+		}
+
+		return resolvedURL, nil
+	}
+
+	if isURL(include) || filepath.IsAbs(include) {
+		return include, nil
+	}
+
+	return filepath.Join(filepath.Dir(parent), include), nil
+}
+
+func isURL(pathOrURL string) bool {
+	return strings.HasPrefix(pathOrURL, "http://") || strings.HasPrefix(pathOrURL, "https://")
+}
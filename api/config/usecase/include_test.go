@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/config/repository"
+	"github.com/monitoror/monitoror/api/config/versions"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfigFiles(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "monitoror-include")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+
+	for name, content := range files {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0700))
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(fmt.Sprintf(content, versions.Version2002)), 0600))
+	}
+
+	return dir
+}
+
+func TestUsecase_Verify_WithInclude(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"main.json": `{"version": %q, "columns": 4, "tiles": [{ "type": "EMPTY" }, { "include": "shared/core.json" }, { "type": "EMPTY" }]}`,
+		"shared/core.json": `{"version": %q, "variables": {"host": "core.example.com"}, "tiles": [
+			{ "type": "PING", "params": { "hostname": "${host}" } },
+			{ "type": "PORT", "params": { "hostname": "${host}", "port": 443 } }
+		]}`,
+	})
+	defer os.RemoveAll(dir)
+
+	usecase := initConfigUsecase(repository.NewConfigRepository("", nil))
+
+	conf := usecase.GetConfig(&models.ConfigParams{Path: filepath.Join(dir, "main.json")})
+	if assert.Len(t, conf.Errors, 0) {
+		usecase.Verify(conf)
+		assert.Len(t, conf.Errors, 0)
+		assert.Equal(t, []string{filepath.Join(dir, "main.json"), filepath.Join(dir, "shared/core.json")}, conf.Config.Files)
+
+		usecase.Hydrate(context.Background(), conf)
+		if assert.Len(t, conf.Config.Tiles, 4) {
+			assert.Equal(t, EmptyTileType, conf.Config.Tiles[0].Type)
+			assert.Equal(t, "/ping/default/ping?hostname=core.example.com", conf.Config.Tiles[1].URL)
+			assert.Equal(t, "/port/default/port?hostname=core.example.com&port=443", conf.Config.Tiles[2].URL)
+			assert.Equal(t, EmptyTileType, conf.Config.Tiles[3].Type)
+		}
+	}
+}
+
+func TestUsecase_Verify_WithInclude_Failed(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"main.json":  `{"version": %q, "columns": 4, "tiles": [{ "include": "cycle.json" }, { "include": "error.json" }]}`,
+		"cycle.json": `{"version": %q, "tiles": [{ "include": "main.json" }]}`,
+		"error.json": `{"version": %q, "tiles": [{ "type": "PING", "params": {} }]}`,
+	})
+	defer os.RemoveAll(dir)
+
+	usecase := initConfigUsecase(repository.NewConfigRepository("", nil))
+
+	conf := usecase.GetConfig(&models.ConfigParams{Path: filepath.Join(dir, "main.json")})
+	if assert.Len(t, conf.Errors, 0) {
+		usecase.Verify(conf)
+
+		if assert.Len(t, conf.Errors, 2) {
+			assert.Equal(t, models.ConfigErrorIncludeCycle, conf.Errors[0].ID)
+			assert.Equal(t, filepath.Join(dir, "cycle.json"), conf.Errors[0].Data.Source)
+			assert.Equal(t, "/tiles/0/include", conf.Errors[0].Data.Pointer)
+
+			assert.Equal(t, models.ConfigErrorMissingRequiredField, conf.Errors[1].ID)
+			assert.Equal(t, filepath.Join(dir, "error.json"), conf.Errors[1].Data.Source)
+			assert.Equal(t, "/tiles/0/params/hostname", conf.Errors[1].Data.Pointer)
+			assert.Equal(t, 1, conf.Errors[1].Data.Line)
+			assert.Equal(t, 48, conf.Errors[1].Data.Column)
+		}
+	}
+}
+
+func TestUsecase_Verify_WithInclude_FailedInYAML(t *testing.T) {
+	dir := writeConfigFiles(t, map[string]string{
+		"main.json": `{"version": %q, "columns": 4, "tiles": [{ "type": "EMPTY" }, { "include": "error.yaml" }]}`,
+		"error.yaml": `version: %q
+tiles:
+  - type: PING
+    params: {}
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	usecase := initConfigUsecase(repository.NewConfigRepository("", nil))
+
+	conf := usecase.GetConfig(&models.ConfigParams{Path: filepath.Join(dir, "main.json")})
+	if assert.Len(t, conf.Errors, 0) {
+		usecase.Verify(conf)
+
+		// Error is located in included YAML source, not in main config
+		if assert.Len(t, conf.Errors, 1) {
+			assert.Equal(t, filepath.Join(dir, "error.yaml"), conf.Errors[0].Data.Source)
+			assert.Equal(t, "/tiles/0/params/hostname", conf.Errors[0].Data.Pointer)
+			assert.Equal(t, 4, conf.Errors[0].Data.Line)
+			assert.Equal(t, 5, conf.Errors[0].Data.Column)
+		}
+	}
+}
+
+func TestUsecase_Verify_WithInclude_InPreviousVersion(t *testing.T) {
+	conf, err := readConfig(fmt.Sprintf(`{"version": %q, "columns": 4, "tiles": [{ "include": "shared.json" }]}`, versions.Version2001))
+	if assert.NoError(t, err) {
+		usecase := initConfigUsecase(nil)
+		usecase.Verify(conf)
+
+		if assert.Len(t, conf.Errors, 1) {
+			assert.Equal(t, models.ConfigErrorFieldNotSupportedInThisVersion, conf.Errors[0].ID)
+			assert.Equal(t, "/tiles/0/include", conf.Errors[0].Data.Pointer)
+		}
+	}
+}
+
+func TestResolveIncludeSource(t *testing.T) {
+	for _, testcase := range []struct {
+		sources  []string
+		include  string
+		expected string
+		err      bool
+	}{
+		{sources: nil, include: "shared.json", expected: "shared.json"},
+		{sources: []string{"/configs/main.json"}, include: "shared.json", expected: "/configs/shared.json"},
+		{sources: []string{"/configs/main.json"}, include: "/other/shared.json", expected: "/other/shared.json"},
+		{sources: []string{"/configs/main.json"}, include: "https://example.com/shared.json", expected: "https://example.com/shared.json"},
+		{sources: []string{"https://example.com/configs/main.json"}, include: "shared.json", expected: "https://example.com/configs/shared.json"},
+		{sources: []string{"https://example.com/configs/main.json"}, include: "/etc/shared.json", expected: "https://example.com/etc/shared.json"},
+		{sources: []string{"https://example.com/configs/main.json"}, include: "file:///etc/shared.json", err: true},
+	} {
+		source, err := resolveIncludeSource(testcase.sources, testcase.include)
+		if testcase.err {
+			assert.Error(t, err)
+		} else if assert.NoError(t, err) {
+			assert.Equal(t, testcase.expected, source)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/config/versions"
+)
+
+// DefaultPageDuration is the display duration (in seconds) of pages without "duration" field
+const DefaultPageDuration = 60
+
+// verifyPages check "pages" field of config and every page definition
+func (cu *configUsecase) verifyPages(configBag *models.ConfigBag) {
+	if configBag.Config.Version.IsLessThan(versions.Version2003) {
+		configBag.AddErrors(newFieldNotSupportedInThisVersionError(configBag, "pages", "/pages", versions.Version2003))
+		return
+	}
+
+	if configBag.Config.Tiles != nil {
+		configBag.AddErrors(models.ConfigError{
+			ID:      models.ConfigErrorUnauthorizedField,
+			Message: `Unauthorized "tiles" key with "pages" key. Tiles must be defined in pages.`,
+			Data: models.ConfigErrorData{
+				FieldName: "tiles",
+				Pointer:   "/tiles",
+			},
+		})
+		return
+	}
+
+	if len(configBag.Config.Pages) == 0 {
+		configBag.AddErrors(models.ConfigError{
+			ID:      models.ConfigErrorInvalidFieldValue,
+			Message: `Invalid "pages" field. Must be a non-empty array.`,
+			Data: models.ConfigErrorData{
+				FieldName: "pages",
+				Pointer:   "/pages",
+			},
+		})
+		return
+	}
+
+	for i := range configBag.Config.Pages {
+		page := &configBag.Config.Pages[i]
+		pointer := models.JSONPointer("/pages", i)
+
+		verifyColumns(configBag, page.Columns, pointer, configBag.Config.Columns == nil)
+		verifyZoom(configBag, page.Zoom, pointer)
+
+		if page.Duration != nil && *page.Duration <= 0 {
+			configBag.AddErrors(models.ConfigError{
+				ID:      models.ConfigErrorInvalidFieldValue,
+				Message: `Invalid "duration" field. Must be a positive integer (in seconds).`,
+				Data: models.ConfigErrorData{
+					FieldName: "duration",
+					Pointer:   models.JSONPointer(pointer, "duration"),
+					Value:     stringify(page.Duration),
+					Expected:  "duration > 0",
+				},
+			})
+		}
+
+		cu.verifyTiles(configBag, page.Tiles, pointer)
+	}
+}
+
+// SelectPage keep only the page number (starting at 1) of multi-page config
+func (cu *configUsecase) SelectPage(configBag *models.ConfigBag, page int) {
+	if configBag.Config.Pages == nil {
+		configBag.AddErrors(models.ConfigError{
+			ID:      models.ConfigErrorInvalidFieldValue,
+			Message: `Invalid "page" param. This config doesn't have "pages" field.`,
+			Data: models.ConfigErrorData{
+				FieldName: "page",
+				Value:     fmt.Sprint(page),
+			},
+		})
+		return
+	}
+
+	if page < 1 || page > len(configBag.Config.Pages) {
+		configBag.AddErrors(models.ConfigError{
+			ID:      models.ConfigErrorInvalidFieldValue,
+			Message: fmt.Sprintf(`Invalid "page" param. Must be between 1 and %d.`, len(configBag.Config.Pages)),
+			Data: models.ConfigErrorData{
+				FieldName: "page",
+				Value:     fmt.Sprint(page),
+				Expected:  fmt.Sprintf("1 <= page <= %d", len(configBag.Config.Pages)),
+			},
+		})
+		return
+	}
+
+	configBag.Config.Pages = configBag.Config.Pages[page-1 : page]
+}
@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/config/versions"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsecase_Verify_WithPages(t *testing.T) {
+	rawConfig := fmt.Sprintf(`
+{
+  "version": %q,
+  "columns": 4,
+  "zoom": 1.5,
+  "pages": [
+    { "duration": 30, "tiles": [{ "type": "PING", "params": { "hostname": "server.example.com" } }] },
+    { "columns": 2, "zoom": 2, "tiles": [{ "type": "PORT", "params": { "hostname": "server.example.com", "port": 443 } }] }
+  ]
+}
+`, versions.Version2003)
+
+	conf, err := readConfig(rawConfig)
+	if assert.NoError(t, err) {
+		usecase := initConfigUsecase(nil)
+		usecase.Verify(conf)
+		assert.Len(t, conf.Errors, 0)
+
+		usecase.Hydrate(context.Background(), conf)
+		if assert.Len(t, conf.Config.Pages, 2) {
+			assert.Equal(t, 4, *conf.Config.Pages[0].Columns)
+			assert.Equal(t, float32(1.5), *conf.Config.Pages[0].Zoom)
+			assert.Equal(t, 30, *conf.Config.Pages[0].Duration)
+			assert.Equal(t, "/ping/default/ping?hostname=server.example.com", conf.Config.Pages[0].Tiles[0].URL)
+
+			assert.Equal(t, 2, *conf.Config.Pages[1].Columns)
+			assert.Equal(t, float32(2), *conf.Config.Pages[1].Zoom)
+			assert.Equal(t, DefaultPageDuration, *conf.Config.Pages[1].Duration)
+			assert.Equal(t, "/port/default/port?hostname=server.example.com&port=443", conf.Config.Pages[1].Tiles[0].URL)
+		}
+	}
+}
+
+func TestUsecase_Verify_WithPages_Failed(t *testing.T) {
+	for _, testcase := range []struct {
+		rawConfig string
+		version   models.RawVersion
+		errorID   models.ConfigErrorID
+		errorData models.ConfigErrorData
+	}{
+		{
+			rawConfig: `{"version": %q, "columns": 4, "pages": [{ "tiles": [{ "type": "EMPTY" }] }]}`,
+			version:   versions.Version2002,
+			errorID:   models.ConfigErrorFieldNotSupportedInThisVersion,
+			errorData: models.ConfigErrorData{
+				FieldName: "pages",
+				Pointer:   "/pages",
+				Line:      1,
+				Column:    34,
+				Expected:  fmt.Sprintf(`%q <= version`, versions.Version2003),
+			},
+		},
+		{
+			rawConfig: `{"version": %q, "columns": 4, "tiles": [], "pages": [{ "tiles": [{ "type": "EMPTY" }] }]}`,
+			version:   versions.Version2003,
+			errorID:   models.ConfigErrorUnauthorizedField,
+			errorData: models.ConfigErrorData{
+				FieldName: "tiles",
+				Pointer:   "/tiles",
+				Line:      1,
+				Column:    34,
+			},
+		},
+		{
+			rawConfig: `{"version": %q, "pages": [{ "tiles": [{ "type": "EMPTY" }] }]}`,
+			version:   versions.Version2003,
+			errorID:   models.ConfigErrorMissingRequiredField,
+			errorData: models.ConfigErrorData{
+				FieldName: "columns",
+				Pointer:   "/pages/0/columns",
+				Line:      1,
+				Column:    30,
+			},
+		},
+		{
+			rawConfig: `{"version": %q, "columns": 4, "pages": [{ "duration": 0, "tiles": [{ "type": "EMPTY" }] }]}`,
+			version:   versions.Version2003,
+			errorID:   models.ConfigErrorInvalidFieldValue,
+			errorData: models.ConfigErrorData{
+				FieldName: "duration",
+				Pointer:   "/pages/0/duration",
+				Line:      1,
+				Column:    46,
+				Value:     "0",
+				Expected:  "duration > 0",
+			},
+		},
+	} {
+		conf, err := readConfig(fmt.Sprintf(testcase.rawConfig, testcase.version))
+		if assert.NoError(t, err) {
+			usecase := initConfigUsecase(nil)
+			usecase.Verify(conf)
+
+			if assert.Len(t, conf.Errors, 1) {
+				assert.Equal(t, testcase.errorID, conf.Errors[0].ID)
+				assert.Equal(t, testcase.errorData, conf.Errors[0].Data)
+			}
+		}
+	}
+}
+
+func TestUsecase_SelectPage(t *testing.T) {
+	rawConfig := fmt.Sprintf(`
+{
+  "version": %q,
+  "columns": 4,
+  "pages": [
+    { "tiles": [{ "type": "EMPTY" }] },
+    { "tiles": [{ "type": "PING", "params": { "hostname": "server.example.com" } }] }
+  ]
+}
+`, versions.Version2003)
+
+	usecase := initConfigUsecase(nil)
+
+	conf, err := readConfig(rawConfig)
+	if assert.NoError(t, err) {
+		usecase.SelectPage(conf, 2)
+		assert.Len(t, conf.Errors, 0)
+		if assert.Len(t, conf.Config.Pages, 1) {
+			assert.Equal(t, "PING", string(conf.Config.Pages[0].Tiles[0].Type))
+		}
+	}
+
+	for _, page := range []int{0, 3} {
+		conf, err = readConfig(rawConfig)
+		if assert.NoError(t, err) {
+			usecase.SelectPage(conf, page)
+			if assert.Len(t, conf.Errors, 1) {
+				assert.Equal(t, models.ConfigErrorInvalidFieldValue, conf.Errors[0].ID)
+				assert.Equal(t, "1 <= page <= 2", conf.Errors[0].Data.Expected)
+			}
+		}
+	}
+
+	conf, err = readConfig(fmt.Sprintf(`{"version": %q, "columns": 4, "tiles": [{ "type": "EMPTY" }]}`, versions.Version2003))
+	if assert.NoError(t, err) {
+		usecase.SelectPage(conf, 1)
+		if assert.Len(t, conf.Errors, 1) {
+			assert.Equal(t, "page", conf.Errors[0].Data.FieldName)
+		}
+	}
+}
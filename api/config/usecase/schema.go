@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/config/versions"
+	coreModels "github.com/monitoror/monitoror/models"
+
+	"github.com/AlekSi/pointer"
+)
+
+const (
+	tileSchemaDefinition = "tile"
+	pageSchemaDefinition = "page"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// GetSchema return JSON Schema of config. Only enabled tile types and variants are described
+func (cu *configUsecase) GetSchema() *models.JSONSchema {
+	var supportedVersions []interface{}
+	for _, version := range versions.SupportedVersions {
+		supportedVersions = append(supportedVersions, version)
+	}
+
+	return &models.JSONSchema{
+		Schema:      models.JSONSchemaDraft,
+		Title:       "Monitoror config",
+		Description: "Dashboard config of Monitoror. Only tiles enabled on this instance are described.",
+		Type:        "object",
+		Properties: map[string]*models.JSONSchema{
+			"version":   {Type: "string", Enum: supportedVersions},
+			"columns":   positiveIntegerSchema(),
+			"zoom":      zoomSchema(),
+			"tiles":     tilesSchema(),
+			"pages":     {Type: "array", MinItems: pointer.ToInt(1), Items: definitionRef(pageSchemaDefinition)},
+			"variables": {Type: "object"},
+			"templates": {Type: "object", AdditionalProperties: definitionRef(tileSchemaDefinition)},
+		},
+		AdditionalProperties: false,
+		Required:             []string{"version"},
+		AnyOf: []*models.JSONSchema{
+			{Required: []string{"tiles"}},
+			{Required: []string{"pages"}},
+		},
+		Definitions: map[string]*models.JSONSchema{
+			tileSchemaDefinition: cu.tileSchema(),
+			pageSchemaDefinition: {
+				Type: "object",
+				Properties: map[string]*models.JSONSchema{
+					"columns":  positiveIntegerSchema(),
+					"zoom":     zoomSchema(),
+					"duration": positiveIntegerSchema(),
+					"tiles":    tilesSchema(),
+				},
+				AdditionalProperties: false,
+				Required:             []string{"tiles"},
+			},
+		},
+	}
+}
+
+// tileSchema describe tile definition. configVariant and params are described by tile type
+func (cu *configUsecase) tileSchema() *models.JSONSchema {
+	tileTypes := []interface{}{EmptyTileType, GroupTileType}
+	var conditions []*models.JSONSchema
+
+	var names []string
+	for tileType := range cu.registry.TileMetadata {
+		names = append(names, string(tileType))
+	}
+	for tileType := range cu.registry.GeneratorMetadata {
+		names = append(names, string(tileType))
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		tileType := coreModels.TileType(name)
+
+		validators := make(map[coreModels.VariantName]models.ParamsValidator)
+		if tileMetadata, exists := cu.registry.TileMetadata[tileType]; exists {
+			for variantName, variantMetadata := range tileMetadata.VariantsMetadata {
+				if variantMetadata.Enabled {
+					validators[variantName] = variantMetadata.ParamsValidator
+				}
+			}
+		} else {
+			for variantName, variantMetadata := range cu.registry.GeneratorMetadata[tileType].VariantsMetadata {
+				if variantMetadata.Enabled {
+					validators[variantName] = variantMetadata.GeneratorParamsValidator
+				}
+			}
+		}
+
+		// Disabled tile type, unusable in config
+		if len(validators) == 0 {
+			continue
+		}
+
+		tileTypes = append(tileTypes, tileType)
+		conditions = append(conditions, &models.JSONSchema{
+			If: &models.JSONSchema{
+				Properties: map[string]*models.JSONSchema{"type": {Const: tileType}},
+				Required:   []string{"type"},
+			},
+			Then: variantsSchema(validators),
+		})
+	}
+
+	return &models.JSONSchema{
+		Type: "object",
+		Properties: map[string]*models.JSONSchema{
+			"type":            {Type: "string", Enum: tileTypes},
+			"template":        {Type: "string"},
+			"include":         {Type: "string"},
+			"label":           {Type: "string"},
+			"rowSpan":         positiveIntegerSchema(),
+			"columnSpan":      positiveIntegerSchema(),
+			"refreshInterval": positiveIntegerSchema(),
+			"tiles":           tilesSchema(),
+			"params":          {Type: "object"},
+			"configVariant":   {Type: "string"},
+		},
+		AdditionalProperties: false,
+		AllOf:                conditions,
+	}
+}
+
+// variantsSchema describe configVariant and params of one tile type.
+// Every variant of a tile type use the same params struct, default variant is used when enabled
+func variantsSchema(validators map[coreModels.VariantName]models.ParamsValidator) *models.JSONSchema {
+	var names []string
+	for variantName := range validators {
+		names = append(names, string(variantName))
+	}
+	sort.Strings(names)
+
+	var variantNames []interface{}
+	for _, name := range names {
+		variantNames = append(variantNames, name)
+	}
+
+	validator, exists := validators[coreModels.DefaultVariant]
+	if !exists {
+		validator = validators[coreModels.VariantName(names[0])]
+	}
+
+	return &models.JSONSchema{
+		Properties: map[string]*models.JSONSchema{
+			"configVariant": {Type: "string", Enum: variantNames},
+			"params":        valueSchema(reflect.TypeOf(validator)),
+		},
+	}
+}
+
+// valueSchema describe go type with json tags of struct fields.
+// Every non-string value can also be replaced by a variable (see variables.go)
+func valueSchema(rType reflect.Type) *models.JSONSchema {
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+
+	if rType == timeType {
+		return &models.JSONSchema{Type: "string", Format: "date-time"}
+	}
+
+	switch rType.Kind() {
+	case reflect.String:
+		return &models.JSONSchema{Type: "string"}
+	case reflect.Bool:
+		return withVariable(&models.JSONSchema{Type: "boolean"})
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return withVariable(&models.JSONSchema{Type: "integer"})
+	case reflect.Float32, reflect.Float64:
+		return withVariable(&models.JSONSchema{Type: "number"})
+	case reflect.Slice, reflect.Array:
+		return withVariable(&models.JSONSchema{Type: "array", Items: valueSchema(rType.Elem())})
+	case reflect.Map:
+		return withVariable(&models.JSONSchema{Type: "object", AdditionalProperties: valueSchema(rType.Elem())})
+	case reflect.Struct:
+		schema := &models.JSONSchema{Type: "object", Properties: make(map[string]*models.JSONSchema), AdditionalProperties: false}
+		addStructProperties(schema, rType)
+		return schema
+	default:
+		return &models.JSONSchema{}
+	}
+}
+
+// addStructProperties add exported fields of struct in schema properties, embedded structs are inlined
+func addStructProperties(schema *models.JSONSchema, rType reflect.Type) {
+	for i := 0; i < rType.NumField(); i++ {
+		field := rType.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+
+		if name == "-" || (field.PkgPath != "" && !field.Anonymous) {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			fieldType := field.Type
+			for fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				addStructProperties(schema, fieldType)
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = valueSchema(field.Type)
+	}
+}
+
+func withVariable(schema *models.JSONSchema) *models.JSONSchema {
+	return &models.JSONSchema{
+		AnyOf: []*models.JSONSchema{
+			schema,
+			{Type: "string", Pattern: `^\$\{[^}]*\}$`},
+		},
+	}
+}
+
+func tilesSchema() *models.JSONSchema {
+	return &models.JSONSchema{Type: "array", Items: definitionRef(tileSchemaDefinition)}
+}
+
+func positiveIntegerSchema() *models.JSONSchema {
+	return &models.JSONSchema{Type: "integer", Minimum: pointer.ToFloat64(1)}
+}
+
+func zoomSchema() *models.JSONSchema {
+	return &models.JSONSchema{Type: "number", ExclusiveMinimum: pointer.ToFloat64(0), Maximum: pointer.ToFloat64(10)}
+}
+
+func definitionRef(definition string) *models.JSONSchema {
+	return &models.JSONSchema{Ref: "#/definitions/" + definition}
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/config/versions"
+	coreModels "github.com/monitoror/monitoror/models"
+	jenkinsApi "github.com/monitoror/monitoror/monitorables/jenkins/api"
+	jenkinsModels "github.com/monitoror/monitoror/monitorables/jenkins/api/models"
+	pingModels "github.com/monitoror/monitoror/monitorables/ping/api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsecase_GetSchema(t *testing.T) {
+	usecase := initConfigUsecase(nil)
+	usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}, &jenkinsModels.BuildGeneratorParams{}).
+		Enable(coreModels.DefaultVariant, nil)
+	usecase.registry.RegisterTile("DISABLED", versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}, 0, &pingModels.PingParams{})
+
+	schema := usecase.GetSchema()
+
+	assert.Equal(t, models.JSONSchemaDraft, schema.Schema)
+	assert.Equal(t, []string{"version"}, schema.Required)
+	assert.Len(t, schema.Properties["version"].Enum, len(versions.SupportedVersions))
+
+	tileSchema := schema.Definitions[tileSchemaDefinition]
+	assert.Equal(t, []interface{}{EmptyTileType, GroupTileType,
+		coreModels.TileType("GENERATE:JENKINS-BUILD"), jenkinsApi.JenkinsBuildTileType,
+		coreModels.TileType("PING"), coreModels.TileType("PINGDOM-CHECK"), coreModels.TileType("PORT"),
+	}, tileSchema.Properties["type"].Enum)
+
+	if assert.Len(t, tileSchema.AllOf, 5) {
+		// JENKINS-BUILD, only enabled variants are described
+		jenkinsSchema := tileSchema.AllOf[1]
+		assert.Equal(t, jenkinsApi.JenkinsBuildTileType, jenkinsSchema.If.Properties["type"].Const)
+		assert.Equal(t, []interface{}{"default"}, jenkinsSchema.Then.Properties["configVariant"].Enum)
+
+		// PORT, params described from json tags of params struct
+		portParamsSchema := tileSchema.AllOf[4].Then.Properties["params"]
+		assert.Equal(t, "object", portParamsSchema.Type)
+		assert.Equal(t, false, portParamsSchema.AdditionalProperties)
+		assert.Equal(t, "string", portParamsSchema.Properties["hostname"].Type)
+		if assert.Len(t, portParamsSchema.Properties["port"].AnyOf, 2) {
+			assert.Equal(t, "integer", portParamsSchema.Properties["port"].AnyOf[0].Type)
+		}
+	}
+
+	_, err := json.Marshal(schema)
+	assert.NoError(t, err)
+}
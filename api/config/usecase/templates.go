@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/config/versions"
+)
+
+// verifyTemplates check "templates" field of config
+func (cu *configUsecase) verifyTemplates(configBag *models.ConfigBag) {
+	if configBag.Config.Templates == nil {
+		return
+	}
+
+	if configBag.Config.Version.IsLessThan(versions.Version2002) {
+		configBag.AddErrors(newFieldNotSupportedInThisVersionError(configBag, "templates", "/templates", versions.Version2002))
+		return
+	}
+
+	var names []string
+	for name := range configBag.Config.Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		template := configBag.Config.Templates[name]
+
+		// Templates can't be nested or include other configs
+		for field, value := range map[string]string{"template": template.Template, "include": template.Include} {
+			if value != "" {
+				configBag.AddErrors(models.ConfigError{
+					ID:      models.ConfigErrorUnauthorizedField,
+					Message: fmt.Sprintf(`Unauthorized %q key in template definition.`, field),
+					Data: models.ConfigErrorData{
+						FieldName:     field,
+						Pointer:       models.JSONPointer("/templates", name, field),
+						ConfigExtract: stringify(template),
+					},
+				})
+			}
+		}
+	}
+}
+
+// applyTemplate merge template into tile. Fields defined in tile override template ones, params are merged by key.
+// Return false if template can't be applied
+func (cu *configUsecase) applyTemplate(configBag *models.ConfigBag, tile *models.TileConfig, pointer string) bool {
+	if configBag.Config.Version.IsLessThan(versions.Version2002) {
+		err := newFieldNotSupportedInThisVersionError(configBag, "template", models.JSONPointer(pointer, "template"), versions.Version2002)
+		err.Data.ConfigExtract = stringify(tile)
+		configBag.AddErrors(err)
+		return false
+	}
+
+	template, exists := configBag.Config.Templates[tile.Template]
+	if !exists {
+		configBag.AddErrors(models.ConfigError{
+			ID:      models.ConfigErrorUnknownTemplate,
+			Message: fmt.Sprintf(`Unknown %q template in tile definition. Must be %s`, tile.Template, keys(configBag.Config.Templates)),
+			Data: models.ConfigErrorData{
+				FieldName:     "template",
+				Pointer:       models.JSONPointer(pointer, "template"),
+				Value:         stringify(tile.Template),
+				Expected:      keys(configBag.Config.Templates),
+				ConfigExtract: stringify(tile),
+			},
+		})
+		return false
+	}
+
+	if template.Template != "" || template.Include != "" {
+		// Already reported by verifyTemplates
+		return false
+	}
+
+	if tile.Type == "" {
+		tile.Type = template.Type
+	}
+	if tile.Label == "" {
+		tile.Label = template.Label
+	}
+	if tile.RowSpan == nil {
+		tile.RowSpan = template.RowSpan
+	}
+	if tile.ColumnSpan == nil {
+		tile.ColumnSpan = template.ColumnSpan
+	}
+	if tile.Tiles == nil && template.Tiles != nil {
+		tile.Tiles = append([]models.TileConfig{}, template.Tiles...)
+	}
+	if tile.InitialMaxDelay == nil {
+		tile.InitialMaxDelay = template.InitialMaxDelay
+	}
+	if tile.RefreshInterval == nil {
+		tile.RefreshInterval = template.RefreshInterval
+	}
+	if tile.ConfigVariant == "" {
+		tile.ConfigVariant = template.ConfigVariant
+	}
+
+	if template.Params != nil {
+		params := make(map[string]interface{})
+		for key, value := range template.Params {
+			params[key] = value
+		}
+		for key, value := range tile.Params {
+			params[key] = value
+		}
+		tile.Params = params
+	}
+
+	tile.Template = ""
+
+	return true
+}
+
+func newFieldNotSupportedInThisVersionError(configBag *models.ConfigBag, field, pointer string, minimalVersion models.RawVersion) models.ConfigError {
+	return models.ConfigError{
+		ID: models.ConfigErrorFieldNotSupportedInThisVersion,
+		Message: fmt.Sprintf(`%q field is not supported in version %q. Minimal supported version is %q`,
+			field, configBag.Config.Version, minimalVersion),
+		Data: models.ConfigErrorData{
+			FieldName: field,
+			Pointer:   pointer,
+			Expected:  fmt.Sprintf(`%q <= version`, minimalVersion),
+		},
+	}
+}
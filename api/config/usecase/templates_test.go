@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/config/versions"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsecase_Verify_WithTemplates(t *testing.T) {
+	rawConfig := fmt.Sprintf(`
+{
+  "version": %q,
+  "columns": 4,
+  "variables": { "domain": "example.com" },
+  "templates": {
+    "port": { "type": "PORT", "columnSpan": 2, "params": { "hostname": "server.${domain}", "port": 80 } }
+  },
+  "tiles": [
+    { "template": "port", "label": "http" },
+    { "template": "port", "label": "https", "columnSpan": 1, "params": { "port": 443 } }
+  ]
+}
+`, versions.Version2002)
+
+	conf, err := readConfig(rawConfig)
+	if assert.NoError(t, err) {
+		usecase := initConfigUsecase(nil)
+		usecase.Verify(conf)
+
+		assert.Len(t, conf.Errors, 0)
+		assert.Equal(t, "", conf.Config.Tiles[0].Template)
+		assert.Equal(t, 2, *conf.Config.Tiles[0].ColumnSpan)
+		assert.Equal(t, 1, *conf.Config.Tiles[1].ColumnSpan)
+		assert.Equal(t, map[string]interface{}{"hostname": "server.example.com", "port": float64(80)}, conf.Config.Tiles[0].Params)
+		assert.Equal(t, map[string]interface{}{"hostname": "server.example.com", "port": float64(443)}, conf.Config.Tiles[1].Params)
+
+		usecase.Hydrate(context.Background(), conf)
+		assert.Nil(t, conf.Config.Templates)
+		assert.Equal(t, "/port/default/port?hostname=server.example.com&port=443", conf.Config.Tiles[1].URL)
+	}
+}
+
+func TestUsecase_Verify_WithTemplates_RefreshInterval(t *testing.T) {
+	rawConfig := fmt.Sprintf(`
+{
+  "version": %q,
+  "columns": 4,
+  "templates": {
+    "check": { "type": "PINGDOM-CHECK", "refreshInterval": 60 }
+  },
+  "tiles": [
+    { "template": "check", "params": { "id": 10 } },
+    { "template": "check", "refreshInterval": 120, "params": { "id": 20 } }
+  ]
+}
+`, versions.Version2004)
+
+	conf, err := readConfig(rawConfig)
+	if assert.NoError(t, err) {
+		usecase := initConfigUsecase(nil)
+		usecase.Verify(conf)
+
+		assert.Len(t, conf.Errors, 0)
+		assert.Equal(t, 60, *conf.Config.Tiles[0].RefreshInterval)
+		assert.Equal(t, 120, *conf.Config.Tiles[1].RefreshInterval)
+
+		usecase.Hydrate(context.Background(), conf)
+		assert.Equal(t, "/pingdom/default/check?id=10&refreshInterval=60", conf.Config.Tiles[0].URL)
+	}
+}
+
+func TestUsecase_Verify_WithTemplates_Failed(t *testing.T) {
+	for _, testcase := range []struct {
+		rawConfig string
+		version   models.RawVersion
+		errorID   models.ConfigErrorID
+		errorData models.ConfigErrorData
+	}{
+		{
+			rawConfig: `{"version": %q, "columns": 4, "templates": {}, "tiles": [{ "type": "EMPTY" }]}`,
+			version:   versions.Version2001,
+			errorID:   models.ConfigErrorFieldNotSupportedInThisVersion,
+			errorData: models.ConfigErrorData{
+				FieldName: "templates",
+				Pointer:   "/templates",
+				Line:      1,
+				Column:    34,
+				Expected:  fmt.Sprintf(`%q <= version`, versions.Version2002),
+			},
+		},
+		{
+			rawConfig: `{"version": %q, "columns": 4, "templates": {"a": {"template": "b"}}, "tiles": [{ "type": "EMPTY" }]}`,
+			version:   versions.Version2002,
+			errorID:   models.ConfigErrorUnauthorizedField,
+			errorData: models.ConfigErrorData{
+				FieldName:     "template",
+				Pointer:       "/templates/a/template",
+				Line:          1,
+				Column:        54,
+				ConfigExtract: `{"type":"","template":"b"}`,
+			},
+		},
+		{
+			rawConfig: `{"version": %q, "columns": 4, "tiles": [{ "template": "unknown" }]}`,
+			version:   versions.Version2002,
+			errorID:   models.ConfigErrorUnknownTemplate,
+			errorData: models.ConfigErrorData{
+				FieldName:     "template",
+				Pointer:       "/tiles/0/template",
+				Line:          1,
+				Column:        46,
+				Value:         `"unknown"`,
+				ConfigExtract: `{"type":"","template":"unknown"}`,
+			},
+		},
+	} {
+		conf, err := readConfig(fmt.Sprintf(testcase.rawConfig, testcase.version))
+		if assert.NoError(t, err) {
+			usecase := initConfigUsecase(nil)
+			usecase.Verify(conf)
+
+			if assert.Len(t, conf.Errors, 1) {
+				assert.Equal(t, testcase.errorID, conf.Errors[0].ID)
+				assert.Equal(t, testcase.errorData, conf.Errors[0].Data)
+			}
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -10,6 +11,8 @@ import (
 	"github.com/monitoror/monitoror/api/config"
 	"github.com/monitoror/monitoror/api/config/models"
 	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/pkg/singleflight"
+	"github.com/monitoror/monitoror/service/metrics"
 	"github.com/monitoror/monitoror/service/registry"
 	"github.com/monitoror/monitoror/service/store"
 
@@ -33,7 +36,22 @@ type (
 		generatorTileStore cache.Store
 		cacheExpiration    time.Duration
 
+		// generatorCalls coalesce concurrent calls of generator with the same params (ex: many walls loading the same config)
+		generatorCalls singleflight.Group
+		// baseContext cancel coalesced generator calls, they aren't canceled with the request starting them
+		baseContext context.Context
+
 		initialMaxDelay int
+
+		// named configs declared on server side (path or url by name)
+		namedConfigs          map[string]string
+		disableFreeFormConfig bool
+
+		// environment variables usable in config with ${env:NAME}
+		allowedEnvVariables map[string]bool
+
+		// record generator invocations, nil when metrics are disabled
+		metrics *metrics.Metrics
 	}
 )
 
@@ -44,12 +62,24 @@ func NewConfigUsecase(repository config.Repository, store *store.Store) config.U
 	tileConfigs[EmptyTileType] = nil
 	tileConfigs[GroupTileType] = nil
 
+	allowedEnvVariables := make(map[string]bool)
+	for _, name := range store.CoreConfig.ConfigAllowedEnvVariables {
+		allowedEnvVariables[name] = true
+	}
+
 	return &configUsecase{
 		repository:         repository,
 		registry:           store.Registry.(*registry.MetadataRegistry),
 		generatorTileStore: store.CacheStore,
 		cacheExpiration:    time.Millisecond * time.Duration(store.CoreConfig.DownstreamCacheExpiration),
+		baseContext:        store.Context,
 		initialMaxDelay:    store.CoreConfig.InitialMaxDelay,
+
+		namedConfigs:          store.CoreConfig.NamedConfigs,
+		disableFreeFormConfig: store.CoreConfig.DisableFreeFormConfig,
+		allowedEnvVariables:   allowedEnvVariables,
+
+		metrics: store.Metrics,
 	}
 }
 
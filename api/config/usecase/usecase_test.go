@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -38,14 +39,14 @@ func initConfigUsecase(repository config.Repository) *configUsecase {
 
 	usecase := NewConfigUsecase(repository, s).(*configUsecase)
 
-	usecase.registry.RegisterTile(pingApi.PingTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}).
-		Enable(coreModels.DefaultVariant, &pingModels.PingParams{}, "/ping/default/ping")
-	usecase.registry.RegisterTile(portApi.PortTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}).
-		Enable(coreModels.DefaultVariant, &portModels.PortParams{}, "/port/default/port")
-	usecase.registry.RegisterTile(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant, "disabledVariant"}).
-		Enable(coreModels.DefaultVariant, &jenkinsModels.BuildParams{}, "/jenkins/default/build")
-	usecase.registry.RegisterTile(pingdomApi.PingdomCheckTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}).
-		Enable(coreModels.DefaultVariant, &pindomModels.CheckParams{}, "/pingdom/default/check")
+	usecase.registry.RegisterTile(pingApi.PingTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}, pingApi.PingMinimalRefreshInterval, &pingModels.PingParams{}).
+		Enable(coreModels.DefaultVariant, "/ping/default/ping")
+	usecase.registry.RegisterTile(portApi.PortTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}, portApi.PortMinimalRefreshInterval, &portModels.PortParams{}).
+		Enable(coreModels.DefaultVariant, "/port/default/port")
+	usecase.registry.RegisterTile(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant, "disabledVariant"}, jenkinsApi.JenkinsBuildMinimalRefreshInterval, &jenkinsModels.BuildParams{}).
+		Enable(coreModels.DefaultVariant, "/jenkins/default/build")
+	usecase.registry.RegisterTile(pingdomApi.PingdomCheckTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}, pingdomApi.PingdomCheckMinimalRefreshInterval, &pindomModels.CheckParams{}).
+		Enable(coreModels.DefaultVariant, "/pingdom/default/check")
 
 	return usecase
 }
@@ -74,7 +75,7 @@ func TestUsecase_Global_Success(t *testing.T) {
 	if assert.NoError(t, err) {
 		usecase := initConfigUsecase(nil)
 		usecase.Verify(config)
-		usecase.Hydrate(config)
+		usecase.Hydrate(context.Background(), config)
 
 		assert.Len(t, config.Errors, 0)
 
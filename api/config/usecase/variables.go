@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/config/versions"
+)
+
+const envVariablePrefix = "env:"
+
+var (
+	// Match ${name}, ${env:NAME} and escaped $${name}
+	variableRegex     = regexp.MustCompile(`\$(\$?)\{([^}]*)\}`)
+	variableNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*$`)
+)
+
+// verifyVariables check "variables" field of config
+func (cu *configUsecase) verifyVariables(configBag *models.ConfigBag) {
+	if configBag.Config.Variables == nil {
+		return
+	}
+
+	if configBag.Config.Version.IsLessThan(versions.Version2001) {
+		configBag.AddErrors(newFieldNotSupportedInThisVersionError(configBag, "variables", "/variables", versions.Version2001))
+		return
+	}
+
+	var names []string
+	for name := range configBag.Config.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !variableNameRegex.MatchString(name) {
+			configBag.AddErrors(models.ConfigError{
+				ID:      models.ConfigErrorInvalidFieldValue,
+				Message: fmt.Sprintf(`Invalid %q variable name. Must match %s.`, name, variableNameRegex.String()),
+				Data: models.ConfigErrorData{
+					FieldName: name,
+					Pointer:   models.JSONPointer("/variables", name),
+					Value:     stringify(name),
+					Expected:  variableNameRegex.String(),
+				},
+			})
+		}
+	}
+}
+
+// interpolateTile replace ${name} and ${env:NAME} in tile label and params.
+// Return false if tile use undefined variables
+func (cu *configUsecase) interpolateTile(configBag *models.ConfigBag, tile *models.TileConfig, pointer string) bool {
+	if configBag.Config.Version.IsLessThan(versions.Version2001) {
+		return true
+	}
+
+	var errors []models.ConfigError
+
+	label := cu.interpolate(configBag, tile.Label, models.JSONPointer(pointer, "label"), &errors)
+	params := cu.interpolate(configBag, tile.Params, models.JSONPointer(pointer, "params"), &errors)
+
+	for i := range errors {
+		errors[i].Data.ConfigExtract = stringify(tile)
+	}
+	configBag.AddErrors(errors...)
+
+	if len(errors) != 0 {
+		return false
+	}
+
+	tile.Label = fmt.Sprint(label)
+	if tile.Params != nil {
+		tile.Params = params.(map[string]interface{})
+	}
+
+	return true
+}
+
+func (cu *configUsecase) interpolate(configBag *models.ConfigBag, value interface{}, pointer string, errors *[]models.ConfigError) interface{} {
+	switch v := value.(type) {
+	case string:
+		return cu.interpolateString(configBag, v, pointer, errors)
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, subValue := range v {
+			result[key] = cu.interpolate(configBag, subValue, models.JSONPointer(pointer, key), errors)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, subValue := range v {
+			result[i] = cu.interpolate(configBag, subValue, models.JSONPointer(pointer, i), errors)
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+// interpolateString replace variables in string. If the whole string is a single variable, the variable keep its type
+func (cu *configUsecase) interpolateString(configBag *models.ConfigBag, str string, pointer string, errors *[]models.ConfigError) interface{} {
+	if match := variableRegex.FindStringSubmatch(str); match != nil && match[0] == str && match[1] == "" {
+		if value, ok := cu.resolveVariable(configBag, match[2], str, pointer, errors); ok {
+			return value
+		}
+		return str
+	}
+
+	return variableRegex.ReplaceAllStringFunc(str, func(expression string) string {
+		match := variableRegex.FindStringSubmatch(expression)
+		if match[1] != "" {
+			// Escaped, $${name} become ${name}
+			return expression[1:]
+		}
+
+		if value, ok := cu.resolveVariable(configBag, match[2], str, pointer, errors); ok {
+			if s, isString := value.(string); isString {
+				return s
+			}
+			return stringify(value)
+		}
+		return expression
+	})
+}
+
+func (cu *configUsecase) resolveVariable(configBag *models.ConfigBag, name, str, pointer string, errors *[]models.ConfigError) (interface{}, bool) {
+	if strings.HasPrefix(name, envVariablePrefix) {
+		envName := strings.TrimPrefix(name, envVariablePrefix)
+		if cu.allowedEnvVariables[envName] {
+			if value, exists := os.LookupEnv(envName); exists {
+				return value, true
+			}
+		}
+
+		*errors = append(*errors, models.ConfigError{
+			ID:      models.ConfigErrorUndefinedVariable,
+			Message: fmt.Sprintf(`Undefined %q environment variable. It must be defined and allowed on the server side with MO_CONFIGALLOWEDENVVARIABLES.`, envName),
+			Data: models.ConfigErrorData{
+				FieldName: name,
+				Pointer:   pointer,
+				Value:     stringify(str),
+			},
+		})
+		return nil, false
+	}
+
+	if value, exists := configBag.Config.Variables[name]; exists {
+		return value, true
+	}
+
+	*errors = append(*errors, models.ConfigError{
+		ID:      models.ConfigErrorUndefinedVariable,
+		Message: fmt.Sprintf(`Undefined %q variable.`, name),
+		Data: models.ConfigErrorData{
+			FieldName: name,
+			Pointer:   pointer,
+			Value:     stringify(str),
+			Expected:  keys(configBag.Config.Variables),
+		},
+	})
+	return nil, false
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/config/versions"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsecase_Verify_WithVariables(t *testing.T) {
+	_ = os.Setenv("MO_TEST_VARIABLES_HOSTNAME", "monitoror.example.com")
+	defer func() { _ = os.Unsetenv("MO_TEST_VARIABLES_HOSTNAME") }()
+
+	rawConfig := fmt.Sprintf(`
+{
+  "version": %q,
+  "columns": 4,
+  "variables": { "host": "server.example.com", "port": 8080, "team": "core" },
+  "tiles": [
+    { "type": "PORT", "label": "${team} - ${host}:${port} - $${escaped}", "params": { "hostname": "${host}", "port": "${port}" } },
+    { "type": "GROUP", "tiles": [
+      { "type": "PING", "params": { "hostname": "${env:MO_TEST_VARIABLES_HOSTNAME}" } }
+    ]}
+  ]
+}
+`, versions.Version2001)
+
+	conf, err := readConfig(rawConfig)
+	if assert.NoError(t, err) {
+		usecase := initConfigUsecase(nil)
+		usecase.allowedEnvVariables["MO_TEST_VARIABLES_HOSTNAME"] = true
+		usecase.Verify(conf)
+
+		assert.Len(t, conf.Errors, 0)
+		assert.Equal(t, "core - server.example.com:8080 - ${escaped}", conf.Config.Tiles[0].Label)
+		assert.Equal(t, "server.example.com", conf.Config.Tiles[0].Params["hostname"])
+		assert.Equal(t, float64(8080), conf.Config.Tiles[0].Params["port"])
+		assert.Equal(t, "monitoror.example.com", conf.Config.Tiles[1].Tiles[0].Params["hostname"])
+
+		usecase.Hydrate(context.Background(), conf)
+		assert.Nil(t, conf.Config.Variables)
+		assert.Equal(t, "/port/default/port?hostname=server.example.com&port=8080", conf.Config.Tiles[0].URL)
+	}
+}
+
+func TestUsecase_Verify_WithVariablesInPreviousVersion(t *testing.T) {
+	rawConfig := fmt.Sprintf(`
+{
+  "version": %q,
+  "columns": 4,
+  "variables": { "host": "server.example.com" },
+  "tiles": [
+    { "type": "PING", "label": "${host}", "params": { "hostname": "server.example.com" } }
+  ]
+}
+`, versions.Version2000)
+
+	conf, err := readConfig(rawConfig)
+	if assert.NoError(t, err) {
+		usecase := initConfigUsecase(nil)
+		usecase.Verify(conf)
+
+		if assert.Len(t, conf.Errors, 1) {
+			assert.Equal(t, models.ConfigErrorFieldNotSupportedInThisVersion, conf.Errors[0].ID)
+			assert.Equal(t, "/variables", conf.Errors[0].Data.Pointer)
+		}
+		// Interpolation is disabled in previous versions
+		assert.Equal(t, "${host}", conf.Config.Tiles[0].Label)
+	}
+}
+
+func TestUsecase_Verify_WithVariables_Failed(t *testing.T) {
+	_ = os.Setenv("MO_TEST_VARIABLES_SECRET", "secret")
+	defer func() { _ = os.Unsetenv("MO_TEST_VARIABLES_SECRET") }()
+
+	for _, testcase := range []struct {
+		rawConfig string
+		errorID   models.ConfigErrorID
+		errorData models.ConfigErrorData
+	}{
+		{
+			rawConfig: `{"version": %q, "columns": 4, "variables": {"host name": "test"}, "tiles": [{ "type": "EMPTY" }]}`,
+			errorID:   models.ConfigErrorInvalidFieldValue,
+			errorData: models.ConfigErrorData{
+				FieldName: "host name",
+				Pointer:   "/variables/host name",
+				Line:      1,
+				Column:    48,
+				Value:     `"host name"`,
+				Expected:  variableNameRegex.String(),
+			},
+		},
+		{
+			rawConfig: `{"version": %q, "columns": 4, "tiles": [{ "type": "PING", "params": { "hostname": "${host}" } }]}`,
+			errorID:   models.ConfigErrorUndefinedVariable,
+			errorData: models.ConfigErrorData{
+				FieldName:     "host",
+				Pointer:       "/tiles/0/params/hostname",
+				Line:          1,
+				Column:        74,
+				Value:         `"${host}"`,
+				ConfigExtract: `{"type":"PING","params":{"hostname":"${host}"}}`,
+			},
+		},
+		{
+			rawConfig: `{"version": %q, "columns": 4, "tiles": [{ "type": "PING", "label": "${env:MO_TEST_VARIABLES_SECRET}", "params": { "hostname": "server.example.com" } }]}`,
+			errorID:   models.ConfigErrorUndefinedVariable,
+			errorData: models.ConfigErrorData{
+				FieldName:     "env:MO_TEST_VARIABLES_SECRET",
+				Pointer:       "/tiles/0/label",
+				Line:          1,
+				Column:        62,
+				Value:         `"${env:MO_TEST_VARIABLES_SECRET}"`,
+				ConfigExtract: `{"type":"PING","label":"${env:MO_TEST_VARIABLES_SECRET}","params":{"hostname":"server.example.com"}}`,
+			},
+		},
+	} {
+		conf, err := readConfig(fmt.Sprintf(testcase.rawConfig, versions.Version2001))
+		if assert.NoError(t, err) {
+			usecase := initConfigUsecase(nil)
+			usecase.Verify(conf)
+
+			if assert.Len(t, conf.Errors, 1) {
+				assert.Equal(t, testcase.errorID, conf.Errors[0].ID)
+				assert.Equal(t, testcase.errorData, conf.Errors[0].Data)
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/monitoror/monitoror/api/config/models"
 	"github.com/monitoror/monitoror/api/config/versions"
@@ -12,94 +13,155 @@ import (
 )
 
 func (cu *configUsecase) Verify(configBag *models.ConfigBag) {
-	if configBag.Config.Version == nil {
-		configBag.AddErrors(models.ConfigError{
-			ID:      models.ConfigErrorMissingRequiredField,
-			Message: fmt.Sprintf(`Required "version" field is missing. Current config version is: %s.`, versions.CurrentVersion),
-			Data: models.ConfigErrorData{
-				FieldName: "version",
-			},
-		})
+	defer configBag.LocateErrors()
+
+	if !verifyVersion(configBag) {
 		return
 	}
 
-	if configBag.Config.Version.IsLessThan(versions.MinimalVersion) || configBag.Config.Version.IsGreaterThan(versions.CurrentVersion) {
-		configBag.AddErrors(models.ConfigError{
-			ID:      models.ConfigErrorUnsupportedVersion,
-			Message: fmt.Sprintf(`Unsupported configuration version. Minimal supported version is %q. Current config version is: %q`, versions.MinimalVersion, versions.CurrentVersion),
-			Data: models.ConfigErrorData{
-				FieldName: "version",
-				Value:     stringify(configBag.Config.Version),
-				Expected:  fmt.Sprintf(`%q <= version <= %q`, versions.MinimalVersion, versions.CurrentVersion),
-			},
-		})
+	cu.verifyVariables(configBag)
+	cu.verifyTemplates(configBag)
+
+	// Multi-page config, columns and zoom are used as default for pages
+	if configBag.Config.Pages != nil {
+		verifyColumns(configBag, configBag.Config.Columns, "", false)
+		verifyZoom(configBag, configBag.Config.Zoom, "")
+		cu.verifyPages(configBag)
 		return
 	}
 
-	if configBag.Config.Columns == nil {
-		configBag.AddErrors(models.ConfigError{
-			ID:      models.ConfigErrorMissingRequiredField,
-			Message: fmt.Sprintf(`Required "columns" field is missing. Must be a positive integer.`),
-			Data: models.ConfigErrorData{
-				FieldName: "columns",
-			},
-		})
-	} else if *configBag.Config.Columns <= 0 {
+	verifyColumns(configBag, configBag.Config.Columns, "", true)
+	verifyZoom(configBag, configBag.Config.Zoom, "")
+	cu.verifyTiles(configBag, configBag.Config.Tiles, "")
+}
+
+// verifyColumns check "columns" field of config or page. pointer is the JSON pointer of config or page
+func verifyColumns(configBag *models.ConfigBag, columns *int, pointer string, required bool) {
+	if columns == nil {
+		if required {
+			configBag.AddErrors(models.ConfigError{
+				ID:      models.ConfigErrorMissingRequiredField,
+				Message: fmt.Sprintf(`Required "columns" field is missing. Must be a positive integer.`),
+				Data: models.ConfigErrorData{
+					FieldName: "columns",
+					Pointer:   models.JSONPointer(pointer, "columns"),
+				},
+			})
+		}
+	} else if *columns <= 0 {
 		configBag.AddErrors(models.ConfigError{
 			ID:      models.ConfigErrorInvalidFieldValue,
 			Message: fmt.Sprintf(`Invalid "columns" field. Must be a positive integer.`),
 			Data: models.ConfigErrorData{
 				FieldName: "columns",
-				Value:     stringify(configBag.Config.Columns),
+				Pointer:   models.JSONPointer(pointer, "columns"),
+				Value:     stringify(columns),
 				Expected:  "columns > 0",
 			},
 		})
 	}
+}
 
-	if configBag.Config.Zoom != nil && (*configBag.Config.Zoom <= 0 || *configBag.Config.Zoom > 10) {
+// verifyZoom check "zoom" field of config or page. pointer is the JSON pointer of config or page
+func verifyZoom(configBag *models.ConfigBag, zoom *float32, pointer string) {
+	if zoom != nil && (*zoom <= 0 || *zoom > 10) {
 		configBag.AddErrors(models.ConfigError{
 			ID:      models.ConfigErrorInvalidFieldValue,
 			Message: `Invalid "zoom" field. Must be a positive float between 0 and 10.`,
 			Data: models.ConfigErrorData{
 				FieldName: "zoom",
-				Value:     stringify(configBag.Config.Zoom),
+				Pointer:   models.JSONPointer(pointer, "zoom"),
+				Value:     stringify(zoom),
 				Expected:  "0 < zoom <= 10",
 			},
 		})
 	}
+}
 
-	if configBag.Config.Tiles == nil {
+// verifyVersion check "version" field of config. Return false if version is missing or unsupported
+func verifyVersion(configBag *models.ConfigBag) bool {
+	if configBag.Config.Version == nil {
+		configBag.AddErrors(models.ConfigError{
+			ID:      models.ConfigErrorMissingRequiredField,
+			Message: fmt.Sprintf(`Required "version" field is missing. Current config version is: %s.`, versions.CurrentVersion),
+			Data: models.ConfigErrorData{
+				FieldName: "version",
+				Pointer:   "/version",
+			},
+		})
+		return false
+	}
+
+	if configBag.Config.Version.IsLessThan(versions.MinimalVersion) || configBag.Config.Version.IsGreaterThan(versions.CurrentVersion) {
+		configBag.AddErrors(models.ConfigError{
+			ID:      models.ConfigErrorUnsupportedVersion,
+			Message: fmt.Sprintf(`Unsupported configuration version. Minimal supported version is %q. Current config version is: %q`, versions.MinimalVersion, versions.CurrentVersion),
+			Data: models.ConfigErrorData{
+				FieldName: "version",
+				Pointer:   "/version",
+				Value:     stringify(configBag.Config.Version),
+				Expected:  fmt.Sprintf(`%q <= version <= %q`, versions.MinimalVersion, versions.CurrentVersion),
+			},
+		})
+		return false
+	}
+
+	return true
+}
+
+// verifyTiles check "tiles" field of config or page and every tile definition. pointer is the JSON pointer of config or page
+func (cu *configUsecase) verifyTiles(configBag *models.ConfigBag, tiles []models.TileConfig, pointer string) {
+	if tiles == nil {
 		configBag.AddErrors(models.ConfigError{
 			ID:      models.ConfigErrorMissingRequiredField,
 			Message: `Missing "tiles" field. Must be a non-empty array.`,
 			Data: models.ConfigErrorData{
 				FieldName: "tiles",
+				Pointer:   models.JSONPointer(pointer, "tiles"),
 			},
 		})
-	} else if len(configBag.Config.Tiles) == 0 {
+	} else if len(tiles) == 0 {
 		configBag.AddErrors(models.ConfigError{
 			ID:      models.ConfigErrorInvalidFieldValue,
 			Message: `Invalid "tiles" field. Must be a non-empty array.`,
 			Data: models.ConfigErrorData{
 				FieldName:     "tiles",
+				Pointer:       models.JSONPointer(pointer, "tiles"),
 				ConfigExtract: stringify(configBag.Config),
 			},
 		})
 	} else {
 		// Iterating through every config tiles
-		for _, tile := range configBag.Config.Tiles {
-			cu.verifyTile(configBag, &tile, nil)
+		// Tiles are verified in place to keep interpolated variables for hydration
+		for i := range tiles {
+			cu.verifyTile(configBag, &tiles[i], nil, models.JSONPointer(pointer, "tiles", i))
 		}
 	}
 }
 
-func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.TileConfig, groupTile *models.TileConfig) {
+// verifyTile check tile definition. pointer is the JSON pointer of tile in config, used to locate errors
+func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.TileConfig, groupTile *models.TileConfig, pointer string) {
+	// Include tile, replaced by tiles of included config
+	if tile.Include != "" {
+		cu.verifyInclude(configBag, tile, groupTile, pointer)
+		return
+	}
+
+	if tile.Template != "" && !cu.applyTemplate(configBag, tile, pointer) {
+		return
+	}
+
+	if !cu.interpolateTile(configBag, tile, pointer) {
+		return
+	}
+
 	if tile.ColumnSpan != nil && *tile.ColumnSpan <= 0 {
 		configBag.AddErrors(models.ConfigError{
 			ID:      models.ConfigErrorInvalidFieldValue,
 			Message: `Invalid "columnSpan" field. Must be a positive integer.`,
 			Data: models.ConfigErrorData{
 				FieldName:     "columnSpan",
+				Pointer:       models.JSONPointer(pointer, "columnSpan"),
 				Expected:      "columnSpan > 0",
 				ConfigExtract: stringify(tile),
 			},
@@ -113,6 +175,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 			Message: `Invalid "rowSpan" field. Must be a positive integer.`,
 			Data: models.ConfigErrorData{
 				FieldName:     "rowSpan",
+				Pointer:       models.JSONPointer(pointer, "rowSpan"),
 				Expected:      "rowSpan > 0",
 				ConfigExtract: stringify(tile),
 			},
@@ -129,6 +192,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 				Data: models.ConfigErrorData{
 					ConfigExtract:          stringify(groupTile),
 					ConfigExtractHighlight: stringify(tile),
+					Pointer:                pointer,
 				},
 			})
 		}
@@ -144,6 +208,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 				Data: models.ConfigErrorData{
 					ConfigExtract:          stringify(groupTile),
 					ConfigExtractHighlight: stringify(tile),
+					Pointer:                pointer,
 				},
 			})
 			return
@@ -155,6 +220,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 				Message: fmt.Sprintf(`Unauthorized "params" key in %s tile definition.`, tile.Type),
 				Data: models.ConfigErrorData{
 					FieldName:     "params",
+					Pointer:       models.JSONPointer(pointer, "params"),
 					ConfigExtract: stringify(tile),
 				},
 			})
@@ -167,6 +233,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 				Message: fmt.Sprintf(`Missing "tiles" field in %s tile definition. Must be a non-empty array.`, tile.Type),
 				Data: models.ConfigErrorData{
 					FieldName:     "tiles",
+					Pointer:       models.JSONPointer(pointer, "tiles"),
 					ConfigExtract: stringify(tile),
 				},
 			})
@@ -176,14 +243,25 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 				Message: fmt.Sprintf(`Invalid "tiles" field in %s tile definition. Must be a non-empty array.`, tile.Type),
 				Data: models.ConfigErrorData{
 					FieldName:     "tiles",
+					Pointer:       models.JSONPointer(pointer, "tiles"),
 					ConfigExtract: stringify(tile),
 				},
 			})
 			return
 		}
 
-		for _, groupTile := range tile.Tiles {
-			cu.verifyTile(configBag, &groupTile, tile)
+		if tile.RefreshInterval != nil && configBag.Config.Version.IsLessThan(versions.Version2004) {
+			configBag.AddErrors(newFieldNotSupportedInThisVersionError(configBag, "refreshInterval", models.JSONPointer(pointer, "refreshInterval"), versions.Version2004))
+			return
+		}
+
+		for i := range tile.Tiles {
+			// Subtiles inherit refresh interval of group tile
+			if tile.Tiles[i].RefreshInterval == nil {
+				tile.Tiles[i].RefreshInterval = tile.RefreshInterval
+			}
+
+			cu.verifyTile(configBag, &tile.Tiles[i], tile, models.JSONPointer(pointer, "tiles", i))
 		}
 
 		return
@@ -206,6 +284,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 				Message: fmt.Sprintf(`Unknown %q generator type in tile definition. Must be %s`, tile.Type, keys(cu.registry.GeneratorMetadata)),
 				Data: models.ConfigErrorData{
 					FieldName:     "type",
+					Pointer:       models.JSONPointer(pointer, "type"),
 					ConfigExtract: stringify(tile),
 					Expected:      keys(cu.registry.GeneratorMetadata),
 				},
@@ -221,6 +300,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 				Message: fmt.Sprintf(`Unknown %q generator type in tile definition. Must be %s`, tile.Type, keys(cu.registry.TileMetadata)),
 				Data: models.ConfigErrorData{
 					FieldName:     "type",
+					Pointer:       models.JSONPointer(pointer, "type"),
 					ConfigExtract: stringify(tile),
 					Expected:      keys(cu.registry.TileMetadata),
 				},
@@ -236,6 +316,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 				tile.Type, configBag.Config.Version, metadataExplorer.GetMinimalVersion()),
 			Data: models.ConfigErrorData{
 				FieldName:     "type",
+				Pointer:       models.JSONPointer(pointer, "type"),
 				ConfigExtract: stringify(tile),
 				Expected:      fmt.Sprintf(`%q <= version`, metadataExplorer.GetMinimalVersion()),
 			},
@@ -251,6 +332,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 				tile.ConfigVariant, tile.Type, stringify(metadataExplorer.GetVariantNames())),
 			Data: models.ConfigErrorData{
 				FieldName:     "configVariant",
+				Pointer:       models.JSONPointer(pointer, "configVariant"),
 				Value:         stringify(tile.ConfigVariant),
 				Expected:      stringify(metadataExplorer.GetVariantNames()),
 				ConfigExtract: stringify(tile),
@@ -265,6 +347,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 			Message: fmt.Sprintf(`Variant %q is disabled for %s type. Check errors on the server side for the reason`, tile.ConfigVariant, tile.Type),
 			Data: models.ConfigErrorData{
 				FieldName:     "configVariant",
+				Pointer:       models.JSONPointer(pointer, "configVariant"),
 				Value:         stringify(tile.ConfigVariant),
 				ConfigExtract: stringify(tile),
 			},
@@ -272,12 +355,17 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 		return
 	}
 
+	if tile.RefreshInterval != nil && !cu.verifyRefreshInterval(configBag, tile, pointer) {
+		return
+	}
+
 	if tile.Params == nil {
 		configBag.AddErrors(models.ConfigError{
 			ID:      models.ConfigErrorMissingRequiredField,
 			Message: fmt.Sprintf(`Missing "params" key in %s tile definition.`, tile.Type),
 			Data: models.ConfigErrorData{
 				FieldName:     "params",
+				Pointer:       models.JSONPointer(pointer, "params"),
 				ConfigExtract: stringify(tile),
 			},
 		})
@@ -296,6 +384,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 			Message: unmarshalErr.Error(),
 			Data: models.ConfigErrorData{
 				FieldName:     "params",
+				Pointer:       models.JSONPointer(pointer, "params"),
 				ConfigExtract: stringify(tile),
 			},
 		})
@@ -315,6 +404,7 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 				Message: fmt.Sprintf(`Unknown %q tile params field.`, field),
 				Data: models.ConfigErrorData{
 					FieldName:     field,
+					Pointer:       models.JSONPointer(pointer, "params", field),
 					ConfigExtract: stringify(tile),
 					Expected:      keys(structParams),
 				},
@@ -324,17 +414,53 @@ func (cu *configUsecase) verifyTile(configBag *models.ConfigBag, tile *models.Ti
 	}
 
 	// Validate config with the config file version
-	if err := rInstance.(models.ParamsValidator).Validate(configBag.Config.Version); err != nil {
-		// TODO
-		//configBag.AddErrors(*err)
+	paramsErrors := models.ValidateParamsVersion(rInstance, configBag.Config.Version)
+	paramsErrors = append(paramsErrors, rInstance.(models.ParamsValidator).Validate(configBag.Config.Version)...)
+	for _, err := range paramsErrors {
+		err.Data.Pointer = models.JSONPointer(pointer, "params")
+		if err.Data.FieldName != "" {
+			err.Data.Pointer = models.JSONPointer(err.Data.Pointer, err.Data.FieldName)
+		}
+		if err.Data.ConfigExtract == "" {
+			err.Data.ConfigExtract = stringify(tile)
+		}
 
+		configBag.AddErrors(err)
+	}
+}
+
+// verifyRefreshInterval check "refreshInterval" field of tile against minimal refresh interval of tile type
+func (cu *configUsecase) verifyRefreshInterval(configBag *models.ConfigBag, tile *models.TileConfig, pointer string) bool {
+	if configBag.Config.Version.IsLessThan(versions.Version2004) {
+		configBag.AddErrors(newFieldNotSupportedInThisVersionError(configBag, "refreshInterval", models.JSONPointer(pointer, "refreshInterval"), versions.Version2004))
+		return false
+	}
+
+	// Generator tiles use minimal refresh interval of generated tile type
+	tileType := tile.Type
+	if generatorMetadata, exists := cu.registry.GeneratorMetadata[tile.Type]; exists {
+		tileType = generatorMetadata.GeneratedTileType
+	}
+
+	minimalRefreshInterval := 1
+	if tileMetadata, exists := cu.registry.TileMetadata[tileType]; exists && tileMetadata.MinimalRefreshInterval > time.Second {
+		minimalRefreshInterval = int(tileMetadata.MinimalRefreshInterval / time.Second)
+	}
+
+	if *tile.RefreshInterval < minimalRefreshInterval {
 		configBag.AddErrors(models.ConfigError{
 			ID:      models.ConfigErrorInvalidFieldValue,
-			Message: fmt.Sprintf(`Invalid params definition for %q: %q.`, tile.Type, string(bytesParams)),
+			Message: fmt.Sprintf(`Invalid "refreshInterval" field. Must be an integer greater or equal to %d (in seconds) for %s tile.`, minimalRefreshInterval, tile.Type),
 			Data: models.ConfigErrorData{
-				FieldName:     "params",
+				FieldName:     "refreshInterval",
+				Pointer:       models.JSONPointer(pointer, "refreshInterval"),
+				Value:         stringify(tile.RefreshInterval),
+				Expected:      fmt.Sprintf("refreshInterval >= %d", minimalRefreshInterval),
 				ConfigExtract: stringify(tile),
 			},
 		})
+		return false
 	}
+
+	return true
 }
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"testing"
@@ -8,8 +9,11 @@ import (
 	"github.com/monitoror/monitoror/api/config/models"
 	"github.com/monitoror/monitoror/api/config/versions"
 	coreModels "github.com/monitoror/monitoror/models"
+	githubApi "github.com/monitoror/monitoror/monitorables/github/api"
+	githubModels "github.com/monitoror/monitoror/monitorables/github/api/models"
 	jenkinsApi "github.com/monitoror/monitoror/monitorables/jenkins/api"
 	jenkinsModels "github.com/monitoror/monitoror/monitorables/jenkins/api/models"
+	pingModels "github.com/monitoror/monitoror/monitorables/ping/api/models"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -76,13 +80,16 @@ func TestUsecase_Verify_Failed(t *testing.T) {
 		{
 			rawConfig: `{}`,
 			errorID:   models.ConfigErrorMissingRequiredField,
-			errorData: models.ConfigErrorData{FieldName: "version"},
+			errorData: models.ConfigErrorData{FieldName: "version", Pointer: "/version", Line: 1, Column: 1},
 		},
 		{
 			rawConfig: `{"version": "0.0"}`,
 			errorID:   models.ConfigErrorUnsupportedVersion,
 			errorData: models.ConfigErrorData{
 				FieldName: "version",
+				Pointer:   "/version",
+				Line:      1,
+				Column:    2,
 				Value:     `"0.0"`,
 				Expected:  fmt.Sprintf(`%q <= version <= %q`, versions.MinimalVersion, versions.CurrentVersion),
 			},
@@ -92,6 +99,9 @@ func TestUsecase_Verify_Failed(t *testing.T) {
 			errorID:   models.ConfigErrorUnsupportedVersion,
 			errorData: models.ConfigErrorData{
 				FieldName: "version",
+				Pointer:   "/version",
+				Line:      1,
+				Column:    2,
 				Value:     `"999.999"`,
 				Expected:  fmt.Sprintf(`%q <= version <= %q`, versions.MinimalVersion, versions.CurrentVersion),
 			},
@@ -101,6 +111,9 @@ func TestUsecase_Verify_Failed(t *testing.T) {
 			errorID:   models.ConfigErrorMissingRequiredField,
 			errorData: models.ConfigErrorData{
 				FieldName: "columns",
+				Pointer:   "/columns",
+				Line:      1,
+				Column:    1,
 			},
 		},
 		{
@@ -108,6 +121,9 @@ func TestUsecase_Verify_Failed(t *testing.T) {
 			errorID:   models.ConfigErrorInvalidFieldValue,
 			errorData: models.ConfigErrorData{
 				FieldName: "columns",
+				Pointer:   "/columns",
+				Line:      1,
+				Column:    20,
 				Value:     `0`,
 				Expected:  "columns > 0",
 			},
@@ -117,6 +133,9 @@ func TestUsecase_Verify_Failed(t *testing.T) {
 			errorID:   models.ConfigErrorInvalidFieldValue,
 			errorData: models.ConfigErrorData{
 				FieldName: "zoom",
+				Pointer:   "/zoom",
+				Line:      1,
+				Column:    34,
 				Value:     `0`,
 				Expected:  "0 < zoom <= 10",
 			},
@@ -126,6 +145,9 @@ func TestUsecase_Verify_Failed(t *testing.T) {
 			errorID:   models.ConfigErrorInvalidFieldValue,
 			errorData: models.ConfigErrorData{
 				FieldName: "zoom",
+				Pointer:   "/zoom",
+				Line:      1,
+				Column:    34,
 				Value:     `20`,
 				Expected:  "0 < zoom <= 10",
 			},
@@ -135,6 +157,9 @@ func TestUsecase_Verify_Failed(t *testing.T) {
 			errorID:   models.ConfigErrorMissingRequiredField,
 			errorData: models.ConfigErrorData{
 				FieldName: "tiles",
+				Pointer:   "/tiles",
+				Line:      1,
+				Column:    1,
 			},
 		},
 		{
@@ -142,6 +167,9 @@ func TestUsecase_Verify_Failed(t *testing.T) {
 			errorID:   models.ConfigErrorInvalidFieldValue,
 			errorData: models.ConfigErrorData{
 				FieldName:     "tiles",
+				Pointer:       "/tiles",
+				Line:          1,
+				Column:        34,
 				ConfigExtract: fmt.Sprintf(`{"version":%q,"columns":1,"tiles":[]}`, versions.CurrentVersion),
 			},
 		},
@@ -163,7 +191,7 @@ func TestUsecase_VerifyTile_Success(t *testing.T) {
 
 	tile, conf := initConfig(t, rawConfig)
 	usecase := initConfigUsecase(nil)
-	usecase.verifyTile(conf, tile, nil)
+	usecase.verifyTile(conf, tile, nil, "/tiles/0")
 
 	assert.Len(t, conf.Errors, 0)
 }
@@ -173,7 +201,7 @@ func TestUsecase_VerifyTile_Success_Empty(t *testing.T) {
 
 	tile, conf := initConfig(t, rawConfig)
 	usecase := initConfigUsecase(nil)
-	usecase.verifyTile(conf, tile, nil)
+	usecase.verifyTile(conf, tile, nil, "/tiles/0")
 
 	assert.Len(t, conf.Errors, 0)
 }
@@ -188,7 +216,7 @@ func TestUsecase_VerifyTile_Success_Group(t *testing.T) {
 
 	tile, conf := initConfig(t, rawConfig)
 	usecase := initConfigUsecase(nil)
-	usecase.verifyTile(conf, tile, nil)
+	usecase.verifyTile(conf, tile, nil, "/tiles/0")
 
 	assert.Len(t, conf.Errors, 0)
 }
@@ -204,6 +232,7 @@ func TestUsecase_VerifyTile_Failed(t *testing.T) {
 			errorID:   models.ConfigErrorInvalidFieldValue,
 			errorData: models.ConfigErrorData{
 				FieldName:     "columnSpan",
+				Pointer:       "/tiles/0/columnSpan",
 				Expected:      "columnSpan > 0",
 				ConfigExtract: `{"type":"PING","columnSpan":-1,"params":{"hostname":"server.com"}}`,
 			},
@@ -213,6 +242,7 @@ func TestUsecase_VerifyTile_Failed(t *testing.T) {
 			errorID:   models.ConfigErrorInvalidFieldValue,
 			errorData: models.ConfigErrorData{
 				FieldName:     "rowSpan",
+				Pointer:       "/tiles/0/rowSpan",
 				Expected:      "rowSpan > 0",
 				ConfigExtract: `{"type":"PING","rowSpan":-1,"params":{"hostname":"server.com"}}`,
 			},
@@ -227,6 +257,7 @@ func TestUsecase_VerifyTile_Failed(t *testing.T) {
 			errorData: models.ConfigErrorData{
 				ConfigExtract:          `{"type":"GROUP","tiles":[{"type":"EMPTY"}]}`,
 				ConfigExtractHighlight: `{"type":"EMPTY"}`,
+				Pointer:                "/tiles/0/tiles/0",
 			},
 		},
 		{
@@ -239,6 +270,7 @@ func TestUsecase_VerifyTile_Failed(t *testing.T) {
 			errorData: models.ConfigErrorData{
 				ConfigExtract:          `{"type":"GROUP","tiles":[{"type":"GROUP"}]}`,
 				ConfigExtractHighlight: `{"type":"GROUP"}`,
+				Pointer:                "/tiles/0/tiles/0",
 			},
 		},
 		{
@@ -246,6 +278,7 @@ func TestUsecase_VerifyTile_Failed(t *testing.T) {
 			errorID:   models.ConfigErrorUnauthorizedField,
 			errorData: models.ConfigErrorData{
 				FieldName:     "params",
+				Pointer:       "/tiles/0/params",
 				ConfigExtract: `{"type":"GROUP","params":{"test":"test"}}`,
 			},
 		},
@@ -254,6 +287,7 @@ func TestUsecase_VerifyTile_Failed(t *testing.T) {
 			errorID:   models.ConfigErrorMissingRequiredField,
 			errorData: models.ConfigErrorData{
 				FieldName:     "tiles",
+				Pointer:       "/tiles/0/tiles",
 				ConfigExtract: `{"type":"GROUP"}`,
 			},
 		},
@@ -262,6 +296,7 @@ func TestUsecase_VerifyTile_Failed(t *testing.T) {
 			errorID:   models.ConfigErrorInvalidFieldValue,
 			errorData: models.ConfigErrorData{
 				FieldName:     "tiles",
+				Pointer:       "/tiles/0/tiles",
 				ConfigExtract: `{"type":"GROUP"}`,
 			},
 		},
@@ -270,14 +305,16 @@ func TestUsecase_VerifyTile_Failed(t *testing.T) {
 			errorID:   models.ConfigErrorMissingRequiredField,
 			errorData: models.ConfigErrorData{
 				FieldName:     "params",
+				Pointer:       "/tiles/0/params",
 				ConfigExtract: `{"type":"PING","configVariant":"default"}`,
 			},
 		},
 		{
 			rawConfig: `{ "type": "PING", "params": { } }`,
-			errorID:   models.ConfigErrorInvalidFieldValue,
+			errorID:   models.ConfigErrorMissingRequiredField,
 			errorData: models.ConfigErrorData{
-				FieldName:     "params",
+				FieldName:     "hostname",
+				Pointer:       "/tiles/0/params/hostname",
 				ConfigExtract: `{"type":"PING","configVariant":"default"}`,
 			},
 		},
@@ -286,6 +323,7 @@ func TestUsecase_VerifyTile_Failed(t *testing.T) {
 			errorID:   models.ConfigErrorUnknownField,
 			errorData: models.ConfigErrorData{
 				FieldName:     "host",
+				Pointer:       "/tiles/0/params/host",
 				ConfigExtract: `{"type":"PING","params":{"host":"server.com"},"configVariant":"default"}`,
 				Expected:      "hostname",
 			},
@@ -295,6 +333,7 @@ func TestUsecase_VerifyTile_Failed(t *testing.T) {
 			errorID:   models.ConfigErrorUnexpectedError,
 			errorData: models.ConfigErrorData{
 				FieldName:     "params",
+				Pointer:       "/tiles/0/params",
 				ConfigExtract: `{"type":"PING","params":{"hostname":["server.com"]},"configVariant":"default"}`,
 			},
 		},
@@ -303,6 +342,7 @@ func TestUsecase_VerifyTile_Failed(t *testing.T) {
 			errorID:   models.ConfigErrorDisabledVariant,
 			errorData: models.ConfigErrorData{
 				FieldName:     "configVariant",
+				Pointer:       "/tiles/0/configVariant",
 				Value:         `"disabledVariant"`,
 				ConfigExtract: `{"type":"JENKINS-BUILD","configVariant":"disabledVariant"}`,
 			},
@@ -310,7 +350,7 @@ func TestUsecase_VerifyTile_Failed(t *testing.T) {
 	} {
 		tile, conf := initConfig(t, testcase.rawConfig)
 		usecase := initConfigUsecase(nil)
-		usecase.verifyTile(conf, tile, nil)
+		usecase.verifyTile(conf, tile, nil, "/tiles/0")
 
 		if assert.Len(t, conf.Errors, 1) {
 			assert.Equal(t, testcase.errorID, conf.Errors[0].ID)
@@ -319,13 +359,79 @@ func TestUsecase_VerifyTile_Failed(t *testing.T) {
 	}
 }
 
+func TestUsecase_VerifyTile_Failed_WithEveryParamsErrors(t *testing.T) {
+	rawConfig := `{ "type": "PORT", "params": { } }`
+
+	tile, conf := initConfig(t, rawConfig)
+	usecase := initConfigUsecase(nil)
+	usecase.verifyTile(conf, tile, nil, "/tiles/0")
+
+	if assert.Len(t, conf.Errors, 2) {
+		assert.Equal(t, models.ConfigErrorMissingRequiredField, conf.Errors[0].ID)
+		assert.Equal(t, "/tiles/0/params/hostname", conf.Errors[0].Data.Pointer)
+		assert.Equal(t, models.ConfigErrorMissingRequiredField, conf.Errors[1].ID)
+		assert.Equal(t, "/tiles/0/params/port", conf.Errors[1].Data.Pointer)
+	}
+}
+
+// versionedPingParams is PingParams with a param added in a future config version
+type versionedPingParams struct {
+	pingModels.PingParams
+	Count int `json:"count,omitempty" available:"since=999.0"`
+}
+
+func TestUsecase_VerifyTile_Failed_ParamNotSupportedInThisVersion(t *testing.T) {
+	rawConfig := `{ "type": "VERSIONED-PING", "params": { "count": 3 } }`
+
+	tile, conf := initConfig(t, rawConfig)
+	usecase := initConfigUsecase(nil)
+	usecase.registry.RegisterTile("VERSIONED-PING", versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}, 0, &versionedPingParams{}).
+		Enable(coreModels.DefaultVariant, "/ping/default/ping")
+	usecase.verifyTile(conf, tile, nil, "/tiles/0")
+
+	if assert.Len(t, conf.Errors, 2) {
+		assert.Equal(t, models.ConfigErrorTileParamNotSupportedInThisVersion, conf.Errors[0].ID)
+		assert.Equal(t, "count", conf.Errors[0].Data.FieldName)
+		assert.Equal(t, `"999.0" <= version`, conf.Errors[0].Data.Expected)
+		assert.Equal(t, "/tiles/0/params/count", conf.Errors[0].Data.Pointer)
+		assert.Equal(t, `{"type":"VERSIONED-PING","params":{"count":3},"configVariant":"default"}`, conf.Errors[0].Data.ConfigExtract)
+
+		// Params are still validated by PingParams
+		assert.Equal(t, models.ConfigErrorMissingRequiredField, conf.Errors[1].ID)
+		assert.Equal(t, "/tiles/0/params/hostname", conf.Errors[1].Data.Pointer)
+	}
+}
+
+func TestUsecase_VerifyTile_WithGenerator_Failed_ParamNotSupportedInThisVersion(t *testing.T) {
+	rawConfig := `{ "type": "GENERATE:GITHUB-CHECKS", "params": { "owner": "monitoror", "repository": "monitoror", "match": "^feat/" } }`
+
+	tile, conf := initConfig(t, rawConfig)
+	usecase := initConfigUsecase(nil)
+	usecase.registry.RegisterGenerator(githubApi.GithubChecksTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}, &githubModels.PullRequestGeneratorParams{}).
+		Enable(coreModels.DefaultVariant, nil)
+
+	// match is supported since 2.5
+	conf.Config.Version = models.ParseVersion(versions.Version2005)
+	usecase.verifyTile(conf, tile, nil, "/tiles/0")
+	assert.Len(t, conf.Errors, 0)
+
+	conf.Config.Version = models.ParseVersion(versions.Version2004)
+	usecase.verifyTile(conf, tile, nil, "/tiles/0")
+	if assert.Len(t, conf.Errors, 1) {
+		assert.Equal(t, models.ConfigErrorTileParamNotSupportedInThisVersion, conf.Errors[0].ID)
+		assert.Equal(t, "match", conf.Errors[0].Data.FieldName)
+		assert.Equal(t, fmt.Sprintf(`%q <= version`, versions.Version2005), conf.Errors[0].Data.Expected)
+		assert.Equal(t, "/tiles/0/params/match", conf.Errors[0].Data.Pointer)
+	}
+}
+
 func TestUsecase_VerifyTile_Failed_WrongMinimalVerison(t *testing.T) {
 	rawConfig := `{ "type": "PING", "params": { "hostname": "server.com" } }`
 
 	tile, conf := initConfig(t, rawConfig)
 	usecase := initConfigUsecase(nil)
 	usecase.registry.TileMetadata["PING"].MinimalVersion = "999.0"
-	usecase.verifyTile(conf, tile, nil)
+	usecase.verifyTile(conf, tile, nil, "/tiles/0")
 
 	if assert.Len(t, conf.Errors, 1) {
 		assert.Equal(t, models.ConfigErrorTileNotSupportedInThisVersion, conf.Errors[0].ID)
@@ -340,7 +446,7 @@ func TestUsecase_VerifyTile_Failed_WrongTileType(t *testing.T) {
 
 	tile, conf := initConfig(t, rawConfig)
 	usecase := initConfigUsecase(nil)
-	usecase.verifyTile(conf, tile, nil)
+	usecase.verifyTile(conf, tile, nil, "/tiles/0")
 
 	if assert.Len(t, conf.Errors, 1) {
 		assert.Equal(t, models.ConfigErrorUnknownTileType, conf.Errors[0].ID)
@@ -354,14 +460,14 @@ func TestUsecase_VerifyTile_WithGenerator(t *testing.T) {
 
 	tile, conf := initConfig(t, rawConfig)
 	params := &jenkinsModels.BuildParams{Job: "test"}
-	mockBuilder := func(_ interface{}) ([]models.GeneratedTile, error) {
+	mockBuilder := func(_ context.Context, _ interface{}) ([]models.GeneratedTile, error) {
 		return []models.GeneratedTile{{Params: params}}, nil
 	}
 
 	usecase := initConfigUsecase(nil)
-	usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}).
-		Enable(coreModels.DefaultVariant, &jenkinsModels.BuildGeneratorParams{}, mockBuilder)
-	usecase.verifyTile(conf, tile, nil)
+	usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}, &jenkinsModels.BuildGeneratorParams{}).
+		Enable(coreModels.DefaultVariant, mockBuilder)
+	usecase.verifyTile(conf, tile, nil, "/tiles/0")
 
 	assert.Len(t, conf.Errors, 0)
 }
@@ -371,10 +477,10 @@ func TestUsecase_VerifyTile_WithGenerator_WithWrongGenerator(t *testing.T) {
 
 	tile, conf := initConfig(t, rawConfig)
 	usecase := initConfigUsecase(nil)
-	usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}).
-		Enable(coreModels.DefaultVariant, &jenkinsModels.BuildGeneratorParams{}, nil)
+	usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}, &jenkinsModels.BuildGeneratorParams{}).
+		Enable(coreModels.DefaultVariant, nil)
 
-	usecase.verifyTile(conf, tile, nil)
+	usecase.verifyTile(conf, tile, nil, "/tiles/0")
 
 	if assert.Len(t, conf.Errors, 1) {
 		assert.Equal(t, models.ConfigErrorUnknownGeneratorTileType, conf.Errors[0].ID)
@@ -390,7 +496,7 @@ func TestUsecase_VerifyTile_WithWrongVariant(t *testing.T) {
 	tile, conf := initConfig(t, rawConfig)
 
 	usecase := initConfigUsecase(nil)
-	usecase.verifyTile(conf, tile, nil)
+	usecase.verifyTile(conf, tile, nil, "/tiles/0")
 
 	if assert.Len(t, conf.Errors, 1) {
 		assert.Equal(t, models.ConfigErrorUnknownVariant, conf.Errors[0].ID)
@@ -407,14 +513,14 @@ func TestUsecase_VerifyTile_WithGenerator_WithWrongVariant(t *testing.T) {
 
 	tile, conf := initConfig(t, rawConfig)
 	params := &jenkinsModels.BuildParams{Job: "test"}
-	mockBuilder := func(_ interface{}) ([]models.GeneratedTile, error) {
+	mockBuilder := func(_ context.Context, _ interface{}) ([]models.GeneratedTile, error) {
 		return []models.GeneratedTile{{Params: params}}, nil
 	}
 
 	usecase := initConfigUsecase(nil)
-	usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}).
-		Enable(coreModels.DefaultVariant, &jenkinsModels.BuildGeneratorParams{}, mockBuilder)
-	usecase.verifyTile(conf, tile, nil)
+	usecase.registry.RegisterGenerator(jenkinsApi.JenkinsBuildTileType, versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}, &jenkinsModels.BuildGeneratorParams{}).
+		Enable(coreModels.DefaultVariant, mockBuilder)
+	usecase.verifyTile(conf, tile, nil, "/tiles/0")
 
 	if assert.Len(t, conf.Errors, 1) {
 		assert.Equal(t, models.ConfigErrorUnknownVariant, conf.Errors[0].ID)
@@ -424,3 +530,57 @@ func TestUsecase_VerifyTile_WithGenerator_WithWrongVariant(t *testing.T) {
 		assert.Equal(t, `{"type":"GENERATE:JENKINS-BUILD","params":{"job":"job1"},"configVariant":"test"}`, conf.Errors[0].Data.ConfigExtract)
 	}
 }
+
+func TestUsecase_VerifyTile_WithRefreshInterval(t *testing.T) {
+	for _, testcase := range []struct {
+		rawConfig string
+		errorID   models.ConfigErrorID
+		expected  string
+	}{
+		{rawConfig: `{ "type": "PING", "refreshInterval": 5, "params": { "hostname": "server.com" } }`},
+		{rawConfig: `{ "type": "PINGDOM-CHECK", "refreshInterval": 60, "params": { "id": 10 } }`},
+		{rawConfig: `{ "type": "GROUP", "refreshInterval": 60, "tiles": [{ "type": "PING", "params": { "hostname": "server.com" } }] }`},
+		{
+			rawConfig: `{ "type": "PING", "refreshInterval": 0, "params": { "hostname": "server.com" } }`,
+			errorID:   models.ConfigErrorInvalidFieldValue,
+			expected:  "refreshInterval >= 5",
+		},
+		{
+			rawConfig: `{ "type": "PINGDOM-CHECK", "refreshInterval": 10, "params": { "id": 10 } }`,
+			errorID:   models.ConfigErrorInvalidFieldValue,
+			expected:  "refreshInterval >= 30",
+		},
+		{
+			rawConfig: `{ "type": "GROUP", "refreshInterval": 10, "tiles": [{ "type": "PINGDOM-CHECK", "params": { "id": 10 } }] }`,
+			errorID:   models.ConfigErrorInvalidFieldValue,
+			expected:  "refreshInterval >= 30",
+		},
+	} {
+		tile, conf := initConfig(t, testcase.rawConfig)
+		usecase := initConfigUsecase(nil)
+		usecase.verifyTile(conf, tile, nil, "/tiles/0")
+
+		if testcase.errorID == "" {
+			assert.Len(t, conf.Errors, 0)
+		} else if assert.Len(t, conf.Errors, 1) {
+			assert.Equal(t, testcase.errorID, conf.Errors[0].ID)
+			assert.Equal(t, "refreshInterval", conf.Errors[0].Data.FieldName)
+			assert.Equal(t, testcase.expected, conf.Errors[0].Data.Expected)
+		}
+	}
+}
+
+func TestUsecase_VerifyTile_WithRefreshInterval_NotSupportedInThisVersion(t *testing.T) {
+	rawConfig := `{ "type": "PING", "refreshInterval": 60, "params": { "hostname": "server.com" } }`
+
+	tile, conf := initConfig(t, rawConfig)
+	conf.Config.Version = models.ParseVersion(versions.Version2003)
+	usecase := initConfigUsecase(nil)
+	usecase.verifyTile(conf, tile, nil, "/tiles/0")
+
+	if assert.Len(t, conf.Errors, 1) {
+		assert.Equal(t, models.ConfigErrorFieldNotSupportedInThisVersion, conf.Errors[0].ID)
+		assert.Equal(t, "/tiles/0/refreshInterval", conf.Errors[0].Data.Pointer)
+		assert.Equal(t, fmt.Sprintf(`%q <= version`, versions.Version2004), conf.Errors[0].Data.Expected)
+	}
+}
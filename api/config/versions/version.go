@@ -6,8 +6,16 @@ import (
 
 // Versions
 const (
-	CurrentVersion = Version2000
+	CurrentVersion = Version2005
 	MinimalVersion = Version2000
 
 	Version2000 models.RawVersion = "2.0" // Initial version
+	Version2001 models.RawVersion = "2.1" // Add variables and ${var} / ${env:NAME} interpolation
+	Version2002 models.RawVersion = "2.2" // Add templates and includes
+	Version2003 models.RawVersion = "2.3" // Add pages
+	Version2004 models.RawVersion = "2.4" // Add tile refreshInterval
+	Version2005 models.RawVersion = "2.5" // Add match / unmatch params on GENERATE:GITHUB-CHECKS
 )
+
+// SupportedVersions list every version between MinimalVersion and CurrentVersion
+var SupportedVersions = []models.RawVersion{Version2000, Version2001, Version2002, Version2003, Version2004, Version2005}
@@ -0,0 +1,12 @@
+//go:generate mockery -name Watcher
+
+package config
+
+type (
+	Watcher interface {
+		// Watch call onChange each time file content at path change. Only one callback is registered by path
+		Watch(path string, onChange func()) error
+		// Unwatch stop watching file at path
+		Unwatch(path string)
+	}
+)
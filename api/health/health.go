@@ -0,0 +1,31 @@
+package health
+
+import (
+	"net/http"
+
+	"github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/service/health"
+
+	"github.com/labstack/echo/v4"
+)
+
+type HTTPHealthDelivery struct {
+	health *health.Health
+}
+
+func NewHTTPHealthDelivery(health *health.Health) *HTTPHealthDelivery {
+	return &HTTPHealthDelivery{health: health}
+}
+
+func (h *HTTPHealthDelivery) GetLive(c echo.Context) error {
+	return c.JSON(http.StatusOK, h.health.GetLiveness())
+}
+
+// GetReady reply 503 when instance isn't ready (monitorables not enabled yet or errored variant)
+func (h *HTTPHealthDelivery) GetReady(c echo.Context) error {
+	response := h.health.GetReadiness()
+	if response.Status != models.HealthStatusUp {
+		return c.JSON(http.StatusServiceUnavailable, response)
+	}
+	return c.JSON(http.StatusOK, response)
+}
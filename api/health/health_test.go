@@ -0,0 +1,66 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/service/health"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func initHealthEcho(path string) (ctx echo.Context, res *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(echo.GET, path, nil)
+	res = httptest.NewRecorder()
+	ctx = e.NewContext(req, res)
+
+	return
+}
+
+func TestGetLive(t *testing.T) {
+	// Init
+	ctx, res := initHealthEcho("/health/live")
+	handler := NewHTTPHealthDelivery(health.NewHealth())
+
+	// Test
+	if assert.NoError(t, handler.GetLive(ctx)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.JSONEq(t, `{"status":"UP"}`, res.Body.String())
+	}
+}
+
+func TestGetReady(t *testing.T) {
+	monitororHealth := health.NewHealth()
+	handler := NewHTTPHealthDelivery(monitororHealth)
+
+	// Monitorables not enabled yet
+	ctx, res := initHealthEcho("/health/ready")
+	if assert.NoError(t, handler.GetReady(ctx)) {
+		assert.Equal(t, http.StatusServiceUnavailable, res.Code)
+	}
+
+	// Monitorables enabled
+	monitororHealth.SetMonitorables([]models.MonitorableHealth{
+		{
+			Name:             "Jenkins",
+			EnabledVariants:  []models.VariantName{models.DefaultVariant},
+			ErroredVariants:  []models.ErroredVariant{},
+			DisabledVariants: []models.VariantName{},
+		},
+	})
+
+	ctx, res = initHealthEcho("/health/ready")
+	if assert.NoError(t, handler.GetReady(ctx)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+
+		var response models.HealthResponse
+		assert.NoError(t, json.Unmarshal(res.Body.Bytes(), &response))
+		assert.Equal(t, models.HealthStatusUp, response.Status)
+		assert.Len(t, response.Monitorables, 1)
+	}
+}
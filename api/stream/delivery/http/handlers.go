@@ -0,0 +1,116 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"time"
+
+	configDelivery "github.com/monitoror/monitoror/api/config/delivery/http"
+	configModels "github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/stream"
+	"github.com/monitoror/monitoror/api/stream/models"
+	coreModels "github.com/monitoror/monitoror/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// KeepAliveInterval is the interval between two SSE comments sent to keep connection open behind proxies
+var KeepAliveInterval = 30 * time.Second
+
+type StreamDelivery struct {
+	configDelivery *configDelivery.ConfigDelivery
+	streamUsecase  stream.Usecase
+}
+
+func NewStreamDelivery(cd *configDelivery.ConfigDelivery, su stream.Usecase) *StreamDelivery {
+	return &StreamDelivery{cd, su}
+}
+
+// GetStream send config as first Server-Sent Event then send every tile when his content change and every server event
+func (h *StreamDelivery) GetStream(c echo.Context) error {
+	// Bind / check Params
+	params := &configModels.ConfigParams{}
+	err := c.Bind(params)
+	if err != nil || !params.IsValid() {
+		return coreModels.ParamsError
+	}
+
+	configBag := h.configDelivery.LoadConfig(c.Request().Context(), c.Request().RequestURI, params)
+
+	response := c.Response()
+	response.Header().Set(echo.HeaderContentType, "text/event-stream")
+	response.Header().Set("Cache-Control", "no-cache")
+	response.Header().Set("Connection", "keep-alive")
+	response.Header().Set("X-Accel-Buffering", "no") // Disable nginx buffering
+	response.WriteHeader(http.StatusOK)
+
+	if err := writeEvent(response, models.ConfigEventType, configBag); err != nil || len(configBag.Errors) > 0 {
+		return nil
+	}
+
+	ctx := c.Request().Context()
+	events := h.streamUsecase.Watch(ctx, configTileURLs(configBag.Config))
+	serverEvents := h.streamUsecase.Listen(ctx)
+
+	keepAlive := time.NewTicker(KeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(response, ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+			response.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := writeEvent(response, models.TileEventType, event); err != nil {
+				return nil
+			}
+		case event, ok := <-serverEvents:
+			if !ok {
+				return nil
+			}
+			if err := writeEvent(response, event.Type, event); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
+func writeEvent(response *echo.Response, eventType models.EventType, data interface{}) error {
+	encoded, _ := configDelivery.JSONMarshal(data) // Ignoring error, assuming there is no function or channel inside this struct
+
+	if _, err := fmt.Fprintf(response, "event: %s\ndata: %s\n\n", eventType, bytes.TrimSpace(encoded)); err != nil {
+		return err
+	}
+	response.Flush()
+
+	return nil
+}
+
+// configTileURLs extract every hydrated tile url of config (including tiles of every page)
+func configTileURLs(config *configModels.Config) []string {
+	urls := tileURLs(config.Tiles)
+	for _, page := range config.Pages {
+		urls = append(urls, tileURLs(page.Tiles)...)
+	}
+	return urls
+}
+
+// tileURLs extract every hydrated tile url (including tiles in group)
+func tileURLs(tiles []configModels.TileConfig) []string {
+	var urls []string
+	for _, tile := range tiles {
+		if tile.URL != "" {
+			urls = append(urls, tile.URL)
+		}
+		urls = append(urls, tileURLs(tile.Tiles)...)
+	}
+	return urls
+}
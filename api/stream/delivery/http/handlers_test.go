@@ -0,0 +1,201 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	configDelivery "github.com/monitoror/monitoror/api/config/delivery/http"
+	configMocks "github.com/monitoror/monitoror/api/config/mocks"
+	configModels "github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/stream/mocks"
+	"github.com/monitoror/monitoror/api/stream/models"
+	coreModels "github.com/monitoror/monitoror/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	. "github.com/stretchr/testify/mock"
+)
+
+func initEcho() (ctx echo.Context, res *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(echo.GET, "/api/v1/stream", nil)
+	res = httptest.NewRecorder()
+	ctx = e.NewContext(req, res)
+
+	return
+}
+
+func TestDelivery_GetStream_Success(t *testing.T) {
+	// Init
+	ctx, res := initEcho()
+	ctx.QueryParams().Set("url", "monitoror.example.com")
+
+	configBag := &configModels.ConfigBag{Config: &configModels.Config{
+		Tiles: []configModels.TileConfig{
+			{Type: "PING", URL: "/ping?hostname=a"},
+			{Type: "GROUP", Tiles: []configModels.TileConfig{{Type: "PORT", URL: "/port?hostname=b"}}},
+		},
+	}}
+
+	events := make(chan models.TileEvent, 1)
+	events <- models.TileEvent{URL: "/ping?hostname=a", Tile: &coreModels.Tile{Type: "PING", Status: coreModels.SuccessStatus}}
+	close(events)
+
+	mockConfigUsecase := new(configMocks.Usecase)
+	mockConfigUsecase.On("GetConfig", Anything).Return(configBag)
+	mockConfigUsecase.On("Verify", Anything)
+	mockConfigUsecase.On("Hydrate", Anything, Anything)
+	mockStreamUsecase := new(mocks.Usecase)
+	mockStreamUsecase.On("Watch", Anything, []string{"/ping?hostname=a", "/port?hostname=b"}).Return((<-chan models.TileEvent)(events))
+	mockStreamUsecase.On("Listen", Anything).Return((<-chan models.ServerEvent)(make(chan models.ServerEvent)))
+	handler := NewStreamDelivery(configDelivery.NewConfigDelivery(mockConfigUsecase), mockStreamUsecase)
+
+	// Test
+	if assert.NoError(t, handler.GetStream(ctx)) {
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "text/event-stream", res.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, `event: config
+data: {"config":{"version":null,"columns":null,"tiles":[{"type":"PING","url":"/ping?hostname=a"},{"type":"GROUP","tiles":[{"type":"PORT","url":"/port?hostname=b"}]}]},"revision":"`+configBag.Revision+`"}
+
+event: tile
+data: {"url":"/ping?hostname=a","tile":{"type":"PING","status":"SUCCESS"}}
+
+`, res.Body.String())
+		mockConfigUsecase.AssertExpectations(t)
+		mockStreamUsecase.AssertExpectations(t)
+	}
+}
+
+func TestDelivery_GetStream_Pages(t *testing.T) {
+	// Init
+	ctx, res := initEcho()
+	ctx.QueryParams().Set("path", "config.json")
+	ctx.QueryParams().Set("page", "2")
+
+	configBag := &configModels.ConfigBag{Config: &configModels.Config{
+		Pages: []configModels.PageConfig{
+			{Tiles: []configModels.TileConfig{{Type: "PING", URL: "/ping?hostname=a"}}},
+			{Tiles: []configModels.TileConfig{{Type: "GROUP", Tiles: []configModels.TileConfig{{Type: "PORT", URL: "/port?hostname=b"}}}}},
+		},
+	}}
+
+	events := make(chan models.TileEvent)
+	close(events)
+
+	mockConfigUsecase := new(configMocks.Usecase)
+	mockConfigUsecase.On("GetConfig", &configModels.ConfigParams{Path: "config.json", Page: 2}).Return(configBag)
+	mockConfigUsecase.On("Verify", Anything)
+	mockConfigUsecase.On("SelectPage", Anything, 2).Run(func(args Arguments) {
+		bag := args.Get(0).(*configModels.ConfigBag)
+		bag.Config.Pages = bag.Config.Pages[1:2]
+	})
+	mockConfigUsecase.On("Hydrate", Anything, Anything)
+	mockStreamUsecase := new(mocks.Usecase)
+	mockStreamUsecase.On("Watch", Anything, []string{"/port?hostname=b"}).Return((<-chan models.TileEvent)(events))
+	mockStreamUsecase.On("Listen", Anything).Return((<-chan models.ServerEvent)(make(chan models.ServerEvent)))
+	handler := NewStreamDelivery(configDelivery.NewConfigDelivery(mockConfigUsecase), mockStreamUsecase)
+
+	// Test
+	if assert.NoError(t, handler.GetStream(ctx)) {
+		assert.Contains(t, res.Body.String(), `"pages":[{"tiles":[{"type":"GROUP","tiles":[{"type":"PORT","url":"/port?hostname=b"}]}]}]`)
+		mockConfigUsecase.AssertExpectations(t)
+		mockStreamUsecase.AssertExpectations(t)
+	}
+}
+
+func TestDelivery_GetStream_WatchConfigFiles(t *testing.T) {
+	// Init
+	ctx, _ := initEcho()
+	ctx.Request().RequestURI = "/api/v1/stream?name=default"
+	ctx.QueryParams().Set("name", "default")
+
+	events := make(chan models.TileEvent)
+	close(events)
+
+	mockConfigUsecase := new(configMocks.Usecase)
+	mockConfigUsecase.On("GetConfig", &configModels.ConfigParams{Name: "default"}).
+		Return(&configModels.ConfigBag{Config: &configModels.Config{Files: []string{"/config/default.json"}}})
+	mockConfigUsecase.On("Verify", Anything)
+	mockConfigUsecase.On("Hydrate", Anything, Anything)
+	mockStreamUsecase := new(mocks.Usecase)
+	mockStreamUsecase.On("Watch", Anything, Anything).Return((<-chan models.TileEvent)(events))
+	mockStreamUsecase.On("Listen", Anything).Return((<-chan models.ServerEvent)(make(chan models.ServerEvent)))
+
+	var onChange func()
+	mockWatcher := new(configMocks.Watcher)
+	mockWatcher.On("Watch", "/config/default.json", Anything).
+		Run(func(args Arguments) { onChange = args.Get(1).(func()) }).
+		Return(nil)
+	mockWatcher.On("Unwatch", Anything)
+
+	var changedRequestURI string
+	confDelivery := configDelivery.NewConfigDelivery(mockConfigUsecase)
+	confDelivery.WatchConfigFiles(mockWatcher, func(requestURI string, _ string) { changedRequestURI = requestURI })
+	handler := NewStreamDelivery(confDelivery, mockStreamUsecase)
+
+	// Test
+	if assert.NoError(t, handler.GetStream(ctx)) && assert.NotNil(t, onChange) {
+		onChange()
+		assert.Equal(t, "/api/v1/stream?name=default", changedRequestURI)
+	}
+}
+
+func TestDelivery_GetStream_QueryParamsError(t *testing.T) {
+	// Init
+	ctx, _ := initEcho()
+
+	handler := NewStreamDelivery(configDelivery.NewConfigDelivery(new(configMocks.Usecase)), new(mocks.Usecase))
+
+	// Test
+	err := handler.GetStream(ctx)
+	assert.Error(t, err)
+	assert.IsType(t, &coreModels.MonitororError{}, err)
+}
+
+func TestDelivery_GetStream_ConfigError(t *testing.T) {
+	// Init
+	ctx, res := initEcho()
+	ctx.QueryParams().Set("url", "monitoror.example.com")
+
+	configBag := &configModels.ConfigBag{}
+	configBag.AddErrors(configModels.ConfigError{ID: configModels.ConfigErrorConfigNotFound, Message: "boom"})
+
+	mockConfigUsecase := new(configMocks.Usecase)
+	mockConfigUsecase.On("GetConfig", Anything).Return(configBag)
+	mockStreamUsecase := new(mocks.Usecase)
+	handler := NewStreamDelivery(configDelivery.NewConfigDelivery(mockConfigUsecase), mockStreamUsecase)
+
+	// Test
+	if assert.NoError(t, handler.GetStream(ctx)) {
+		assert.Equal(t, "event: config\ndata: {\"errors\":[{\"id\":\"ERROR_CONFIG_NOT_FOUND\",\"message\":\"boom\",\"data\":{}}],\"revision\":\""+configBag.Revision+"\"}\n\n", res.Body.String())
+		mockConfigUsecase.AssertNumberOfCalls(t, "Verify", 0)
+		mockStreamUsecase.AssertNumberOfCalls(t, "Watch", 0)
+	}
+}
+
+func TestDelivery_GetStream_ServerEvent(t *testing.T) {
+	// Init
+	ctx, res := initEcho()
+	ctx.QueryParams().Set("path", "config.json")
+
+	configBag := &configModels.ConfigBag{Config: &configModels.Config{}}
+
+	serverEvents := make(chan models.ServerEvent, 1)
+	serverEvents <- models.ServerEvent{Type: models.ReloadEventType}
+	close(serverEvents)
+
+	mockConfigUsecase := new(configMocks.Usecase)
+	mockConfigUsecase.On("GetConfig", Anything).Return(configBag)
+	mockConfigUsecase.On("Verify", Anything)
+	mockConfigUsecase.On("Hydrate", Anything, Anything)
+	mockStreamUsecase := new(mocks.Usecase)
+	mockStreamUsecase.On("Watch", Anything, Anything).Return((<-chan models.TileEvent)(make(chan models.TileEvent)))
+	mockStreamUsecase.On("Listen", Anything).Return((<-chan models.ServerEvent)(serverEvents))
+	handler := NewStreamDelivery(configDelivery.NewConfigDelivery(mockConfigUsecase), mockStreamUsecase)
+
+	// Test
+	if assert.NoError(t, handler.GetStream(ctx)) {
+		assert.Contains(t, res.Body.String(), "event: reload\ndata: {\"type\":\"reload\"}\n\n")
+	}
+}
@@ -0,0 +1,191 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	configDelivery "github.com/monitoror/monitoror/api/config/delivery/http"
+	configModels "github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/stream"
+	"github.com/monitoror/monitoror/api/stream/models"
+	"github.com/monitoror/monitoror/service/registry"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/net/websocket"
+)
+
+// KeepAliveInterval is the interval between two keep-alive frames sent to keep connection open behind proxies
+var KeepAliveInterval = 30 * time.Second
+
+type (
+	WebSocketDelivery struct {
+		configDelivery *configDelivery.ConfigDelivery
+		streamUsecase  stream.Usecase
+		registry       *registry.MetadataRegistry
+
+		// allowedOrigins are origins allowed to open websocket from browser (see checkOrigin)
+		allowedOrigins []string
+		authEnabled    bool
+	}
+
+	// session of one websocket client
+	session struct {
+		conn       *websocket.Conn
+		writeMutex sync.Mutex
+
+		// subscriptions contains cancel function of every subscribed tile url
+		subscriptions map[string]context.CancelFunc
+	}
+)
+
+// NewWebSocketDelivery use allowedOrigins of CORS configuration. "*" only allow every origin when authentication is disabled
+func NewWebSocketDelivery(cd *configDelivery.ConfigDelivery, su stream.Usecase, registry *registry.MetadataRegistry, allowedOrigins []string, authEnabled bool) *WebSocketDelivery {
+	return &WebSocketDelivery{configDelivery: cd, streamUsecase: su, registry: registry, allowedOrigins: allowedOrigins, authEnabled: authEnabled}
+}
+
+// GetWebSocket upgrade connection and handle client subscriptions until client disconnect.
+// Config params (url, path or name) are optional, when given the client receive reload event when config files change
+func (h *WebSocketDelivery) GetWebSocket(c echo.Context) error {
+	params := &configModels.ConfigParams{}
+	if err := c.Bind(params); err == nil && params.IsValid() {
+		h.configDelivery.WatchConfig(c.Request().RequestURI, params)
+	}
+
+	server := websocket.Server{Handler: h.handleSession, Handshake: h.checkOrigin}
+	server.ServeHTTP(c.Response(), c.Request())
+	return nil
+}
+
+// checkOrigin refuse websocket opened by other sites (browsers send cookies with websocket handshake, CORS doesn't apply).
+// Clients without Origin header (not a browser) and same origin are allowed
+func (h *WebSocketDelivery) checkOrigin(_ *websocket.Config, req *http.Request) error {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return nil
+	}
+
+	originURL, err := url.Parse(origin)
+	if err != nil {
+		return fmt.Errorf("invalid origin %q", origin)
+	}
+	if originURL.Host == req.Host {
+		return nil
+	}
+
+	for _, allowedOrigin := range h.allowedOrigins {
+		if (allowedOrigin == "*" && !h.authEnabled) || strings.EqualFold(allowedOrigin, origin) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("origin %q not allowed", origin)
+}
+
+func (h *WebSocketDelivery) handleSession(conn *websocket.Conn) {
+	ctx, cancel := context.WithCancel(conn.Request().Context())
+	defer cancel()
+
+	s := &session{conn: conn, subscriptions: make(map[string]context.CancelFunc)}
+	go s.forwardServerEvents(ctx, h.streamUsecase.Listen(ctx))
+
+	for {
+		var command models.Command
+		if err := websocket.JSON.Receive(conn, &command); err != nil {
+			switch err.(type) {
+			case *json.SyntaxError, *json.UnmarshalTypeError:
+				s.sendError(fmt.Sprintf("unable to parse command, %v", err))
+				continue
+			default:
+				// Connection closed
+				return
+			}
+		}
+
+		switch command.Action {
+		case models.SubscribeAction:
+			for _, tileURL := range command.URLs {
+				if _, exists := s.subscriptions[tileURL]; exists {
+					continue
+				}
+
+				if !h.isTileURL(tileURL) {
+					s.sendError(fmt.Sprintf("unable to subscribe to %q, unknown tile url", tileURL))
+					continue
+				}
+
+				subscriptionCtx, subscriptionCancel := context.WithCancel(ctx)
+				s.subscriptions[tileURL] = subscriptionCancel
+				go s.forwardTileEvents(h.streamUsecase.Watch(subscriptionCtx, []string{tileURL}))
+			}
+		case models.UnsubscribeAction:
+			for _, tileURL := range command.URLs {
+				if subscriptionCancel, exists := s.subscriptions[tileURL]; exists {
+					subscriptionCancel()
+					delete(s.subscriptions, tileURL)
+				}
+			}
+		default:
+			s.sendError(fmt.Sprintf("unknown %q action. Must be %s or %s", command.Action, models.SubscribeAction, models.UnsubscribeAction))
+		}
+	}
+}
+
+// isTileURL check if url target an enabled tile route
+func (h *WebSocketDelivery) isTileURL(rawURL string) bool {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	for _, tileMetadata := range h.registry.TileMetadata {
+		for _, variantMetadata := range tileMetadata.VariantsMetadata {
+			if variantMetadata.Enabled && variantMetadata.RoutePath != nil && *variantMetadata.RoutePath == parsedURL.Path {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (s *session) forwardTileEvents(events <-chan models.TileEvent) {
+	for event := range events {
+		_ = s.send(models.Frame{Type: models.TileEventType, Data: event})
+	}
+}
+
+func (s *session) forwardServerEvents(ctx context.Context, events <-chan models.ServerEvent) {
+	keepAlive := time.NewTicker(KeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepAlive.C:
+			_ = s.send(models.Frame{Type: models.KeepAliveEventType})
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			_ = s.send(models.Frame{Type: event.Type, Data: event})
+		}
+	}
+}
+
+func (s *session) sendError(message string) {
+	_ = s.send(models.Frame{Type: models.ErrorEventType, Data: models.ServerEvent{Type: models.ErrorEventType, Message: message}})
+}
+
+func (s *session) send(frame models.Frame) error {
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+
+	return websocket.JSON.Send(s.conn, frame)
+}
@@ -0,0 +1,175 @@
+package websocket
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	configDelivery "github.com/monitoror/monitoror/api/config/delivery/http"
+	configMocks "github.com/monitoror/monitoror/api/config/mocks"
+	configModels "github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/api/config/versions"
+	"github.com/monitoror/monitoror/api/stream/mocks"
+	"github.com/monitoror/monitoror/api/stream/models"
+	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/service/registry"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	. "github.com/stretchr/testify/mock"
+	"golang.org/x/net/websocket"
+)
+
+func initWebSocket(t *testing.T, mockUsecase *mocks.Usecase) (*websocket.Conn, func()) {
+	r := registry.NewRegistry()
+	r.RegisterTile("PING", versions.MinimalVersion, []coreModels.VariantName{coreModels.DefaultVariant}, 0, nil).
+		Enable(coreModels.DefaultVariant, "/api/v1/ping/default/ping")
+
+	e := echo.New()
+	e.GET("/api/v1/ws", NewWebSocketDelivery(configDelivery.NewConfigDelivery(new(configMocks.Usecase)), mockUsecase, r, []string{"*"}, false).GetWebSocket)
+	ts := httptest.NewServer(e)
+
+	conn, err := websocket.Dial(strings.Replace(ts.URL, "http", "ws", 1)+"/api/v1/ws", "", ts.URL)
+	assert.NoError(t, err)
+
+	return conn, func() {
+		_ = conn.Close()
+		ts.Close()
+	}
+}
+
+func receive(t *testing.T, conn *websocket.Conn) map[string]interface{} {
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+
+	frame := make(map[string]interface{})
+	assert.NoError(t, websocket.JSON.Receive(conn, &frame))
+	return frame
+}
+
+func TestDelivery_WebSocket_Subscribe(t *testing.T) {
+	tileEvents := make(chan models.TileEvent, 1)
+	tileEvents <- models.TileEvent{URL: "/api/v1/ping/default/ping?hostname=a", Tile: &coreModels.Tile{Type: "PING", Status: coreModels.SuccessStatus}}
+
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("Listen", Anything).Return((<-chan models.ServerEvent)(make(chan models.ServerEvent)))
+	mockUsecase.On("Watch", Anything, []string{"/api/v1/ping/default/ping?hostname=a"}).Return((<-chan models.TileEvent)(tileEvents))
+
+	conn, closeFunc := initWebSocket(t, mockUsecase)
+	defer closeFunc()
+
+	assert.NoError(t, websocket.JSON.Send(conn, models.Command{Action: models.SubscribeAction, URLs: []string{"/api/v1/ping/default/ping?hostname=a"}}))
+
+	frame := receive(t, conn)
+	assert.Equal(t, "tile", frame["type"])
+	assert.Equal(t, "/api/v1/ping/default/ping?hostname=a", frame["data"].(map[string]interface{})["url"])
+
+	// Subscribe again to the same url is ignored
+	assert.NoError(t, websocket.JSON.Send(conn, models.Command{Action: models.SubscribeAction, URLs: []string{"/api/v1/ping/default/ping?hostname=a"}}))
+	assert.NoError(t, websocket.JSON.Send(conn, models.Command{Action: models.UnsubscribeAction, URLs: []string{"/api/v1/ping/default/ping?hostname=a"}}))
+	assert.NoError(t, websocket.JSON.Send(conn, models.Command{Action: "boom"}))
+
+	frame = receive(t, conn)
+	assert.Equal(t, "error", frame["type"])
+	mockUsecase.AssertNumberOfCalls(t, "Watch", 1)
+}
+
+func TestDelivery_WebSocket_UnknownURL(t *testing.T) {
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("Listen", Anything).Return((<-chan models.ServerEvent)(make(chan models.ServerEvent)))
+
+	conn, closeFunc := initWebSocket(t, mockUsecase)
+	defer closeFunc()
+
+	assert.NoError(t, websocket.JSON.Send(conn, models.Command{Action: models.SubscribeAction, URLs: []string{"/api/v1/ws"}}))
+
+	frame := receive(t, conn)
+	assert.Equal(t, "error", frame["type"])
+	assert.Equal(t, `unable to subscribe to "/api/v1/ws", unknown tile url`, frame["data"].(map[string]interface{})["message"])
+	mockUsecase.AssertNumberOfCalls(t, "Watch", 0)
+}
+
+func TestDelivery_WebSocket_ServerEvent(t *testing.T) {
+	serverEvents := make(chan models.ServerEvent, 1)
+	serverEvents <- models.ServerEvent{Type: models.ReloadEventType, Message: "config changed"}
+
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("Listen", Anything).Return((<-chan models.ServerEvent)(serverEvents))
+
+	conn, closeFunc := initWebSocket(t, mockUsecase)
+	defer closeFunc()
+
+	frame := receive(t, conn)
+	assert.Equal(t, "reload", frame["type"])
+	assert.Equal(t, "config changed", frame["data"].(map[string]interface{})["message"])
+}
+
+func TestDelivery_WebSocket_WatchConfig(t *testing.T) {
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("Listen", Anything).Return((<-chan models.ServerEvent)(make(chan models.ServerEvent)))
+
+	mockConfigUsecase := new(configMocks.Usecase)
+	mockConfigUsecase.On("GetConfig", &configModels.ConfigParams{Name: "default"}).
+		Return(&configModels.ConfigBag{Config: &configModels.Config{Files: []string{"/config/default.json"}}})
+	mockConfigUsecase.On("Verify", Anything)
+	mockWatcher := new(configMocks.Watcher)
+	mockWatcher.On("Watch", "/config/default.json", Anything).Return(nil)
+
+	confDelivery := configDelivery.NewConfigDelivery(mockConfigUsecase)
+	confDelivery.WatchConfigFiles(mockWatcher, func(string, string) {})
+
+	e := echo.New()
+	e.GET("/api/v1/ws", NewWebSocketDelivery(confDelivery, mockUsecase, registry.NewRegistry(), nil, false).GetWebSocket)
+	ts := httptest.NewServer(e)
+	defer ts.Close()
+
+	conn, err := websocket.Dial(strings.Replace(ts.URL, "http", "ws", 1)+"/api/v1/ws?name=default", "", ts.URL)
+	if assert.NoError(t, err) {
+		_ = conn.Close()
+
+		// Config files are watched without hydrating config
+		mockWatcher.AssertExpectations(t)
+		mockConfigUsecase.AssertNotCalled(t, "Hydrate", Anything, Anything)
+	}
+}
+
+func TestDelivery_WebSocket_Origin(t *testing.T) {
+	mockUsecase := new(mocks.Usecase)
+	mockUsecase.On("Listen", Anything).Return((<-chan models.ServerEvent)(make(chan models.ServerEvent)))
+
+	for _, testcase := range []struct {
+		origin         string
+		allowedOrigins []string
+		authEnabled    bool
+		expectedError  bool
+	}{
+		{origin: "https://evil.example.com", allowedOrigins: []string{"*"}, authEnabled: false},
+		{origin: "https://evil.example.com", allowedOrigins: []string{"*"}, authEnabled: true, expectedError: true},
+		{origin: "https://wall.example.com", allowedOrigins: []string{"https://wall.example.com"}, authEnabled: true},
+		{origin: "https://evil.example.com", allowedOrigins: []string{"https://wall.example.com"}, authEnabled: false, expectedError: true},
+		{origin: "same", allowedOrigins: nil, authEnabled: true},
+	} {
+		e := echo.New()
+		e.GET("/api/v1/ws", NewWebSocketDelivery(configDelivery.NewConfigDelivery(new(configMocks.Usecase)), mockUsecase, registry.NewRegistry(), testcase.allowedOrigins, testcase.authEnabled).GetWebSocket)
+		ts := httptest.NewServer(e)
+
+		origin := testcase.origin
+		if origin == "same" {
+			origin = ts.URL
+		}
+
+		conn, err := websocket.Dial(strings.Replace(ts.URL, "http", "ws", 1)+"/api/v1/ws", "", origin)
+		if testcase.expectedError {
+			assert.Error(t, err, testcase.origin)
+		} else if assert.NoError(t, err, testcase.origin) {
+			_ = conn.Close()
+		}
+
+		ts.Close()
+	}
+
+	// Clients without Origin header aren't browsers
+	delivery := NewWebSocketDelivery(configDelivery.NewConfigDelivery(new(configMocks.Usecase)), mockUsecase, registry.NewRegistry(), nil, true)
+	assert.NoError(t, delivery.checkOrigin(nil, httptest.NewRequest(http.MethodGet, "/api/v1/ws", nil)))
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	coreModels "github.com/monitoror/monitoror/models"
+)
+
+type (
+	EventType     string
+	CommandAction string
+
+	// TileEvent is pushed to stream clients each time the tile behind URL has changed
+	TileEvent struct {
+		URL  string           `json:"url"`
+		Tile *coreModels.Tile `json:"tile"`
+	}
+
+	// ServerEvent is pushed to every stream clients when something happen on server side (ex: config changed)
+	ServerEvent struct {
+		Type    EventType `json:"type"`
+		Message string    `json:"message,omitempty"`
+
+		// Request URI (config, stream or websocket) and new revision of the changed config for ReloadEventType
+		URL      string `json:"url,omitempty"`
+		Revision string `json:"revision,omitempty"`
+	}
+
+	// Frame is the envelope of every message sent to websocket clients
+	Frame struct {
+		Type EventType   `json:"type"`
+		Data interface{} `json:"data,omitempty"`
+	}
+
+	// Command is sent by websocket clients to manage their tile subscriptions
+	Command struct {
+		Action CommandAction `json:"action"`
+		URLs   []string      `json:"urls"`
+	}
+)
+
+const (
+	ConfigEventType    EventType = "config"
+	TileEventType      EventType = "tile"
+	ReloadEventType    EventType = "reload"
+	ErrorEventType     EventType = "error"
+	KeepAliveEventType EventType = "keep-alive"
+
+	SubscribeAction   CommandAction = "subscribe"
+	UnsubscribeAction CommandAction = "unsubscribe"
+)
@@ -0,0 +1,17 @@
+//go:generate mockery -name Usecase
+
+package stream
+
+import (
+	"context"
+
+	"github.com/monitoror/monitoror/api/stream/models"
+)
+
+type (
+	Usecase interface {
+		Watch(ctx context.Context, urls []string) <-chan models.TileEvent
+		Listen(ctx context.Context) <-chan models.ServerEvent
+		Notify(event models.ServerEvent)
+	}
+)
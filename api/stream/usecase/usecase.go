@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	neturl "net/url"
+	"sync"
+	"time"
+
+	"github.com/monitoror/monitoror/api/stream"
+	"github.com/monitoror/monitoror/api/stream/models"
+	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/pkg/hash"
+	"github.com/monitoror/monitoror/service/store"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"github.com/labstack/gommon/random"
+)
+
+const listenerBufferSize = 10
+
+type (
+	streamUsecase struct {
+		// handler is the echo server itself. Tiles are fetched through it to reuse routes and cache middlewares
+		handler http.Handler
+
+		refreshInterval time.Duration
+		initialMaxDelay time.Duration
+
+		// listeners of server events
+		listenersMutex sync.RWMutex
+		listeners      map[chan models.ServerEvent]bool
+	}
+
+	// responseRecorder is a minimal http.ResponseWriter used to capture tile responses
+	responseRecorder struct {
+		header http.Header
+		status int
+		body   bytes.Buffer
+	}
+)
+
+func NewStreamUsecase(handler http.Handler, store *store.Store) stream.Usecase {
+	return &streamUsecase{
+		handler:         handler,
+		refreshInterval: time.Millisecond * time.Duration(store.CoreConfig.StreamRefreshInterval),
+		initialMaxDelay: time.Millisecond * time.Duration(store.CoreConfig.InitialMaxDelay),
+		listeners:       make(map[chan models.ServerEvent]bool),
+	}
+}
+
+// Watch refresh every tile url on refresh interval and publish it when his content changed.
+// Returned channel is closed when ctx is done.
+func (su *streamUsecase) Watch(ctx context.Context, urls []string) <-chan models.TileEvent {
+	events := make(chan models.TileEvent)
+
+	var wg sync.WaitGroup
+	watchedURLs := make(map[string]bool)
+	for _, url := range urls {
+		// Same tile can be used many times in the same config, refresh it only once
+		if watchedURLs[url] {
+			continue
+		}
+		watchedURLs[url] = true
+
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			su.watchTile(ctx, url, events)
+		}(url)
+	}
+
+	go func() {
+		wg.Wait()
+		close(events)
+	}()
+
+	return events
+}
+
+// Listen return a channel receiving every server events until ctx is done.
+func (su *streamUsecase) Listen(ctx context.Context) <-chan models.ServerEvent {
+	listener := make(chan models.ServerEvent, listenerBufferSize)
+
+	su.listenersMutex.Lock()
+	su.listeners[listener] = true
+	su.listenersMutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		su.listenersMutex.Lock()
+		delete(su.listeners, listener)
+		close(listener)
+		su.listenersMutex.Unlock()
+	}()
+
+	return listener
+}
+
+// Notify send event to every listeners. Event is dropped for listeners which are not consuming them
+func (su *streamUsecase) Notify(event models.ServerEvent) {
+	su.listenersMutex.RLock()
+	defer su.listenersMutex.RUnlock()
+
+	for listener := range su.listeners {
+		select {
+		case listener <- event:
+		default:
+			log.Warnf("stream listener is full, %s event dropped", event.Type)
+		}
+	}
+}
+
+func (su *streamUsecase) watchTile(ctx context.Context, url string, events chan<- models.TileEvent) {
+	// Add initial delay to avoid bursting every requests in same time on start (same as UI)
+	var delay time.Duration
+	if su.initialMaxDelay > 0 {
+		delay = time.Duration(rand.Int63n(int64(su.initialMaxDelay)))
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	// Tiles with custom refresh interval are refreshed less often
+	refreshInterval := su.refreshInterval
+	if parsedURL, err := neturl.Parse(url); err == nil {
+		if tileRefreshInterval := coreModels.ParseRefreshInterval(parsedURL.Query()); tileRefreshInterval > refreshInterval {
+			refreshInterval = tileRefreshInterval
+		}
+	}
+
+	var previousHash string
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		// Request ID is generated here to find refresh request in logs
+		requestID := random.String(32)
+		tile, tileHash, err := su.fetchTile(ctx, url, requestID)
+		if err != nil {
+			log.Warnf("unable to refresh tile %s (request id: %s), %v", url, requestID, err)
+		} else if tileHash != previousHash {
+			previousHash = tileHash
+
+			select {
+			case <-ctx.Done():
+				return
+			case events <- models.TileEvent{URL: url, Tile: tile}:
+			}
+		}
+
+		timer.Reset(refreshInterval)
+	}
+}
+
+func (su *streamUsecase) fetchTile(ctx context.Context, url, requestID string) (*coreModels.Tile, string, error) {
+	req, err := http.NewRequestWithContext(coreModels.ContextWithRequestID(ctx, requestID), http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	// Kept by echo RequestID middleware instead of generating a new one
+	req.Header.Set(echo.HeaderXRequestID, requestID)
+	// RequestURI is not set by NewRequest but is used by cache middleware to build cache key
+	req.RequestURI = url
+
+	recorder := newResponseRecorder()
+	su.handler.ServeHTTP(recorder, req)
+
+	if recorder.status != http.StatusOK {
+		return nil, "", fmt.Errorf("unexpected status code: %d", recorder.status)
+	}
+
+	tile := &coreModels.Tile{}
+	if err := json.Unmarshal(recorder.body.Bytes(), tile); err != nil {
+		return nil, "", err
+	}
+
+	return tile, hash.GetMD5Hash(recorder.body.String()), nil
+}
+
+func newResponseRecorder() *responseRecorder {
+	return &responseRecorder{header: make(http.Header), status: http.StatusOK}
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/monitoror/monitoror/api/stream/models"
+	coreConfig "github.com/monitoror/monitoror/config"
+	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/service/store"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func initStreamUsecase(handler http.Handler) *streamUsecase {
+	s := &store.Store{
+		CoreConfig: &coreConfig.Config{StreamRefreshInterval: 10},
+	}
+
+	return NewStreamUsecase(handler, s).(*streamUsecase)
+}
+
+func TestUsecase_Watch_OnlyChangedTiles(t *testing.T) {
+	var calls int32
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/ping", r.RequestURI)
+		assert.NotEmpty(t, r.Header.Get("X-Request-ID"))
+		assert.Equal(t, r.Header.Get("X-Request-ID"), coreModels.RequestIDFromContext(r.Context()))
+		count := atomic.AddInt32(&calls, 1)
+		// Status change every 3 calls
+		_, _ = fmt.Fprintf(w, `{"type":"PING","status":"SUCCESS","label":"%d"}`, (count-1)/3)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := initStreamUsecase(handler).Watch(ctx, []string{"/ping", "/ping"})
+
+	for i := 0; i < 2; i++ {
+		select {
+		case event := <-events:
+			assert.Equal(t, "/ping", event.URL)
+			assert.Equal(t, coreModels.SuccessStatus, event.Tile.Status)
+			assert.Equal(t, fmt.Sprint(i), event.Tile.Label)
+		case <-time.After(time.Second):
+			assert.Fail(t, "timeout waiting for tile event")
+		}
+	}
+
+	cancel()
+	for range events {
+		// Drain until close
+	}
+	assert.True(t, atomic.LoadInt32(&calls) >= 4)
+}
+
+func TestUsecase_Watch_Error(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	events := initStreamUsecase(handler).Watch(ctx, []string{"/ping"})
+	for range events {
+		assert.Fail(t, "unexpected tile event")
+	}
+}
+
+func TestUsecase_ListenAndNotify(t *testing.T) {
+	usecase := initStreamUsecase(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	listener1 := usecase.Listen(ctx)
+	listener2 := usecase.Listen(context.Background())
+
+	usecase.Notify(models.ServerEvent{Type: models.ReloadEventType})
+	assert.Equal(t, models.ReloadEventType, (<-listener1).Type)
+	assert.Equal(t, models.ReloadEventType, (<-listener2).Type)
+
+	// Closed listener are removed
+	cancel()
+	_, ok := <-listener1
+	assert.False(t, ok)
+	assert.Len(t, usecase.listeners, 1)
+
+	// Full listener drop events without blocking
+	for i := 0; i < listenerBufferSize+1; i++ {
+		usecase.Notify(models.ServerEvent{Type: models.ReloadEventType})
+	}
+	assert.Len(t, listener2, listenerBufferSize)
+}
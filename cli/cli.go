@@ -4,8 +4,11 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"strings"
+	"time"
 
+	configModels "github.com/monitoror/monitoror/api/config/models"
 	"github.com/monitoror/monitoror/cli/version"
 	coreModels "github.com/monitoror/monitoror/models"
 
@@ -52,6 +55,20 @@ ENABLED MONITORABLES
 Check the documentation to know how to enabled them:
 %s
 `
+	diagnosisHeader = `
+MONITORABLES DIAGNOSIS
+
+`
+	diagnosisFooter        = "\n%d variant(s) diagnosed, %s\n"
+	diagnosisFooterNothing = "  No enabled variant can be diagnosed\n"
+
+	monitorableListHeader = `
+REGISTERED MONITORABLES
+
+`
+	configValid   = "%s %s is valid\n"
+	configInvalid = "%s %s has %d error(s)\n"
+
 	echoStartup = `
 
 Monitoror is running at:
@@ -68,7 +85,10 @@ type (
 		PrintMonitorableHeader()
 		PrintMonitorable(displayName string, enabledVariants []coreModels.VariantName, erroredVariants []ErroredVariant)
 		PrintMonitorableFooter(isProduction bool, nonEnabledMonitorableCount int)
-		PrintServerStartup(ip string, port int)
+		PrintDiagnosisHeader()
+		PrintMonitorableDiagnosis(displayName string, variantDiagnoses []VariantDiagnosis)
+		PrintDiagnosisFooter(diagnosedVariantCount int, failedVariantCount int)
+		PrintServerStartup(scheme string, ip string, port int)
 	}
 
 	ErroredVariant struct {
@@ -76,15 +96,65 @@ type (
 		Err         error
 	}
 
+	// VariantDiagnosis is result of connection check of an enabled variant. Used by doctor command
+	VariantDiagnosis struct {
+		VariantName coreModels.VariantName
+		Status      DiagnosisStatus
+		Latency     time.Duration
+		Err         error
+	}
+
+	DiagnosisStatus string
+
+	// MonitorableDescription describe a registered monitorable with his variants and tiles. Used by list command
+	MonitorableDescription struct {
+		DisplayName string               `json:"displayName"`
+		Variants    []VariantDescription `json:"variants"`
+		Tiles       []TileDescription    `json:"tiles"`
+	}
+
+	VariantDescription struct {
+		VariantName coreModels.VariantName `json:"variantName"`
+		Enabled     bool                   `json:"enabled"`
+		Error       string                 `json:"error,omitempty"`
+	}
+
+	TileDescription struct {
+		TileType          coreModels.TileType     `json:"type"`
+		GeneratedTileType coreModels.TileType     `json:"generatedType,omitempty"`
+		MinimalVersion    configModels.RawVersion `json:"minimalVersion"`
+		// MinimalRefreshInterval in second, 0 for generators
+		MinimalRefreshInterval int `json:"minimalRefreshInterval,omitempty"`
+		// Params accepted by ParamsValidator of tile
+		Params []ParamDescription `json:"params"`
+	}
+
+	ParamDescription struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	}
+
 	MonitororCLI struct{}
 )
 
+const (
+	DiagnosisOK           DiagnosisStatus = "OK"
+	DiagnosisUnauthorized DiagnosisStatus = "UNAUTHORIZED"
+	DiagnosisUnreachable  DiagnosisStatus = "UNREACHABLE"
+	DiagnosisErrored      DiagnosisStatus = "ERRORED"
+)
+
 var colorer = color.New()
 
 func New() *MonitororCLI {
 	return &MonitororCLI{}
 }
 
+// SetOutput change output of every print, stdout by default
+func (cli *MonitororCLI) SetOutput(w io.Writer) {
+	colorer.SetOutput(w)
+}
+
 func (cli *MonitororCLI) PrintBanner() {
 	var tagFlag = ""
 	if version.BuildTags != "" {
@@ -171,10 +241,134 @@ func (cli *MonitororCLI) PrintMonitorableFooter(isProduction bool, nonEnabledMon
 	)
 }
 
-func (cli *MonitororCLI) PrintServerStartup(ip string, port int) {
+func (cli *MonitororCLI) PrintDiagnosisHeader() {
+	colorer.Printf(colorer.Black(colorer.Green(diagnosisHeader)))
+}
+
+func (cli *MonitororCLI) PrintMonitorableDiagnosis(displayName string, variantDiagnoses []VariantDiagnosis) {
+	monitorableName := strings.Replace(displayName, "(faker)", colorer.Grey("(faker)"), 1)
+	colorer.Printf("  %s\n", monitorableName)
+
+	for _, variantDiagnosis := range variantDiagnoses {
+		latency := colorer.Grey(fmt.Sprintf("(%s)", variantDiagnosis.Latency.Round(time.Millisecond)))
+
+		switch variantDiagnosis.Status {
+		case DiagnosisOK:
+			colorer.Printf("    %s %s: reachable, authenticated %s\n", colorer.Green("✓"), variantDiagnosis.VariantName, latency)
+		case DiagnosisUnauthorized:
+			colorer.Printf("    %s %s: reachable, authentication failed %s\n", colorer.Red("✕"), variantDiagnosis.VariantName, latency)
+		case DiagnosisUnreachable:
+			colorer.Printf("    %s %s: unreachable %s\n", colorer.Red("✕"), variantDiagnosis.VariantName, latency)
+		default:
+			colorer.Printf("    %s %s: reachable, unexpected error %s\n", colorer.Yellow("!"), variantDiagnosis.VariantName, latency)
+		}
+
+		if variantDiagnosis.Err != nil {
+			colorer.Printf(colorer.Red("        %s\n"), variantDiagnosis.Err.Error())
+		}
+	}
+}
+
+func (cli *MonitororCLI) PrintDiagnosisFooter(diagnosedVariantCount int, failedVariantCount int) {
+	if diagnosedVariantCount == 0 {
+		colorer.Printf(colorer.Yellow(diagnosisFooterNothing))
+		return
+	}
+
+	result := colorer.Green("no error")
+	if failedVariantCount > 0 {
+		result = colorer.Red(fmt.Sprintf("%d failed", failedVariantCount))
+	}
+	colorer.Printf(diagnosisFooter, diagnosedVariantCount, result)
+}
+
+// PrintMonitorableDescriptions print registered monitorables with variants, tiles and params. Used by list command
+func (cli *MonitororCLI) PrintMonitorableDescriptions(descriptions []MonitorableDescription) {
+	colorer.Printf(colorer.Black(colorer.Green(monitorableListHeader)))
+
+	for _, description := range descriptions {
+		enabledCount, erroredCount := 0, 0
+		for _, variant := range description.Variants {
+			if variant.Enabled {
+				enabledCount++
+			}
+			if variant.Error != "" {
+				erroredCount++
+			}
+		}
+
+		prefixStatus := colorer.Grey("-")
+		if enabledCount > 0 && erroredCount > 0 {
+			prefixStatus = colorer.Yellow("!")
+		} else if enabledCount > 0 {
+			prefixStatus = colorer.Green("✓")
+		} else if erroredCount > 0 {
+			prefixStatus = colorer.Red("✕")
+		}
+		monitorableName := strings.Replace(description.DisplayName, "(faker)", colorer.Grey("(faker)"), 1)
+		colorer.Printf("  %s %s\n", prefixStatus, monitorableName)
+
+		// Print variants
+		colorer.Printf("    variants:\n")
+		for _, variant := range description.Variants {
+			if variant.Enabled {
+				colorer.Printf("      %s %s\n", colorer.Green("✓"), variant.VariantName)
+			} else if variant.Error != "" {
+				colorer.Printf("      %s %s\n", colorer.Red("✕"), variant.VariantName)
+				colorer.Printf(colorer.Red("          %s\n"), variant.Error)
+			} else {
+				colorer.Printf("      %s %s %s\n", colorer.Grey("-"), variant.VariantName, colorer.Grey("(not configured)"))
+			}
+		}
+
+		// Print tiles
+		colorer.Printf("    tiles:\n")
+		for _, tile := range description.Tiles {
+			details := fmt.Sprintf("version >= %s", tile.MinimalVersion)
+			if tile.MinimalRefreshInterval > 0 {
+				details = fmt.Sprintf("%s, refreshInterval >= %ds", details, tile.MinimalRefreshInterval)
+			}
+			colorer.Printf("      %s %s\n", colorer.Bold(string(tile.TileType)), colorer.Grey(fmt.Sprintf("(%s)", details)))
+
+			for _, param := range tile.Params {
+				colorer.Printf("        %s: %s\n", param.Name, colorer.Grey(param.Type))
+			}
+		}
+	}
+}
+
+// PrintConfigErrors print result of config verify in human readable format. Used by verify command
+func (cli *MonitororCLI) PrintConfigErrors(pathOrURL string, configErrors []configModels.ConfigError) {
+	if len(configErrors) == 0 {
+		colorer.Printf(configValid, colorer.Green("✓"), pathOrURL)
+		return
+	}
+
+	colorer.Printf(configInvalid, colorer.Red("✕"), pathOrURL, len(configErrors))
+
+	for _, configError := range configErrors {
+		location := pathOrURL
+		if configError.Data.Source != "" {
+			location = configError.Data.Source
+		}
+		if configError.Data.Line > 0 {
+			location = fmt.Sprintf("%s:%d:%d", location, configError.Data.Line, configError.Data.Column)
+		}
+		if configError.Data.Pointer != "" {
+			location = fmt.Sprintf("%s (%s)", location, configError.Data.Pointer)
+		}
+
+		colorer.Printf("\n  %s %s\n    %s\n", colorer.Red(configError.ID), colorer.Grey(location), configError.Message)
+		if configError.Data.Expected != "" {
+			colorer.Printf("    expected: %s\n", configError.Data.Expected)
+		}
+	}
+}
+
+func (cli *MonitororCLI) PrintServerStartup(scheme string, ip string, port int) {
 	colorer.Printf(
 		echoStartup,
-		colorer.Blue(fmt.Sprintf("http://localhost:%d", port)),
-		colorer.Blue(fmt.Sprintf("http://%s:%d", ip, port)),
+		colorer.Blue(fmt.Sprintf("%s://localhost:%d", scheme, port)),
+		colorer.Blue(fmt.Sprintf("%s://%s:%d", scheme, ip, port)),
 	)
 }
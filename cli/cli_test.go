@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"errors"
 	"testing"
+	"time"
 
+	configModels "github.com/monitoror/monitoror/api/config/models"
 	"github.com/monitoror/monitoror/cli/version"
 	"github.com/stretchr/testify/assert"
 
@@ -214,11 +216,138 @@ https://monitoror.com/documentation/
 
 }
 
+func TestPrintConfigErrors(t *testing.T) {
+	cli := New()
+	output := &bytes.Buffer{}
+	colorer.SetOutput(output)
+
+	cli.PrintConfigErrors("config.json", nil)
+	assert.Equal(t, "✓ config.json is valid\n", output.String())
+
+	output.Reset()
+	cli.PrintConfigErrors("config.json", []configModels.ConfigError{
+		{
+			ID:      configModels.ConfigErrorInvalidFieldValue,
+			Message: `Invalid "columns" field. Must be a positive integer.`,
+			Data:    configModels.ConfigErrorData{Line: 3, Column: 14, Pointer: "/columns", Expected: "columns > 0"},
+		},
+		{
+			ID:      configModels.ConfigErrorConfigNotFound,
+			Message: "Config not found",
+			Data:    configModels.ConfigErrorData{Source: "included.json"},
+		},
+	})
+	expected := `✕ config.json has 2 error(s)
+
+  ERROR_INVALID_FIELD_VALUE config.json:3:14 (/columns)
+    Invalid "columns" field. Must be a positive integer.
+    expected: columns > 0
+
+  ERROR_CONFIG_NOT_FOUND included.json
+    Config not found
+`
+	assert.Equal(t, expected, output.String())
+}
+
+func TestPrintDiagnosis(t *testing.T) {
+	cli := New()
+	output := &bytes.Buffer{}
+	colorer.SetOutput(output)
+
+	cli.PrintDiagnosisHeader()
+	cli.PrintMonitorableDiagnosis("Test", []VariantDiagnosis{
+		{VariantName: coreModels.DefaultVariant, Status: DiagnosisOK, Latency: 123456789},
+		{VariantName: "variant1", Status: DiagnosisUnauthorized, Latency: 40 * time.Millisecond, Err: errors.New("authentication failed: 401 Unauthorized")},
+		{VariantName: "variant2", Status: DiagnosisUnreachable, Latency: 2 * time.Millisecond, Err: errors.New("connection refused")},
+		{VariantName: "variant3", Status: DiagnosisErrored, Latency: 10 * time.Millisecond, Err: errors.New("unexpected response: 404 Not Found")},
+	})
+	cli.PrintDiagnosisFooter(4, 3)
+	expected := `
+MONITORABLES DIAGNOSIS
+
+  Test
+    ✓ default: reachable, authenticated (123ms)
+    ✕ variant1: reachable, authentication failed (40ms)
+        authentication failed: 401 Unauthorized
+    ✕ variant2: unreachable (2ms)
+        connection refused
+    ! variant3: reachable, unexpected error (10ms)
+        unexpected response: 404 Not Found
+
+4 variant(s) diagnosed, 3 failed
+`
+	assert.Equal(t, expected, output.String())
+
+	output.Reset()
+	cli.PrintDiagnosisFooter(1, 0)
+	assert.Equal(t, "\n1 variant(s) diagnosed, no error\n", output.String())
+
+	output.Reset()
+	cli.PrintDiagnosisFooter(0, 0)
+	assert.Equal(t, "  No enabled variant can be diagnosed\n", output.String())
+}
+
+func TestPrintMonitorableDescriptions(t *testing.T) {
+	cli := New()
+	output := &bytes.Buffer{}
+	colorer.SetOutput(output)
+
+	cli.PrintMonitorableDescriptions([]MonitorableDescription{
+		{
+			DisplayName: "Test",
+			Variants: []VariantDescription{
+				{VariantName: coreModels.DefaultVariant, Enabled: true},
+				{VariantName: "variant1", Error: "config error details"},
+			},
+			Tiles: []TileDescription{
+				{
+					TileType:               "TEST",
+					MinimalVersion:         "2.0",
+					MinimalRefreshInterval: 10,
+					Params:                 []ParamDescription{{Name: "hostname", Type: "string"}, {Name: "port", Type: "int"}},
+				},
+				{
+					TileType:          "GENERATE:TEST",
+					GeneratedTileType: "TEST",
+					MinimalVersion:    "2.2",
+					Params:            []ParamDescription{},
+				},
+			},
+		},
+		{
+			DisplayName: "Test2 (faker)",
+			Variants:    []VariantDescription{{VariantName: coreModels.DefaultVariant}},
+			Tiles:       []TileDescription{{TileType: "TEST2", MinimalVersion: "2.0", Params: []ParamDescription{{Name: "url", Type: "string"}}}},
+		},
+	})
+	expected := `
+REGISTERED MONITORABLES
+
+  ! Test
+    variants:
+      ✓ default
+      ✕ variant1
+          config error details
+    tiles:
+      TEST (version >= 2.0, refreshInterval >= 10s)
+        hostname: string
+        port: int
+      GENERATE:TEST (version >= 2.2)
+  - Test2 (faker)
+    variants:
+      - default (not configured)
+    tiles:
+      TEST2 (version >= 2.0)
+        url: string
+`
+	assert.Equal(t, expected, output.String())
+}
+
 func TestPrintServerStartup(t *testing.T) {
 	cli := New()
 	output := &bytes.Buffer{}
 	colorer.SetOutput(output)
-	cli.PrintServerStartup("1.2.3.4", 9999)
+	cli.PrintServerStartup("http", "1.2.3.4", 9999)
 	actual := output.String()
 	expected := `
 
@@ -228,4 +357,8 @@ Monitoror is running at:
 
 `
 	assert.Equal(t, expected, actual)
+
+	output.Reset()
+	cli.PrintServerStartup("https", "1.2.3.4", 9999)
+	assert.Contains(t, output.String(), "https://1.2.3.4:9999")
 }
@@ -4,6 +4,7 @@ package mocks
 
 import (
 	cli "github.com/monitoror/monitoror/cli"
+
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/monitoror/monitoror/models"
@@ -24,11 +25,26 @@ func (_m *CLI) PrintDevMode() {
 	_m.Called()
 }
 
+// PrintDiagnosisFooter provides a mock function with given fields: diagnosedVariantCount, failedVariantCount
+func (_m *CLI) PrintDiagnosisFooter(diagnosedVariantCount int, failedVariantCount int) {
+	_m.Called(diagnosedVariantCount, failedVariantCount)
+}
+
+// PrintDiagnosisHeader provides a mock function with given fields:
+func (_m *CLI) PrintDiagnosisHeader() {
+	_m.Called()
+}
+
 // PrintMonitorable provides a mock function with given fields: displayName, enabledVariants, erroredVariants
 func (_m *CLI) PrintMonitorable(displayName string, enabledVariants []models.VariantName, erroredVariants []cli.ErroredVariant) {
 	_m.Called(displayName, enabledVariants, erroredVariants)
 }
 
+// PrintMonitorableDiagnosis provides a mock function with given fields: displayName, variantDiagnoses
+func (_m *CLI) PrintMonitorableDiagnosis(displayName string, variantDiagnoses []cli.VariantDiagnosis) {
+	_m.Called(displayName, variantDiagnoses)
+}
+
 // PrintMonitorableFooter provides a mock function with given fields: isProduction, nonEnabledMonitorableCount
 func (_m *CLI) PrintMonitorableFooter(isProduction bool, nonEnabledMonitorableCount int) {
 	_m.Called(isProduction, nonEnabledMonitorableCount)
@@ -39,7 +55,7 @@ func (_m *CLI) PrintMonitorableHeader() {
 	_m.Called()
 }
 
-// PrintServerStartup provides a mock function with given fields: ip, port
-func (_m *CLI) PrintServerStartup(ip string, port int) {
-	_m.Called(ip, port)
+// PrintServerStartup provides a mock function with given fields: scheme, ip, port
+func (_m *CLI) PrintServerStartup(scheme string, ip string, port int) {
+	_m.Called(scheme, ip, port)
 }
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/monitoror/monitoror/cli"
+	"github.com/monitoror/monitoror/config"
+	"github.com/monitoror/monitoror/service"
+
+	"github.com/labstack/gommon/log"
+)
+
+const doctorCommand = "doctor"
+
+// doctor check every enabled monitorable variant against its service (reachability, authentication, latency).
+// Return exit code of command: 0 if every variant is healthy, 1 if at least one failed, 2 if args are invalid
+func doctor(conf *config.Config, monitororCLI *cli.MonitororCLI, args []string) int {
+	flagSet := flag.NewFlagSet(doctorCommand, flag.ExitOnError)
+	flagSet.Usage = func() {
+		fmt.Fprint(flagSet.Output(), "Usage:\n  monitoror doctor\n")
+	}
+	_ = flagSet.Parse(args)
+
+	if flagSet.NArg() != 0 {
+		flagSet.Usage()
+		return 2
+	}
+
+	// Monitorables are printed on stderr to keep stdout for diagnosis only
+	monitororCLI.SetOutput(os.Stderr)
+	log.SetOutput(os.Stderr)
+	server := service.InitOffline(conf, monitororCLI)
+	monitororCLI.SetOutput(os.Stdout)
+
+	if server.DiagnoseMonitorables() != 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/monitoror/monitoror/cli"
+	"github.com/monitoror/monitoror/config"
+	"github.com/monitoror/monitoror/service"
+
+	"github.com/labstack/gommon/log"
+)
+
+const listCommand = "list"
+
+// list print registered monitorables with their variants (extract from MO_MONITORABLE_* env), tiles and params.
+// Return exit code of command: 0 on success, 2 if args are invalid
+func list(conf *config.Config, monitororCLI *cli.MonitororCLI, args []string) int {
+	flagSet := flag.NewFlagSet(listCommand, flag.ExitOnError)
+	format := flagSet.String("format", textFormat, fmt.Sprintf("output format of monitorables (%s or %s)", textFormat, jsonFormat))
+	flagSet.Usage = func() {
+		fmt.Fprint(flagSet.Output(), "Usage:\n  monitoror list [options]\n\nOptions:\n")
+		flagSet.PrintDefaults()
+	}
+	_ = flagSet.Parse(args)
+
+	if flagSet.NArg() != 0 || (*format != textFormat && *format != jsonFormat) {
+		flagSet.Usage()
+		return 2
+	}
+
+	// Monitorables are printed on stderr to keep stdout for list result only
+	monitororCLI.SetOutput(os.Stderr)
+	log.SetOutput(os.Stderr)
+	server := service.InitOffline(conf, monitororCLI)
+	monitororCLI.SetOutput(os.Stdout)
+
+	descriptions := server.DescribeMonitorables()
+
+	if *format == jsonFormat {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(descriptions)
+	} else {
+		monitororCLI.PrintMonitorableDescriptions(descriptions)
+	}
+
+	return 0
+}
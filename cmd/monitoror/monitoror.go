@@ -1,6 +1,8 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -12,7 +14,23 @@ import (
 	"github.com/labstack/gommon/log"
 )
 
+const usage = `Usage:
+  monitoror [options]                              Start monitoror server
+  monitoror verify [options] <config path or url>  Verify dashboard config without starting server
+  monitoror list [options]                         List monitorables with their variants, tiles and params
+  monitoror doctor                                 Check enabled monitorables against their services
+
+Options:
+`
+
 func main() {
+	printConfigSchema := flag.Bool("config-schema", false, "print JSON Schema of dashboard config for enabled tiles and exit")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
 	//  Default Logger
 	log.SetPrefix("")
 	log.SetHeader("[${level}]")
@@ -30,6 +48,33 @@ func main() {
 
 	// CLI
 	cli := cli.New()
+
+	// Commands
+	switch flag.Arg(0) {
+	case "":
+	case verifyCommand:
+		os.Exit(verify(conf, cli, flag.Args()[1:]))
+	case listCommand:
+		os.Exit(list(conf, cli, flag.Args()[1:]))
+	case doctorCommand:
+		os.Exit(doctor(conf, cli, flag.Args()[1:]))
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *printConfigSchema {
+		// Keep stdout for schema only
+		cli.SetOutput(os.Stderr)
+		log.SetOutput(os.Stderr)
+
+		server := service.InitOffline(conf, cli)
+		if err := server.PrintConfigSchema(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
 	cli.PrintBanner()
 
 	// Start Service
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/cli"
+	"github.com/monitoror/monitoror/config"
+	"github.com/monitoror/monitoror/service"
+
+	"github.com/labstack/gommon/log"
+)
+
+const (
+	verifyCommand = "verify"
+
+	textFormat = "text"
+	jsonFormat = "json"
+)
+
+// verify load and verify config given in args without starting server. Return exit code of command:
+// 0 if config is valid, 1 if config has errors, 2 if args are invalid
+func verify(conf *config.Config, monitororCLI *cli.MonitororCLI, args []string) int {
+	flagSet := flag.NewFlagSet(verifyCommand, flag.ExitOnError)
+	format := flagSet.String("format", textFormat, fmt.Sprintf("output format of config errors (%s or %s)", textFormat, jsonFormat))
+	hydrate := flagSet.Bool("hydrate", false, "hydrate config after verify (tile generators are called)")
+	flagSet.Usage = func() {
+		fmt.Fprint(flagSet.Output(), "Usage:\n  monitoror verify [options] <config path or url>\n\nOptions:\n")
+		flagSet.PrintDefaults()
+	}
+	_ = flagSet.Parse(args)
+
+	if flagSet.NArg() != 1 || (*format != textFormat && *format != jsonFormat) {
+		flagSet.Usage()
+		return 2
+	}
+	pathOrURL := flagSet.Arg(0)
+
+	// Monitorables are printed on stderr to keep stdout for verify result only
+	monitororCLI.SetOutput(os.Stderr)
+	log.SetOutput(os.Stderr)
+	server := service.InitOffline(conf, monitororCLI)
+	monitororCLI.SetOutput(os.Stdout)
+
+	configBag := server.VerifyConfig(pathOrURL, *hydrate)
+
+	if *format == jsonFormat {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(models.ConfigBag{Errors: configBag.Errors})
+	} else {
+		monitororCLI.PrintConfigErrors(pathOrURL, configBag.Errors)
+	}
+
+	if len(configBag.Errors) != 0 {
+		return 1
+	}
+	return 0
+}
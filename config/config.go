@@ -1,6 +1,8 @@
 package config
 
 import (
+	"fmt"
+	"os"
 	"strings"
 
 	"github.com/fatih/structs"
@@ -9,31 +11,130 @@ import (
 
 const EnvPrefix = "MO"
 const MonitorablePrefix = "MONITORABLE"
+const NamedConfigPrefix = "CONFIG"
+
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+const (
+	CacheBackendMemory    = "memory"
+	CacheBackendRedis     = "redis"
+	CacheBackendMemcached = "memcached"
+)
 
 type (
 	// Config contain backend Configuration
 	Config struct {
 		// --- General Configuration ---
 		Port int
-		Env  string
+		// Address is the interface address server listen on (ex: 127.0.0.1). Empty means every interface
+		Address string
+		Env     string
+
+		// LogLevel is one of debug, info, warn, error, off
+		LogLevel string
+		// LogFormat is text or json (see LogFormatText / LogFormatJSON)
+		LogFormat string
+
+		// StartupDiagnosis check enabled monitorables variants against their services on startup (see doctor command)
+		StartupDiagnosis bool
+		// ShutdownTimeout is the maximum duration to wait in-flight requests on SIGINT / SIGTERM before closing connections
+		ShutdownTimeout int // in Millisecond
+		// EnableMetrics expose Prometheus metrics on /metrics (requests, upstream calls, caches, timeouts, generators)
+		EnableMetrics bool
+
+		// --- TLS Configuration ---
+		// TLSCertFile and TLSKeyFile enable HTTPS (and HTTP/2). Files are reloaded when modified (ex: certificate renewal)
+		TLSCertFile string
+		TLSKeyFile  string
+		// TLSSelfSigned enable HTTPS with generated self-signed certificate. Written in TLSCertFile / TLSKeyFile if they are set but missing
+		TLSSelfSigned bool
+		// TLSRedirectPort start HTTP server on this port, redirecting every request to HTTPS. 0 means disabled
+		TLSRedirectPort int
+		// DisableHTTP2 serve HTTPS with HTTP/1.1 only
+		DisableHTTP2 bool
+
+		// --- Security Configuration ---
+		// CorsAllowedOrigins list origins allowed to call api from browser ("*" for every origin)
+		CorsAllowedOrigins []string
+		// AuthBearerTokens are accepted in "Authorization: Bearer <token>" header. Setting any Auth* field enable authentication
+		AuthBearerTokens []string
+		// AuthBasicCredentials are "user:password" accepted with HTTP basic authentication
+		AuthBasicCredentials []string
+		// AuthDisplayTokens are read-only tokens, accepted in "token" query param to be embedded in wall URL
+		AuthDisplayTokens []string
+		// AuthOIDCIssuer enable OpenID Connect login for browsers (ex: https://accounts.google.com)
+		AuthOIDCIssuer       string
+		AuthOIDCClientID     string
+		AuthOIDCClientSecret string
+		// AuthOIDCRedirectURL is the public URL of /auth/callback route, registered in OIDC provider
+		AuthOIDCRedirectURL string
+		// AuthOIDCAllowedEmails and AuthOIDCAllowedDomains (ex: example.com) restrict OIDC login to these verified emails.
+		// OIDC login is refused to everyone when both are empty
+		AuthOIDCAllowedEmails  []string
+		AuthOIDCAllowedDomains []string
+		// AuthSessionSecret sign session cookies (OIDC and display token). Random when empty, sessions are lost on restart
+		AuthSessionSecret string
+
+		// --- Dashboard Configuration ---
+		// NamedConfigs contains config path or url by name. Loaded from MO_CONFIG_<NAME> env
+		NamedConfigs map[string]string
+		// DisableFreeFormConfig disable "path" and "url" params of config api, only named configs can be used
+		DisableFreeFormConfig bool
+		// ConfigRoot restrict configs loaded by path to this directory (symlinks are evaluated), relative paths are resolved from it.
+		// Empty means no restriction
+		ConfigRoot string
+		// ConfigAllowedExtensions restrict configs loaded by path to these file extensions (ex: ".json"). Empty means every extension
+		ConfigAllowedExtensions []string
+		// ConfigAllowedEnvVariables list environment variables usable in configs with ${env:NAME}. Empty means none
+		ConfigAllowedEnvVariables []string
 
 		// --- Cache Configuration ---
+		// CacheBackend store caches and generator results: memory, redis or memcached (see CacheBackend* constants).
+		// Use redis or memcached to share them between replicas
+		CacheBackend string
+		// CacheBackendAddresses are "host:port" of cache servers (redis only use the first one)
+		CacheBackendAddresses []string
+		// CacheBackendPassword is used to authenticate on redis
+		CacheBackendPassword string
+		// CacheBackendKeyPrefix is added to every key stored in redis / memcached (ex: to share server with other applications)
+		CacheBackendKeyPrefix string
 		// UpstreamCacheExpiration is used to respond before executing the request. Avoid overloading services.
 		UpstreamCacheExpiration int
 		// DownstreamCacheExpiration is used to respond after executing the request in case of timeout error.
 		DownstreamCacheExpiration int
+		// DownstreamCacheSnapshotFile is used to save downstream cache on shutdown and restore it on startup. Empty means disabled
+		DownstreamCacheSnapshotFile string
+		// CachePersistenceFile keep downstream cache, builds history and generator results on disk so they survive restarts.
+		// Empty means disabled. Ignored with redis / memcached cache backend. File is locked, only one server can use it
+		CachePersistenceFile string
+		// CachePersistenceMaxSize is the maximum size of CachePersistenceFile, oldest entries are removed above
+		CachePersistenceMaxSize int // in Megabyte
 
 		// InitialMaxDelay is used to add delay on first methode to avoid bursting x requets in same time on start
 		InitialMaxDelay int // in Millisecond
+
+		// --- Stream Configuration ---
+		// StreamRefreshInterval is used by /stream endpoint to refresh tiles on server side
+		StreamRefreshInterval int // in Millisecond
 	}
 )
 
 var defaultConfig = &Config{
 	Port:                      8080,
 	Env:                       "production",
+	LogLevel:                  "info",
+	LogFormat:                 LogFormatText,
+	ShutdownTimeout:           10000,
+	CorsAllowedOrigins:        []string{"*"},
+	CacheBackend:              CacheBackendMemory,
 	UpstreamCacheExpiration:   10000,
 	DownstreamCacheExpiration: 120000,
+	CachePersistenceMaxSize:   50,
 	InitialMaxDelay:           1700,
+	StreamRefreshInterval:     10000,
 }
 
 // InitConfig from configuration file / env / default value
@@ -53,5 +154,27 @@ func InitConfig() *Config {
 
 	_ = v.Unmarshal(&config)
 
+	config.NamedConfigs = loadNamedConfigs()
+
 	return &config
 }
+
+// loadNamedConfigs look for every MO_CONFIG_<NAME> env. Names are case insensitive
+func loadNamedConfigs() map[string]string {
+	namedConfigs := make(map[string]string)
+	envPrefix := fmt.Sprintf("%s_%s_", EnvPrefix, NamedConfigPrefix)
+
+	for _, env := range os.Environ() {
+		if !strings.HasPrefix(env, envPrefix) {
+			continue
+		}
+
+		splitedEnv := strings.SplitN(env, "=", 2)
+		name := strings.ToLower(strings.TrimPrefix(splitedEnv[0], envPrefix))
+		if name != "" && splitedEnv[1] != "" {
+			namedConfigs[name] = splitedEnv[1]
+		}
+	}
+
+	return namedConfigs
+}
@@ -10,6 +10,12 @@ import (
 func TestInitConfig_Default(t *testing.T) {
 	config := InitConfig()
 	assert.Equal(t, 8080, config.Port)
+	assert.Equal(t, "info", config.LogLevel)
+	assert.Equal(t, LogFormatText, config.LogFormat)
+	assert.Equal(t, []string{"*"}, config.CorsAllowedOrigins)
+	assert.Equal(t, 10000, config.ShutdownTimeout)
+	assert.Equal(t, CacheBackendMemory, config.CacheBackend)
+	assert.Equal(t, 50, config.CachePersistenceMaxSize)
 }
 
 func TestInitConfig_WithEnv(t *testing.T) {
@@ -25,3 +31,53 @@ func TestInitConfig_WithEnv(t *testing.T) {
 	assert.Equal(t, "production", config.Env)
 	assert.Equal(t, 3000, config.Port)
 }
+
+func TestInitConfig_NamedConfigs(t *testing.T) {
+	_ = os.Setenv(EnvPrefix+"_CONFIG_TEAM_A", "./config.json")
+	_ = os.Setenv(EnvPrefix+"_CONFIG_LOBBY", "https://monitoror.example.com/config.json")
+	_ = os.Setenv(EnvPrefix+"_CONFIG_EMPTY", "")
+	_ = os.Setenv(EnvPrefix+"_DISABLEFREEFORMCONFIG", "true")
+	defer func() {
+		_ = os.Unsetenv(EnvPrefix + "_CONFIG_TEAM_A")
+		_ = os.Unsetenv(EnvPrefix + "_CONFIG_LOBBY")
+		_ = os.Unsetenv(EnvPrefix + "_CONFIG_EMPTY")
+		_ = os.Unsetenv(EnvPrefix + "_DISABLEFREEFORMCONFIG")
+	}()
+
+	config := InitConfig()
+
+	assert.True(t, config.DisableFreeFormConfig)
+	assert.Equal(t, map[string]string{
+		"team_a": "./config.json",
+		"lobby":  "https://monitoror.example.com/config.json",
+	}, config.NamedConfigs)
+}
+
+func TestInitConfig_ConfigSandbox(t *testing.T) {
+	_ = os.Setenv(EnvPrefix+"_CONFIGROOT", "/etc/monitoror")
+	_ = os.Setenv(EnvPrefix+"_CONFIGALLOWEDEXTENSIONS", ".json,.JSON")
+	defer func() {
+		_ = os.Unsetenv(EnvPrefix + "_CONFIGROOT")
+		_ = os.Unsetenv(EnvPrefix + "_CONFIGALLOWEDEXTENSIONS")
+	}()
+
+	config := InitConfig()
+
+	assert.Equal(t, "/etc/monitoror", config.ConfigRoot)
+	assert.Equal(t, []string{".json", ".JSON"}, config.ConfigAllowedExtensions)
+	assert.NotContains(t, config.NamedConfigs, "root")
+}
+
+func TestInitConfig_OIDCAllowList(t *testing.T) {
+	_ = os.Setenv(EnvPrefix+"_AUTHOIDCALLOWEDEMAILS", "john@other.com,jane@other.com")
+	_ = os.Setenv(EnvPrefix+"_AUTHOIDCALLOWEDDOMAINS", "example.com")
+	defer func() {
+		_ = os.Unsetenv(EnvPrefix + "_AUTHOIDCALLOWEDEMAILS")
+		_ = os.Unsetenv(EnvPrefix + "_AUTHOIDCALLOWEDDOMAINS")
+	}()
+
+	config := InitConfig()
+
+	assert.Equal(t, []string{"john@other.com", "jane@other.com"}, config.AuthOIDCAllowedEmails)
+	assert.Equal(t, []string{"example.com"}, config.AuthOIDCAllowedDomains)
+}
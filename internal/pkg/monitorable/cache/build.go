@@ -2,38 +2,46 @@ package cache
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/monitoror/monitoror/models"
-	cmap "github.com/orcaman/concurrent-map"
+
+	"github.com/jsdidierlaurent/echo-middleware/cache"
 )
 
+// buildCacheExpiration keep history of builds not updated for a long time (ex: unused tile)
+const buildCacheExpiration = time.Hour * 24 * 7
+
+// BuildCache keep small history of builds in cache store (persisted when store is persistent)
 type BuildCache struct {
-	maxSize        int
-	previousBuilds cmap.ConcurrentMap
+	store   cache.Store
+	name    string
+	maxSize int
+
+	lock sync.Mutex
 }
 
 type build struct {
-	id       string
-	status   models.TileStatus
-	duration time.Duration
+	ID       string
+	Status   models.TileStatus
+	Duration time.Duration
 }
 
-func NewBuildCache(size int) *BuildCache {
-	return &BuildCache{maxSize: size, previousBuilds: cmap.New()}
+// NewBuildCache create build history stored in store. name must be unique for each usecase (ex: monitorable and variant)
+func NewBuildCache(store cache.Store, name string, size int) *BuildCache {
+	return &BuildCache{store: store, name: name, maxSize: size}
 }
 
 func (c *BuildCache) GetEstimatedDuration(key interface{}) *time.Duration {
-	k := fmt.Sprint(key)
-	value, ok := c.previousBuilds.Get(k)
-	if !ok {
+	builds := c.get(key)
+	if len(builds) == 0 {
 		return nil
 	}
-	builds := value.([]build)
 
 	var total int64
 	for _, c := range builds {
-		total += int64(c.duration)
+		total += int64(c.Duration)
 	}
 	average := total / int64(len(builds))
 	duration := time.Duration(average)
@@ -42,45 +50,51 @@ func (c *BuildCache) GetEstimatedDuration(key interface{}) *time.Duration {
 
 // Get Previous Status excludes current status in case of multiple call with the same current build
 func (c *BuildCache) GetPreviousStatus(key interface{}, id string) *models.TileStatus {
-	k := fmt.Sprint(key)
-	value, ok := c.previousBuilds.Get(k)
-	if !ok {
+	builds := c.get(key)
+	if len(builds) == 0 {
 		return nil
 	}
-	builds := value.([]build)
 
 	previous := builds[0]
-	if previous.id == id {
+	if previous.ID == id {
 		if len(builds) == 1 {
 			return nil
 		}
 		previous = builds[1]
 	}
 
-	return &previous.status
+	return &previous.Status
 }
 
 func (c *BuildCache) Add(key interface{}, id string, s models.TileStatus, d time.Duration) {
-	k := fmt.Sprint(key)
-	// If cache is not found, create it
-	var builds []build
-	if tmp, ok := c.previousBuilds.Get(k); !ok {
-		c.previousBuilds.Set(k, builds)
-	} else {
-		builds = tmp.([]build)
-	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	builds := c.get(key)
 
 	// if id already exist, skip
 	for _, value := range builds {
-		if value.id == id {
+		if value.ID == id {
 			return
 		}
 	}
 
 	// Remove old elements
-	if len(builds) == c.maxSize {
-		builds = builds[:len(builds)-1]
+	if len(builds) >= c.maxSize {
+		builds = builds[:c.maxSize-1]
 	}
 
-	c.previousBuilds.Set(k, append([]build{{id, s, d}}, builds...))
+	_ = c.store.Set(c.key(key), append([]build{{id, s, d}}, builds...), buildCacheExpiration)
+}
+
+func (c *BuildCache) get(key interface{}) []build {
+	var builds []build
+	if err := c.store.Get(c.key(key), &builds); err != nil {
+		return nil
+	}
+	return builds
+}
+
+func (c *BuildCache) key(key interface{}) string {
+	return fmt.Sprintf("%s:%s:%v", models.BuildCacheStoreKeyPrefix, c.name, key)
 }
@@ -5,11 +5,13 @@ import (
 	"time"
 
 	"github.com/monitoror/monitoror/models"
+
+	"github.com/jsdidierlaurent/echo-middleware/cache"
 	"github.com/stretchr/testify/assert"
 )
 
 func Test(t *testing.T) {
-	cache := NewBuildCache(4)
+	cache := NewBuildCache(cache.NewGoCacheStore(time.Minute, time.Minute), "test", 4)
 
 	cache.Add("key", "0", models.SuccessStatus, time.Second*1)
 	cache.Add("key", "1", models.SuccessStatus, time.Second*1)
@@ -23,14 +25,14 @@ func Test(t *testing.T) {
 }
 
 func Test_Empty(t *testing.T) {
-	cache := NewBuildCache(2)
+	cache := NewBuildCache(cache.NewGoCacheStore(time.Minute, time.Minute), "test", 2)
 
 	assert.Nil(t, cache.GetPreviousStatus("key", "1"))
 	assert.Nil(t, cache.GetEstimatedDuration("key"))
 }
 
 func Test_AlreadyInCache(t *testing.T) {
-	cache := NewBuildCache(4)
+	cache := NewBuildCache(cache.NewGoCacheStore(time.Minute, time.Minute), "test", 4)
 
 	cache.Add("key", "1", models.SuccessStatus, time.Second)
 	cache.Add("key", "1", models.SuccessStatus, time.Second)
@@ -40,3 +42,14 @@ func Test_AlreadyInCache(t *testing.T) {
 	cache.Add("key", "2", models.SuccessStatus, time.Second)
 	assert.Equal(t, models.SuccessStatus, *cache.GetPreviousStatus("key", "2"))
 }
+
+func Test_SharedStore(t *testing.T) {
+	store := cache.NewGoCacheStore(time.Minute, time.Minute)
+
+	buildCache := NewBuildCache(store, "test", 4)
+	buildCache.Add("key", "1", models.FailedStatus, time.Second)
+
+	// Same name use same history (ex: after restart with persistent store)
+	assert.Equal(t, models.FailedStatus, *NewBuildCache(store, "test", 4).GetPreviousStatus("key", "2"))
+	assert.Nil(t, NewBuildCache(store, "other", 4).GetPreviousStatus("key", "2"))
+}
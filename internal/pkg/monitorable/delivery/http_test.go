@@ -14,7 +14,7 @@ import (
 
 type FakeValidator map[string]string
 
-func (m *FakeValidator) Validate(_ *models.ConfigVersion) *models.ConfigError { return nil }
+func (m *FakeValidator) Validate(_ *models.ConfigVersion) []models.ConfigError { return nil }
 
 func TestBindAndValidateRequestParams(t *testing.T) {
 	e := echo.New()
@@ -32,7 +32,7 @@ func TestBindAndValidateRequestParams(t *testing.T) {
 	assert.Error(t, BindAndValidateRequestParams(ctx, &fake))
 
 	mockValidator2 := new(mocks.ParamsValidator)
-	mockValidator2.On("Validate", mock.Anything).Return(&models.ConfigError{Message: "boom"})
+	mockValidator2.On("Validate", mock.Anything).Return([]models.ConfigError{{Message: "boom"}})
 	err := BindAndValidateRequestParams(ctx, mockValidator2)
 	assert.Error(t, err)
 	assert.Equal(t, "boom", err.Error())
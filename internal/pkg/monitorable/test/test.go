@@ -29,7 +29,7 @@ type (
 
 func InitMockAndStore() (*store.Store, MockMonitorableHelper) {
 	mockRouterGroup := new(serviceMocks.MonitorableRouterGroup)
-	mockRouterGroup.On("GET", mock.AnythingOfType("string"), mock.AnythingOfType("echo.HandlerFunc"), mock.Anything).Return(&echo.Route{Path: "/path"})
+	mockRouterGroup.On("GET", mock.AnythingOfType("string"), mock.AnythingOfType("echo.HandlerFunc"), mock.Anything, mock.Anything).Return(&echo.Route{Path: "/path"})
 
 	mockRouter := new(serviceMocks.MonitorableRouter)
 	mockRouter.On("Group", mock.AnythingOfType("string"), mock.AnythingOfType("models.VariantName")).Return(mockRouterGroup)
@@ -37,13 +37,11 @@ func InitMockAndStore() (*store.Store, MockMonitorableHelper) {
 	mockTileEnabler := new(serviceMocks.TileEnabler)
 	mockTileEnabler.On("Enable",
 		mock.AnythingOfType("models.VariantName"),
-		mock.Anything, //	I didn't find a way to test that it's an validator.SimpleValidator interface	:(
 		mock.AnythingOfType("string"),
 	)
 	mockGeneratorEnabler := new(serviceMocks.GeneratorEnabler)
 	mockGeneratorEnabler.On("Enable",
 		mock.AnythingOfType("models.VariantName"),
-		mock.Anything, //	I didn't find a way to test that it's an validator.SimpleValidator interface :(
 		mock.AnythingOfType("models.TileGeneratorFunction"),
 	)
 
@@ -52,11 +50,14 @@ func InitMockAndStore() (*store.Store, MockMonitorableHelper) {
 		mock.AnythingOfType("models.TileType"),
 		mock.AnythingOfType("models.RawVersion"),
 		mock.AnythingOfType("[]models.VariantName"),
+		mock.AnythingOfType("time.Duration"),
+		mock.Anything, //	I didn't find a way to test that it's an validator.SimpleValidator interface	:(
 	).Return(mockTileEnabler)
 	mockRegistry.On("RegisterGenerator",
 		mock.AnythingOfType("models.TileType"),
 		mock.AnythingOfType("models.RawVersion"),
 		mock.AnythingOfType("[]models.VariantName"),
+		mock.Anything, //	I didn't find a way to test that it's an validator.SimpleValidator interface :(
 	).Return(mockGeneratorEnabler)
 
 	return &store.Store{
@@ -1,6 +1,8 @@
 package validator
 
 import (
+	"strings"
+
 	uiConfigModels "github.com/monitoror/monitoror/api/config/models"
 	uiCoreVersions "github.com/monitoror/monitoror/api/config/versions"
 	coreModels "github.com/monitoror/monitoror/models"
@@ -9,8 +11,13 @@ import (
 var configVersion = uiConfigModels.ParseVersion(uiCoreVersions.CurrentVersion)
 
 func Validate(v uiConfigModels.ParamsValidator) error {
-	if err := v.Validate(configVersion); err != nil {
-		return &coreModels.MonitororError{Message: err.Message}
+	if errors := v.Validate(configVersion); len(errors) > 0 {
+		var messages []string
+		for _, err := range errors {
+			messages = append(messages, err.Message)
+		}
+
+		return &coreModels.MonitororError{Message: strings.Join(messages, " ")}
 	}
 
 	return nil
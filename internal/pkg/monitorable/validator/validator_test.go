@@ -16,8 +16,8 @@ func TestValidate(t *testing.T) {
 	assert.NoError(t, Validate(mockValidator))
 
 	mockValidator2 := new(mocks.ParamsValidator)
-	mockValidator2.On("Validate", mock.Anything).Return(&uiConfigModels.ConfigError{Message: "boom"})
+	mockValidator2.On("Validate", mock.Anything).Return([]uiConfigModels.ConfigError{{Message: "boom."}, {Message: "bam."}})
 	err := Validate(mockValidator2)
 	assert.Error(t, err)
-	assert.Equal(t, "boom", err.Error())
+	assert.Equal(t, "boom. bam.", err.Error())
 }
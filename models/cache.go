@@ -1,9 +1,32 @@
 package models
 
+import (
+	"net/url"
+	"strconv"
+	"time"
+)
+
 const (
 	DownstreamStoreContextKey = "monitoror.downstream.store"
 	DownstreamStoreKeyPrefix  = "monitoror.downstream.key"
 	DownstreamCacheHeader     = "Timeout-Recover"
 
 	UpstreamStoreKeyPrefix = "monitoror.upstream.key"
+
+	// BuildCacheStoreKeyPrefix is used by build history of CI monitorables (previous status, estimated duration)
+	BuildCacheStoreKeyPrefix = "monitoror.build.key"
+
+	// RefreshIntervalQueryParam is added by config hydration on tile URL with custom refresh interval (in seconds).
+	// Used to extend upstream cache expiration of this URL
+	RefreshIntervalQueryParam = "refreshInterval"
 )
+
+// ParseRefreshInterval return refresh interval of tile URL query. Return 0 if missing or invalid
+func ParseRefreshInterval(query url.Values) time.Duration {
+	seconds, err := strconv.Atoi(query.Get(RefreshIntervalQueryParam))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRefreshInterval(t *testing.T) {
+	for _, testcase := range []struct {
+		query    string
+		expected time.Duration
+	}{
+		{query: "", expected: 0},
+		{query: "refreshInterval=abc", expected: 0},
+		{query: "refreshInterval=-10", expected: 0},
+		{query: "refreshInterval=60", expected: time.Minute},
+		{query: "hostname=server.com&refreshInterval=30", expected: time.Second * 30},
+	} {
+		query, err := url.ParseQuery(testcase.query)
+		if assert.NoError(t, err) {
+			assert.Equal(t, testcase.expected, ParseRefreshInterval(query))
+		}
+	}
+}
@@ -21,11 +21,19 @@ type (
 		// ErrorStatus is used for override current tile Status when error happen
 		// Default : ErrorStatus
 		ErrorStatus TileStatus
+
+		// RequestID of request that produced this error, used to find request in logs (see X-Request-ID header).
+		// Set by error handler from request context when empty
+		RequestID string
 	}
 )
 
 var (
 	ParamsError = &MonitororError{Message: "invalid configuration, unable to parse request parameters"}
+
+	// UnauthorizedError is wrapped by repositories when a service reject credentials of monitorable variant.
+	// Used by monitorables diagnosis to distinguish authentication errors
+	UnauthorizedError = errors.New("authentication failed")
 )
 
 func (e *MonitororError) Error() string {
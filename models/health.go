@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+type (
+	HealthStatus string
+
+	// HealthResponse response for health routes
+	HealthResponse struct {
+		Status HealthStatus `json:"status"`
+
+		// Only used by readiness
+		Monitorables []MonitorableHealth `json:"monitorables,omitempty"`
+		Upstreams    []UpstreamHealth    `json:"upstreams,omitempty"`
+	}
+
+	// MonitorableHealth contains variants state at the end of monitorables enabling
+	MonitorableHealth struct {
+		Name             string           `json:"name"`
+		EnabledVariants  []VariantName    `json:"enabledVariants"`
+		ErroredVariants  []ErroredVariant `json:"erroredVariants"`
+		DisabledVariants []VariantName    `json:"disabledVariants"`
+	}
+
+	ErroredVariant struct {
+		VariantName VariantName `json:"variant"`
+		Error       string      `json:"error"`
+	}
+
+	// UpstreamHealth contains last successful call of monitorable routes by variant.
+	// Monitorable is named by its route (ex: /api/v1/jenkins/default/... => jenkins)
+	UpstreamHealth struct {
+		Monitorable string      `json:"monitorable"`
+		VariantName VariantName `json:"variant"`
+		LastSuccess *time.Time  `json:"lastSuccess,omitempty"`
+		LastError   *time.Time  `json:"lastError,omitempty"`
+	}
+)
+
+const (
+	HealthStatusUp   HealthStatus = "UP"
+	HealthStatusDown HealthStatus = "DOWN"
+)
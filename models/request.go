@@ -0,0 +1,16 @@
+package models
+
+import "context"
+
+type requestIDContextKey struct{}
+
+// ContextWithRequestID return copy of ctx carrying request ID (see X-Request-ID header)
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestIDFromContext return request ID set by ContextWithRequestID, empty string if ctx doesn't carry one
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
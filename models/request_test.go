@@ -0,0 +1,13 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestIDFromContext(t *testing.T) {
+	assert.Equal(t, "", RequestIDFromContext(context.Background()))
+	assert.Equal(t, "request-id", RequestIDFromContext(ContextWithRequestID(context.Background(), "request-id")))
+}
@@ -25,7 +25,7 @@ func (h *AzureDevOpsDelivery) GetBuild(c echo.Context) error {
 		return err
 	}
 
-	tile, err := h.azureDevOpsUsecase.Build(params)
+	tile, err := h.azureDevOpsUsecase.Build(c.Request().Context(), params)
 	if err != nil {
 		return err
 	}
@@ -40,7 +40,7 @@ func (h *AzureDevOpsDelivery) GetRelease(c echo.Context) error {
 		return err
 	}
 
-	tile, err := h.azureDevOpsUsecase.Release(params)
+	tile, err := h.azureDevOpsUsecase.Release(c.Request().Context(), params)
 	if err != nil {
 		return err
 	}
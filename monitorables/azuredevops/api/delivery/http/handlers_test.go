@@ -41,7 +41,7 @@ func TestDelivery_BuildHandler_Success(t *testing.T) {
 	tile.Status = coreModels.SuccessStatus
 
 	mockUsecase := new(mocks.Usecase)
-	mockUsecase.On("Build", &models.BuildParams{Project: "test", Definition: pointer.ToInt(1), Branch: pointer.ToString("master")}).Return(tile, nil)
+	mockUsecase.On("Build", Anything, &models.BuildParams{Project: "test", Definition: pointer.ToInt(1), Branch: pointer.ToString("master")}).Return(tile, nil)
 	handler := NewAzureDevOpsDelivery(mockUsecase)
 
 	// Expected
@@ -76,7 +76,7 @@ func TestDelivery_BuildHandler_Error(t *testing.T) {
 	ctx, _ := initEcho()
 
 	mockUsecase := new(mocks.Usecase)
-	mockUsecase.On("Build", Anything).Return(nil, errors.New("build error"))
+	mockUsecase.On("Build", Anything, Anything).Return(nil, errors.New("build error"))
 	handler := NewAzureDevOpsDelivery(mockUsecase)
 
 	// Test
@@ -93,7 +93,7 @@ func TestDelivery_GetRelease_Success(t *testing.T) {
 	tile.Status = coreModels.SuccessStatus
 
 	mockUsecase := new(mocks.Usecase)
-	mockUsecase.On("Release", &models.ReleaseParams{Project: "test", Definition: pointer.ToInt(1)}).Return(tile, nil)
+	mockUsecase.On("Release", Anything, &models.ReleaseParams{Project: "test", Definition: pointer.ToInt(1)}).Return(tile, nil)
 	handler := NewAzureDevOpsDelivery(mockUsecase)
 
 	// Expected
@@ -128,7 +128,7 @@ func TestDelivery_GetRelease_Error(t *testing.T) {
 	ctx, _ := initEcho()
 
 	mockUsecase := new(mocks.Usecase)
-	mockUsecase.On("Release", Anything).Return(nil, errors.New("build error"))
+	mockUsecase.On("Release", Anything, Anything).Return(nil, errors.New("build error"))
 	handler := NewAzureDevOpsDelivery(mockUsecase)
 
 	// Test
@@ -4,6 +4,11 @@ package mocks
 
 import (
 	build "github.com/jsdidierlaurent/azure-devops-go-api/azuredevops/build"
+
+	context "context"
+
+	location "github.com/jsdidierlaurent/azure-devops-go-api/azuredevops/location"
+
 	mock "github.com/stretchr/testify/mock"
 
 	release "github.com/jsdidierlaurent/azure-devops-go-api/azuredevops/release"
@@ -14,13 +19,13 @@ type Connection struct {
 	mock.Mock
 }
 
-// GetBuildConnection provides a mock function with given fields:
-func (_m *Connection) GetBuildConnection() (build.Client, error) {
-	ret := _m.Called()
+// GetBuildConnection provides a mock function with given fields: ctx
+func (_m *Connection) GetBuildConnection(ctx context.Context) (build.Client, error) {
+	ret := _m.Called(ctx)
 
 	var r0 build.Client
-	if rf, ok := ret.Get(0).(func() build.Client); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) build.Client); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(build.Client)
@@ -28,8 +33,8 @@ func (_m *Connection) GetBuildConnection() (build.Client, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -37,13 +42,29 @@ func (_m *Connection) GetBuildConnection() (build.Client, error) {
 	return r0, r1
 }
 
-// GetReleaseConnection provides a mock function with given fields:
-func (_m *Connection) GetReleaseConnection() (release.Client, error) {
+// GetLocationConnection provides a mock function with given fields:
+func (_m *Connection) GetLocationConnection() location.Client {
 	ret := _m.Called()
 
-	var r0 release.Client
-	if rf, ok := ret.Get(0).(func() release.Client); ok {
+	var r0 location.Client
+	if rf, ok := ret.Get(0).(func() location.Client); ok {
 		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(location.Client)
+		}
+	}
+
+	return r0
+}
+
+// GetReleaseConnection provides a mock function with given fields: ctx
+func (_m *Connection) GetReleaseConnection(ctx context.Context) (release.Client, error) {
+	ret := _m.Called(ctx)
+
+	var r0 release.Client
+	if rf, ok := ret.Get(0).(func(context.Context) release.Client); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(release.Client)
@@ -51,8 +72,8 @@ func (_m *Connection) GetReleaseConnection() (release.Client, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
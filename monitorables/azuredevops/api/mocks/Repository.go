@@ -3,8 +3,11 @@
 package mocks
 
 import (
-	models "github.com/monitoror/monitoror/monitorables/azuredevops/api/models"
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/monitoror/monitoror/monitorables/azuredevops/api/models"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -12,13 +15,27 @@ type Repository struct {
 	mock.Mock
 }
 
-// GetBuild provides a mock function with given fields: project, definition, branch
-func (_m *Repository) GetBuild(project string, definition int, branch *string) (*models.Build, error) {
-	ret := _m.Called(project, definition, branch)
+// CheckConnection provides a mock function with given fields: ctx
+func (_m *Repository) CheckConnection(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetBuild provides a mock function with given fields: ctx, project, definition, branch
+func (_m *Repository) GetBuild(ctx context.Context, project string, definition int, branch *string) (*models.Build, error) {
+	ret := _m.Called(ctx, project, definition, branch)
 
 	var r0 *models.Build
-	if rf, ok := ret.Get(0).(func(string, int, *string) *models.Build); ok {
-		r0 = rf(project, definition, branch)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, *string) *models.Build); ok {
+		r0 = rf(ctx, project, definition, branch)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Build)
@@ -26,8 +43,8 @@ func (_m *Repository) GetBuild(project string, definition int, branch *string) (
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, int, *string) error); ok {
-		r1 = rf(project, definition, branch)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, *string) error); ok {
+		r1 = rf(ctx, project, definition, branch)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -35,13 +52,13 @@ func (_m *Repository) GetBuild(project string, definition int, branch *string) (
 	return r0, r1
 }
 
-// GetRelease provides a mock function with given fields: project, definition
-func (_m *Repository) GetRelease(project string, definition int) (*models.Release, error) {
-	ret := _m.Called(project, definition)
+// GetRelease provides a mock function with given fields: ctx, project, definition
+func (_m *Repository) GetRelease(ctx context.Context, project string, definition int) (*models.Release, error) {
+	ret := _m.Called(ctx, project, definition)
 
 	var r0 *models.Release
-	if rf, ok := ret.Get(0).(func(string, int) *models.Release); ok {
-		r0 = rf(project, definition)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *models.Release); ok {
+		r0 = rf(ctx, project, definition)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Release)
@@ -49,8 +66,8 @@ func (_m *Repository) GetRelease(project string, definition int) (*models.Releas
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, int) error); ok {
-		r1 = rf(project, definition)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, project, definition)
 	} else {
 		r1 = ret.Error(1)
 	}
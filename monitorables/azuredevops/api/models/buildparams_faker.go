@@ -27,16 +27,16 @@ type (
 	}
 )
 
-func (p *BuildParams) Validate(_ *uiConfigModels.ConfigVersion) *uiConfigModels.ConfigError {
-	// TODO
+func (p *BuildParams) Validate(_ *uiConfigModels.ConfigVersion) []uiConfigModels.ConfigError {
+	var errors []uiConfigModels.ConfigError
 
 	if p.Project == "" {
-		return &uiConfigModels.ConfigError{}
+		errors = append(errors, uiConfigModels.NewMissingParamError("project"))
 	}
 
 	if p.Definition == nil {
-		return &uiConfigModels.ConfigError{}
+		errors = append(errors, uiConfigModels.NewMissingParamError("definition"))
 	}
 
-	return nil
+	return errors
 }
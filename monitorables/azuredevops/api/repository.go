@@ -3,20 +3,25 @@
 package api
 
 import (
+	"context"
+
 	"github.com/monitoror/monitoror/monitorables/azuredevops/api/models"
 
 	"github.com/jsdidierlaurent/azure-devops-go-api/azuredevops/build"
+	"github.com/jsdidierlaurent/azure-devops-go-api/azuredevops/location"
 	"github.com/jsdidierlaurent/azure-devops-go-api/azuredevops/release"
 )
 
 type (
 	Connection interface {
-		GetBuildConnection() (build.Client, error)
-		GetReleaseConnection() (release.Client, error)
+		GetBuildConnection(ctx context.Context) (build.Client, error)
+		GetReleaseConnection(ctx context.Context) (release.Client, error)
+		GetLocationConnection() location.Client
 	}
 
 	Repository interface {
-		GetBuild(project string, definition int, branch *string) (*models.Build, error)
-		GetRelease(project string, definition int) (*models.Release, error)
+		CheckConnection(ctx context.Context) error
+		GetBuild(ctx context.Context, project string, definition int, branch *string) (*models.Build, error)
+		GetRelease(ctx context.Context, project string, definition int) (*models.Release, error)
 	}
 )
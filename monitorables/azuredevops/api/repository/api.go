@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/AlekSi/pointer"
 	azureDevOpsApi "github.com/jsdidierlaurent/azure-devops-go-api/azuredevops"
 	"github.com/jsdidierlaurent/azure-devops-go-api/azuredevops/build"
+	"github.com/jsdidierlaurent/azure-devops-go-api/azuredevops/location"
 	"github.com/jsdidierlaurent/azure-devops-go-api/azuredevops/release"
 )
 
@@ -28,12 +30,16 @@ type (
 	}
 )
 
-func (c *connection) GetBuildConnection() (build.Client, error) {
-	return build.NewClient(context.TODO(), c.connection)
+func (c *connection) GetBuildConnection(ctx context.Context) (build.Client, error) {
+	return build.NewClient(ctx, c.connection)
 }
 
-func (c *connection) GetReleaseConnection() (release.Client, error) {
-	return release.NewClient(context.TODO(), c.connection)
+func (c *connection) GetReleaseConnection(ctx context.Context) (release.Client, error) {
+	return release.NewClient(ctx, c.connection)
+}
+
+func (c *connection) GetLocationConnection() location.Client {
+	return location.NewClient(context.TODO(), c.connection)
 }
 
 func NewAzureDevOpsRepository(config *config.AzureDevOps) api.Repository {
@@ -52,7 +58,28 @@ func NewAzureDevOpsRepository(config *config.AzureDevOps) api.Repository {
 	}
 }
 
-func (r *azureDevOpsRepository) GetBuild(project string, definition int, branch *string) (*models.Build, error) {
+// CheckConnection load connection data (authenticated user) to check URL and token
+func (r *azureDevOpsRepository) CheckConnection(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(r.config.Timeout)*time.Millisecond)
+	defer cancel()
+
+	_, err := r.connection.GetLocationConnection().GetConnectionData(ctx, location.GetConnectionDataArgs{})
+
+	// Azure DevOps client return WrappedError by value or by pointer
+	var statusCode *int
+	if wrappedError, ok := err.(azureDevOpsApi.WrappedError); ok {
+		statusCode = wrappedError.StatusCode
+	} else if wrappedError, ok := err.(*azureDevOpsApi.WrappedError); ok {
+		statusCode = wrappedError.StatusCode
+	}
+	if statusCode != nil && (*statusCode == http.StatusUnauthorized || *statusCode == http.StatusForbidden) {
+		return fmt.Errorf("%w: %s", coreModels.UnauthorizedError, err.Error())
+	}
+
+	return err
+}
+
+func (r *azureDevOpsRepository) GetBuild(ctx context.Context, project string, definition int, branch *string) (*models.Build, error) {
 	// Inject "refs/heads/" in branch name
 	if branch != nil && !strings.HasPrefix(*branch, "refs/") {
 		branch = pointer.ToString(fmt.Sprintf("refs/heads/%s", *branch))
@@ -66,12 +93,12 @@ func (r *azureDevOpsRepository) GetBuild(project string, definition int, branch
 		MaxBuildsPerDefinition: pointer.ToInt(1),
 	}
 
-	client, err := r.connection.GetBuildConnection()
+	client, err := r.connection.GetBuildConnection(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	aBuilds, err := client.GetBuilds(context.TODO(), args)
+	aBuilds, err := client.GetBuilds(ctx, args)
 	if err != nil {
 		return nil, err
 	}
@@ -142,7 +169,7 @@ func (r *azureDevOpsRepository) GetBuild(project string, definition int, branch
 	return result, nil
 }
 
-func (r *azureDevOpsRepository) GetRelease(project string, definition int) (*models.Release, error) {
+func (r *azureDevOpsRepository) GetRelease(ctx context.Context, project string, definition int) (*models.Release, error) {
 	args := release.GetDeploymentsArgs{
 		Project:            pointer.ToString(project),
 		DefinitionId:       pointer.ToInt(definition),
@@ -150,12 +177,12 @@ func (r *azureDevOpsRepository) GetRelease(project string, definition int) (*mod
 		Top:                pointer.ToInt(1),
 	}
 
-	client, err := r.connection.GetReleaseConnection()
+	client, err := r.connection.GetReleaseConnection(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	aReleases, err := client.GetDeployments(context.TODO(), args)
+	aReleases, err := client.GetDeployments(ctx, args)
 	if err != nil {
 		return nil, err
 	}
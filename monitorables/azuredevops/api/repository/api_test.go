@@ -1,19 +1,23 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
 
+	coreModels "github.com/monitoror/monitoror/models"
 	"github.com/monitoror/monitoror/monitorables/azuredevops/api/mocks"
 	"github.com/monitoror/monitoror/monitorables/azuredevops/api/models"
 	"github.com/monitoror/monitoror/monitorables/azuredevops/config"
 	mocksBuild "github.com/monitoror/monitoror/pkg/goazuredevops/build/mocks"
+	mocksLocation "github.com/monitoror/monitoror/pkg/goazuredevops/location/mocks"
 	mocksRelease "github.com/monitoror/monitoror/pkg/goazuredevops/release/mocks"
 
 	. "github.com/AlekSi/pointer"
 	"github.com/jsdidierlaurent/azure-devops-go-api/azuredevops"
 	"github.com/jsdidierlaurent/azure-devops-go-api/azuredevops/build"
+	"github.com/jsdidierlaurent/azure-devops-go-api/azuredevops/location"
 	"github.com/jsdidierlaurent/azure-devops-go-api/azuredevops/release"
 	"github.com/jsdidierlaurent/azure-devops-go-api/azuredevops/webapi"
 	"github.com/stretchr/testify/assert"
@@ -29,16 +33,16 @@ func initRepository(t *testing.T, buildClient build.Client, releaseClient releas
 
 	mockConnection := new(mocks.Connection)
 	if buildClient != nil {
-		mockConnection.On("GetBuildConnection").Return(buildClient, nil)
+		mockConnection.On("GetBuildConnection", Anything, Anything).Return(buildClient, nil)
 	} else {
-		mockConnection.On("GetBuildConnection").
+		mockConnection.On("GetBuildConnection", Anything, Anything).
 			Return(nil, errors.New("GetBuildConnectionError"))
 	}
 
 	if releaseClient != nil {
-		mockConnection.On("GetReleaseConnection").Return(releaseClient, nil)
+		mockConnection.On("GetReleaseConnection", Anything, Anything).Return(releaseClient, nil)
 	} else {
-		mockConnection.On("GetReleaseConnection").
+		mockConnection.On("GetReleaseConnection", Anything, Anything).
 			Return(nil, errors.New("GetReleaseConnectionError"))
 	}
 
@@ -57,7 +61,7 @@ func initRepository(t *testing.T, buildClient build.Client, releaseClient releas
 func TestConnection_GetBuildConnection(t *testing.T) {
 	// Fake connection, just fort testing if NewClient is call correctly
 	con := &connection{&azuredevops.Connection{}}
-	client, err := con.GetBuildConnection()
+	client, err := con.GetBuildConnection(context.Background())
 	assert.Error(t, err)
 	assert.Nil(t, client)
 }
@@ -65,14 +69,49 @@ func TestConnection_GetBuildConnection(t *testing.T) {
 func TestConnection_GetReleaseConnection(t *testing.T) {
 	// Fake connection, just fort testing if NewClient is call correctly
 	con := &connection{&azuredevops.Connection{}}
-	client, err := con.GetReleaseConnection()
+	client, err := con.GetReleaseConnection(context.Background())
 	assert.Error(t, err)
 	assert.Nil(t, client)
 }
 
+func TestConnection_GetLocationConnection(t *testing.T) {
+	// Fake connection, just fort testing if NewClient is call correctly
+	con := &connection{&azuredevops.Connection{}}
+	client := con.GetLocationConnection()
+	assert.NotNil(t, client)
+}
+
+func TestRepository_CheckConnection(t *testing.T) {
+	for _, testcase := range []struct {
+		err          error
+		unauthorized bool
+	}{
+		{err: nil},
+		{err: errors.New("GetConnectionDataError")},
+		{err: azuredevops.WrappedError{Message: ToString("Not found"), StatusCode: ToInt(404)}},
+		{err: azuredevops.WrappedError{Message: ToString("Unauthorized"), StatusCode: ToInt(401)}, unauthorized: true},
+		{err: &azuredevops.WrappedError{Message: ToString("Forbidden"), StatusCode: ToInt(403)}, unauthorized: true},
+	} {
+		mockLocation := new(mocksLocation.Client)
+		mockLocation.On("GetConnectionData", Anything, AnythingOfType("location.GetConnectionDataArgs")).
+			Return(&location.ConnectionData{}, testcase.err)
+
+		repository := initRepository(t, nil, nil)
+		mockConnection := new(mocks.Connection)
+		mockConnection.On("GetLocationConnection").Return(mockLocation)
+		repository.connection = mockConnection
+
+		err := repository.CheckConnection(context.Background())
+		assert.Equal(t, testcase.err != nil, err != nil)
+		assert.Equal(t, testcase.unauthorized, errors.Is(err, coreModels.UnauthorizedError))
+		mockLocation.AssertNumberOfCalls(t, "GetConnectionData", 1)
+		mockLocation.AssertExpectations(t)
+	}
+}
+
 func TestRepository_GetBuild_Failure_ErrorOnGetClient(t *testing.T) {
 	repository := initRepository(t, nil, nil)
-	_, err := repository.GetBuild("test", 1, ToString("master"))
+	_, err := repository.GetBuild(context.Background(), "test", 1, ToString("master"))
 
 	assert.Error(t, err)
 	assert.Equal(t, "GetBuildConnectionError", err.Error())
@@ -84,7 +123,7 @@ func TestRepository_GetBuild_Failure_ErrorOnGetBuilds(t *testing.T) {
 		Return(nil, errors.New("GetBuildsError"))
 
 	repository := initRepository(t, mockBuild, nil)
-	_, err := repository.GetBuild("test", 1, ToString("master"))
+	_, err := repository.GetBuild(context.Background(), "test", 1, ToString("master"))
 
 	if assert.Error(t, err) {
 		assert.Equal(t, "GetBuildsError", err.Error())
@@ -103,7 +142,7 @@ func TestRepository_GetBuild_Failure_NoBuildsFound(t *testing.T) {
 		Return(azureDevOpsBuild, nil)
 
 	repository := initRepository(t, mockBuild, nil)
-	bu, err := repository.GetBuild("test", 1, ToString("master"))
+	bu, err := repository.GetBuild(context.Background(), "test", 1, ToString("master"))
 
 	if assert.NoError(t, err) {
 		assert.Nil(t, bu)
@@ -156,7 +195,7 @@ func TestRepository_GetBuild_Success(t *testing.T) {
 
 	repository := initRepository(t, mockBuild, nil)
 	if repository != nil {
-		b, err := repository.GetBuild("test", 1, ToString("master"))
+		b, err := repository.GetBuild(context.Background(), "test", 1, ToString("master"))
 		assert.NoError(t, err)
 		assert.Equal(t, expectedBuild, b)
 		mockBuild.AssertNumberOfCalls(t, "GetBuilds", 1)
@@ -211,7 +250,7 @@ func TestRepository_GetBuild_Success_WithoutAuthor(t *testing.T) {
 
 	repository := initRepository(t, mockBuild, nil)
 	if repository != nil {
-		b, err := repository.GetBuild("test", 1, ToString("master"))
+		b, err := repository.GetBuild(context.Background(), "test", 1, ToString("master"))
 		assert.NoError(t, err)
 		assert.Equal(t, expectedBuild, b)
 		mockBuild.AssertNumberOfCalls(t, "GetBuilds", 1)
@@ -221,7 +260,7 @@ func TestRepository_GetBuild_Success_WithoutAuthor(t *testing.T) {
 
 func TestRepository_GetRelease_Failure_ErrorOnGetClient(t *testing.T) {
 	repository := initRepository(t, nil, nil)
-	_, err := repository.GetRelease("test", 1)
+	_, err := repository.GetRelease(context.Background(), "test", 1)
 
 	assert.Error(t, err)
 	assert.Equal(t, "GetReleaseConnectionError", err.Error())
@@ -233,7 +272,7 @@ func TestRepository_GetRelease_Failure_ErrorOnGetBuilds(t *testing.T) {
 		Return(nil, errors.New("GetDeploymentsError"))
 
 	repository := initRepository(t, nil, mockRelease)
-	_, err := repository.GetRelease("test", 1)
+	_, err := repository.GetRelease(context.Background(), "test", 1)
 
 	assert.Error(t, err)
 	assert.Equal(t, "GetDeploymentsError", err.Error())
@@ -255,7 +294,7 @@ func TestRepository_GetRelease_Failure_NoBuildsFound(t *testing.T) {
 		Return(azureDevOpsDeployments, nil)
 
 	repository := initRepository(t, nil, mockRelease)
-	bu, err := repository.GetRelease("test", 1)
+	bu, err := repository.GetRelease(context.Background(), "test", 1)
 
 	if assert.NoError(t, err) {
 		assert.Nil(t, bu)
@@ -308,7 +347,7 @@ func TestRepository_GetRelease_Success(t *testing.T) {
 
 	repository := initRepository(t, nil, mockRelease)
 	if repository != nil {
-		r, err := repository.GetRelease("test", 1)
+		r, err := repository.GetRelease(context.Background(), "test", 1)
 		assert.NoError(t, err)
 		assert.Equal(t, expectedRelease, r)
 		mockRelease.AssertNumberOfCalls(t, "GetDeployments", 1)
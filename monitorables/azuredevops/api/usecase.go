@@ -3,6 +3,9 @@
 package api
 
 import (
+	"context"
+	"time"
+
 	coreModels "github.com/monitoror/monitoror/models"
 	"github.com/monitoror/monitoror/monitorables/azuredevops/api/models"
 )
@@ -10,11 +13,14 @@ import (
 const (
 	AzureDevOpsBuildTileType   coreModels.TileType = "AZUREDEVOPS-BUILD"
 	AzureDevOpsReleaseTileType coreModels.TileType = "AZUREDEVOPS-RELEASE"
+
+	AzureDevOpsBuildMinimalRefreshInterval   = 10 * time.Second
+	AzureDevOpsReleaseMinimalRefreshInterval = 10 * time.Second
 )
 
 type (
 	Usecase interface {
-		Build(params *models.BuildParams) (*coreModels.Tile, error)
-		Release(params *models.ReleaseParams) (*coreModels.Tile, error)
+		Build(ctx context.Context, params *models.BuildParams) (*coreModels.Tile, error)
+		Release(ctx context.Context, params *models.ReleaseParams) (*coreModels.Tile, error)
 	}
 )
@@ -3,6 +3,7 @@
 package usecase
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/monitoror/monitoror/pkg/git"
 
 	"github.com/AlekSi/pointer"
+	echoCache "github.com/jsdidierlaurent/echo-middleware/cache"
 )
 
 type (
@@ -26,20 +28,20 @@ type (
 
 const buildCacheSize = 5
 
-func NewAzureDevOpsUsecase(repository api.Repository) api.Usecase {
+func NewAzureDevOpsUsecase(repository api.Repository, store echoCache.Store, variantName coreModels.VariantName) api.Usecase {
 	return &azureDevOpsUsecase{
 		repository,
-		cache.NewBuildCache(buildCacheSize),
+		cache.NewBuildCache(store, fmt.Sprintf("%s:%s", api.AzureDevOpsBuildTileType, variantName), buildCacheSize),
 	}
 }
 
-func (au *azureDevOpsUsecase) Build(params *models.BuildParams) (*coreModels.Tile, error) {
+func (au *azureDevOpsUsecase) Build(ctx context.Context, params *models.BuildParams) (*coreModels.Tile, error) {
 	tile := coreModels.NewTile(api.AzureDevOpsBuildTileType).WithBuild()
 	// Default label if build not found
 	tile.Label = params.Project
 
 	// Lookup for build
-	build, err := au.repository.GetBuild(params.Project, *params.Definition, params.Branch)
+	build, err := au.repository.GetBuild(ctx, params.Project, *params.Definition, params.Branch)
 	if err != nil {
 		return nil, &coreModels.MonitororError{Err: err, Tile: tile, Message: "unable to find build"}
 	}
@@ -101,13 +103,13 @@ func (au *azureDevOpsUsecase) Build(params *models.BuildParams) (*coreModels.Til
 	return tile, nil
 }
 
-func (au *azureDevOpsUsecase) Release(params *models.ReleaseParams) (*coreModels.Tile, error) {
+func (au *azureDevOpsUsecase) Release(ctx context.Context, params *models.ReleaseParams) (*coreModels.Tile, error) {
 	tile := coreModels.NewTile(api.AzureDevOpsReleaseTileType).WithBuild()
 	// Default label if build not found
 	tile.Label = params.Project
 
 	// Lookup for release
-	release, err := au.repository.GetRelease(params.Project, *params.Definition)
+	release, err := au.repository.GetRelease(ctx, params.Project, *params.Definition)
 	if err != nil {
 		return nil, &coreModels.MonitororError{Err: err, Tile: tile, Message: "unable to find release"}
 	}
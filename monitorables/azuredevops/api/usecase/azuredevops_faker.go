@@ -3,6 +3,7 @@
 package usecase
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"time"
@@ -44,7 +45,7 @@ func NewAzureDevOpsUsecase() api.Usecase {
 	return &azureDevOpsUsecase{cmap.New()}
 }
 
-func (au *azureDevOpsUsecase) Build(params *azureModels.BuildParams) (tile *models.Tile, err error) {
+func (au *azureDevOpsUsecase) Build(_ context.Context, params *azureModels.BuildParams) (tile *models.Tile, err error) {
 	tile = models.NewTile(api.AzureDevOpsBuildTileType).WithBuild()
 	tile.Label = fmt.Sprintf("%s (build-qa-%d)", params.Project, *params.Definition)
 	tile.Build.ID = pointer.ToString("12")
@@ -95,7 +96,7 @@ func (au *azureDevOpsUsecase) Build(params *azureModels.BuildParams) (tile *mode
 	return
 }
 
-func (au *azureDevOpsUsecase) Release(params *azureModels.ReleaseParams) (tile *models.Tile, err error) {
+func (au *azureDevOpsUsecase) Release(_ context.Context, params *azureModels.ReleaseParams) (tile *models.Tile, err error) {
 	tile = models.NewTile(api.AzureDevOpsReleaseTileType).WithBuild()
 	tile.Label = fmt.Sprintf("%s (release-%d)", params.Project, *params.Definition)
 	tile.Build.ID = pointer.ToString("12")
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -11,16 +12,17 @@ import (
 	"github.com/monitoror/monitoror/monitorables/azuredevops/api/models"
 
 	. "github.com/AlekSi/pointer"
+	echoCache "github.com/jsdidierlaurent/echo-middleware/cache"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
 func TestAzureDevOpsUsecase_Build_ErrorOnGetBuild(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetBuild", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("GetBuildError"))
+	mockRepository.On("GetBuild", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("GetBuildError"))
 
-	usecase := NewAzureDevOpsUsecase(mockRepository)
-	tile, err := usecase.Build(&models.BuildParams{Project: "test", Definition: ToInt(1), Branch: ToString("master")})
+	usecase := NewAzureDevOpsUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
+	tile, err := usecase.Build(context.Background(), &models.BuildParams{Project: "test", Definition: ToInt(1), Branch: ToString("master")})
 
 	if assert.Error(t, err) {
 		assert.Nil(t, tile)
@@ -32,10 +34,10 @@ func TestAzureDevOpsUsecase_Build_ErrorOnGetBuild(t *testing.T) {
 
 func TestAzureDevOpsUsecase_Build_ErrorNoBuildFound(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetBuild", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	mockRepository.On("GetBuild", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
-	usecase := NewAzureDevOpsUsecase(mockRepository)
-	tile, err := usecase.Build(&models.BuildParams{Project: "test", Definition: ToInt(1), Branch: ToString("master")})
+	usecase := NewAzureDevOpsUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
+	tile, err := usecase.Build(context.Background(), &models.BuildParams{Project: "test", Definition: ToInt(1), Branch: ToString("master")})
 
 	if assert.Error(t, err) {
 		assert.Nil(t, tile)
@@ -63,7 +65,7 @@ func TestAzureDevOpsUsecase_Build_Success(t *testing.T) {
 	}
 
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetBuild", mock.Anything, mock.Anything, mock.Anything).Return(build, nil)
+	mockRepository.On("GetBuild", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(build, nil)
 
 	expected := coreModels.NewTile(api.AzureDevOpsBuildTileType).WithBuild()
 	expected.Label = "test (definitionName)"
@@ -77,8 +79,8 @@ func TestAzureDevOpsUsecase_Build_Success(t *testing.T) {
 
 	params := &models.BuildParams{Project: "test", Definition: ToInt(1), Branch: ToString("master")}
 
-	usecase := NewAzureDevOpsUsecase(mockRepository)
-	tile, err := usecase.Build(params)
+	usecase := NewAzureDevOpsUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
+	tile, err := usecase.Build(context.Background(), params)
 	if assert.NoError(t, err) {
 		assert.NotNil(t, tile)
 		assert.Equal(t, expected, tile)
@@ -105,7 +107,7 @@ func TestAzureDevOpsUsecase_Build_Failed(t *testing.T) {
 	}
 
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetBuild", mock.Anything, mock.Anything, mock.Anything).Return(build, nil)
+	mockRepository.On("GetBuild", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(build, nil)
 
 	expected := coreModels.NewTile(api.AzureDevOpsBuildTileType).WithBuild()
 	expected.Label = "test (definitionName)"
@@ -123,8 +125,8 @@ func TestAzureDevOpsUsecase_Build_Failed(t *testing.T) {
 
 	params := &models.BuildParams{Project: "test", Definition: ToInt(1), Branch: ToString("master")}
 
-	usecase := NewAzureDevOpsUsecase(mockRepository)
-	tile, err := usecase.Build(params)
+	usecase := NewAzureDevOpsUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
+	tile, err := usecase.Build(context.Background(), params)
 	if assert.NoError(t, err) {
 		assert.NotNil(t, tile)
 		assert.Equal(t, expected, tile)
@@ -147,9 +149,9 @@ func TestAzureDevOpsUsecase_Build_Running(t *testing.T) {
 	}
 
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetBuild", mock.Anything, mock.Anything, mock.Anything).Return(build, nil)
+	mockRepository.On("GetBuild", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(build, nil)
 
-	au := NewAzureDevOpsUsecase(mockRepository)
+	au := NewAzureDevOpsUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 	aUsecase, ok := au.(*azureDevOpsUsecase)
 	if assert.True(t, ok, "enable to case au into azureDevOpsUsecase") {
 		expected := coreModels.NewTile(api.AzureDevOpsBuildTileType).WithBuild()
@@ -164,7 +166,7 @@ func TestAzureDevOpsUsecase_Build_Running(t *testing.T) {
 		expected.Build.EstimatedDuration = ToInt64(0)
 
 		params := &models.BuildParams{Project: "test", Definition: ToInt(1), Branch: ToString("master")}
-		tile, err := au.Build(params)
+		tile, err := au.Build(context.Background(), params)
 		if assert.NoError(t, err) {
 			assert.NotNil(t, tile)
 			assert.Equal(t, expected, tile)
@@ -175,7 +177,7 @@ func TestAzureDevOpsUsecase_Build_Running(t *testing.T) {
 		expected.Build.PreviousStatus = coreModels.SuccessStatus
 		expected.Build.EstimatedDuration = ToInt64(int64(120))
 
-		tile, err = au.Build(params)
+		tile, err = au.Build(context.Background(), params)
 		if assert.NoError(t, err) {
 			assert.Equal(t, expected, tile)
 		}
@@ -199,9 +201,9 @@ func TestAzureDevOpsUsecase_Build_Queued(t *testing.T) {
 	}
 
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetBuild", mock.Anything, mock.Anything, mock.Anything).Return(build, nil)
+	mockRepository.On("GetBuild", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(build, nil)
 
-	au := NewAzureDevOpsUsecase(mockRepository)
+	au := NewAzureDevOpsUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 	expected := coreModels.NewTile(api.AzureDevOpsBuildTileType).WithBuild()
 	expected.Label = "test (definitionName)"
 	expected.Build.ID = ToString("1")
@@ -212,7 +214,7 @@ func TestAzureDevOpsUsecase_Build_Queued(t *testing.T) {
 	expected.Build.StartedAt = &now
 
 	params := &models.BuildParams{Project: "test", Definition: ToInt(1), Branch: ToString("master")}
-	tile, err := au.Build(params)
+	tile, err := au.Build(context.Background(), params)
 	if assert.NoError(t, err) {
 		assert.NotNil(t, tile)
 		assert.Equal(t, expected, tile)
@@ -223,10 +225,10 @@ func TestAzureDevOpsUsecase_Build_Queued(t *testing.T) {
 
 func TestAzureDevOpsUsecase_Release_ErrorOnGetRelease(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetRelease", mock.Anything, mock.Anything).Return(nil, errors.New("GetReleaseError"))
+	mockRepository.On("GetRelease", mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("GetReleaseError"))
 
-	usecase := NewAzureDevOpsUsecase(mockRepository)
-	tile, err := usecase.Release(&models.ReleaseParams{Project: "test", Definition: ToInt(1)})
+	usecase := NewAzureDevOpsUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
+	tile, err := usecase.Release(context.Background(), &models.ReleaseParams{Project: "test", Definition: ToInt(1)})
 
 	if assert.Error(t, err) {
 		assert.Nil(t, tile)
@@ -238,10 +240,10 @@ func TestAzureDevOpsUsecase_Release_ErrorOnGetRelease(t *testing.T) {
 
 func TestAzureDevOpsUsecase_Release_ErrorNoBuildFound(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetRelease", mock.Anything, mock.Anything).Return(nil, nil)
+	mockRepository.On("GetRelease", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
-	usecase := NewAzureDevOpsUsecase(mockRepository)
-	tile, err := usecase.Release(&models.ReleaseParams{Project: "test", Definition: ToInt(1)})
+	usecase := NewAzureDevOpsUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
+	tile, err := usecase.Release(context.Background(), &models.ReleaseParams{Project: "test", Definition: ToInt(1)})
 
 	if assert.Error(t, err) {
 		assert.Nil(t, tile)
@@ -267,7 +269,7 @@ func TestAzureDevOpsUsecase_Release_Success(t *testing.T) {
 	}
 
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetRelease", mock.Anything, mock.Anything).Return(release, nil)
+	mockRepository.On("GetRelease", mock.Anything, mock.Anything, mock.Anything).Return(release, nil)
 
 	expected := coreModels.NewTile(api.AzureDevOpsReleaseTileType).WithBuild()
 	expected.Label = "test (definitionName)"
@@ -280,8 +282,8 @@ func TestAzureDevOpsUsecase_Release_Success(t *testing.T) {
 
 	params := &models.ReleaseParams{Project: "test", Definition: ToInt(1)}
 
-	usecase := NewAzureDevOpsUsecase(mockRepository)
-	tile, err := usecase.Release(params)
+	usecase := NewAzureDevOpsUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
+	tile, err := usecase.Release(context.Background(), params)
 	if assert.NoError(t, err) {
 		assert.NotNil(t, tile)
 		assert.Equal(t, expected, tile)
@@ -306,7 +308,7 @@ func TestAzureDevOpsUsecase_Release_Failed(t *testing.T) {
 	}
 
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetRelease", mock.Anything, mock.Anything).Return(release, nil)
+	mockRepository.On("GetRelease", mock.Anything, mock.Anything, mock.Anything).Return(release, nil)
 
 	expected := coreModels.NewTile(api.AzureDevOpsReleaseTileType).WithBuild()
 	expected.Label = "test (definitionName)"
@@ -323,8 +325,8 @@ func TestAzureDevOpsUsecase_Release_Failed(t *testing.T) {
 
 	params := &models.ReleaseParams{Project: "test", Definition: ToInt(1)}
 
-	usecase := NewAzureDevOpsUsecase(mockRepository)
-	tile, err := usecase.Release(params)
+	usecase := NewAzureDevOpsUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
+	tile, err := usecase.Release(context.Background(), params)
 	if assert.NoError(t, err) {
 		assert.NotNil(t, tile)
 		assert.Equal(t, expected, tile)
@@ -345,9 +347,9 @@ func TestAzureDevOpsUsecase_Release_Running(t *testing.T) {
 	}
 
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetRelease", mock.Anything, mock.Anything).Return(release, nil)
+	mockRepository.On("GetRelease", mock.Anything, mock.Anything, mock.Anything).Return(release, nil)
 
-	au := NewAzureDevOpsUsecase(mockRepository)
+	au := NewAzureDevOpsUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 	aUsecase, ok := au.(*azureDevOpsUsecase)
 	if assert.True(t, ok, "enable to case au into azureDevOpsUsecase") {
 		expected := coreModels.NewTile(api.AzureDevOpsReleaseTileType).WithBuild()
@@ -361,7 +363,7 @@ func TestAzureDevOpsUsecase_Release_Running(t *testing.T) {
 		expected.Build.EstimatedDuration = ToInt64(0)
 
 		params := &models.ReleaseParams{Project: "test", Definition: ToInt(1)}
-		tile, err := au.Release(params)
+		tile, err := au.Release(context.Background(), params)
 		if assert.NoError(t, err) {
 			assert.NotNil(t, tile)
 			assert.Equal(t, expected, tile)
@@ -372,7 +374,7 @@ func TestAzureDevOpsUsecase_Release_Running(t *testing.T) {
 		expected.Build.PreviousStatus = coreModels.SuccessStatus
 		expected.Build.EstimatedDuration = ToInt64(int64(120))
 
-		tile, err = au.Release(params)
+		tile, err = au.Release(context.Background(), params)
 		if assert.NoError(t, err) {
 			assert.Equal(t, expected, tile)
 		}
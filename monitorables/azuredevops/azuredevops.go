@@ -3,6 +3,7 @@
 package azuredevops
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 
@@ -15,6 +16,7 @@ import (
 	azuredevopsRepository "github.com/monitoror/monitoror/monitorables/azuredevops/api/repository"
 	azuredevopsUsecase "github.com/monitoror/monitoror/monitorables/azuredevops/api/usecase"
 	azuredevopsConfig "github.com/monitoror/monitoror/monitorables/azuredevops/config"
+	"github.com/monitoror/monitoror/service/options"
 	"github.com/monitoror/monitoror/service/registry"
 	"github.com/monitoror/monitoror/service/store"
 )
@@ -38,8 +40,8 @@ func NewMonitorable(store *store.Store) *Monitorable {
 	pkgMonitorable.LoadConfig(&m.config, azuredevopsConfig.Default)
 
 	// Register Monitorable Tile in config manager
-	m.buildTileEnabler = store.Registry.RegisterTile(api.AzureDevOpsBuildTileType, versions.MinimalVersion, m.GetVariantNames())
-	m.releaseTileEnabler = store.Registry.RegisterTile(api.AzureDevOpsReleaseTileType, versions.MinimalVersion, m.GetVariantNames())
+	m.buildTileEnabler = store.Registry.RegisterTile(api.AzureDevOpsBuildTileType, versions.MinimalVersion, m.GetVariantNames(), api.AzureDevOpsBuildMinimalRefreshInterval, &azuredevopsModels.BuildParams{})
+	m.releaseTileEnabler = store.Registry.RegisterTile(api.AzureDevOpsReleaseTileType, versions.MinimalVersion, m.GetVariantNames(), api.AzureDevOpsReleaseMinimalRefreshInterval, &azuredevopsModels.ReleaseParams{})
 
 	return m
 }
@@ -52,6 +54,10 @@ func (m *Monitorable) GetVariantNames() []coreModels.VariantName {
 	return pkgMonitorable.GetVariants(m.config)
 }
 
+func (m *Monitorable) GetTileTypes() []coreModels.TileType {
+	return []coreModels.TileType{api.AzureDevOpsBuildTileType, api.AzureDevOpsReleaseTileType}
+}
+
 func (m *Monitorable) Validate(variantName coreModels.VariantName) (bool, error) {
 	conf := m.config[variantName]
 
@@ -77,15 +83,19 @@ func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	conf := m.config[variantName]
 
 	repository := azuredevopsRepository.NewAzureDevOpsRepository(conf)
-	usecase := azuredevopsUsecase.NewAzureDevOpsUsecase(repository)
+	usecase := azuredevopsUsecase.NewAzureDevOpsUsecase(repository, m.store.CacheStore, variantName)
 	delivery := azuredevopsDelivery.NewAzureDevOpsDelivery(usecase)
 
 	// EnableTile route to echo
 	routeGroup := m.store.MonitorableRouter.Group("/azuredevops", variantName)
-	routeBuild := routeGroup.GET("/build", delivery.GetBuild)
-	routeRelease := routeGroup.GET("/release", delivery.GetRelease)
+	routeBuild := routeGroup.GET("/build", delivery.GetBuild, options.WithUpstreamURL(conf.URL))
+	routeRelease := routeGroup.GET("/release", delivery.GetRelease, options.WithUpstreamURL(conf.URL))
 
 	// EnableTile data for config hydration
-	m.buildTileEnabler.Enable(variantName, &azuredevopsModels.BuildParams{}, routeBuild.Path)
-	m.releaseTileEnabler.Enable(variantName, &azuredevopsModels.ReleaseParams{}, routeRelease.Path)
+	m.buildTileEnabler.Enable(variantName, routeBuild.Path)
+	m.releaseTileEnabler.Enable(variantName, routeRelease.Path)
+}
+
+func (m *Monitorable) Diagnose(ctx context.Context, variantName coreModels.VariantName) error {
+	return azuredevopsRepository.NewAzureDevOpsRepository(m.config[variantName]).CheckConnection(ctx)
 }
@@ -28,8 +28,8 @@ func NewMonitorable(store *store.Store) *Monitorable {
 	m.store = store
 
 	// Register Monitorable Tile in config manager
-	m.buildTileEnabler = store.Registry.RegisterTile(api.AzureDevOpsBuildTileType, versions.MinimalVersion, m.GetVariantNames())
-	m.releaseTileEnabler = store.Registry.RegisterTile(api.AzureDevOpsReleaseTileType, versions.MinimalVersion, m.GetVariantNames())
+	m.buildTileEnabler = store.Registry.RegisterTile(api.AzureDevOpsBuildTileType, versions.MinimalVersion, m.GetVariantNames(), api.AzureDevOpsBuildMinimalRefreshInterval, &azuredevopsModels.BuildParams{})
+	m.releaseTileEnabler = store.Registry.RegisterTile(api.AzureDevOpsReleaseTileType, versions.MinimalVersion, m.GetVariantNames(), api.AzureDevOpsReleaseMinimalRefreshInterval, &azuredevopsModels.ReleaseParams{})
 
 	return m
 }
@@ -38,6 +38,10 @@ func (m *Monitorable) GetDisplayName() string {
 	return "Azure DevOps (faker)"
 }
 
+func (m *Monitorable) GetTileTypes() []coreModels.TileType {
+	return []coreModels.TileType{api.AzureDevOpsBuildTileType, api.AzureDevOpsReleaseTileType}
+}
+
 func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	usecase := azuredevopsUsecase.NewAzureDevOpsUsecase()
 	delivery := azuredevopsDelivery.NewAzureDevOpsDelivery(usecase)
@@ -48,6 +52,6 @@ func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	routeRelease := routeGroup.GET("/release", delivery.GetRelease)
 
 	// EnableTile data for config hydration
-	m.buildTileEnabler.Enable(variantName, &azuredevopsModels.BuildParams{}, routeBuild.Path)
-	m.releaseTileEnabler.Enable(variantName, &azuredevopsModels.ReleaseParams{}, routeRelease.Path)
+	m.buildTileEnabler.Enable(variantName, routeBuild.Path)
+	m.releaseTileEnabler.Enable(variantName, routeRelease.Path)
 }
@@ -0,0 +1,82 @@
+package monitorables
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/monitoror/monitoror/cli"
+	coreModels "github.com/monitoror/monitoror/models"
+)
+
+// Diagnosable is implemented by monitorables able to check a variant against their service
+// (reachability and authentication). Validate only check config syntax
+type Diagnosable interface {
+	//Diagnose call a lightweight authenticated endpoint of service with variant config
+	// return error wrapping coreModels.UnauthorizedError if credentials are rejected
+	Diagnose(ctx context.Context, variantName coreModels.VariantName) error
+}
+
+// DiagnoseMonitorables check every enabled variant of diagnosable monitorables and print results.
+// Return number of failed variants. ctx cancel pending diagnoses
+func (m *Manager) DiagnoseMonitorables(ctx context.Context) int {
+	m.store.Cli.PrintDiagnosisHeader()
+
+	diagnosedVariantCount := 0
+	failedVariantCount := 0
+
+	for _, monitorable := range m.monitorables {
+		diagnosable, ok := monitorable.(Diagnosable)
+		if !ok {
+			continue
+		}
+
+		var variantDiagnoses []cli.VariantDiagnosis
+		for _, variantName := range sortVariantNames(monitorable.GetVariantNames()) {
+			if valid, _ := monitorable.Validate(variantName); !valid {
+				continue
+			}
+
+			start := time.Now()
+			err := diagnosable.Diagnose(ctx, variantName)
+			variantDiagnoses = append(variantDiagnoses, cli.VariantDiagnosis{
+				VariantName: variantName,
+				Status:      diagnosisStatus(err),
+				Latency:     time.Since(start),
+				Err:         err,
+			})
+
+			diagnosedVariantCount++
+			if err != nil {
+				failedVariantCount++
+			}
+		}
+
+		if len(variantDiagnoses) > 0 {
+			m.store.Cli.PrintMonitorableDiagnosis(monitorable.GetDisplayName(), variantDiagnoses)
+		}
+	}
+
+	m.store.Cli.PrintDiagnosisFooter(diagnosedVariantCount, failedVariantCount)
+
+	return failedVariantCount
+}
+
+func diagnosisStatus(err error) cli.DiagnosisStatus {
+	if err == nil {
+		return cli.DiagnosisOK
+	}
+
+	if errors.Is(err, coreModels.UnauthorizedError) {
+		return cli.DiagnosisUnauthorized
+	}
+
+	// Timeout, DNS error, connection refused, ... (url.Error returned by http client implement net.Error)
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return cli.DiagnosisUnreachable
+	}
+
+	return cli.DiagnosisErrored
+}
@@ -0,0 +1,88 @@
+package monitorables
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/monitoror/monitoror/cli"
+	cliMocks "github.com/monitoror/monitoror/cli/mocks"
+	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/service/store"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type diagnosableMonitorableMock struct {
+	monitorableMock
+	diagnoseErrors map[coreModels.VariantName]error
+}
+
+func (m *diagnosableMonitorableMock) Diagnose(_ context.Context, variantName coreModels.VariantName) error {
+	return m.diagnoseErrors[variantName]
+}
+
+func TestManager_DiagnoseMonitorables(t *testing.T) {
+	cliMock := new(cliMocks.CLI)
+	cliMock.On("PrintDiagnosisHeader")
+	cliMock.On("PrintMonitorableDiagnosis", mock.AnythingOfType("string"), mock.Anything)
+	cliMock.On("PrintDiagnosisFooter", mock.AnythingOfType("int"), mock.AnythingOfType("int"))
+
+	manager := NewMonitorableManager(&store.Store{Cli: cliMock})
+
+	// Not diagnosable
+	manager.register(&monitorableMock{
+		displayName:  "Monitorable mock 1",
+		variants:     []coreModels.VariantName{coreModels.DefaultVariant},
+		validateBool: true,
+	})
+	// Diagnosable without enabled variant
+	manager.register(&diagnosableMonitorableMock{
+		monitorableMock: monitorableMock{
+			displayName:   "Monitorable mock 2",
+			variants:      []coreModels.VariantName{coreModels.DefaultVariant},
+			validateError: errors.New("boom"),
+		},
+	})
+	// Diagnosable
+	manager.register(&diagnosableMonitorableMock{
+		monitorableMock: monitorableMock{
+			displayName:  "Monitorable mock 3",
+			variants:     []coreModels.VariantName{"variant1", coreModels.DefaultVariant, "variant2"},
+			validateBool: true,
+		},
+		diagnoseErrors: map[coreModels.VariantName]error{
+			"variant1": fmt.Errorf("%w: 401 Unauthorized", coreModels.UnauthorizedError),
+			"variant2": errors.New("unexpected response: 404 Not Found"),
+		},
+	})
+
+	assert.Equal(t, 2, manager.DiagnoseMonitorables(context.Background()))
+
+	cliMock.AssertNumberOfCalls(t, "PrintDiagnosisHeader", 1)
+	cliMock.AssertNumberOfCalls(t, "PrintMonitorableDiagnosis", 1)
+	cliMock.AssertCalled(t, "PrintDiagnosisFooter", 3, 2)
+
+	variantDiagnoses := cliMock.Calls[1].Arguments.Get(1).([]cli.VariantDiagnosis)
+	assert.Equal(t, "Monitorable mock 3", cliMock.Calls[1].Arguments.String(0))
+	if assert.Len(t, variantDiagnoses, 3) {
+		assert.Equal(t, coreModels.DefaultVariant, variantDiagnoses[0].VariantName)
+		assert.Equal(t, cli.DiagnosisOK, variantDiagnoses[0].Status)
+		assert.Equal(t, coreModels.VariantName("variant1"), variantDiagnoses[1].VariantName)
+		assert.Equal(t, cli.DiagnosisUnauthorized, variantDiagnoses[1].Status)
+		assert.Equal(t, coreModels.VariantName("variant2"), variantDiagnoses[2].VariantName)
+		assert.Equal(t, cli.DiagnosisErrored, variantDiagnoses[2].Status)
+	}
+}
+
+func TestDiagnosisStatus(t *testing.T) {
+	assert.Equal(t, cli.DiagnosisOK, diagnosisStatus(nil))
+	assert.Equal(t, cli.DiagnosisUnauthorized, diagnosisStatus(fmt.Errorf("%w: Bad credentials", coreModels.UnauthorizedError)))
+	assert.Equal(t, cli.DiagnosisUnreachable, diagnosisStatus(&url.Error{Op: "Get", URL: "http://example.com", Err: errors.New("connection refused")}))
+	assert.Equal(t, cli.DiagnosisUnreachable, diagnosisStatus(&net.DNSError{Err: "no such host", Name: "example.com"}))
+	assert.Equal(t, cli.DiagnosisErrored, diagnosisStatus(errors.New("unexpected response: 500 Internal Server Error")))
+}
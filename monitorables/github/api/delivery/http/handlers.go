@@ -25,7 +25,7 @@ func (h *GithubDelivery) GetCount(c echo.Context) error {
 		return err
 	}
 
-	tile, err := h.githubUsecase.Count(params)
+	tile, err := h.githubUsecase.Count(c.Request().Context(), params)
 	if err != nil {
 		return err
 	}
@@ -40,7 +40,7 @@ func (h *GithubDelivery) GetChecks(c echo.Context) error {
 		return err
 	}
 
-	tile, err := h.githubUsecase.Checks(params)
+	tile, err := h.githubUsecase.Checks(c.Request().Context(), params)
 	if err != nil {
 		return err
 	}
@@ -37,7 +37,7 @@ func TestDelivery_GetCount_Success(t *testing.T) {
 	tile.Status = coreModels.SuccessStatus
 
 	mockUsecase := new(mocks.Usecase)
-	mockUsecase.On("Count", &models.CountParams{Query: "test"}).Return(tile, nil)
+	mockUsecase.On("Count", Anything, &models.CountParams{Query: "test"}).Return(tile, nil)
 	handler := NewGithubDelivery(mockUsecase)
 
 	// Expected
@@ -77,7 +77,7 @@ func TestDelivery_GetCount_Error(t *testing.T) {
 	ctx.QueryParams().Set("query", "test")
 
 	mockUsecase := new(mocks.Usecase)
-	mockUsecase.On("Count", Anything).Return(nil, errors.New("build error"))
+	mockUsecase.On("Count", Anything, Anything).Return(nil, errors.New("build error"))
 	handler := NewGithubDelivery(mockUsecase)
 
 	// Test
@@ -101,7 +101,7 @@ func TestDelivery_GetChecks_Success(t *testing.T) {
 	tile.Status = coreModels.SuccessStatus
 
 	mockUsecase := new(mocks.Usecase)
-	mockUsecase.On("Checks", &models.ChecksParams{Owner: "test", Repository: "test", Ref: "master"}).Return(tile, nil)
+	mockUsecase.On("Checks", Anything, &models.ChecksParams{Owner: "test", Repository: "test", Ref: "master"}).Return(tile, nil)
 	handler := NewGithubDelivery(mockUsecase)
 
 	// Expected
@@ -143,7 +143,7 @@ func TestDelivery_GetChecks_Error(t *testing.T) {
 	ctx.QueryParams().Set("ref", "master")
 
 	mockUsecase := new(mocks.Usecase)
-	mockUsecase.On("Checks", Anything).Return(nil, errors.New("build error"))
+	mockUsecase.On("Checks", Anything, Anything).Return(nil, errors.New("build error"))
 	handler := NewGithubDelivery(mockUsecase)
 
 	// Test
@@ -3,8 +3,11 @@
 package mocks
 
 import (
-	models "github.com/monitoror/monitoror/monitorables/github/api/models"
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/monitoror/monitoror/monitorables/github/api/models"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -12,13 +15,27 @@ type Repository struct {
 	mock.Mock
 }
 
-// GetChecks provides a mock function with given fields: owner, repository, ref
-func (_m *Repository) GetChecks(owner string, repository string, ref string) (*models.Checks, error) {
-	ret := _m.Called(owner, repository, ref)
+// CheckConnection provides a mock function with given fields: ctx
+func (_m *Repository) CheckConnection(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetChecks provides a mock function with given fields: ctx, owner, repository, ref
+func (_m *Repository) GetChecks(ctx context.Context, owner string, repository string, ref string) (*models.Checks, error) {
+	ret := _m.Called(ctx, owner, repository, ref)
 
 	var r0 *models.Checks
-	if rf, ok := ret.Get(0).(func(string, string, string) *models.Checks); ok {
-		r0 = rf(owner, repository, ref)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.Checks); ok {
+		r0 = rf(ctx, owner, repository, ref)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Checks)
@@ -26,8 +43,8 @@ func (_m *Repository) GetChecks(owner string, repository string, ref string) (*m
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = rf(owner, repository, ref)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, owner, repository, ref)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -35,13 +52,13 @@ func (_m *Repository) GetChecks(owner string, repository string, ref string) (*m
 	return r0, r1
 }
 
-// GetCommit provides a mock function with given fields: owner, repository, sha
-func (_m *Repository) GetCommit(owner string, repository string, sha string) (*models.Commit, error) {
-	ret := _m.Called(owner, repository, sha)
+// GetCommit provides a mock function with given fields: ctx, owner, repository, sha
+func (_m *Repository) GetCommit(ctx context.Context, owner string, repository string, sha string) (*models.Commit, error) {
+	ret := _m.Called(ctx, owner, repository, sha)
 
 	var r0 *models.Commit
-	if rf, ok := ret.Get(0).(func(string, string, string) *models.Commit); ok {
-		r0 = rf(owner, repository, sha)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.Commit); ok {
+		r0 = rf(ctx, owner, repository, sha)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Commit)
@@ -49,8 +66,8 @@ func (_m *Repository) GetCommit(owner string, repository string, sha string) (*m
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = rf(owner, repository, sha)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, owner, repository, sha)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -58,20 +75,20 @@ func (_m *Repository) GetCommit(owner string, repository string, sha string) (*m
 	return r0, r1
 }
 
-// GetCount provides a mock function with given fields: query
-func (_m *Repository) GetCount(query string) (int, error) {
-	ret := _m.Called(query)
+// GetCount provides a mock function with given fields: ctx, query
+func (_m *Repository) GetCount(ctx context.Context, query string) (int, error) {
+	ret := _m.Called(ctx, query)
 
 	var r0 int
-	if rf, ok := ret.Get(0).(func(string) int); ok {
-		r0 = rf(query)
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, query)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(query)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, query)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -79,13 +96,13 @@ func (_m *Repository) GetCount(query string) (int, error) {
 	return r0, r1
 }
 
-// GetPullRequests provides a mock function with given fields: owner, repository
-func (_m *Repository) GetPullRequests(owner string, repository string) ([]models.PullRequest, error) {
-	ret := _m.Called(owner, repository)
+// GetPullRequests provides a mock function with given fields: ctx, owner, repository
+func (_m *Repository) GetPullRequests(ctx context.Context, owner string, repository string) ([]models.PullRequest, error) {
+	ret := _m.Called(ctx, owner, repository)
 
 	var r0 []models.PullRequest
-	if rf, ok := ret.Get(0).(func(string, string) []models.PullRequest); ok {
-		r0 = rf(owner, repository)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []models.PullRequest); ok {
+		r0 = rf(ctx, owner, repository)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.PullRequest)
@@ -93,8 +110,8 @@ func (_m *Repository) GetPullRequests(owner string, repository string) ([]models
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(owner, repository)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, owner, repository)
 	} else {
 		r1 = ret.Error(1)
 	}
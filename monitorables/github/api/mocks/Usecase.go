@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	configmodels "github.com/monitoror/monitoror/api/config/models"
 	mock "github.com/stretchr/testify/mock"
 
@@ -16,13 +18,13 @@ type Usecase struct {
 	mock.Mock
 }
 
-// Checks provides a mock function with given fields: params
-func (_m *Usecase) Checks(params *models.ChecksParams) (*monitorormodels.Tile, error) {
-	ret := _m.Called(params)
+// Checks provides a mock function with given fields: ctx, params
+func (_m *Usecase) Checks(ctx context.Context, params *models.ChecksParams) (*monitorormodels.Tile, error) {
+	ret := _m.Called(ctx, params)
 
 	var r0 *monitorormodels.Tile
-	if rf, ok := ret.Get(0).(func(*models.ChecksParams) *monitorormodels.Tile); ok {
-		r0 = rf(params)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ChecksParams) *monitorormodels.Tile); ok {
+		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*monitorormodels.Tile)
@@ -30,8 +32,8 @@ func (_m *Usecase) Checks(params *models.ChecksParams) (*monitorormodels.Tile, e
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*models.ChecksParams) error); ok {
-		r1 = rf(params)
+	if rf, ok := ret.Get(1).(func(context.Context, *models.ChecksParams) error); ok {
+		r1 = rf(ctx, params)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -39,13 +41,13 @@ func (_m *Usecase) Checks(params *models.ChecksParams) (*monitorormodels.Tile, e
 	return r0, r1
 }
 
-// Count provides a mock function with given fields: params
-func (_m *Usecase) Count(params *models.CountParams) (*monitorormodels.Tile, error) {
-	ret := _m.Called(params)
+// Count provides a mock function with given fields: ctx, params
+func (_m *Usecase) Count(ctx context.Context, params *models.CountParams) (*monitorormodels.Tile, error) {
+	ret := _m.Called(ctx, params)
 
 	var r0 *monitorormodels.Tile
-	if rf, ok := ret.Get(0).(func(*models.CountParams) *monitorormodels.Tile); ok {
-		r0 = rf(params)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CountParams) *monitorormodels.Tile); ok {
+		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*monitorormodels.Tile)
@@ -53,8 +55,8 @@ func (_m *Usecase) Count(params *models.CountParams) (*monitorormodels.Tile, err
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*models.CountParams) error); ok {
-		r1 = rf(params)
+	if rf, ok := ret.Get(1).(func(context.Context, *models.CountParams) error); ok {
+		r1 = rf(ctx, params)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -62,13 +64,13 @@ func (_m *Usecase) Count(params *models.CountParams) (*monitorormodels.Tile, err
 	return r0, r1
 }
 
-// PullRequestsGenerator provides a mock function with given fields: params
-func (_m *Usecase) PullRequestsGenerator(params interface{}) ([]configmodels.GeneratedTile, error) {
-	ret := _m.Called(params)
+// PullRequestsGenerator provides a mock function with given fields: ctx, params
+func (_m *Usecase) PullRequestsGenerator(ctx context.Context, params interface{}) ([]configmodels.GeneratedTile, error) {
+	ret := _m.Called(ctx, params)
 
 	var r0 []configmodels.GeneratedTile
-	if rf, ok := ret.Get(0).(func(interface{}) []configmodels.GeneratedTile); ok {
-		r0 = rf(params)
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) []configmodels.GeneratedTile); ok {
+		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]configmodels.GeneratedTile)
@@ -76,8 +78,8 @@ func (_m *Usecase) PullRequestsGenerator(params interface{}) ([]configmodels.Gen
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(interface{}) error); ok {
-		r1 = rf(params)
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}) error); ok {
+		r1 = rf(ctx, params)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -16,22 +16,22 @@ type (
 	}
 )
 
-func (p *ChecksParams) Validate(_ *uiConfigModels.ConfigVersion) *uiConfigModels.ConfigError {
-	// TODO
+func (p *ChecksParams) Validate(_ *uiConfigModels.ConfigVersion) []uiConfigModels.ConfigError {
+	var errors []uiConfigModels.ConfigError
 
 	if p.Owner == "" {
-		return &uiConfigModels.ConfigError{}
+		errors = append(errors, uiConfigModels.NewMissingParamError("owner"))
 	}
 
 	if p.Repository == "" {
-		return &uiConfigModels.ConfigError{}
+		errors = append(errors, uiConfigModels.NewMissingParamError("repository"))
 	}
 
 	if p.Ref == "" {
-		return &uiConfigModels.ConfigError{}
+		errors = append(errors, uiConfigModels.NewMissingParamError("ref"))
 	}
 
-	return nil
+	return errors
 }
 
 // Used by cache as identifier
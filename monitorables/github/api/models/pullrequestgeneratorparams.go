@@ -1,24 +1,42 @@
 package models
 
 import (
+	"regexp"
+
 	uiConfigModels "github.com/monitoror/monitoror/api/config/models"
 )
 
 type PullRequestGeneratorParams struct {
 	Owner      string `json:"owner" query:"owner"`
 	Repository string `json:"repository" query:"repository"`
+
+	// Filter pull requests on their head branch, same as jenkins generator
+	Match   string `json:"match,omitempty" query:"match" available:"since=2.5"`
+	Unmatch string `json:"unmatch,omitempty" query:"unmatch" available:"since=2.5"`
 }
 
-func (p *PullRequestGeneratorParams) Validate(_ *uiConfigModels.ConfigVersion) *uiConfigModels.ConfigError {
-	// TODO
+func (p *PullRequestGeneratorParams) Validate(_ *uiConfigModels.ConfigVersion) []uiConfigModels.ConfigError {
+	var errors []uiConfigModels.ConfigError
 
 	if p.Owner == "" {
-		return &uiConfigModels.ConfigError{}
+		errors = append(errors, uiConfigModels.NewMissingParamError("owner"))
 	}
 
 	if p.Repository == "" {
-		return &uiConfigModels.ConfigError{}
+		errors = append(errors, uiConfigModels.NewMissingParamError("repository"))
+	}
+
+	if p.Match != "" {
+		if _, err := regexp.Compile(p.Match); err != nil {
+			errors = append(errors, uiConfigModels.NewInvalidRegexParamError("match", p.Match, err))
+		}
+	}
+
+	if p.Unmatch != "" {
+		if _, err := regexp.Compile(p.Unmatch); err != nil {
+			errors = append(errors, uiConfigModels.NewInvalidRegexParamError("unmatch", p.Unmatch, err))
+		}
 	}
 
-	return nil
+	return errors
 }
@@ -16,4 +16,13 @@ func TestPullRequestGeneratorParams_Validate(t *testing.T) {
 
 	param = &PullRequestGeneratorParams{}
 	assert.Error(t, validator.Validate(param))
+
+	param = &PullRequestGeneratorParams{Owner: "test", Repository: "test", Match: "^feat/", Unmatch: "wip"}
+	assert.NoError(t, validator.Validate(param))
+
+	param = &PullRequestGeneratorParams{Owner: "test", Repository: "test", Match: "("}
+	assert.Error(t, validator.Validate(param))
+
+	param = &PullRequestGeneratorParams{Owner: "test", Repository: "test", Unmatch: "("}
+	assert.Error(t, validator.Validate(param))
 }
@@ -2,13 +2,18 @@
 
 package api
 
-import "github.com/monitoror/monitoror/monitorables/github/api/models"
+import (
+	"context"
+
+	"github.com/monitoror/monitoror/monitorables/github/api/models"
+)
 
 type (
 	Repository interface {
-		GetCount(query string) (int, error)
-		GetChecks(owner, repository, ref string) (*models.Checks, error)
-		GetPullRequests(owner, repository string) ([]models.PullRequest, error)
-		GetCommit(owner, repository, sha string) (*models.Commit, error)
+		CheckConnection(ctx context.Context) error
+		GetCount(ctx context.Context, query string) (int, error)
+		GetChecks(ctx context.Context, owner, repository, ref string) (*models.Checks, error)
+		GetPullRequests(ctx context.Context, owner, repository string) ([]models.PullRequest, error)
+		GetCommit(ctx context.Context, owner, repository, sha string) (*models.Commit, error)
 	}
 )
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
@@ -26,6 +27,7 @@ type (
 		repositoriesService gogithub.RepositoriesService
 		pullRequestService  gogithub.PullRequestService
 		gitService          gogithub.GitService
+		rateLimitsService   gogithub.RateLimitsService
 
 		config *config.Github
 	}
@@ -60,12 +62,29 @@ func NewGithubRepository(config *config.Github) api.Repository {
 		repositoriesService: client.Repositories,
 		pullRequestService:  client.PullRequests,
 		gitService:          client.Git,
+		rateLimitsService:   client,
 		config:              config,
 	}
 }
 
-func (gr *githubRepository) GetCount(query string) (int, error) {
-	issuesResult, _, err := gr.searchService.Issues(context.TODO(), query, nil)
+// CheckConnection call rate limit endpoint (free of charge) to check URL and token
+func (gr *githubRepository) CheckConnection(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(gr.config.Timeout)*time.Millisecond)
+	defer cancel()
+
+	_, _, err := gr.rateLimitsService.RateLimits(ctx)
+
+	if errorResponse, ok := err.(*githubApi.ErrorResponse); ok && errorResponse.Response != nil {
+		if statusCode := errorResponse.Response.StatusCode; statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden {
+			return fmt.Errorf("%w: %s", coreModels.UnauthorizedError, errorResponse.Message)
+		}
+	}
+
+	return err
+}
+
+func (gr *githubRepository) GetCount(ctx context.Context, query string) (int, error) {
+	issuesResult, _, err := gr.searchService.Issues(ctx, query, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -73,10 +92,10 @@ func (gr *githubRepository) GetCount(query string) (int, error) {
 	return issuesResult.GetTotal(), err
 }
 
-func (gr *githubRepository) GetChecks(owner, repository, ref string) (*models.Checks, error) {
+func (gr *githubRepository) GetChecks(ctx context.Context, owner, repository, ref string) (*models.Checks, error) {
 	checks := &models.Checks{Runs: []models.Run{}, Statuses: []models.Status{}}
 
-	checkRuns, _, err := gr.checksService.ListCheckRunsForRef(context.TODO(), owner, repository, ref, nil)
+	checkRuns, _, err := gr.checksService.ListCheckRunsForRef(ctx, owner, repository, ref, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -103,7 +122,7 @@ func (gr *githubRepository) GetChecks(owner, repository, ref string) (*models.Ch
 		}
 	}
 
-	repoStatuses, _, err := gr.repositoriesService.ListStatuses(context.TODO(), owner, repository, ref, nil)
+	repoStatuses, _, err := gr.repositoriesService.ListStatuses(ctx, owner, repository, ref, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -132,8 +151,8 @@ func (gr *githubRepository) GetChecks(owner, repository, ref string) (*models.Ch
 	return checks, nil
 }
 
-func (gr *githubRepository) GetPullRequests(owner, repository string) ([]models.PullRequest, error) {
-	pullRequests, _, err := gr.pullRequestService.List(context.TODO(), owner, repository, nil)
+func (gr *githubRepository) GetPullRequests(ctx context.Context, owner, repository string) ([]models.PullRequest, error) {
+	pullRequests, _, err := gr.pullRequestService.List(ctx, owner, repository, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -153,8 +172,8 @@ func (gr *githubRepository) GetPullRequests(owner, repository string) ([]models.
 	return result, nil
 }
 
-func (gr *githubRepository) GetCommit(owner, repository, sha string) (*models.Commit, error) {
-	commit, _, err := gr.gitService.GetCommit(context.TODO(), owner, repository, sha)
+func (gr *githubRepository) GetCommit(ctx context.Context, owner, repository, sha string) (*models.Commit, error) {
+	commit, _, err := gr.gitService.GetCommit(ctx, owner, repository, sha)
 	if err != nil {
 		return nil, err
 	}
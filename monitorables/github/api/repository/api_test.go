@@ -1,10 +1,13 @@
 package repository
 
 import (
+	"context"
 	"errors"
+	"net/http"
 	"testing"
 	"time"
 
+	coreModels "github.com/monitoror/monitoror/models"
 	"github.com/monitoror/monitoror/monitorables/github/config"
 	"github.com/monitoror/monitoror/pkg/gogithub/mocks"
 	"github.com/monitoror/monitoror/pkg/gravatar"
@@ -34,6 +37,50 @@ func initRepository(t *testing.T) *githubRepository {
 	return nil
 }
 
+func TestRepository_CheckConnection_Success(t *testing.T) {
+	mocksRateLimitsService := new(mocks.RateLimitsService)
+	mocksRateLimitsService.On("RateLimits", MatchedBy(func(ctx context.Context) bool {
+		// CheckConnection is bounded by config timeout
+		_, ok := ctx.Deadline()
+		return ok
+	})).Return(&github.RateLimits{}, nil, nil)
+
+	repository := initRepository(t)
+	if repository != nil {
+		repository.rateLimitsService = mocksRateLimitsService
+
+		assert.NoError(t, repository.CheckConnection(context.Background()))
+		mocksRateLimitsService.AssertNumberOfCalls(t, "RateLimits", 1)
+		mocksRateLimitsService.AssertExpectations(t)
+	}
+}
+
+func TestRepository_CheckConnection_Error(t *testing.T) {
+	for _, testcase := range []struct {
+		err          error
+		unauthorized bool
+	}{
+		{err: errors.New("github error")},
+		{err: &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}, Message: "Not Found"}},
+		{err: &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusUnauthorized}, Message: "Bad credentials"}, unauthorized: true},
+	} {
+		mocksRateLimitsService := new(mocks.RateLimitsService)
+		mocksRateLimitsService.On("RateLimits", Anything).Return(nil, nil, testcase.err)
+
+		repository := initRepository(t)
+		if repository != nil {
+			repository.rateLimitsService = mocksRateLimitsService
+
+			err := repository.CheckConnection(context.Background())
+			if assert.Error(t, err) {
+				assert.Equal(t, testcase.unauthorized, errors.Is(err, coreModels.UnauthorizedError))
+				mocksRateLimitsService.AssertNumberOfCalls(t, "RateLimits", 1)
+				mocksRateLimitsService.AssertExpectations(t)
+			}
+		}
+	}
+}
+
 func TestRepository_GetSearchCount_Error(t *testing.T) {
 	githubErr := errors.New("github error")
 
@@ -45,7 +92,7 @@ func TestRepository_GetSearchCount_Error(t *testing.T) {
 	if repository != nil {
 		repository.searchService = mocksSearchService
 
-		_, err := repository.GetCount("test")
+		_, err := repository.GetCount(context.Background(), "test")
 		if assert.Error(t, err) {
 			assert.Contains(t, err.Error(), "github error")
 			mocksSearchService.AssertNumberOfCalls(t, "Issues", 1)
@@ -63,7 +110,7 @@ func TestRepository_GetSearchCount_Success(t *testing.T) {
 	if repository != nil {
 		repository.searchService = mocksSearchService
 
-		value, err := repository.GetCount("test")
+		value, err := repository.GetCount(context.Background(), "test")
 		if assert.NoError(t, err) {
 			assert.Equal(t, 42, value)
 			mocksSearchService.AssertNumberOfCalls(t, "Issues", 1)
@@ -84,7 +131,7 @@ func TestRepository_GetChecks_CheckServiceError(t *testing.T) {
 	if repository != nil {
 		repository.checksService = mocksChecksService
 
-		_, err := repository.GetChecks("test", "test", "master")
+		_, err := repository.GetChecks(context.Background(), "test", "test", "master")
 		if assert.Error(t, err) {
 			assert.Contains(t, err.Error(), "github error")
 			mocksChecksService.AssertNumberOfCalls(t, "ListCheckRunsForRef", 1)
@@ -111,7 +158,7 @@ func TestRepository_GetChecks_RepositoriesServiceError(t *testing.T) {
 		repository.checksService = mocksChecksService
 		repository.repositoriesService = mocksRepositoriesService
 
-		_, err := repository.GetChecks("test", "test", "master")
+		_, err := repository.GetChecks(context.Background(), "test", "test", "master")
 		if assert.Error(t, err) {
 			assert.Contains(t, err.Error(), "github error")
 			mocksChecksService.AssertNumberOfCalls(t, "ListCheckRunsForRef", 1)
@@ -160,7 +207,7 @@ func TestRepository_GetChecks_Success(t *testing.T) {
 		repository.checksService = mocksChecksService
 		repository.repositoriesService = mocksRepositoriesService
 
-		checks, err := repository.GetChecks("test", "test", "test")
+		checks, err := repository.GetChecks(context.Background(), "test", "test", "test")
 		if assert.NoError(t, err) {
 			assert.Len(t, checks.Runs, 1)
 			assert.Len(t, checks.Statuses, 1)
@@ -197,7 +244,7 @@ func TestRepository_GetPullRequest_Error(t *testing.T) {
 	if repository != nil {
 		repository.pullRequestService = mocksPullRequestService
 
-		_, err := repository.GetPullRequests("test", "test")
+		_, err := repository.GetPullRequests(context.Background(), "test", "test")
 		if assert.Error(t, err) {
 			assert.Contains(t, err.Error(), "github error")
 			mocksPullRequestService.AssertNumberOfCalls(t, "List", 1)
@@ -221,7 +268,7 @@ func TestRepository_GetPullRequest_Success(t *testing.T) {
 	if repository != nil {
 		repository.pullRequestService = mocksPullRequestService
 
-		pullRequests, err := repository.GetPullRequests("test", "test")
+		pullRequests, err := repository.GetPullRequests(context.Background(), "test", "test")
 		if assert.NoError(t, err) {
 			assert.Len(t, pullRequests, 1)
 			assert.Equal(t, 10, pullRequests[0].ID)
@@ -246,7 +293,7 @@ func TestRepository_GetCommit_Error(t *testing.T) {
 	if repository != nil {
 		repository.gitService = mocksGitService
 
-		_, err := repository.GetCommit("test", "test", "sha")
+		_, err := repository.GetCommit(context.Background(), "test", "test", "sha")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "github error")
 		mocksGitService.AssertNumberOfCalls(t, "GetCommit", 1)
@@ -268,7 +315,7 @@ func TestRepository_GetCommit_Success(t *testing.T) {
 	if repository != nil {
 		repository.gitService = mocksGitService
 
-		commit, err := repository.GetCommit("test", "test", "sha")
+		commit, err := repository.GetCommit(context.Background(), "test", "test", "sha")
 		if assert.NoError(t, err) {
 			assert.Equal(t, "sha", commit.SHA)
 			assert.Equal(t, "Test", commit.Author.Name)
@@ -3,6 +3,9 @@
 package api
 
 import (
+	"context"
+	"time"
+
 	uiConfigModels "github.com/monitoror/monitoror/api/config/models"
 	coreModels "github.com/monitoror/monitoror/models"
 	"github.com/monitoror/monitoror/monitorables/github/api/models"
@@ -11,13 +14,16 @@ import (
 const (
 	GithubCountTileType  coreModels.TileType = "GITHUB-COUNT"
 	GithubChecksTileType coreModels.TileType = "GITHUB-CHECKS"
+
+	GithubCountMinimalRefreshInterval  = 30 * time.Second
+	GithubChecksMinimalRefreshInterval = 10 * time.Second
 )
 
 type (
 	Usecase interface {
-		Count(params *models.CountParams) (*coreModels.Tile, error)
-		Checks(params *models.ChecksParams) (*coreModels.Tile, error)
+		Count(ctx context.Context, params *models.CountParams) (*coreModels.Tile, error)
+		Checks(ctx context.Context, params *models.ChecksParams) (*coreModels.Tile, error)
 
-		PullRequestsGenerator(params interface{}) ([]uiConfigModels.GeneratedTile, error)
+		PullRequestsGenerator(ctx context.Context, params interface{}) ([]uiConfigModels.GeneratedTile, error)
 	}
 )
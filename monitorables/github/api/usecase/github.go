@@ -3,7 +3,9 @@
 package usecase
 
 import (
+	"context"
 	"fmt"
+	"regexp"
 	"sort"
 	"time"
 
@@ -16,6 +18,7 @@ import (
 	"github.com/monitoror/monitoror/pkg/hash"
 
 	"github.com/AlekSi/pointer"
+	echoCache "github.com/jsdidierlaurent/echo-middleware/cache"
 )
 
 type (
@@ -41,18 +44,18 @@ var orderedTileStatus = map[coreModels.TileStatus]int{
 
 const buildCacheSize = 5
 
-func NewGithubUsecase(repository api.Repository) api.Usecase {
+func NewGithubUsecase(repository api.Repository, store echoCache.Store, variantName coreModels.VariantName) api.Usecase {
 	return &githubUsecase{
 		repository,
-		cache.NewBuildCache(buildCacheSize),
+		cache.NewBuildCache(store, fmt.Sprintf("%s:%s", api.GithubChecksTileType, variantName), buildCacheSize),
 	}
 }
 
-func (gu *githubUsecase) Count(params *models.CountParams) (*coreModels.Tile, error) {
+func (gu *githubUsecase) Count(ctx context.Context, params *models.CountParams) (*coreModels.Tile, error) {
 	tile := coreModels.NewTile(api.GithubCountTileType).WithValue(coreModels.NumberUnit)
 	tile.Label = params.Query
 
-	count, err := gu.repository.GetCount(params.Query)
+	count, err := gu.repository.GetCount(ctx, params.Query)
 	if err != nil {
 		return nil, &coreModels.MonitororError{Err: err, Tile: tile, Message: "unable to find count or wrong query"}
 	}
@@ -63,13 +66,13 @@ func (gu *githubUsecase) Count(params *models.CountParams) (*coreModels.Tile, er
 	return tile, nil
 }
 
-func (gu *githubUsecase) Checks(params *models.ChecksParams) (*coreModels.Tile, error) {
+func (gu *githubUsecase) Checks(ctx context.Context, params *models.ChecksParams) (*coreModels.Tile, error) {
 	tile := coreModels.NewTile(api.GithubChecksTileType).WithBuild()
 	tile.Label = params.Repository
 	tile.Build.Branch = pointer.ToString(git.HumanizeBranch(params.Ref))
 
 	// Request
-	checks, err := gu.repository.GetChecks(params.Owner, params.Repository, params.Ref)
+	checks, err := gu.repository.GetChecks(ctx, params.Owner, params.Repository, params.Ref)
 	if err != nil {
 		return nil, &coreModels.MonitororError{Err: err, Tile: tile, Message: "unable to find ref checks"}
 	}
@@ -92,7 +95,7 @@ func (gu *githubUsecase) Checks(params *models.ChecksParams) (*coreModels.Tile,
 
 	// Author
 	if tile.Status == coreModels.FailedStatus && checks.HeadCommit != nil {
-		commit, err := gu.repository.GetCommit(params.Owner, params.Repository, *checks.HeadCommit)
+		commit, err := gu.repository.GetCommit(ctx, params.Owner, params.Repository, *checks.HeadCommit)
 		if err == nil {
 			tile.Build.Author = &coreModels.Author{
 				Name:      commit.Author.Name,
@@ -127,16 +130,31 @@ func (gu *githubUsecase) Checks(params *models.ChecksParams) (*coreModels.Tile,
 	return tile, nil
 }
 
-func (gu *githubUsecase) PullRequestsGenerator(params interface{}) ([]uiConfigModels.GeneratedTile, error) {
+func (gu *githubUsecase) PullRequestsGenerator(ctx context.Context, params interface{}) ([]uiConfigModels.GeneratedTile, error) {
 	prParams := params.(*models.PullRequestGeneratorParams)
 
-	pullRequests, err := gu.repository.GetPullRequests(prParams.Owner, prParams.Repository)
+	pullRequests, err := gu.repository.GetPullRequests(ctx, prParams.Owner, prParams.Repository)
 	if err != nil {
 		return nil, &coreModels.MonitororError{Err: err, Message: "unable to find pull request"}
 	}
 
+	matcher, err := regexp.Compile(prParams.Match)
+	if err != nil {
+		return nil, err
+	}
+
+	unmatcher, err := regexp.Compile(prParams.Unmatch)
+	if err != nil {
+		return nil, err
+	}
+
 	var results []uiConfigModels.GeneratedTile
 	for _, pullRequest := range pullRequests {
+		if !matcher.MatchString(pullRequest.Ref) ||
+			(prParams.Unmatch != "" && unmatcher.MatchString(pullRequest.Ref)) {
+			continue
+		}
+
 		p := &models.ChecksParams{}
 		p.Owner = pullRequest.Owner
 		p.Repository = pullRequest.Repository
@@ -3,6 +3,7 @@
 package usecase
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"time"
@@ -40,7 +41,7 @@ func NewGithubUsecase() api.Usecase {
 	return &githubUsecase{cmap.New()}
 }
 
-func (gu *githubUsecase) Count(params *models.CountParams) (*coreModels.Tile, error) {
+func (gu *githubUsecase) Count(_ context.Context, params *models.CountParams) (*coreModels.Tile, error) {
 	tile := coreModels.NewTile(api.GithubCountTileType).WithValue(coreModels.NumberUnit)
 	tile.Label = params.Query
 
@@ -55,7 +56,7 @@ func (gu *githubUsecase) Count(params *models.CountParams) (*coreModels.Tile, er
 	return tile, nil
 }
 
-func (gu *githubUsecase) Checks(params *models.ChecksParams) (tile *coreModels.Tile, err error) {
+func (gu *githubUsecase) Checks(_ context.Context, params *models.ChecksParams) (tile *coreModels.Tile, err error) {
 	tile = coreModels.NewTile(api.GithubChecksTileType).WithBuild()
 	tile.Label = params.Repository
 
@@ -109,7 +110,7 @@ func (gu *githubUsecase) Checks(params *models.ChecksParams) (tile *coreModels.T
 	return tile, nil
 }
 
-func (gu *githubUsecase) PullRequestsGenerator(params interface{}) ([]uiConfigModels.GeneratedTile, error) {
+func (gu *githubUsecase) PullRequestsGenerator(_ context.Context, params interface{}) ([]uiConfigModels.GeneratedTile, error) {
 	panic("unimplemented")
 }
 
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -14,18 +15,19 @@ import (
 	"github.com/monitoror/monitoror/pkg/hash"
 
 	. "github.com/AlekSi/pointer"
+	echoCache "github.com/jsdidierlaurent/echo-middleware/cache"
 	"github.com/stretchr/testify/assert"
 	. "github.com/stretchr/testify/mock"
 )
 
 func TestCount_Error(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetCount", AnythingOfType("string")).
+	mockRepository.On("GetCount", Anything, AnythingOfType("string")).
 		Return(0, errors.New("boom"))
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 
-	tile, err := gu.Count(&models.CountParams{Query: "test"})
+	tile, err := gu.Count(context.Background(), &models.CountParams{Query: "test"})
 	if assert.Error(t, err) {
 		assert.Nil(t, tile)
 		assert.IsType(t, &coreModels.MonitororError{}, err)
@@ -37,17 +39,17 @@ func TestCount_Error(t *testing.T) {
 
 func TestCount_Success(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetCount", AnythingOfType("string")).
+	mockRepository.On("GetCount", Anything, AnythingOfType("string")).
 		Return(10, nil)
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 
 	expected := coreModels.NewTile(api.GithubCountTileType).WithValue(coreModels.NumberUnit)
 	expected.Label = "test"
 	expected.Status = coreModels.SuccessStatus
 	expected.Value.Values = []string{"10"}
 
-	tile, err := gu.Count(&models.CountParams{Query: "test"})
+	tile, err := gu.Count(context.Background(), &models.CountParams{Query: "test"})
 	if assert.NoError(t, err) {
 		assert.NotNil(t, tile)
 		assert.Equal(t, expected, tile)
@@ -59,12 +61,12 @@ func TestCount_Success(t *testing.T) {
 
 func TestChecks_Error(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetChecks", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetChecks", Anything, AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(nil, errors.New("boom"))
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 
-	tile, err := gu.Checks(&models.ChecksParams{Owner: "test", Repository: "test", Ref: "master"})
+	tile, err := gu.Checks(context.Background(), &models.ChecksParams{Owner: "test", Repository: "test", Ref: "master"})
 	if assert.Error(t, err) {
 		assert.Nil(t, tile)
 		assert.IsType(t, &coreModels.MonitororError{}, err)
@@ -76,12 +78,12 @@ func TestChecks_Error(t *testing.T) {
 
 func TestChecks_NoChecks(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetChecks", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetChecks", Anything, AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(&models.Checks{}, nil)
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 
-	tile, err := gu.Checks(&models.ChecksParams{Owner: "test", Repository: "test", Ref: "master"})
+	tile, err := gu.Checks(context.Background(), &models.ChecksParams{Owner: "test", Repository: "test", Ref: "master"})
 	if assert.Error(t, err) {
 		assert.Nil(t, tile)
 		assert.IsType(t, &coreModels.MonitororError{}, err)
@@ -97,7 +99,7 @@ func TestChecks_Success(t *testing.T) {
 	finishedAt := refTime.Add(-time.Second * 15)
 
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetChecks", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetChecks", Anything, AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(&models.Checks{
 			Runs: []models.Run{
 				{
@@ -110,7 +112,7 @@ func TestChecks_Success(t *testing.T) {
 			},
 		}, nil)
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 
 	expected := coreModels.NewTile(api.GithubChecksTileType).WithBuild()
 	expected.Label = "test"
@@ -121,7 +123,7 @@ func TestChecks_Success(t *testing.T) {
 	expected.Build.StartedAt = ToTime(startedAt)
 	expected.Build.FinishedAt = ToTime(finishedAt)
 
-	tile, err := gu.Checks(&models.ChecksParams{Owner: "test", Repository: "test", Ref: "master"})
+	tile, err := gu.Checks(context.Background(), &models.ChecksParams{Owner: "test", Repository: "test", Ref: "master"})
 	if assert.NoError(t, err) {
 		assert.NotNil(t, tile)
 		assert.Equal(t, expected, tile)
@@ -135,7 +137,7 @@ func TestChecks_Failure(t *testing.T) {
 	refTime := time.Now()
 
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetChecks", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetChecks", Anything, AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(&models.Checks{
 			HeadCommit: ToString("sha"),
 			Runs: []models.Run{
@@ -148,7 +150,7 @@ func TestChecks_Failure(t *testing.T) {
 				},
 			},
 		}, nil)
-	mockRepository.On("GetCommit", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetCommit", Anything, AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(&models.Commit{
 			Author: &coreModels.Author{
 				Name:      "test",
@@ -156,7 +158,7 @@ func TestChecks_Failure(t *testing.T) {
 			},
 		}, nil)
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 
 	expected := coreModels.NewTile(api.GithubChecksTileType).WithBuild()
 	expected.Label = "test"
@@ -171,7 +173,7 @@ func TestChecks_Failure(t *testing.T) {
 		AvatarURL: "https://test.example.com",
 	}
 
-	tile, err := gu.Checks(&models.ChecksParams{Owner: "test", Repository: "test", Ref: "master"})
+	tile, err := gu.Checks(context.Background(), &models.ChecksParams{Owner: "test", Repository: "test", Ref: "master"})
 	if assert.NoError(t, err) {
 		assert.NotNil(t, tile)
 		assert.Equal(t, expected, tile)
@@ -186,7 +188,7 @@ func TestChecks_Queued(t *testing.T) {
 	refTime := time.Now()
 
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetChecks", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetChecks", Anything, AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(&models.Checks{
 			HeadCommit: ToString("sha"),
 			Runs: []models.Run{
@@ -198,7 +200,7 @@ func TestChecks_Queued(t *testing.T) {
 			},
 		}, nil)
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 
 	expected := coreModels.NewTile(api.GithubChecksTileType).WithBuild()
 	expected.Label = "test"
@@ -208,7 +210,7 @@ func TestChecks_Queued(t *testing.T) {
 	expected.Build.PreviousStatus = coreModels.UnknownStatus
 	expected.Build.StartedAt = ToTime(refTime.Add(-time.Second * 30))
 
-	tile, err := gu.Checks(&models.ChecksParams{Owner: "test", Repository: "test", Ref: "master"})
+	tile, err := gu.Checks(context.Background(), &models.ChecksParams{Owner: "test", Repository: "test", Ref: "master"})
 	if assert.NoError(t, err) {
 		assert.NotNil(t, tile)
 		assert.Equal(t, expected, tile)
@@ -222,7 +224,7 @@ func TestChecks_Running(t *testing.T) {
 	refTime := time.Now()
 
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetChecks", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetChecks", Anything, AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(&models.Checks{
 			HeadCommit: ToString("sha"),
 			Runs: []models.Run{
@@ -234,7 +236,7 @@ func TestChecks_Running(t *testing.T) {
 			},
 		}, nil)
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 	gUsecase, ok := gu.(*githubUsecase)
 	if assert.True(t, ok) {
 		expected := coreModels.NewTile(api.GithubChecksTileType).WithBuild()
@@ -247,7 +249,7 @@ func TestChecks_Running(t *testing.T) {
 		expected.Build.Duration = ToInt64(int64(30))
 		expected.Build.EstimatedDuration = ToInt64(int64(0))
 
-		tile, err := gUsecase.Checks(&models.ChecksParams{Owner: "test", Repository: "test", Ref: "master"})
+		tile, err := gUsecase.Checks(context.Background(), &models.ChecksParams{Owner: "test", Repository: "test", Ref: "master"})
 		if assert.NoError(t, err) {
 			assert.NotNil(t, tile)
 			assert.Equal(t, expected, tile)
@@ -259,7 +261,7 @@ func TestChecks_Running(t *testing.T) {
 		expected.Build.PreviousStatus = coreModels.SuccessStatus
 		expected.Build.EstimatedDuration = ToInt64(int64(120))
 
-		tile, err = gUsecase.Checks(&models.ChecksParams{Owner: "test", Repository: "test", Ref: "master"})
+		tile, err = gUsecase.Checks(context.Background(), &models.ChecksParams{Owner: "test", Repository: "test", Ref: "master"})
 		if assert.NoError(t, err) {
 			assert.NotNil(t, tile)
 			assert.Equal(t, expected, tile)
@@ -272,12 +274,12 @@ func TestChecks_Running(t *testing.T) {
 
 func TestPullRequestsGenerator_Error(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetPullRequests", AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetPullRequests", Anything, AnythingOfType("string"), AnythingOfType("string")).
 		Return(nil, errors.New("boom"))
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 
-	results, err := gu.PullRequestsGenerator(&models.PullRequestGeneratorParams{Owner: "test", Repository: "test"})
+	results, err := gu.PullRequestsGenerator(context.Background(), &models.PullRequestGeneratorParams{Owner: "test", Repository: "test"})
 	if assert.Error(t, err) {
 		assert.Nil(t, results)
 		assert.IsType(t, &coreModels.MonitororError{}, err)
@@ -289,7 +291,7 @@ func TestPullRequestsGenerator_Error(t *testing.T) {
 
 func TestPullRequestsGenerator_Success(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetPullRequests", AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetPullRequests", Anything, AnythingOfType("string"), AnythingOfType("string")).
 		Return([]models.PullRequest{
 			{
 				ID:         2,
@@ -305,9 +307,9 @@ func TestPullRequestsGenerator_Success(t *testing.T) {
 			},
 		}, nil)
 
-	gu := NewGithubUsecase(mockRepository)
+	gu := NewGithubUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 
-	results, err := gu.PullRequestsGenerator(&models.PullRequestGeneratorParams{Owner: "test", Repository: "test"})
+	results, err := gu.PullRequestsGenerator(context.Background(), &models.PullRequestGeneratorParams{Owner: "test", Repository: "test"})
 	if assert.NoError(t, err) {
 		assert.NotNil(t, results)
 		assert.Len(t, results, 2)
@@ -329,6 +331,26 @@ func TestPullRequestsGenerator_Success(t *testing.T) {
 	}
 }
 
+func TestPullRequestsGenerator_WithFilter(t *testing.T) {
+	mockRepository := new(mocks.Repository)
+	mockRepository.On("GetPullRequests", Anything, AnythingOfType("string"), AnythingOfType("string")).
+		Return([]models.PullRequest{
+			{ID: 2, Owner: "test", Repository: "test", Ref: "feat/stream"},
+			{ID: 3, Owner: "test", Repository: "test", Ref: "feat/wip-pages"},
+			{ID: 4, Owner: "test", Repository: "test", Ref: "fix/cache"},
+		}, nil)
+
+	gu := NewGithubUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
+
+	results, err := gu.PullRequestsGenerator(context.Background(), &models.PullRequestGeneratorParams{Owner: "test", Repository: "test", Match: "^feat/", Unmatch: "wip"})
+	if assert.NoError(t, err) {
+		if assert.Len(t, results, 1) {
+			assert.Equal(t, "PR#2 @ test", results[0].Label)
+		}
+		mockRepository.AssertExpectations(t)
+	}
+}
+
 func TestComputeRefStatus_Status(t *testing.T) {
 	for index, testcase := range []struct {
 		runs           []models.Run
@@ -3,6 +3,7 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"time"
@@ -41,9 +42,9 @@ func NewMonitorable(store *store.Store) *Monitorable {
 	pkgMonitorable.LoadConfig(&m.config, githubConfig.Default)
 
 	// Register Monitorable Tile in config manager
-	m.countTileEnabler = store.Registry.RegisterTile(api.GithubCountTileType, versions.MinimalVersion, m.GetVariantNames())
-	m.checksTileEnabler = store.Registry.RegisterTile(api.GithubChecksTileType, versions.MinimalVersion, m.GetVariantNames())
-	m.pullrequestGeneratorEnabler = store.Registry.RegisterGenerator(api.GithubChecksTileType, versions.MinimalVersion, m.GetVariantNames())
+	m.countTileEnabler = store.Registry.RegisterTile(api.GithubCountTileType, versions.MinimalVersion, m.GetVariantNames(), api.GithubCountMinimalRefreshInterval, &githubModels.CountParams{})
+	m.checksTileEnabler = store.Registry.RegisterTile(api.GithubChecksTileType, versions.MinimalVersion, m.GetVariantNames(), api.GithubChecksMinimalRefreshInterval, &githubModels.ChecksParams{})
+	m.pullrequestGeneratorEnabler = store.Registry.RegisterGenerator(api.GithubChecksTileType, versions.MinimalVersion, m.GetVariantNames(), &githubModels.PullRequestGeneratorParams{})
 
 	return m
 }
@@ -56,6 +57,10 @@ func (m *Monitorable) GetVariantNames() []coreModels.VariantName {
 	return pkgMonitorable.GetVariants(m.config)
 }
 
+func (m *Monitorable) GetTileTypes() []coreModels.TileType {
+	return []coreModels.TileType{api.GithubCountTileType, api.GithubChecksTileType, coreModels.NewGeneratorTileType(api.GithubChecksTileType)}
+}
+
 func (m *Monitorable) Validate(variantName coreModels.VariantName) (bool, error) {
 	conf := m.config[variantName]
 
@@ -84,16 +89,20 @@ func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	countCacheExpiration := time.Millisecond * time.Duration(conf.CountCacheExpiration)
 
 	repository := githubRepository.NewGithubRepository(conf)
-	usecase := githubUsecase.NewGithubUsecase(repository)
+	usecase := githubUsecase.NewGithubUsecase(repository, m.store.CacheStore, variantName)
 	delivery := githubDelivery.NewGithubDelivery(usecase)
 
 	// EnableTile route to echo
 	routeGroup := m.store.MonitorableRouter.Group("/github", variantName)
-	routeCount := routeGroup.GET("/count", delivery.GetCount, options.WithCustomCacheExpiration(countCacheExpiration))
-	routeChecks := routeGroup.GET("/checks", delivery.GetChecks)
+	routeCount := routeGroup.GET("/count", delivery.GetCount, options.WithCustomCacheExpiration(countCacheExpiration), options.WithUpstreamURL(conf.URL))
+	routeChecks := routeGroup.GET("/checks", delivery.GetChecks, options.WithUpstreamURL(conf.URL))
 
 	// EnableTile data for config hydration
-	m.countTileEnabler.Enable(variantName, &githubModels.CountParams{}, routeCount.Path)
-	m.checksTileEnabler.Enable(variantName, &githubModels.ChecksParams{}, routeChecks.Path)
-	m.pullrequestGeneratorEnabler.Enable(variantName, &githubModels.PullRequestGeneratorParams{}, usecase.PullRequestsGenerator)
+	m.countTileEnabler.Enable(variantName, routeCount.Path)
+	m.checksTileEnabler.Enable(variantName, routeChecks.Path)
+	m.pullrequestGeneratorEnabler.Enable(variantName, usecase.PullRequestsGenerator)
+}
+
+func (m *Monitorable) Diagnose(ctx context.Context, variantName coreModels.VariantName) error {
+	return githubRepository.NewGithubRepository(m.config[variantName]).CheckConnection(ctx)
 }
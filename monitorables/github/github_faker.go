@@ -29,8 +29,8 @@ func NewMonitorable(store *store.Store) *Monitorable {
 	m.store = store
 
 	// Register Monitorable Tile in config manager
-	m.countTileEnabler = store.Registry.RegisterTile(api.GithubCountTileType, versions.MinimalVersion, m.GetVariantNames())
-	m.checksTileEnabler = store.Registry.RegisterTile(api.GithubChecksTileType, versions.MinimalVersion, m.GetVariantNames())
+	m.countTileEnabler = store.Registry.RegisterTile(api.GithubCountTileType, versions.MinimalVersion, m.GetVariantNames(), api.GithubCountMinimalRefreshInterval, &githubModels.CountParams{})
+	m.checksTileEnabler = store.Registry.RegisterTile(api.GithubChecksTileType, versions.MinimalVersion, m.GetVariantNames(), api.GithubChecksMinimalRefreshInterval, &githubModels.ChecksParams{})
 
 	return m
 }
@@ -39,6 +39,10 @@ func (m *Monitorable) GetDisplayName() string {
 	return "GitHub (faker)"
 }
 
+func (m *Monitorable) GetTileTypes() []coreModels.TileType {
+	return []coreModels.TileType{api.GithubCountTileType, api.GithubChecksTileType}
+}
+
 func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	usecase := githubUsecase.NewGithubUsecase()
 	delivery := githubDelivery.NewGithubDelivery(usecase)
@@ -49,6 +53,6 @@ func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	routeChecks := routeGroup.GET("/checks", delivery.GetChecks)
 
 	// EnableTile data for config hydration
-	m.countTileEnabler.Enable(variantName, &githubModels.CountParams{}, routeCount.Path)
-	m.checksTileEnabler.Enable(variantName, &githubModels.ChecksParams{}, routeChecks.Path)
+	m.countTileEnabler.Enable(variantName, routeCount.Path)
+	m.checksTileEnabler.Enable(variantName, routeChecks.Path)
 }
@@ -26,7 +26,7 @@ func (h *HTTPDelivery) GetHTTPStatus(c echo.Context) error {
 		return err
 	}
 
-	tile, err := h.httpUsecase.HTTPStatus(params)
+	tile, err := h.httpUsecase.HTTPStatus(c.Request().Context(), params)
 	if err != nil {
 		return err
 	}
@@ -41,7 +41,7 @@ func (h *HTTPDelivery) GetHTTPRaw(c echo.Context) error {
 		return err
 	}
 
-	tile, err := h.httpUsecase.HTTPRaw(params)
+	tile, err := h.httpUsecase.HTTPRaw(c.Request().Context(), params)
 	if err != nil {
 		return err
 	}
@@ -56,7 +56,7 @@ func (h *HTTPDelivery) GetHTTPFormatted(c echo.Context) error {
 		return err
 	}
 
-	tile, err := h.httpUsecase.HTTPFormatted(params)
+	tile, err := h.httpUsecase.HTTPFormatted(c.Request().Context(), params)
 	if err != nil {
 		return err
 	}
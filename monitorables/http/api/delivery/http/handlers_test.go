@@ -38,7 +38,7 @@ func TestQueryParams_HTTPStatusParams(t *testing.T) {
 	ctx.QueryParams().Set("statusCodeMax", "400")
 
 	mockUsecase := new(mocks.Usecase)
-	mockUsecase.On("HTTPStatus", &models.HTTPStatusParams{
+	mockUsecase.On("HTTPStatus", Anything, &models.HTTPStatusParams{
 		URL:           "http://monitoror.example.com",
 		StatusCodeMin: pointer.ToInt(300),
 		StatusCodeMax: pointer.ToInt(400),
@@ -55,7 +55,7 @@ func TestQueryParams_HTTPRawParams(t *testing.T) {
 	ctx.QueryParams().Set("statusCodeMax", "400")
 
 	mockUsecase := new(mocks.Usecase)
-	mockUsecase.On("HTTPRaw", &models.HTTPRawParams{
+	mockUsecase.On("HTTPRaw", Anything, &models.HTTPRawParams{
 		URL:           "http://monitoror.example.com",
 		Regex:         "test",
 		StatusCodeMin: pointer.ToInt(300),
@@ -75,7 +75,7 @@ func TestQueryParams_HTTPFormattedParams(t *testing.T) {
 	ctx.QueryParams().Set("statusCodeMax", "400")
 
 	mockUsecase := new(mocks.Usecase)
-	mockUsecase.On("HTTPFormatted", &models.HTTPFormattedParams{
+	mockUsecase.On("HTTPFormatted", Anything, &models.HTTPFormattedParams{
 		URL:           "http://monitoror.example.com",
 		Regex:         "test",
 		Key:           "key",
@@ -148,7 +148,7 @@ func Test_httpHttpDelivery_GetHttp_Error(t *testing.T) {
 		ctx.QueryParams().Set("key", "key")
 
 		mockUsecase := new(mocks.Usecase)
-		mockUsecase.On(testcase.mockFuncName, Anything).Return(nil, errors.New("boom"))
+		mockUsecase.On(testcase.mockFuncName, Anything, Anything).Return(nil, errors.New("boom"))
 		handler := NewHTTPDelivery(mockUsecase)
 
 		// Test
@@ -199,7 +199,7 @@ func Test_httpHttpDelivery_GetHttp(t *testing.T) {
 
 		tile := coreModels.NewTile(testcase.tileType)
 		mockUsecase := new(mocks.Usecase)
-		mockUsecase.On(testcase.mockFuncName, Anything).Return(tile, nil)
+		mockUsecase.On(testcase.mockFuncName, Anything, Anything).Return(tile, nil)
 		handler := NewHTTPDelivery(mockUsecase)
 
 		// Expected
@@ -3,8 +3,11 @@
 package mocks
 
 import (
-	models "github.com/monitoror/monitoror/monitorables/http/api/models"
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/monitoror/monitoror/monitorables/http/api/models"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -12,13 +15,13 @@ type Repository struct {
 	mock.Mock
 }
 
-// Get provides a mock function with given fields: url
-func (_m *Repository) Get(url string) (*models.Response, error) {
-	ret := _m.Called(url)
+// Get provides a mock function with given fields: ctx, url
+func (_m *Repository) Get(ctx context.Context, url string) (*models.Response, error) {
+	ret := _m.Called(ctx, url)
 
 	var r0 *models.Response
-	if rf, ok := ret.Get(0).(func(string) *models.Response); ok {
-		r0 = rf(url)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Response); ok {
+		r0 = rf(ctx, url)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Response)
@@ -26,8 +29,8 @@ func (_m *Repository) Get(url string) (*models.Response, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(url)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, url)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	monitorormodels "github.com/monitoror/monitoror/models"
 	models "github.com/monitoror/monitoror/monitorables/http/api/models"
 	mock "github.com/stretchr/testify/mock"
@@ -13,13 +15,13 @@ type Usecase struct {
 	mock.Mock
 }
 
-// HTTPFormatted provides a mock function with given fields: params
-func (_m *Usecase) HTTPFormatted(params *models.HTTPFormattedParams) (*monitorormodels.Tile, error) {
-	ret := _m.Called(params)
+// HTTPFormatted provides a mock function with given fields: ctx, params
+func (_m *Usecase) HTTPFormatted(ctx context.Context, params *models.HTTPFormattedParams) (*monitorormodels.Tile, error) {
+	ret := _m.Called(ctx, params)
 
 	var r0 *monitorormodels.Tile
-	if rf, ok := ret.Get(0).(func(*models.HTTPFormattedParams) *monitorormodels.Tile); ok {
-		r0 = rf(params)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.HTTPFormattedParams) *monitorormodels.Tile); ok {
+		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*monitorormodels.Tile)
@@ -27,8 +29,8 @@ func (_m *Usecase) HTTPFormatted(params *models.HTTPFormattedParams) (*monitoror
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*models.HTTPFormattedParams) error); ok {
-		r1 = rf(params)
+	if rf, ok := ret.Get(1).(func(context.Context, *models.HTTPFormattedParams) error); ok {
+		r1 = rf(ctx, params)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -36,13 +38,13 @@ func (_m *Usecase) HTTPFormatted(params *models.HTTPFormattedParams) (*monitoror
 	return r0, r1
 }
 
-// HTTPRaw provides a mock function with given fields: params
-func (_m *Usecase) HTTPRaw(params *models.HTTPRawParams) (*monitorormodels.Tile, error) {
-	ret := _m.Called(params)
+// HTTPRaw provides a mock function with given fields: ctx, params
+func (_m *Usecase) HTTPRaw(ctx context.Context, params *models.HTTPRawParams) (*monitorormodels.Tile, error) {
+	ret := _m.Called(ctx, params)
 
 	var r0 *monitorormodels.Tile
-	if rf, ok := ret.Get(0).(func(*models.HTTPRawParams) *monitorormodels.Tile); ok {
-		r0 = rf(params)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.HTTPRawParams) *monitorormodels.Tile); ok {
+		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*monitorormodels.Tile)
@@ -50,8 +52,8 @@ func (_m *Usecase) HTTPRaw(params *models.HTTPRawParams) (*monitorormodels.Tile,
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*models.HTTPRawParams) error); ok {
-		r1 = rf(params)
+	if rf, ok := ret.Get(1).(func(context.Context, *models.HTTPRawParams) error); ok {
+		r1 = rf(ctx, params)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -59,13 +61,13 @@ func (_m *Usecase) HTTPRaw(params *models.HTTPRawParams) (*monitorormodels.Tile,
 	return r0, r1
 }
 
-// HTTPStatus provides a mock function with given fields: params
-func (_m *Usecase) HTTPStatus(params *models.HTTPStatusParams) (*monitorormodels.Tile, error) {
-	ret := _m.Called(params)
+// HTTPStatus provides a mock function with given fields: ctx, params
+func (_m *Usecase) HTTPStatus(ctx context.Context, params *models.HTTPStatusParams) (*monitorormodels.Tile, error) {
+	ret := _m.Called(ctx, params)
 
 	var r0 *monitorormodels.Tile
-	if rf, ok := ret.Get(0).(func(*models.HTTPStatusParams) *monitorormodels.Tile); ok {
-		r0 = rf(params)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.HTTPStatusParams) *monitorormodels.Tile); ok {
+		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*monitorormodels.Tile)
@@ -73,8 +75,8 @@ func (_m *Usecase) HTTPStatus(params *models.HTTPStatusParams) (*monitorormodels
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*models.HTTPStatusParams) error); ok {
-		r1 = rf(params)
+	if rf, ok := ret.Get(1).(func(context.Context, *models.HTTPStatusParams) error); ok {
+		r1 = rf(ctx, params)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -19,26 +19,15 @@ type (
 	}
 )
 
-func (p *HTTPFormattedParams) Validate(_ *uiConfigModels.ConfigVersion) *uiConfigModels.ConfigError {
-	// TODO
+func (p *HTTPFormattedParams) Validate(_ *uiConfigModels.ConfigVersion) []uiConfigModels.ConfigError {
+	var errors []uiConfigModels.ConfigError
 
-	if !isValid(p.URL, p) {
-		return &uiConfigModels.ConfigError{}
-	}
-
-	if !isSupportedFormat(p) {
-		return &uiConfigModels.ConfigError{}
-	}
-
-	if !isValidKey(p) {
-		return &uiConfigModels.ConfigError{}
-	}
-
-	if !isValidRegex(p) {
-		return &uiConfigModels.ConfigError{}
-	}
+	errors = append(errors, validateURL(p.URL)...)
+	errors = append(errors, validateStatusCodes(p)...)
+	errors = append(errors, validateFormattedData(p)...)
+	errors = append(errors, validateRegex(p)...)
 
-	return nil
+	return errors
 }
 
 func (p *HTTPFormattedParams) GetStatusCodes() (min int, max int) {
@@ -1,8 +1,11 @@
 package models
 
 import (
+	"fmt"
 	"regexp"
+	"strings"
 
+	uiConfigModels "github.com/monitoror/monitoror/api/config/models"
 	"github.com/monitoror/monitoror/pkg/slice"
 )
 
@@ -35,43 +38,49 @@ const (
 
 var supportedFormats = []string{JSONFormat, YAMLFormat, XMLFormat}
 
-func isValid(url string, statusCodesProvider StatusCodesProvider) bool {
+func validateURL(url string) []uiConfigModels.ConfigError {
 	if url == "" {
-		return false
+		return []uiConfigModels.ConfigError{uiConfigModels.NewMissingParamError("url")}
 	}
 
-	min, max := statusCodesProvider.GetStatusCodes()
-	return min <= max
+	return nil
+}
+
+func validateStatusCodes(statusCodesProvider StatusCodesProvider) []uiConfigModels.ConfigError {
+	if min, max := statusCodesProvider.GetStatusCodes(); min > max {
+		return []uiConfigModels.ConfigError{uiConfigModels.NewInvalidParamError("statusCodeMin", min, fmt.Sprintf("lower or equal to statusCodeMax (%d)", max))}
+	}
+
+	return nil
 }
 
-func isValidRegex(regexProvider RegexProvider) bool {
+func validateRegex(regexProvider RegexProvider) []uiConfigModels.ConfigError {
 	regex := regexProvider.GetRegex()
 	if regex != "" {
-		_, err := regexp.Compile(regex)
-		if err != nil {
-			return false
+		if _, err := regexp.Compile(regex); err != nil {
+			return []uiConfigModels.ConfigError{uiConfigModels.NewInvalidRegexParamError("regex", regex, err)}
 		}
 	}
 
-	return true
+	return nil
 }
 
-func isValidKey(formattedDataProvider FormattedDataProvider) bool {
-	key := formattedDataProvider.GetKey()
-	if key == "" || key == "." {
-		return false
-	}
+func validateFormattedData(formattedDataProvider FormattedDataProvider) []uiConfigModels.ConfigError {
+	var errors []uiConfigModels.ConfigError
 
-	return true
-}
+	if format := formattedDataProvider.GetFormat(); format == "" {
+		errors = append(errors, uiConfigModels.NewMissingParamError("format"))
+	} else if _, find := slice.Find(supportedFormats, format); !find {
+		errors = append(errors, uiConfigModels.NewInvalidParamError("format", format, strings.Join(supportedFormats, ", ")))
+	}
 
-func isSupportedFormat(formattedDataProvider FormattedDataProvider) bool {
-	format := formattedDataProvider.GetFormat()
-	if _, find := slice.Find(supportedFormats, format); !find {
-		return false
+	if key := formattedDataProvider.GetKey(); key == "" {
+		errors = append(errors, uiConfigModels.NewMissingParamError("key"))
+	} else if key == "." {
+		errors = append(errors, uiConfigModels.NewInvalidParamError("key", key, "a path to a value (ex: data.value)"))
 	}
 
-	return true
+	return errors
 }
 
 func getStatusCodes(statusCodeMin, statusCodeMax *int) (min int, max int) {
@@ -88,7 +97,7 @@ func getStatusCodes(statusCodeMin, statusCodeMax *int) (min int, max int) {
 
 func getRegexp(regex string) *regexp.Regexp {
 	if regex != "" {
-		return regexp.MustCompile(regex) // Already validate by isValid
+		return regexp.MustCompile(regex) // Already validate by validateRegex
 	}
 	return nil
 }
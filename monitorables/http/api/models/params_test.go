@@ -65,7 +65,7 @@ func TestHTTPParams_GetRegex(t *testing.T) {
 		{&HTTPFormattedParams{Regex: "(.*)"}, "(.*)", regexp.MustCompile("(.*)")},
 	} {
 		assert.Equal(t, testcase.expectedRegex, testcase.params.GetRegex())
-		if isValidRegex(testcase.params) {
+		if len(validateRegex(testcase.params)) == 0 {
 			assert.Equal(t, testcase.expectedRegexp, testcase.params.GetRegexp())
 		}
 	}
@@ -23,18 +23,14 @@ type (
 	}
 )
 
-func (p *HTTPRawParams) Validate(_ *uiConfigModels.ConfigVersion) *uiConfigModels.ConfigError {
-	// TODO
+func (p *HTTPRawParams) Validate(_ *uiConfigModels.ConfigVersion) []uiConfigModels.ConfigError {
+	var errors []uiConfigModels.ConfigError
 
-	if !isValid(p.URL, p) {
-		return &uiConfigModels.ConfigError{}
-	}
-
-	if !isValidRegex(p) {
-		return &uiConfigModels.ConfigError{}
-	}
+	errors = append(errors, validateURL(p.URL)...)
+	errors = append(errors, validateStatusCodes(p)...)
+	errors = append(errors, validateRegex(p)...)
 
-	return nil
+	return errors
 }
 
 func (p *HTTPRawParams) GetStatusCodes() (min int, max int) {
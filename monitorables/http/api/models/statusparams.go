@@ -14,14 +14,13 @@ type (
 	}
 )
 
-func (p *HTTPStatusParams) Validate(_ *uiConfigModels.ConfigVersion) *uiConfigModels.ConfigError {
-	// TODO
+func (p *HTTPStatusParams) Validate(_ *uiConfigModels.ConfigVersion) []uiConfigModels.ConfigError {
+	var errors []uiConfigModels.ConfigError
 
-	if !isValid(p.URL, p) {
-		return &uiConfigModels.ConfigError{}
-	}
+	errors = append(errors, validateURL(p.URL)...)
+	errors = append(errors, validateStatusCodes(p)...)
 
-	return nil
+	return errors
 }
 
 func (p *HTTPStatusParams) GetStatusCodes() (min int, max int) {
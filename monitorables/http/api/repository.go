@@ -3,11 +3,13 @@
 package api
 
 import (
+	"context"
+
 	"github.com/monitoror/monitoror/monitorables/http/api/models"
 )
 
 type (
 	Repository interface {
-		Get(url string) (*models.Response, error)
+		Get(ctx context.Context, url string) (*models.Response, error)
 	}
 )
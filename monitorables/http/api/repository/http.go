@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"crypto/tls"
 	"io/ioutil"
 	"net/http"
@@ -26,8 +27,13 @@ func NewHTTPRepository(config *config.HTTP) api.Repository {
 	return &httpRepository{client}
 }
 
-func (r *httpRepository) Get(url string) (response *models.Response, err error) {
-	resp, err := r.httpClient.Get(url)
+func (r *httpRepository) Get(ctx context.Context, url string) (response *models.Response, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return
+	}
+
+	resp, err := r.httpClient.Do(req)
 	if err != nil {
 		return
 	}
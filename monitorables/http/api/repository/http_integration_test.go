@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -26,7 +28,7 @@ func TestHTTPRepository_Get(t *testing.T) {
 	defer ts.Close()
 
 	repository := NewHTTPRepository(&config.HTTP{SSLVerify: false, Timeout: 2000})
-	response, err := repository.Get(ts.URL)
+	response, err := repository.Get(context.Background(), ts.URL)
 
 	if assert.NoError(t, err) {
 		assert.Equal(t, 200, response.StatusCode)
@@ -36,10 +38,24 @@ func TestHTTPRepository_Get(t *testing.T) {
 
 func TestHTTPRepository_Get_Error(t *testing.T) {
 	repository := NewHTTPRepository(&config.HTTP{SSLVerify: false, Timeout: 2000})
-	_, err := repository.Get("http://monitoror.example.com")
+	_, err := repository.Get(context.Background(), "http://monitoror.example.com")
 	assert.Error(t, err)
 }
 
+func TestHTTPRepository_Get_Canceled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintln(w, "Hello")
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	repository := NewHTTPRepository(&config.HTTP{SSLVerify: false, Timeout: 2000})
+	_, err := repository.Get(ctx, ts.URL)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
 func TestHTTPRepository_Get_ReadAll_Error(t *testing.T) {
 	client := test.NewTestClient(func(req *http.Request) *http.Response {
 		// Test request parameters
@@ -51,6 +67,6 @@ func TestHTTPRepository_Get_ReadAll_Error(t *testing.T) {
 	})
 	repository := httpRepository{httpClient: client}
 
-	_, err := repository.Get("http://monitoror.example.com")
+	_, err := repository.Get(context.Background(), "http://monitoror.example.com")
 	assert.Error(t, err)
 }
@@ -3,6 +3,9 @@
 package api
 
 import (
+	"context"
+	"time"
+
 	coreModels "github.com/monitoror/monitoror/models"
 	"github.com/monitoror/monitoror/monitorables/http/api/models"
 )
@@ -11,12 +14,16 @@ const (
 	HTTPStatusTileType    coreModels.TileType = "HTTP-STATUS"
 	HTTPRawTileType       coreModels.TileType = "HTTP-RAW"
 	HTTPFormattedTileType coreModels.TileType = "HTTP-FORMATTED"
+
+	HTTPStatusMinimalRefreshInterval    = 5 * time.Second
+	HTTPRawMinimalRefreshInterval       = 5 * time.Second
+	HTTPFormattedMinimalRefreshInterval = 5 * time.Second
 )
 
 type (
 	Usecase interface {
-		HTTPStatus(params *models.HTTPStatusParams) (*coreModels.Tile, error)
-		HTTPRaw(params *models.HTTPRawParams) (*coreModels.Tile, error)
-		HTTPFormatted(params *models.HTTPFormattedParams) (*coreModels.Tile, error)
+		HTTPStatus(ctx context.Context, params *models.HTTPStatusParams) (*coreModels.Tile, error)
+		HTTPRaw(ctx context.Context, params *models.HTTPRawParams) (*coreModels.Tile, error)
+		HTTPFormatted(ctx context.Context, params *models.HTTPFormattedParams) (*coreModels.Tile, error)
 	}
 )
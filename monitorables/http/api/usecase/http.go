@@ -4,6 +4,7 @@ package usecase
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"regexp"
@@ -40,26 +41,26 @@ func NewHTTPUsecase(repository api.Repository, store cache.Store, cacheExpiratio
 	return &httpUsecase{repository, store, cacheExpiration}
 }
 
-func (hu *httpUsecase) HTTPStatus(params *models.HTTPStatusParams) (*coreModels.Tile, error) {
-	return hu.httpAll(api.HTTPStatusTileType, params.URL, params)
+func (hu *httpUsecase) HTTPStatus(ctx context.Context, params *models.HTTPStatusParams) (*coreModels.Tile, error) {
+	return hu.httpAll(ctx, api.HTTPStatusTileType, params.URL, params)
 }
 
-func (hu *httpUsecase) HTTPRaw(params *models.HTTPRawParams) (*coreModels.Tile, error) {
-	return hu.httpAll(api.HTTPRawTileType, params.URL, params)
+func (hu *httpUsecase) HTTPRaw(ctx context.Context, params *models.HTTPRawParams) (*coreModels.Tile, error) {
+	return hu.httpAll(ctx, api.HTTPRawTileType, params.URL, params)
 }
 
-func (hu *httpUsecase) HTTPFormatted(params *models.HTTPFormattedParams) (*coreModels.Tile, error) {
-	return hu.httpAll(api.HTTPFormattedTileType, params.URL, params)
+func (hu *httpUsecase) HTTPFormatted(ctx context.Context, params *models.HTTPFormattedParams) (*coreModels.Tile, error) {
+	return hu.httpAll(ctx, api.HTTPFormattedTileType, params.URL, params)
 }
 
 // httpAll handle all http usecase by checking if params match interfaces listed in coreModels.params
-func (hu *httpUsecase) httpAll(tileType coreModels.TileType, url string, params interface{}) (*coreModels.Tile, error) {
+func (hu *httpUsecase) httpAll(ctx context.Context, tileType coreModels.TileType, url string, params interface{}) (*coreModels.Tile, error) {
 	tile := coreModels.NewTile(tileType)
 	tile.Label = url
 	tile.Status = coreModels.SuccessStatus
 
 	// Download page
-	response, err := hu.get(url)
+	response, err := hu.get(ctx, url)
 	if err != nil {
 		return nil, &coreModels.MonitororError{Err: err, Tile: tile, Message: fmt.Sprintf("unable to get %s", url)}
 	}
@@ -143,7 +144,7 @@ func (hu *httpUsecase) httpAll(tileType coreModels.TileType, url string, params
 }
 
 // Adding cache to Repository.Get
-func (hu *httpUsecase) get(url string) (*models.Response, error) {
+func (hu *httpUsecase) get(ctx context.Context, url string) (*models.Response, error) {
 	response := &models.Response{}
 
 	// Lookup in cache
@@ -154,7 +155,7 @@ func (hu *httpUsecase) get(url string) (*models.Response, error) {
 	}
 
 	// Download page
-	response, err := hu.repository.Get(url)
+	response, err := hu.repository.Get(ctx, url)
 	if err != nil {
 		return nil, err
 	}
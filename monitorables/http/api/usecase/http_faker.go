@@ -3,6 +3,7 @@
 package usecase
 
 import (
+	"context"
 	"math/rand"
 	"strconv"
 	"time"
@@ -30,16 +31,16 @@ func NewHTTPUsecase() api.Usecase {
 }
 
 // HTTPStatus only check status code
-func (hu *httpUsecase) HTTPStatus(params *models.HTTPStatusParams) (tile *coreModels.Tile, err error) {
+func (hu *httpUsecase) HTTPStatus(_ context.Context, params *models.HTTPStatusParams) (tile *coreModels.Tile, err error) {
 	return hu.httpAll(api.HTTPStatusTileType, params.URL, params)
 }
 
 // HTTPRaw check status code and content
-func (hu *httpUsecase) HTTPRaw(params *models.HTTPRawParams) (tile *coreModels.Tile, err error) {
+func (hu *httpUsecase) HTTPRaw(_ context.Context, params *models.HTTPRawParams) (tile *coreModels.Tile, err error) {
 	return hu.httpAll(api.HTTPRawTileType, params.URL, params)
 }
 
-func (hu *httpUsecase) HTTPFormatted(params *models.HTTPFormattedParams) (tile *coreModels.Tile, err error) {
+func (hu *httpUsecase) HTTPFormatted(_ context.Context, params *models.HTTPFormattedParams) (tile *coreModels.Tile, err error) {
 	return hu.httpAll(api.HTTPFormattedTileType, params.URL, params)
 }
 
@@ -20,10 +20,10 @@ import (
 
 func TestHTTPStatus_WithError(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("Get", AnythingOfType("string")).Return(nil, context.DeadlineExceeded)
+	mockRepository.On("Get", Anything, AnythingOfType("string")).Return(nil, context.DeadlineExceeded)
 	tu := NewHTTPUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), 2000)
 
-	tile, err := tu.HTTPStatus(&models.HTTPStatusParams{URL: "toto"})
+	tile, err := tu.HTTPStatus(context.Background(), &models.HTTPStatusParams{URL: "toto"})
 	if assert.Error(t, err) {
 		assert.Nil(t, tile)
 		mockRepository.AssertNumberOfCalls(t, "Get", 1)
@@ -44,14 +44,14 @@ func TestHtmlAll_WithoutErrors(t *testing.T) {
 		{
 			// HTTP Status
 			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
-				return usecase.HTTPStatus(&models.HTTPStatusParams{URL: "toto"})
+				return usecase.HTTPStatus(context.Background(), &models.HTTPStatusParams{URL: "toto"})
 			},
 			expectedStatus: coreModels.SuccessStatus, expectedLabel: "toto",
 		},
 		{
 			// HTTP Status with wrong status
 			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
-				return usecase.HTTPStatus(&models.HTTPStatusParams{URL: "toto", StatusCodeMin: pointer.ToInt(400), StatusCodeMax: pointer.ToInt(499)})
+				return usecase.HTTPStatus(context.Background(), &models.HTTPStatusParams{URL: "toto", StatusCodeMin: pointer.ToInt(400), StatusCodeMax: pointer.ToInt(499)})
 			},
 			expectedStatus: coreModels.FailedStatus, expectedLabel: "toto", expectedMessage: "status code 200",
 		},
@@ -59,7 +59,7 @@ func TestHtmlAll_WithoutErrors(t *testing.T) {
 			// HTTP Raw with matched regex
 			body: "test",
 			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
-				return usecase.HTTPStatus(&models.HTTPStatusParams{URL: "toto"})
+				return usecase.HTTPStatus(context.Background(), &models.HTTPStatusParams{URL: "toto"})
 			},
 			expectedStatus: coreModels.SuccessStatus, expectedLabel: "toto",
 		},
@@ -67,7 +67,7 @@ func TestHtmlAll_WithoutErrors(t *testing.T) {
 			// HTTP Raw with matched regex
 			body: "errors: 28",
 			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
-				return usecase.HTTPRaw(&models.HTTPRawParams{URL: "toto", Regex: `errors: (\d*)`})
+				return usecase.HTTPRaw(context.Background(), &models.HTTPRawParams{URL: "toto", Regex: `errors: (\d*)`})
 			},
 			expectedStatus: coreModels.SuccessStatus, expectedLabel: "toto", expectedValueUnit: coreModels.NumberUnit, expectedValueValues: []string{"28"},
 		},
@@ -75,7 +75,7 @@ func TestHtmlAll_WithoutErrors(t *testing.T) {
 			// HTTP Raw without matched regex
 			body: "api call: 20",
 			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
-				return usecase.HTTPRaw(&models.HTTPRawParams{URL: "toto", Regex: `errors: (\d*)`})
+				return usecase.HTTPRaw(context.Background(), &models.HTTPRawParams{URL: "toto", Regex: `errors: (\d*)`})
 			},
 			expectedStatus: coreModels.FailedStatus, expectedLabel: "toto", expectedValueUnit: coreModels.RawUnit, expectedValueValues: []string{`api call: 20`},
 		},
@@ -83,7 +83,7 @@ func TestHtmlAll_WithoutErrors(t *testing.T) {
 			// HTTP Json
 			body: `{"key": "value"}`,
 			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
-				return usecase.HTTPFormatted(&models.HTTPFormattedParams{URL: "toto", Format: models.JSONFormat, Key: "key"})
+				return usecase.HTTPFormatted(context.Background(), &models.HTTPFormattedParams{URL: "toto", Format: models.JSONFormat, Key: "key"})
 			},
 			expectedStatus: coreModels.SuccessStatus, expectedLabel: "toto", expectedValueUnit: coreModels.RawUnit, expectedValueValues: []string{"value"},
 		},
@@ -91,7 +91,7 @@ func TestHtmlAll_WithoutErrors(t *testing.T) {
 			// HTTP Json with key jq like
 			body: `{"key": "value"}`,
 			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
-				return usecase.HTTPFormatted(&models.HTTPFormattedParams{URL: "toto", Format: models.JSONFormat, Key: ".key"})
+				return usecase.HTTPFormatted(context.Background(), &models.HTTPFormattedParams{URL: "toto", Format: models.JSONFormat, Key: ".key"})
 			},
 			expectedStatus: coreModels.SuccessStatus, expectedLabel: "toto", expectedValueUnit: coreModels.RawUnit, expectedValueValues: []string{"value"},
 		},
@@ -99,7 +99,7 @@ func TestHtmlAll_WithoutErrors(t *testing.T) {
 			// HTTP Json with long float
 			body: `{"key": 123456789 }`,
 			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
-				return usecase.HTTPFormatted(&models.HTTPFormattedParams{URL: "toto", Format: models.JSONFormat, Key: "key"})
+				return usecase.HTTPFormatted(context.Background(), &models.HTTPFormattedParams{URL: "toto", Format: models.JSONFormat, Key: "key"})
 			},
 			expectedStatus: coreModels.SuccessStatus, expectedLabel: "toto", expectedValueUnit: coreModels.NumberUnit, expectedValueValues: []string{"123456789"},
 		},
@@ -107,7 +107,7 @@ func TestHtmlAll_WithoutErrors(t *testing.T) {
 			// HTTP Json missing key
 			body: `{"key": "value"}`,
 			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
-				return usecase.HTTPFormatted(&models.HTTPFormattedParams{URL: "toto", Format: models.JSONFormat, Key: "key2"})
+				return usecase.HTTPFormatted(context.Background(), &models.HTTPFormattedParams{URL: "toto", Format: models.JSONFormat, Key: "key2"})
 			},
 			expectedStatus: coreModels.FailedStatus, expectedLabel: "toto", expectedMessage: `unable to lookup for key "key2"`,
 		},
@@ -115,7 +115,7 @@ func TestHtmlAll_WithoutErrors(t *testing.T) {
 			// HTTP Json unable to unmarshal
 			body: `{"key": "value`,
 			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
-				return usecase.HTTPFormatted(&models.HTTPFormattedParams{URL: "toto", Format: models.JSONFormat, Key: "key"})
+				return usecase.HTTPFormatted(context.Background(), &models.HTTPFormattedParams{URL: "toto", Format: models.JSONFormat, Key: "key"})
 			},
 			expectedStatus: coreModels.FailedStatus, expectedLabel: "toto", expectedMessage: `unable to unmarshal content`,
 		},
@@ -123,7 +123,7 @@ func TestHtmlAll_WithoutErrors(t *testing.T) {
 			// HTTP XML
 			body: `<check><status test="2">OK</status></check>`,
 			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
-				return usecase.HTTPFormatted(&models.HTTPFormattedParams{URL: "toto", Format: models.XMLFormat, Key: "check.status.#content"})
+				return usecase.HTTPFormatted(context.Background(), &models.HTTPFormattedParams{URL: "toto", Format: models.XMLFormat, Key: "check.status.#content"})
 			},
 			expectedStatus: coreModels.SuccessStatus, expectedLabel: "toto", expectedValueUnit: coreModels.RawUnit, expectedValueValues: []string{"OK"},
 		},
@@ -131,7 +131,7 @@ func TestHtmlAll_WithoutErrors(t *testing.T) {
 			// HTTP XML unable to convert to json
 			body: `<check><status test="2">OK</stat`,
 			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
-				return usecase.HTTPFormatted(&models.HTTPFormattedParams{URL: "toto", Format: models.XMLFormat, Key: "check.status.#content"})
+				return usecase.HTTPFormatted(context.Background(), &models.HTTPFormattedParams{URL: "toto", Format: models.XMLFormat, Key: "check.status.#content"})
 			},
 			expectedStatus: coreModels.FailedStatus, expectedLabel: "toto", expectedMessage: "unable to convert xml to json",
 		},
@@ -139,13 +139,13 @@ func TestHtmlAll_WithoutErrors(t *testing.T) {
 			// HTTP YAML
 			body: "key: value",
 			usecaseFunc: func(usecase api.Usecase) (*coreModels.Tile, error) {
-				return usecase.HTTPFormatted(&models.HTTPFormattedParams{URL: "toto", Format: models.YAMLFormat, Key: "key"})
+				return usecase.HTTPFormatted(context.Background(), &models.HTTPFormattedParams{URL: "toto", Format: models.YAMLFormat, Key: "key"})
 			},
 			expectedStatus: coreModels.SuccessStatus, expectedLabel: "toto", expectedValueUnit: coreModels.RawUnit, expectedValueValues: []string{"value"},
 		},
 	} {
 		mockRepository := new(mocks.Repository)
-		mockRepository.On("Get", AnythingOfType("string")).
+		mockRepository.On("Get", Anything, AnythingOfType("string")).
 			Return(&models.Response{StatusCode: 200, Body: []byte(testcase.body)}, nil)
 		tu := NewHTTPUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), 2000)
 
@@ -166,18 +166,18 @@ func TestHtmlAll_WithoutErrors(t *testing.T) {
 
 func TestHTTPStatus_WithCache(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("Get", AnythingOfType("string")).
+	mockRepository.On("Get", Anything, AnythingOfType("string")).
 		Return(&models.Response{StatusCode: 200, Body: []byte("test with cache")}, nil)
 
 	tu := NewHTTPUsecase(mockRepository, cache.NewGoCacheStore(time.Minute*5, time.Second), 2000)
 
-	tile, err := tu.HTTPRaw(&models.HTTPRawParams{URL: "toto"})
+	tile, err := tu.HTTPRaw(context.Background(), &models.HTTPRawParams{URL: "toto"})
 	if assert.NoError(t, err) {
 		assert.Equal(t, "toto", tile.Label)
 		assert.Equal(t, "test with cache", tile.Value.Values[0])
 	}
 
-	tile, err = tu.HTTPRaw(&models.HTTPRawParams{URL: "toto"})
+	tile, err = tu.HTTPRaw(context.Background(), &models.HTTPRawParams{URL: "toto"})
 	if assert.NoError(t, err) {
 		assert.Equal(t, "toto", tile.Label)
 		assert.Equal(t, "test with cache", tile.Value.Values[0])
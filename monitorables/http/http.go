@@ -36,9 +36,9 @@ func NewMonitorable(store *store.Store) *Monitorable {
 	pkgMonitorable.LoadConfig(&m.config, httpConfig.Default)
 
 	// Register Monitorable Tile in config manager
-	m.statusTileEnabler = store.Registry.RegisterTile(api.HTTPStatusTileType, versions.MinimalVersion, m.GetVariantNames())
-	m.rawTileEnabler = store.Registry.RegisterTile(api.HTTPRawTileType, versions.MinimalVersion, m.GetVariantNames())
-	m.formattedTileEnabler = store.Registry.RegisterTile(api.HTTPFormattedTileType, versions.MinimalVersion, m.GetVariantNames())
+	m.statusTileEnabler = store.Registry.RegisterTile(api.HTTPStatusTileType, versions.MinimalVersion, m.GetVariantNames(), api.HTTPStatusMinimalRefreshInterval, &httpModels.HTTPStatusParams{})
+	m.rawTileEnabler = store.Registry.RegisterTile(api.HTTPRawTileType, versions.MinimalVersion, m.GetVariantNames(), api.HTTPRawMinimalRefreshInterval, &httpModels.HTTPRawParams{})
+	m.formattedTileEnabler = store.Registry.RegisterTile(api.HTTPFormattedTileType, versions.MinimalVersion, m.GetVariantNames(), api.HTTPFormattedMinimalRefreshInterval, &httpModels.HTTPFormattedParams{})
 
 	return m
 }
@@ -51,6 +51,10 @@ func (m *Monitorable) GetVariantNames() []coreModels.VariantName {
 	return pkgMonitorable.GetVariants(m.config)
 }
 
+func (m *Monitorable) GetTileTypes() []coreModels.TileType {
+	return []coreModels.TileType{api.HTTPStatusTileType, api.HTTPRawTileType, api.HTTPFormattedTileType}
+}
+
 func (m *Monitorable) Validate(_ coreModels.VariantName) (bool, error) {
 	return true, nil
 }
@@ -69,7 +73,7 @@ func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	routeJSON := routeGroup.GET("/formatted", delivery.GetHTTPFormatted)
 
 	// EnableTile data for config hydration
-	m.statusTileEnabler.Enable(variantName, &httpModels.HTTPStatusParams{}, routeStatus.Path)
-	m.rawTileEnabler.Enable(variantName, &httpModels.HTTPRawParams{}, routeRaw.Path)
-	m.formattedTileEnabler.Enable(variantName, &httpModels.HTTPFormattedParams{}, routeJSON.Path)
+	m.statusTileEnabler.Enable(variantName, routeStatus.Path)
+	m.rawTileEnabler.Enable(variantName, routeRaw.Path)
+	m.formattedTileEnabler.Enable(variantName, routeJSON.Path)
 }
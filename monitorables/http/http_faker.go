@@ -30,15 +30,19 @@ func NewMonitorable(store *store.Store) *Monitorable {
 	m.store = store
 
 	// Register Monitorable Tile in config manager
-	m.statusTileEnabler = store.Registry.RegisterTile(api.HTTPStatusTileType, versions.MinimalVersion, m.GetVariantNames())
-	m.rawTileEnabler = store.Registry.RegisterTile(api.HTTPRawTileType, versions.MinimalVersion, m.GetVariantNames())
-	m.formattedTileEnabler = store.Registry.RegisterTile(api.HTTPFormattedTileType, versions.MinimalVersion, m.GetVariantNames())
+	m.statusTileEnabler = store.Registry.RegisterTile(api.HTTPStatusTileType, versions.MinimalVersion, m.GetVariantNames(), api.HTTPStatusMinimalRefreshInterval, &httpModels.HTTPStatusParams{})
+	m.rawTileEnabler = store.Registry.RegisterTile(api.HTTPRawTileType, versions.MinimalVersion, m.GetVariantNames(), api.HTTPRawMinimalRefreshInterval, &httpModels.HTTPRawParams{})
+	m.formattedTileEnabler = store.Registry.RegisterTile(api.HTTPFormattedTileType, versions.MinimalVersion, m.GetVariantNames(), api.HTTPFormattedMinimalRefreshInterval, &httpModels.HTTPFormattedParams{})
 
 	return m
 }
 
 func (m *Monitorable) GetDisplayName() string { return "HTTP (faker)" }
 
+func (m *Monitorable) GetTileTypes() []coreModels.TileType {
+	return []coreModels.TileType{api.HTTPStatusTileType, api.HTTPRawTileType, api.HTTPFormattedTileType}
+}
+
 func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	usecase := httpUsecase.NewHTTPUsecase()
 	delivery := httpDelivery.NewHTTPDelivery(usecase)
@@ -50,7 +54,7 @@ func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	routeJSON := routeGroup.GET("/formatted", delivery.GetHTTPFormatted)
 
 	// EnableTile data for config hydration
-	m.statusTileEnabler.Enable(variantName, &httpModels.HTTPStatusParams{}, routeStatus.Path)
-	m.rawTileEnabler.Enable(variantName, &httpModels.HTTPRawParams{}, routeRaw.Path)
-	m.formattedTileEnabler.Enable(variantName, &httpModels.HTTPFormattedParams{}, routeJSON.Path)
+	m.statusTileEnabler.Enable(variantName, routeStatus.Path)
+	m.rawTileEnabler.Enable(variantName, routeRaw.Path)
+	m.formattedTileEnabler.Enable(variantName, routeJSON.Path)
 }
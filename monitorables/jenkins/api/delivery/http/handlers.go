@@ -25,7 +25,7 @@ func (h *JenkinsDelivery) GetBuild(c echo.Context) error {
 		return err
 	}
 
-	tile, err := h.jenkinsUsecase.Build(params)
+	tile, err := h.jenkinsUsecase.Build(c.Request().Context(), params)
 	if err != nil {
 		return err
 	}
@@ -38,7 +38,7 @@ func TestDelivery_GetBuild_Success(t *testing.T) {
 	tile.Status = coreModels.SuccessStatus
 
 	mockUsecase := new(mocks.Usecase)
-	mockUsecase.On("Build", &models.BuildParams{Job: "test", Branch: "master"}).Return(tile, nil)
+	mockUsecase.On("Build", Anything, &models.BuildParams{Job: "test", Branch: "master"}).Return(tile, nil)
 	handler := NewJenkinsDelivery(mockUsecase)
 
 	// Expected
@@ -73,7 +73,7 @@ func TestDelivery_GetBuild_Error(t *testing.T) {
 	ctx, _ := initEcho()
 
 	mockUsecase := new(mocks.Usecase)
-	mockUsecase.On("Build", Anything).Return(nil, errors.New("build error"))
+	mockUsecase.On("Build", Anything, Anything).Return(nil, errors.New("build error"))
 	handler := NewJenkinsDelivery(mockUsecase)
 
 	// Test
@@ -3,8 +3,11 @@
 package mocks
 
 import (
-	models "github.com/monitoror/monitoror/monitorables/jenkins/api/models"
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/monitoror/monitoror/monitorables/jenkins/api/models"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -12,13 +15,27 @@ type Repository struct {
 	mock.Mock
 }
 
-// GetJob provides a mock function with given fields: jobName, branch
-func (_m *Repository) GetJob(jobName string, branch string) (*models.Job, error) {
-	ret := _m.Called(jobName, branch)
+// CheckConnection provides a mock function with given fields: ctx
+func (_m *Repository) CheckConnection(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetJob provides a mock function with given fields: ctx, jobName, branch
+func (_m *Repository) GetJob(ctx context.Context, jobName string, branch string) (*models.Job, error) {
+	ret := _m.Called(ctx, jobName, branch)
 
 	var r0 *models.Job
-	if rf, ok := ret.Get(0).(func(string, string) *models.Job); ok {
-		r0 = rf(jobName, branch)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Job); ok {
+		r0 = rf(ctx, jobName, branch)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Job)
@@ -26,8 +43,8 @@ func (_m *Repository) GetJob(jobName string, branch string) (*models.Job, error)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(jobName, branch)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, jobName, branch)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -35,13 +52,13 @@ func (_m *Repository) GetJob(jobName string, branch string) (*models.Job, error)
 	return r0, r1
 }
 
-// GetLastBuildStatus provides a mock function with given fields: job
-func (_m *Repository) GetLastBuildStatus(job *models.Job) (*models.Build, error) {
-	ret := _m.Called(job)
+// GetLastBuildStatus provides a mock function with given fields: ctx, job
+func (_m *Repository) GetLastBuildStatus(ctx context.Context, job *models.Job) (*models.Build, error) {
+	ret := _m.Called(ctx, job)
 
 	var r0 *models.Build
-	if rf, ok := ret.Get(0).(func(*models.Job) *models.Build); ok {
-		r0 = rf(job)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Job) *models.Build); ok {
+		r0 = rf(ctx, job)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Build)
@@ -49,8 +66,8 @@ func (_m *Repository) GetLastBuildStatus(job *models.Job) (*models.Build, error)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*models.Job) error); ok {
-		r1 = rf(job)
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Job) error); ok {
+		r1 = rf(ctx, job)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	configmodels "github.com/monitoror/monitoror/api/config/models"
 	mock "github.com/stretchr/testify/mock"
 
@@ -16,13 +18,13 @@ type Usecase struct {
 	mock.Mock
 }
 
-// Build provides a mock function with given fields: params
-func (_m *Usecase) Build(params *models.BuildParams) (*monitorormodels.Tile, error) {
-	ret := _m.Called(params)
+// Build provides a mock function with given fields: ctx, params
+func (_m *Usecase) Build(ctx context.Context, params *models.BuildParams) (*monitorormodels.Tile, error) {
+	ret := _m.Called(ctx, params)
 
 	var r0 *monitorormodels.Tile
-	if rf, ok := ret.Get(0).(func(*models.BuildParams) *monitorormodels.Tile); ok {
-		r0 = rf(params)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.BuildParams) *monitorormodels.Tile); ok {
+		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*monitorormodels.Tile)
@@ -30,8 +32,8 @@ func (_m *Usecase) Build(params *models.BuildParams) (*monitorormodels.Tile, err
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*models.BuildParams) error); ok {
-		r1 = rf(params)
+	if rf, ok := ret.Get(1).(func(context.Context, *models.BuildParams) error); ok {
+		r1 = rf(ctx, params)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -39,13 +41,13 @@ func (_m *Usecase) Build(params *models.BuildParams) (*monitorormodels.Tile, err
 	return r0, r1
 }
 
-// BuildGenerator provides a mock function with given fields: params
-func (_m *Usecase) BuildGenerator(params interface{}) ([]configmodels.GeneratedTile, error) {
-	ret := _m.Called(params)
+// BuildGenerator provides a mock function with given fields: ctx, params
+func (_m *Usecase) BuildGenerator(ctx context.Context, params interface{}) ([]configmodels.GeneratedTile, error) {
+	ret := _m.Called(ctx, params)
 
 	var r0 []configmodels.GeneratedTile
-	if rf, ok := ret.Get(0).(func(interface{}) []configmodels.GeneratedTile); ok {
-		r0 = rf(params)
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) []configmodels.GeneratedTile); ok {
+		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]configmodels.GeneratedTile)
@@ -53,8 +55,8 @@ func (_m *Usecase) BuildGenerator(params interface{}) ([]configmodels.GeneratedT
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(interface{}) error); ok {
-		r1 = rf(params)
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}) error); ok {
+		r1 = rf(ctx, params)
 	} else {
 		r1 = ret.Error(1)
 	}
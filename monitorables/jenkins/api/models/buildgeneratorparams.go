@@ -16,24 +16,24 @@ type (
 	}
 )
 
-func (p *BuildGeneratorParams) Validate(_ *uiConfigModels.ConfigVersion) *uiConfigModels.ConfigError {
-	// TODO
+func (p *BuildGeneratorParams) Validate(_ *uiConfigModels.ConfigVersion) []uiConfigModels.ConfigError {
+	var errors []uiConfigModels.ConfigError
 
 	if p.Job == "" {
-		return &uiConfigModels.ConfigError{}
+		errors = append(errors, uiConfigModels.NewMissingParamError("job"))
 	}
 
 	if p.Match != "" {
 		if _, err := regexp.Compile(p.Match); err != nil {
-			return &uiConfigModels.ConfigError{}
+			errors = append(errors, uiConfigModels.NewInvalidRegexParamError("match", p.Match, err))
 		}
 	}
 
 	if p.Unmatch != "" {
 		if _, err := regexp.Compile(p.Unmatch); err != nil {
-			return &uiConfigModels.ConfigError{}
+			errors = append(errors, uiConfigModels.NewInvalidRegexParamError("unmatch", p.Unmatch, err))
 		}
 	}
 
-	return nil
+	return errors
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	uiConfigModels "github.com/monitoror/monitoror/api/config/models"
 	"github.com/monitoror/monitoror/internal/pkg/monitorable/validator"
 )
 
@@ -25,3 +26,17 @@ func TestBuildGeneratorParams_Validate(t *testing.T) {
 	param = &BuildGeneratorParams{Job: "test", Unmatch: "("}
 	assert.Error(t, validator.Validate(param))
 }
+
+func TestBuildGeneratorParams_Validate_Errors(t *testing.T) {
+	param := &BuildGeneratorParams{Match: "(", Unmatch: "("}
+	errors := param.Validate(nil)
+
+	if assert.Len(t, errors, 3) {
+		assert.Equal(t, uiConfigModels.ConfigErrorMissingRequiredField, errors[0].ID)
+		assert.Equal(t, "job", errors[0].Data.FieldName)
+		assert.Equal(t, uiConfigModels.ConfigErrorInvalidFieldValue, errors[1].ID)
+		assert.Equal(t, "match", errors[1].Data.FieldName)
+		assert.Equal(t, `"("`, errors[1].Data.Value)
+		assert.Equal(t, "unmatch", errors[2].Data.FieldName)
+	}
+}
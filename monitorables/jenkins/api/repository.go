@@ -3,12 +3,15 @@
 package api
 
 import (
+	"context"
+
 	"github.com/monitoror/monitoror/monitorables/jenkins/api/models"
 )
 
 type (
 	Repository interface {
-		GetJob(jobName string, branch string) (*models.Job, error)
-		GetLastBuildStatus(job *models.Job) (*models.Build, error)
+		CheckConnection(ctx context.Context) error
+		GetJob(ctx context.Context, jobName string, branch string) (*models.Job, error)
+		GetLastBuildStatus(ctx context.Context, job *models.Job) (*models.Build, error)
 	}
 )
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 	"github.com/monitoror/monitoror/monitorables/jenkins/api/models"
 	"github.com/monitoror/monitoror/monitorables/jenkins/config"
 	pkgJenkins "github.com/monitoror/monitoror/pkg/gojenkins"
+	pkgNet "github.com/monitoror/monitoror/pkg/net"
 	"github.com/monitoror/monitoror/pkg/gravatar"
 
 	gojenkins "github.com/jsdidierlaurent/golang-jenkins"
@@ -19,8 +21,12 @@ import (
 
 type (
 	jenkinsRepository struct {
-		// Interfaces for Jenkins API
-		jenkinsAPI pkgJenkins.Jenkins
+		// Create Jenkins API client sending requests with given context (Jenkins API client doesn't support context)
+		jenkinsAPI func(ctx context.Context) pkgJenkins.Jenkins
+
+		// Used by CheckConnection, whoAmI endpoint isn't available in Jenkins API client
+		httpClient *http.Client
+		config     *config.Jenkins
 	}
 )
 
@@ -36,27 +42,62 @@ func NewJenkinsRepository(config *config.Jenkins) api.Repository {
 	// Remove last /
 	config.URL = strings.TrimRight(config.URL, "/")
 
-	jenkins := gojenkins.NewJenkins(auth, config.URL)
-
 	// Override transport
 	tr := &http.Transport{
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: !config.SSLVerify},
 	}
 	client := &http.Client{Transport: tr, Timeout: time.Duration(config.Timeout) * time.Millisecond}
-	jenkins.SetHTTPClient(client)
 
 	return &jenkinsRepository{
-		jenkins,
+		jenkinsAPI: func(ctx context.Context) pkgJenkins.Jenkins {
+			jenkins := gojenkins.NewJenkins(auth, config.URL)
+			jenkins.SetHTTPClient(&http.Client{Transport: pkgNet.NewContextTransport(ctx, tr)})
+			return jenkins
+		},
+		httpClient: client,
+		config:     config,
+	}
+}
+
+// CheckConnection call whoAmI endpoint to check URL and credentials
+func (r *jenkinsRepository) CheckConnection(ctx context.Context) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/whoAmI/api/json", r.config.URL), nil)
+	if err != nil {
+		return err
+	}
+	if r.config.Login != "" {
+		req.SetBasicAuth(r.config.Login, r.config.Token)
+	}
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return err
 	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w: %s", coreModels.UnauthorizedError, resp.Status)
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+
+	return nil
 }
 
-func (r *jenkinsRepository) GetJob(jobName string, branch string) (job *models.Job, err error) {
+func (r *jenkinsRepository) GetJob(ctx context.Context, jobName string, branch string) (job *models.Job, err error) {
 	jobID := jobName
 	if branch != "" {
 		jobID = fmt.Sprintf("%s/job/%s", jobName, branch)
 	}
 
-	jenkinsJob, err := r.jenkinsAPI.GetJob(jobID)
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	jenkinsJob, err := r.jenkinsAPI(ctx).GetJob(jobID)
 	if err != nil {
 		return nil, err
 	}
@@ -83,8 +124,11 @@ func (r *jenkinsRepository) GetJob(jobName string, branch string) (job *models.J
 }
 
 //GetBuildStatus fetch build information from travis-ci
-func (r *jenkinsRepository) GetLastBuildStatus(job *models.Job) (*models.Build, error) {
-	jenkinsBuild, err := r.jenkinsAPI.GetLastBuildByJobId(job.ID)
+func (r *jenkinsRepository) GetLastBuildStatus(ctx context.Context, job *models.Job) (*models.Build, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	jenkinsBuild, err := r.jenkinsAPI(ctx).GetLastBuildByJobId(job.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -119,6 +163,11 @@ func (r *jenkinsRepository) GetLastBuildStatus(job *models.Job) (*models.Build,
 	return build, nil
 }
 
+// withTimeout add configured timeout to ctx, http client timeout is lost when request context is replaced
+func (r *jenkinsRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, time.Duration(r.config.Timeout)*time.Millisecond)
+}
+
 func parseDate(date int64) time.Time {
 	return time.Unix(date/int64(time.Microsecond), 0)
 }
@@ -1,7 +1,10 @@
 package repository
 
 import (
+	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	coreModels "github.com/monitoror/monitoror/models"
@@ -31,12 +34,49 @@ func initRepository(t *testing.T, buildsAPI pkgJenkins.Jenkins) *jenkinsReposito
 
 	apiJenkinsRepository, ok := repository.(*jenkinsRepository)
 	if assert.True(t, ok) {
-		apiJenkinsRepository.jenkinsAPI = buildsAPI
+		apiJenkinsRepository.jenkinsAPI = func(context.Context) pkgJenkins.Jenkins { return buildsAPI }
 		return apiJenkinsRepository
 	}
 	return nil
 }
 
+func TestRepository_CheckConnection(t *testing.T) {
+	for _, testcase := range []struct {
+		statusCode   int
+		err          bool
+		unauthorized bool
+	}{
+		{statusCode: http.StatusOK},
+		{statusCode: http.StatusNotFound, err: true},
+		{statusCode: http.StatusUnauthorized, err: true, unauthorized: true},
+		{statusCode: http.StatusForbidden, err: true, unauthorized: true},
+	} {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			login, token, _ := r.BasicAuth()
+			assert.Equal(t, "/whoAmI/api/json", r.URL.Path)
+			assert.Equal(t, "test", login)
+			assert.Equal(t, "Test", token)
+			w.WriteHeader(testcase.statusCode)
+		}))
+
+		repository := NewJenkinsRepository(&config.Jenkins{URL: ts.URL, Login: "test", Token: "Test", Timeout: config.Default.Timeout})
+
+		err := repository.CheckConnection(context.Background())
+		assert.Equal(t, testcase.err, err != nil)
+		assert.Equal(t, testcase.unauthorized, errors.Is(err, coreModels.UnauthorizedError))
+
+		ts.Close()
+	}
+}
+
+func TestRepository_CheckConnection_Unreachable(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	ts.Close()
+
+	repository := NewJenkinsRepository(&config.Jenkins{URL: ts.URL, Timeout: config.Default.Timeout})
+	assert.Error(t, repository.CheckConnection(context.Background()))
+}
+
 func TestRepository_GetJob_Error(t *testing.T) {
 	jenkinsErr := errors.New("jenkins error")
 
@@ -46,7 +86,7 @@ func TestRepository_GetJob_Error(t *testing.T) {
 
 	repository := initRepository(t, mocksJenkins)
 	if repository != nil {
-		_, err := repository.GetJob("master", "test")
+		_, err := repository.GetJob(context.Background(), "master", "test")
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "jenkins error")
 		mocksJenkins.AssertNumberOfCalls(t, "GetJob", 1)
@@ -78,7 +118,7 @@ func TestRepository_GetJob_Success(t *testing.T) {
 
 	repository := initRepository(t, mocksJenkins)
 	if repository != nil {
-		job, err := repository.GetJob("test", "master")
+		job, err := repository.GetJob(context.Background(), "test", "master")
 		assert.NoError(t, err)
 		assert.Equal(t, expectedJob, job)
 		mocksJenkins.AssertNumberOfCalls(t, "GetJob", 1)
@@ -111,7 +151,7 @@ func TestRepository_GetJob_SuccessWithQueue(t *testing.T) {
 
 	repository := initRepository(t, mocksJenkins)
 	if repository != nil {
-		job, err := repository.GetJob("test", "master")
+		job, err := repository.GetJob(context.Background(), "test", "master")
 		assert.NoError(t, err)
 		assert.Equal(t, expectedJob, job)
 		mocksJenkins.AssertNumberOfCalls(t, "GetJob", 1)
@@ -142,7 +182,7 @@ func TestRepository_GetJob_SuccessWithBranch(t *testing.T) {
 
 	repository := initRepository(t, mocksJenkins)
 	if repository != nil {
-		job, err := repository.GetJob("test", "master")
+		job, err := repository.GetJob(context.Background(), "test", "master")
 		assert.NoError(t, err)
 		assert.Equal(t, expectedJob, job)
 		mocksJenkins.AssertNumberOfCalls(t, "GetJob", 1)
@@ -159,7 +199,7 @@ func TestRepository_GetLastBuildStatus_Error(t *testing.T) {
 
 	repository := initRepository(t, mocksJenkins)
 	if repository != nil {
-		build, err := repository.GetLastBuildStatus(&models.Job{ID: "test/job/master"})
+		build, err := repository.GetLastBuildStatus(context.Background(), &models.Job{ID: "test/job/master"})
 		assert.Error(t, err)
 		assert.Nil(t, build)
 		mocksJenkins.AssertNumberOfCalls(t, "GetLastBuildByJobId", 1)
@@ -207,7 +247,7 @@ func TestRepository_GetLastBuildStatus_Success(t *testing.T) {
 
 	repository := initRepository(t, mockJenkins)
 	if repository != nil {
-		build, err := repository.GetLastBuildStatus(&models.Job{ID: "test/job/master"})
+		build, err := repository.GetLastBuildStatus(context.Background(), &models.Job{ID: "test/job/master"})
 		assert.NoError(t, err)
 		assert.Equal(t, expectedBuild, build)
 		mockJenkins.AssertNumberOfCalls(t, "GetLastBuildByJobId", 1)
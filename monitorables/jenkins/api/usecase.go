@@ -3,6 +3,9 @@
 package api
 
 import (
+	"context"
+	"time"
+
 	uiConfigModels "github.com/monitoror/monitoror/api/config/models"
 	coreModels "github.com/monitoror/monitoror/models"
 	"github.com/monitoror/monitoror/monitorables/jenkins/api/models"
@@ -10,11 +13,13 @@ import (
 
 const (
 	JenkinsBuildTileType coreModels.TileType = "JENKINS-BUILD"
+
+	JenkinsBuildMinimalRefreshInterval = 10 * time.Second
 )
 
 type (
 	Usecase interface {
-		Build(params *models.BuildParams) (*coreModels.Tile, error)
-		BuildGenerator(params interface{}) ([]uiConfigModels.GeneratedTile, error)
+		Build(ctx context.Context, params *models.BuildParams) (*coreModels.Tile, error)
+		BuildGenerator(ctx context.Context, params interface{}) ([]uiConfigModels.GeneratedTile, error)
 	}
 )
@@ -3,6 +3,8 @@
 package usecase
 
 import (
+	"context"
+	"fmt"
 	"net/url"
 	"regexp"
 	"time"
@@ -15,6 +17,7 @@ import (
 	"github.com/monitoror/monitoror/pkg/git"
 
 	"github.com/AlekSi/pointer"
+	echoCache "github.com/jsdidierlaurent/echo-middleware/cache"
 )
 
 type (
@@ -28,14 +31,14 @@ type (
 
 const buildCacheSize = 5
 
-func NewJenkinsUsecase(repository api.Repository) api.Usecase {
+func NewJenkinsUsecase(repository api.Repository, store echoCache.Store, variantName coreModels.VariantName) api.Usecase {
 	return &jenkinsUsecase{
 		repository,
-		cache.NewBuildCache(buildCacheSize),
+		cache.NewBuildCache(store, fmt.Sprintf("%s:%s", api.JenkinsBuildTileType, variantName), buildCacheSize),
 	}
 }
 
-func (tu *jenkinsUsecase) Build(params *models.BuildParams) (*coreModels.Tile, error) {
+func (tu *jenkinsUsecase) Build(ctx context.Context, params *models.BuildParams) (*coreModels.Tile, error) {
 	tile := coreModels.NewTile(api.JenkinsBuildTileType).WithBuild()
 
 	tile.Label, _ = url.QueryUnescape(params.Job)
@@ -44,7 +47,7 @@ func (tu *jenkinsUsecase) Build(params *models.BuildParams) (*coreModels.Tile, e
 		tile.Build.Branch = pointer.ToString(git.HumanizeBranch(branchLabel))
 	}
 
-	job, err := tu.repository.GetJob(params.Job, params.Branch)
+	job, err := tu.repository.GetJob(ctx, params.Job, params.Branch)
 	if err != nil {
 		return nil, &coreModels.MonitororError{Err: err, Tile: tile, Message: "unable to find job"}
 	}
@@ -71,7 +74,7 @@ func (tu *jenkinsUsecase) Build(params *models.BuildParams) (*coreModels.Tile, e
 	}
 
 	// Get Last Build
-	build, err := tu.repository.GetLastBuildStatus(job)
+	build, err := tu.repository.GetLastBuildStatus(ctx, job)
 	if err != nil || build == nil {
 		return nil, &coreModels.MonitororError{Err: err, Tile: tile, Message: "no build found", ErrorStatus: coreModels.UnknownStatus}
 	}
@@ -119,10 +122,10 @@ func (tu *jenkinsUsecase) Build(params *models.BuildParams) (*coreModels.Tile, e
 	return tile, nil
 }
 
-func (tu *jenkinsUsecase) BuildGenerator(params interface{}) ([]uiConfigModels.GeneratedTile, error) {
+func (tu *jenkinsUsecase) BuildGenerator(ctx context.Context, params interface{}) ([]uiConfigModels.GeneratedTile, error) {
 	mbParams := params.(*models.BuildGeneratorParams)
 
-	job, err := tu.repository.GetJob(mbParams.Job, "")
+	job, err := tu.repository.GetJob(ctx, mbParams.Job, "")
 	if err != nil {
 		return nil, &coreModels.MonitororError{Err: err, Message: "unable to find job"}
 	}
@@ -3,6 +3,7 @@
 package usecase
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"time"
@@ -39,7 +40,7 @@ func NewJenkinsUsecase() api.Usecase {
 	return &jenkinsUsecase{cmap.New()}
 }
 
-func (ju *jenkinsUsecase) Build(params *jenkinsModels.BuildParams) (tile *models.Tile, err error) {
+func (ju *jenkinsUsecase) Build(_ context.Context, params *jenkinsModels.BuildParams) (tile *models.Tile, err error) {
 	tile = models.NewTile(api.JenkinsBuildTileType).WithBuild()
 
 	tile.Label = params.Job
@@ -100,7 +101,7 @@ func (ju *jenkinsUsecase) Build(params *jenkinsModels.BuildParams) (tile *models
 	return
 }
 
-func (tu *jenkinsUsecase) BuildGenerator(params interface{}) ([]uiConfigModels.GeneratedTile, error) {
+func (tu *jenkinsUsecase) BuildGenerator(_ context.Context, params interface{}) ([]uiConfigModels.GeneratedTile, error) {
 	panic("unimplemented")
 }
 
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/monitoror/monitoror/pkg/git"
 
 	. "github.com/AlekSi/pointer"
+	echoCache "github.com/jsdidierlaurent/echo-middleware/cache"
 	"github.com/stretchr/testify/assert"
 	. "github.com/stretchr/testify/mock"
 )
@@ -21,12 +23,12 @@ var job, branch = "test", "master"
 
 func TestBuild_Error(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetJob", AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetJob", Anything, AnythingOfType("string"), AnythingOfType("string")).
 		Return(nil, errors.New("boom"))
 
-	tu := NewJenkinsUsecase(mockRepository)
+	tu := NewJenkinsUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 
-	tile, err := tu.Build(&models.BuildParams{Job: job, Branch: branch})
+	tile, err := tu.Build(context.Background(), &models.BuildParams{Job: job, Branch: branch})
 	if assert.Error(t, err) {
 		assert.Nil(t, tile)
 		assert.IsType(t, &coreModels.MonitororError{}, err)
@@ -42,12 +44,12 @@ func TestBuild_DisabledBuild(t *testing.T) {
 	}
 
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetJob", AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetJob", Anything, AnythingOfType("string"), AnythingOfType("string")).
 		Return(repositoryJob, nil)
 
-	tu := NewJenkinsUsecase(mockRepository)
+	tu := NewJenkinsUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 
-	tile, err := tu.Build(&models.BuildParams{Job: job})
+	tile, err := tu.Build(context.Background(), &models.BuildParams{Job: job})
 	if assert.NoError(t, err) {
 		assert.Equal(t, job, tile.Label)
 		assert.Equal(t, coreModels.DisabledStatus, tile.Status)
@@ -62,14 +64,14 @@ func TestBuild_Error_NoBuild(t *testing.T) {
 	}
 
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetJob", AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetJob", Anything, AnythingOfType("string"), AnythingOfType("string")).
 		Return(repositoryJob, nil)
-	mockRepository.On("GetLastBuildStatus", Anything).
+	mockRepository.On("GetLastBuildStatus", Anything, Anything).
 		Return(nil, errors.New("boom"))
 
-	tu := NewJenkinsUsecase(mockRepository)
+	tu := NewJenkinsUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 
-	tile, err := tu.Build(&models.BuildParams{Job: job, Branch: branch})
+	tile, err := tu.Build(context.Background(), &models.BuildParams{Job: job, Branch: branch})
 	if assert.Error(t, err) {
 		assert.Nil(t, tile)
 		assert.IsType(t, &coreModels.MonitororError{}, err)
@@ -88,12 +90,12 @@ func CheckBuild(t *testing.T, result string) {
 	repositoryBuild := buildResponse(result, time.Date(2000, 01, 01, 10, 00, 00, 00, time.UTC), time.Minute)
 
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetJob", AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetJob", Anything, AnythingOfType("string"), AnythingOfType("string")).
 		Return(repositoryJob, nil)
-	mockRepository.On("GetLastBuildStatus", Anything).
+	mockRepository.On("GetLastBuildStatus", Anything, Anything).
 		Return(repositoryBuild, nil)
 
-	tu := NewJenkinsUsecase(mockRepository)
+	tu := NewJenkinsUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 	tUsecase, ok := tu.(*jenkinsUsecase)
 	if assert.True(t, ok, "enable to case tu into travisCIUsecase") {
 		expected := coreModels.NewTile(api.JenkinsBuildTileType).WithBuild()
@@ -116,7 +118,7 @@ func CheckBuild(t *testing.T, result string) {
 		// Add cache for previousStatus
 		params := &models.BuildParams{Job: job, Branch: branch}
 		tUsecase.buildsCache.Add(params, "0", coreModels.SuccessStatus, time.Second*120)
-		tile, err := tu.Build(params)
+		tile, err := tu.Build(context.Background(), params)
 		if assert.NoError(t, err) {
 			assert.Equal(t, expected, tile)
 			mockRepository.AssertNumberOfCalls(t, "GetJob", 1)
@@ -150,10 +152,10 @@ func TestBuild_Queued(t *testing.T) {
 	}
 
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetJob", AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetJob", Anything, AnythingOfType("string"), AnythingOfType("string")).
 		Return(repositoryJob, nil)
 
-	tu := NewJenkinsUsecase(mockRepository)
+	tu := NewJenkinsUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 	tUsecase, ok := tu.(*jenkinsUsecase)
 	if assert.True(t, ok, "enable to case tu into travisCIUsecase") {
 		expected := coreModels.NewTile(api.JenkinsBuildTileType).WithBuild()
@@ -167,7 +169,7 @@ func TestBuild_Queued(t *testing.T) {
 		// Add cache for previousStatus
 		params := &models.BuildParams{Job: job, Branch: branch}
 		tUsecase.buildsCache.Add(params, "0", coreModels.SuccessStatus, time.Second*120)
-		tile, err := tu.Build(params)
+		tile, err := tu.Build(context.Background(), params)
 		if assert.NoError(t, err) {
 			assert.Equal(t, expected, tile)
 			mockRepository.AssertNumberOfCalls(t, "GetJob", 1)
@@ -184,12 +186,12 @@ func TestBuild_Running(t *testing.T) {
 	repositoryBuild.Building = true
 
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetJob", AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetJob", Anything, AnythingOfType("string"), AnythingOfType("string")).
 		Return(repositoryJob, nil)
-	mockRepository.On("GetLastBuildStatus", Anything).
+	mockRepository.On("GetLastBuildStatus", Anything, Anything).
 		Return(repositoryBuild, nil)
 
-	ju := NewJenkinsUsecase(mockRepository)
+	ju := NewJenkinsUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 	jUsecase, ok := ju.(*jenkinsUsecase)
 	if assert.True(t, ok, "enable to case ju into jenkinsUsecase") {
 		// Without cached build
@@ -205,7 +207,7 @@ func TestBuild_Running(t *testing.T) {
 		expected.Build.EstimatedDuration = ToInt64(int64(0))
 
 		params := &models.BuildParams{Job: job, Branch: branch}
-		tile, err := ju.Build(params)
+		tile, err := ju.Build(context.Background(), params)
 		if assert.NoError(t, err) {
 			assert.Equal(t, expected, tile)
 		}
@@ -216,7 +218,7 @@ func TestBuild_Running(t *testing.T) {
 		expected.Build.PreviousStatus = coreModels.SuccessStatus
 		expected.Build.EstimatedDuration = ToInt64(int64(120))
 
-		tile, err = ju.Build(params)
+		tile, err = ju.Build(context.Background(), params)
 		if assert.NoError(t, err) {
 			assert.Equal(t, expected, tile)
 		}
@@ -236,12 +238,12 @@ func TestBuildGenerator_Success(t *testing.T) {
 	}
 
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetJob", AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetJob", Anything, AnythingOfType("string"), AnythingOfType("string")).
 		Return(repositoryJob, nil)
 
-	tu := NewJenkinsUsecase(mockRepository)
+	tu := NewJenkinsUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 
-	tiles, err := tu.BuildGenerator(&models.BuildGeneratorParams{Job: job})
+	tiles, err := tu.BuildGenerator(context.Background(), &models.BuildGeneratorParams{Job: job})
 	if assert.NoError(t, err) {
 		assert.Len(t, tiles, 3)
 		params, ok := tiles[0].Params.(*models.BuildParams)
@@ -261,7 +263,7 @@ func TestBuildGenerator_Success(t *testing.T) {
 		}
 	}
 
-	tiles, err = tu.BuildGenerator(&models.BuildGeneratorParams{Job: job, Match: "feat/*"})
+	tiles, err = tu.BuildGenerator(context.Background(), &models.BuildGeneratorParams{Job: job, Match: "feat/*"})
 	if assert.NoError(t, err) {
 		assert.Len(t, tiles, 1)
 		params, ok := tiles[0].Params.(*models.BuildParams)
@@ -277,12 +279,12 @@ func TestBuildGenerator_Success(t *testing.T) {
 
 func TestBuildGenerator_Error(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetJob", AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetJob", Anything, AnythingOfType("string"), AnythingOfType("string")).
 		Return(nil, errors.New("boom"))
 
-	tu := NewJenkinsUsecase(mockRepository)
+	tu := NewJenkinsUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 
-	_, err := tu.BuildGenerator(&models.BuildGeneratorParams{Job: "test"})
+	_, err := tu.BuildGenerator(context.Background(), &models.BuildGeneratorParams{Job: "test"})
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "unable to find job")
 
@@ -292,16 +294,16 @@ func TestBuildGenerator_Error(t *testing.T) {
 
 func TestBuildGenerator_ErrorWithRegex(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetJob", AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetJob", Anything, AnythingOfType("string"), AnythingOfType("string")).
 		Return(nil, nil)
 
-	tu := NewJenkinsUsecase(mockRepository)
+	tu := NewJenkinsUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 
-	_, err := tu.BuildGenerator(&models.BuildGeneratorParams{Job: "test", Match: "("})
+	_, err := tu.BuildGenerator(context.Background(), &models.BuildGeneratorParams{Job: "test", Match: "("})
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "error parsing regexp")
 
-	_, err = tu.BuildGenerator(&models.BuildGeneratorParams{Job: "test", Unmatch: "("})
+	_, err = tu.BuildGenerator(context.Background(), &models.BuildGeneratorParams{Job: "test", Unmatch: "("})
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "error parsing regexp")
 
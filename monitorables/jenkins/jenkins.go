@@ -3,6 +3,7 @@
 package jenkins
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 
@@ -15,6 +16,7 @@ import (
 	jenkinsRepository "github.com/monitoror/monitoror/monitorables/jenkins/api/repository"
 	jenkinsUsecase "github.com/monitoror/monitoror/monitorables/jenkins/api/usecase"
 	jenkinsConfig "github.com/monitoror/monitoror/monitorables/jenkins/config"
+	"github.com/monitoror/monitoror/service/options"
 	"github.com/monitoror/monitoror/service/registry"
 	"github.com/monitoror/monitoror/service/store"
 )
@@ -38,8 +40,8 @@ func NewMonitorable(store *store.Store) *Monitorable {
 	pkgMonitorable.LoadConfig(&m.config, jenkinsConfig.Default)
 
 	// Register Monitorable Tile in config manager
-	m.buildTileEnabler = store.Registry.RegisterTile(api.JenkinsBuildTileType, versions.MinimalVersion, m.GetVariantNames())
-	m.buildGeneratorEnabler = store.Registry.RegisterGenerator(api.JenkinsBuildTileType, versions.MinimalVersion, m.GetVariantNames())
+	m.buildTileEnabler = store.Registry.RegisterTile(api.JenkinsBuildTileType, versions.MinimalVersion, m.GetVariantNames(), api.JenkinsBuildMinimalRefreshInterval, &jenkinsModels.BuildParams{})
+	m.buildGeneratorEnabler = store.Registry.RegisterGenerator(api.JenkinsBuildTileType, versions.MinimalVersion, m.GetVariantNames(), &jenkinsModels.BuildGeneratorParams{})
 
 	return m
 }
@@ -52,6 +54,10 @@ func (m *Monitorable) GetVariantNames() []coreModels.VariantName {
 	return pkgMonitorable.GetVariants(m.config)
 }
 
+func (m *Monitorable) GetTileTypes() []coreModels.TileType {
+	return []coreModels.TileType{api.JenkinsBuildTileType, coreModels.NewGeneratorTileType(api.JenkinsBuildTileType)}
+}
+
 func (m *Monitorable) Validate(variantName coreModels.VariantName) (bool, error) {
 	conf := m.config[variantName]
 
@@ -72,14 +78,18 @@ func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	conf := m.config[variantName]
 
 	repository := jenkinsRepository.NewJenkinsRepository(conf)
-	usecase := jenkinsUsecase.NewJenkinsUsecase(repository)
+	usecase := jenkinsUsecase.NewJenkinsUsecase(repository, m.store.CacheStore, variantName)
 	delivery := jenkinsDelivery.NewJenkinsDelivery(usecase)
 
 	// EnableTile route to echo
 	routeGroup := m.store.MonitorableRouter.Group("/jenkins", variantName)
-	route := routeGroup.GET("/build", delivery.GetBuild)
+	route := routeGroup.GET("/build", delivery.GetBuild, options.WithUpstreamURL(conf.URL))
 
 	// EnableTile data for config hydration
-	m.buildTileEnabler.Enable(variantName, &jenkinsModels.BuildParams{}, route.Path)
-	m.buildGeneratorEnabler.Enable(variantName, &jenkinsModels.BuildGeneratorParams{}, usecase.BuildGenerator)
+	m.buildTileEnabler.Enable(variantName, route.Path)
+	m.buildGeneratorEnabler.Enable(variantName, usecase.BuildGenerator)
+}
+
+func (m *Monitorable) Diagnose(ctx context.Context, variantName coreModels.VariantName) error {
+	return jenkinsRepository.NewJenkinsRepository(m.config[variantName]).CheckConnection(ctx)
 }
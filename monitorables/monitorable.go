@@ -1,8 +1,15 @@
 package monitorables
 
 import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	configModels "github.com/monitoror/monitoror/api/config/models"
 	"github.com/monitoror/monitoror/cli"
 	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/service/registry"
 	"github.com/monitoror/monitoror/service/store"
 )
 
@@ -13,6 +20,9 @@ type Monitorable interface {
 	//GetVariantNames return variant list extract from config
 	GetVariantNames() []coreModels.VariantName
 
+	//GetTileTypes return tile types registered by monitorable (generators included)
+	GetTileTypes() []coreModels.TileType
+
 	//Validate test if config variant is valid
 	// return false if empty and error if config have an error (ex: wrong url format)
 	Validate(variantName coreModels.VariantName) (bool, error)
@@ -41,20 +51,32 @@ func (m *Manager) EnableMonitorables() {
 	m.store.Cli.PrintMonitorableHeader()
 
 	nonEnabledMonitorableCount := 0
+	monitorableHealths := []coreModels.MonitorableHealth{}
 
 	for _, monitorable := range m.monitorables {
 		var enabledVariants []coreModels.VariantName
 		var erroredVariants []cli.ErroredVariant
+		monitorableHealth := coreModels.MonitorableHealth{
+			Name:             monitorable.GetDisplayName(),
+			EnabledVariants:  []coreModels.VariantName{},
+			ErroredVariants:  []coreModels.ErroredVariant{},
+			DisabledVariants: []coreModels.VariantName{},
+		}
 
 		for _, variantName := range monitorable.GetVariantNames() {
 			valid, err := monitorable.Validate(variantName)
 			if err != nil {
 				erroredVariants = append(erroredVariants, cli.ErroredVariant{VariantName: variantName, Err: err})
+				monitorableHealth.ErroredVariants = append(monitorableHealth.ErroredVariants,
+					coreModels.ErroredVariant{VariantName: variantName, Error: err.Error()})
 			}
 
 			if valid {
 				monitorable.Enable(variantName)
 				enabledVariants = append(enabledVariants, variantName)
+				monitorableHealth.EnabledVariants = append(monitorableHealth.EnabledVariants, variantName)
+			} else if err == nil {
+				monitorableHealth.DisabledVariants = append(monitorableHealth.DisabledVariants, variantName)
 			}
 		}
 
@@ -63,7 +85,106 @@ func (m *Manager) EnableMonitorables() {
 		}
 
 		m.store.Cli.PrintMonitorable(monitorable.GetDisplayName(), enabledVariants, erroredVariants)
+		monitorableHealths = append(monitorableHealths, monitorableHealth)
 	}
 
 	m.store.Cli.PrintMonitorableFooter(m.store.CoreConfig.Env == "production", nonEnabledMonitorableCount)
+	m.store.Health.SetMonitorables(monitorableHealths)
+}
+
+// DescribeMonitorables return registered monitorables with status of their variants and their tiles
+func (m *Manager) DescribeMonitorables() []cli.MonitorableDescription {
+	metadataRegistry, _ := m.store.Registry.(*registry.MetadataRegistry)
+
+	var descriptions []cli.MonitorableDescription
+	for _, monitorable := range m.monitorables {
+		description := cli.MonitorableDescription{DisplayName: monitorable.GetDisplayName()}
+
+		for _, variantName := range sortVariantNames(monitorable.GetVariantNames()) {
+			variantDescription := cli.VariantDescription{VariantName: variantName}
+			valid, err := monitorable.Validate(variantName)
+			variantDescription.Enabled = valid
+			if err != nil {
+				variantDescription.Error = err.Error()
+			}
+			description.Variants = append(description.Variants, variantDescription)
+		}
+
+		tileTypes := monitorable.GetTileTypes()
+		sort.Slice(tileTypes, func(i, j int) bool { return tileTypes[i] < tileTypes[j] })
+
+		for _, tileType := range tileTypes {
+			if tileMetadata, exists := metadataRegistry.TileMetadata[tileType]; exists {
+				description.Tiles = append(description.Tiles, cli.TileDescription{
+					TileType:               tileType,
+					MinimalVersion:         tileMetadata.MinimalVersion,
+					MinimalRefreshInterval: int(tileMetadata.MinimalRefreshInterval / time.Second),
+					Params:                 describeParams(tileMetadata.ParamsValidator),
+				})
+			} else if generatorMetadata, exists := metadataRegistry.GeneratorMetadata[tileType]; exists {
+				description.Tiles = append(description.Tiles, cli.TileDescription{
+					TileType:          tileType,
+					GeneratedTileType: generatorMetadata.GeneratedTileType,
+					MinimalVersion:    generatorMetadata.MinimalVersion,
+					Params:            describeParams(generatorMetadata.GeneratorParamsValidator),
+				})
+			}
+		}
+
+		descriptions = append(descriptions, description)
+	}
+
+	return descriptions
+}
+
+// sortVariantNames sort variant names alphabetically with default variant first
+func sortVariantNames(variantNames []coreModels.VariantName) []coreModels.VariantName {
+	sort.Slice(variantNames, func(i, j int) bool {
+		if variantNames[i] == coreModels.DefaultVariant || variantNames[j] == coreModels.DefaultVariant {
+			return variantNames[i] == coreModels.DefaultVariant && variantNames[j] != coreModels.DefaultVariant
+		}
+		return variantNames[i] < variantNames[j]
+	})
+	return variantNames
+}
+
+// describeParams list exported fields of params struct with their json name
+func describeParams(paramsValidator configModels.ParamsValidator) []cli.ParamDescription {
+	if paramsValidator == nil {
+		return nil
+	}
+	return describeParamsType(reflect.TypeOf(paramsValidator))
+}
+
+// describeParamsType list exported fields of struct type with their json name, embedded structs are inlined
+func describeParamsType(rType reflect.Type) []cli.ParamDescription {
+	for rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+
+	params := []cli.ParamDescription{}
+	if rType.Kind() != reflect.Struct {
+		return params
+	}
+
+	for i := 0; i < rType.NumField(); i++ {
+		field := rType.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+
+		if name == "-" || (field.PkgPath != "" && !field.Anonymous) {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			params = append(params, describeParamsType(field.Type)...)
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		params = append(params, cli.ParamDescription{Name: name, Type: strings.TrimLeft(field.Type.String(), "*")})
+	}
+
+	return params
 }
@@ -3,10 +3,15 @@ package monitorables
 import (
 	"errors"
 	"testing"
+	"time"
 
+	configModels "github.com/monitoror/monitoror/api/config/models"
+	"github.com/monitoror/monitoror/cli"
 	cliMocks "github.com/monitoror/monitoror/cli/mocks"
 	"github.com/monitoror/monitoror/config"
 	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/service/health"
+	"github.com/monitoror/monitoror/service/registry"
 	"github.com/monitoror/monitoror/service/store"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -15,12 +20,14 @@ import (
 type monitorableMock struct {
 	displayName   string
 	variants      []coreModels.VariantName
+	tileTypes     []coreModels.TileType
 	validateBool  bool
 	validateError error
 }
 
 func (m *monitorableMock) GetDisplayName() string                    { return m.displayName }
 func (m *monitorableMock) GetVariantNames() []coreModels.VariantName { return m.variants }
+func (m *monitorableMock) GetTileTypes() []coreModels.TileType       { return m.tileTypes }
 func (m *monitorableMock) Validate(_ coreModels.VariantName) (bool, error) {
 	return m.validateBool, m.validateError
 }
@@ -52,11 +59,13 @@ func TestManager_EnableMonitorables(t *testing.T) {
 		validateError: errors.New("boom"),
 	}
 
+	monitorableHealth := health.NewHealth()
 	manager := NewMonitorableManager(&store.Store{
 		CoreConfig: &config.Config{
 			Env: "production",
 		},
-		Cli: cliMock,
+		Cli:    cliMock,
+		Health: monitorableHealth,
 	})
 
 	manager.register(mockMonitorable1)
@@ -71,6 +80,15 @@ func TestManager_EnableMonitorables(t *testing.T) {
 	cliMock.AssertNumberOfCalls(t, "PrintMonitorableFooter", 1)
 	cliMock.AssertCalled(t, "PrintMonitorableFooter", true, 0)
 
+	readiness := monitorableHealth.GetReadiness()
+	assert.Equal(t, coreModels.HealthStatusDown, readiness.Status)
+	if assert.Len(t, readiness.Monitorables, 2) {
+		assert.Equal(t, "Monitorable mock 1", readiness.Monitorables[0].Name)
+		assert.Equal(t, []coreModels.VariantName{coreModels.DefaultVariant}, readiness.Monitorables[0].EnabledVariants)
+		assert.Empty(t, readiness.Monitorables[0].ErroredVariants)
+		assert.Equal(t, []coreModels.ErroredVariant{{VariantName: coreModels.DefaultVariant, Error: "boom"}}, readiness.Monitorables[1].ErroredVariants)
+	}
+
 	// Count non-enabled monitorables
 	mockMonitorable3 := &monitorableMock{
 		displayName:   "Monitorable mock 3",
@@ -83,4 +101,88 @@ func TestManager_EnableMonitorables(t *testing.T) {
 
 	manager.EnableMonitorables()
 	cliMock.AssertCalled(t, "PrintMonitorableFooter", true, 1)
+	if readiness := monitorableHealth.GetReadiness(); assert.Len(t, readiness.Monitorables, 3) {
+		assert.Empty(t, readiness.Monitorables[2].EnabledVariants)
+		assert.Empty(t, readiness.Monitorables[2].DisabledVariants)
+	}
+}
+
+type (
+	describedParams struct {
+		embeddedParams
+		Hostname string `json:"hostname"`
+		Port     *int   `json:"port,omitempty"`
+		Ignored  string `json:"-"`
+		private  string
+	}
+
+	embeddedParams struct {
+		Label string `json:"label"`
+	}
+)
+
+func (p *describedParams) Validate(_ *configModels.ConfigVersion) []configModels.ConfigError {
+	return nil
+}
+
+func (p *embeddedParams) Validate(_ *configModels.ConfigVersion) []configModels.ConfigError {
+	return nil
+}
+
+func TestManager_DescribeMonitorables(t *testing.T) {
+	metadataRegistry := registry.NewRegistry()
+	manager := NewMonitorableManager(&store.Store{Registry: metadataRegistry})
+
+	// Monitorable 1 register 1 tile and 1 generator with default variant enabled
+	enabler := metadataRegistry.RegisterTile("TEST", "2.0", []coreModels.VariantName{coreModels.DefaultVariant, "variant1"}, 10*time.Second, &describedParams{})
+	enabler.Enable(coreModels.DefaultVariant, "/test")
+	metadataRegistry.RegisterGenerator("TEST", "2.2", []coreModels.VariantName{coreModels.DefaultVariant}, nil)
+	manager.register(&monitorableMock{
+		displayName:   "Monitorable mock 1",
+		variants:      []coreModels.VariantName{"variant1", coreModels.DefaultVariant},
+		tileTypes:     []coreModels.TileType{"TEST", "GENERATE:TEST"},
+		validateBool:  true,
+		validateError: nil,
+	})
+
+	// Monitorable 2 register 1 tile without enabled variant, params are listed anyway
+	metadataRegistry.RegisterTile("TEST2", "2.0", []coreModels.VariantName{coreModels.DefaultVariant}, 0, &embeddedParams{})
+	manager.register(&monitorableMock{
+		displayName:   "Monitorable mock 2",
+		variants:      []coreModels.VariantName{coreModels.DefaultVariant},
+		tileTypes:     []coreModels.TileType{"TEST2"},
+		validateBool:  false,
+		validateError: errors.New("boom"),
+	})
+
+	expected := []cli.MonitorableDescription{
+		{
+			DisplayName: "Monitorable mock 1",
+			Variants: []cli.VariantDescription{
+				{VariantName: coreModels.DefaultVariant, Enabled: true},
+				{VariantName: "variant1", Enabled: true},
+			},
+			Tiles: []cli.TileDescription{
+				{TileType: "GENERATE:TEST", GeneratedTileType: "TEST", MinimalVersion: "2.2"},
+				{
+					TileType:               "TEST",
+					MinimalVersion:         "2.0",
+					MinimalRefreshInterval: 10,
+					Params: []cli.ParamDescription{
+						{Name: "label", Type: "string"},
+						{Name: "hostname", Type: "string"},
+						{Name: "port", Type: "int"},
+					},
+				},
+			},
+		},
+		{
+			DisplayName: "Monitorable mock 2",
+			Variants:    []cli.VariantDescription{{VariantName: coreModels.DefaultVariant, Error: "boom"}},
+			Tiles: []cli.TileDescription{
+				{TileType: "TEST2", MinimalVersion: "2.0", Params: []cli.ParamDescription{{Name: "label", Type: "string"}}},
+			},
+		},
+	}
+	assert.Equal(t, expected, manager.DescribeMonitorables())
 }
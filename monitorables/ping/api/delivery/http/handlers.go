@@ -25,7 +25,7 @@ func (h *PingDelivery) GetPing(c echo.Context) error {
 		return err
 	}
 
-	tile, err := h.pingUsecase.Ping(params)
+	tile, err := h.pingUsecase.Ping(c.Request().Context(), params)
 	if err != nil {
 		return err
 	}
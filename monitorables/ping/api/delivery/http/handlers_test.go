@@ -37,7 +37,7 @@ func TestDelivery_PingHandler_Success(t *testing.T) {
 	tile.Status = coreModels.SuccessStatus
 
 	mockUsecase := new(mocks.Usecase)
-	mockUsecase.On("Ping", &models.PingParams{Hostname: "monitoror.example.com"}).Return(tile, nil)
+	mockUsecase.On("Ping", Anything, &models.PingParams{Hostname: "monitoror.example.com"}).Return(tile, nil)
 	handler := NewPingDelivery(mockUsecase)
 
 	// Expected
@@ -72,7 +72,7 @@ func TestDelivery_PingHandler_Error(t *testing.T) {
 	ctx, _ := initEcho()
 
 	mockUsecase := new(mocks.Usecase)
-	mockUsecase.On("Ping", Anything).Return(nil, errors.New("ping error"))
+	mockUsecase.On("Ping", Anything, Anything).Return(nil, errors.New("ping error"))
 	handler := NewPingDelivery(mockUsecase)
 
 	// Test
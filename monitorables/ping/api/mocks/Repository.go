@@ -3,8 +3,11 @@
 package mocks
 
 import (
-	models "github.com/monitoror/monitoror/monitorables/ping/api/models"
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/monitoror/monitoror/monitorables/ping/api/models"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -12,13 +15,13 @@ type Repository struct {
 	mock.Mock
 }
 
-// ExecutePing provides a mock function with given fields: hostname
-func (_m *Repository) ExecutePing(hostname string) (*models.Ping, error) {
-	ret := _m.Called(hostname)
+// ExecutePing provides a mock function with given fields: ctx, hostname
+func (_m *Repository) ExecutePing(ctx context.Context, hostname string) (*models.Ping, error) {
+	ret := _m.Called(ctx, hostname)
 
 	var r0 *models.Ping
-	if rf, ok := ret.Get(0).(func(string) *models.Ping); ok {
-		r0 = rf(hostname)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Ping); ok {
+		r0 = rf(ctx, hostname)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Ping)
@@ -26,8 +29,8 @@ func (_m *Repository) ExecutePing(hostname string) (*models.Ping, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(hostname)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hostname)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	monitorormodels "github.com/monitoror/monitoror/models"
 	models "github.com/monitoror/monitoror/monitorables/ping/api/models"
 	mock "github.com/stretchr/testify/mock"
@@ -13,13 +15,13 @@ type Usecase struct {
 	mock.Mock
 }
 
-// Ping provides a mock function with given fields: params
-func (_m *Usecase) Ping(params *models.PingParams) (*monitorormodels.Tile, error) {
-	ret := _m.Called(params)
+// Ping provides a mock function with given fields: ctx, params
+func (_m *Usecase) Ping(ctx context.Context, params *models.PingParams) (*monitorormodels.Tile, error) {
+	ret := _m.Called(ctx, params)
 
 	var r0 *monitorormodels.Tile
-	if rf, ok := ret.Get(0).(func(*models.PingParams) *monitorormodels.Tile); ok {
-		r0 = rf(params)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PingParams) *monitorormodels.Tile); ok {
+		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*monitorormodels.Tile)
@@ -27,8 +29,8 @@ func (_m *Usecase) Ping(params *models.PingParams) (*monitorormodels.Tile, error
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*models.PingParams) error); ok {
-		r1 = rf(params)
+	if rf, ok := ret.Get(1).(func(context.Context, *models.PingParams) error); ok {
+		r1 = rf(ctx, params)
 	} else {
 		r1 = ret.Error(1)
 	}
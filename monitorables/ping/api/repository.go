@@ -3,11 +3,13 @@
 package api
 
 import (
+	"context"
+
 	"github.com/monitoror/monitoror/monitorables/ping/api/models"
 )
 
 type (
 	Repository interface {
-		ExecutePing(hostname string) (*models.Ping, error)
+		ExecutePing(ctx context.Context, hostname string) (*models.Ping, error)
 	}
 )
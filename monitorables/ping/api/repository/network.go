@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"time"
 
@@ -21,7 +22,7 @@ func NewPingRepository(config *config.Ping) api.Repository {
 	return &pingRepository{config}
 }
 
-func (r *pingRepository) ExecutePing(hostname string) (*models.Ping, error) {
+func (r *pingRepository) ExecutePing(ctx context.Context, hostname string) (*models.Ping, error) {
 	pinger, err := goPing.NewPinger(hostname)
 	if err != nil {
 		return nil, err
@@ -32,7 +33,19 @@ func (r *pingRepository) ExecutePing(hostname string) (*models.Ping, error) {
 	pinger.Timeout = time.Millisecond * time.Duration(r.config.Timeout)
 	pinger.SetPrivileged(true) // NEED ROOT PRIVILEGED
 
-	pinger.Run()
+	finished := make(chan struct{})
+	go func() {
+		pinger.Run()
+		close(finished)
+	}()
+
+	select {
+	case <-ctx.Done():
+		// Pinger.Stop can panic when ping already ended, pinger will stop by itself at timeout
+		return nil, ctx.Err()
+	case <-finished:
+	}
+
 	stats := pinger.Statistics()
 
 	if stats.PacketsRecv == 0 {
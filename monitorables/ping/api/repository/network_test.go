@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -15,7 +16,7 @@ import (
 func TestRepository_Ping_Error(t *testing.T) {
 	pingRepository := NewPingRepository(pingConfig.Default)
 
-	ping, err := pingRepository.ExecutePing("0.0.0.0")
+	ping, err := pingRepository.ExecutePing(context.Background(), "0.0.0.0")
 
 	// I can't mock ping library, so i just test this repository
 	if system.IsRawSocketAvailable() {
@@ -3,16 +3,21 @@
 package api
 
 import (
+	"context"
+	"time"
+
 	coreModels "github.com/monitoror/monitoror/models"
 	"github.com/monitoror/monitoror/monitorables/ping/api/models"
 )
 
 const (
 	PingTileType coreModels.TileType = "PING"
+
+	PingMinimalRefreshInterval = 5 * time.Second
 )
 
 type (
 	Usecase interface {
-		Ping(params *models.PingParams) (*coreModels.Tile, error)
+		Ping(ctx context.Context, params *models.PingParams) (*coreModels.Tile, error)
 	}
 )
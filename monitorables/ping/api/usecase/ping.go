@@ -3,6 +3,7 @@
 package usecase
 
 import (
+	"context"
 	"fmt"
 
 	coreModels "github.com/monitoror/monitoror/models"
@@ -20,11 +21,11 @@ func NewPingUsecase(repository api.Repository) api.Usecase {
 	return &pingUsecase{repository}
 }
 
-func (pu *pingUsecase) Ping(params *models.PingParams) (tile *coreModels.Tile, err error) {
+func (pu *pingUsecase) Ping(ctx context.Context, params *models.PingParams) (tile *coreModels.Tile, err error) {
 	tile = coreModels.NewTile(api.PingTileType)
 	tile.Label = params.Hostname
 
-	ping, err := pu.repository.ExecutePing(params.Hostname)
+	ping, err := pu.repository.ExecutePing(ctx, params.Hostname)
 	if err == nil {
 		tile.Status = coreModels.SuccessStatus
 		tile.WithValue(coreModels.MillisecondUnit)
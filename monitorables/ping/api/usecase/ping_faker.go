@@ -3,6 +3,7 @@
 package usecase
 
 import (
+	"context"
 	"fmt"
 	"math/rand"
 	"time"
@@ -29,7 +30,7 @@ func NewPingUsecase() api.Usecase {
 	return &pingUsecase{make(map[string]time.Time)}
 }
 
-func (pu *pingUsecase) Ping(params *models.PingParams) (tile *coreModels.Tile, err error) {
+func (pu *pingUsecase) Ping(_ context.Context, params *models.PingParams) (tile *coreModels.Tile, err error) {
 	tile = coreModels.NewTile(api.PingTileType)
 	tile.Label = params.Hostname
 
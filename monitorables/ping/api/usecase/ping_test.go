@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -17,7 +18,7 @@ import (
 func TestUsecase_Ping_Success(t *testing.T) {
 	// Init
 	mockRepo := new(mocks.Repository)
-	mockRepo.On("ExecutePing", AnythingOfType("string")).Return(&models.Ping{
+	mockRepo.On("ExecutePing", Anything, AnythingOfType("string")).Return(&models.Ping{
 		Average: time.Second,
 		Min:     time.Second,
 		Max:     time.Second,
@@ -36,7 +37,7 @@ func TestUsecase_Ping_Success(t *testing.T) {
 	eTile.Value.Values = append(eTile.Value.Values, "1000")
 
 	// Test
-	rTile, err := usecase.Ping(param)
+	rTile, err := usecase.Ping(context.Background(), param)
 
 	if assert.NoError(t, err) {
 		assert.Equal(t, eTile, rTile)
@@ -48,7 +49,7 @@ func TestUsecase_Ping_Success(t *testing.T) {
 func TestUsecase_Ping_Fail(t *testing.T) {
 	// Init
 	mockRepo := new(mocks.Repository)
-	mockRepo.On("ExecutePing", AnythingOfType("string")).Return(nil, errors.New("ping error"))
+	mockRepo.On("ExecutePing", Anything, AnythingOfType("string")).Return(nil, errors.New("ping error"))
 
 	usecase := NewPingUsecase(mockRepo)
 
@@ -63,7 +64,7 @@ func TestUsecase_Ping_Fail(t *testing.T) {
 	eTile.Status = coreModels.FailedStatus
 
 	// Test
-	rTile, err := usecase.Ping(param)
+	rTile, err := usecase.Ping(context.Background(), param)
 
 	if assert.NoError(t, err) {
 		assert.Equal(t, eTile, rTile)
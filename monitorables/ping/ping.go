@@ -35,7 +35,7 @@ func NewMonitorable(store *store.Store) *Monitorable {
 	pkgMonitorable.LoadConfig(&m.config, pingConfig.Default)
 
 	// Register Monitorable Tile in config manager
-	m.pingTileEnabler = store.Registry.RegisterTile(api.PingTileType, versions.MinimalVersion, m.GetVariantNames())
+	m.pingTileEnabler = store.Registry.RegisterTile(api.PingTileType, versions.MinimalVersion, m.GetVariantNames(), api.PingMinimalRefreshInterval, &pingModels.PingParams{})
 
 	return m
 }
@@ -48,6 +48,10 @@ func (m *Monitorable) GetVariantNames() []coreModels.VariantName {
 	return pkgMonitorable.GetVariants(m.config)
 }
 
+func (m *Monitorable) GetTileTypes() []coreModels.TileType {
+	return []coreModels.TileType{api.PingTileType}
+}
+
 func (m *Monitorable) Validate(_ coreModels.VariantName) (bool, error) {
 	return system.IsRawSocketAvailable(), nil
 }
@@ -64,5 +68,5 @@ func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	route := routeGroup.GET("/ping", delivery.GetPing)
 
 	// EnableTile data for config hydration
-	m.pingTileEnabler.Enable(variantName, &pingModels.PingParams{}, route.Path)
+	m.pingTileEnabler.Enable(variantName, route.Path)
 }
@@ -28,13 +28,17 @@ func NewMonitorable(store *store.Store) *Monitorable {
 	m.store = store
 
 	// Register Monitorable Tile in config manager
-	m.pingTileEnabler = store.Registry.RegisterTile(api.PingTileType, versions.MinimalVersion, m.GetVariantNames())
+	m.pingTileEnabler = store.Registry.RegisterTile(api.PingTileType, versions.MinimalVersion, m.GetVariantNames(), api.PingMinimalRefreshInterval, &pingModels.PingParams{})
 
 	return m
 }
 
 func (m *Monitorable) GetDisplayName() string { return "Ping (faker)" }
 
+func (m *Monitorable) GetTileTypes() []coreModels.TileType {
+	return []coreModels.TileType{api.PingTileType}
+}
+
 func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	usecase := pingUsecase.NewPingUsecase()
 	delivery := pingDelivery.NewPingDelivery(usecase)
@@ -44,5 +48,5 @@ func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	route := routeGroup.GET("/ping", delivery.GetPing)
 
 	// EnableTile data for config hydration
-	m.pingTileEnabler.Enable(variantName, &pingModels.PingParams{}, route.Path)
+	m.pingTileEnabler.Enable(variantName, route.Path)
 }
@@ -25,7 +25,7 @@ func (h *PingdomDelivery) GetCheck(c echo.Context) error {
 		return err
 	}
 
-	tile, err := h.pingdomUsecase.Check(params)
+	tile, err := h.pingdomUsecase.Check(c.Request().Context(), params)
 	if err != nil {
 		return err
 	}
@@ -40,7 +40,7 @@ func TestDelivery_GetCheck_Success(t *testing.T) {
 	tile.Status = coreModels.SuccessStatus
 
 	mockUsecase := new(mocks.Usecase)
-	mockUsecase.On("Check", &models.CheckParams{ID: pointer.ToInt(123456)}).Return(tile, nil)
+	mockUsecase.On("Check", Anything, &models.CheckParams{ID: pointer.ToInt(123456)}).Return(tile, nil)
 	handler := NewPingdomDelivery(mockUsecase)
 
 	// Expected
@@ -75,7 +75,7 @@ func TestDelivery_GetCheck_Error(t *testing.T) {
 	ctx, _ := initEcho()
 
 	mockUsecase := new(mocks.Usecase)
-	mockUsecase.On("Check", Anything).Return(nil, errors.New("boom"))
+	mockUsecase.On("Check", Anything, Anything).Return(nil, errors.New("boom"))
 	handler := NewPingdomDelivery(mockUsecase)
 
 	// Test
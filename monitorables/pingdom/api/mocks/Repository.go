@@ -3,8 +3,11 @@
 package mocks
 
 import (
-	models "github.com/monitoror/monitoror/monitorables/pingdom/api/models"
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/monitoror/monitoror/monitorables/pingdom/api/models"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -12,13 +15,27 @@ type Repository struct {
 	mock.Mock
 }
 
-// GetCheck provides a mock function with given fields: checkID
-func (_m *Repository) GetCheck(checkID int) (*models.Check, error) {
-	ret := _m.Called(checkID)
+// CheckConnection provides a mock function with given fields: ctx
+func (_m *Repository) CheckConnection(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetCheck provides a mock function with given fields: ctx, checkID
+func (_m *Repository) GetCheck(ctx context.Context, checkID int) (*models.Check, error) {
+	ret := _m.Called(ctx, checkID)
 
 	var r0 *models.Check
-	if rf, ok := ret.Get(0).(func(int) *models.Check); ok {
-		r0 = rf(checkID)
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.Check); ok {
+		r0 = rf(ctx, checkID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Check)
@@ -26,8 +43,8 @@ func (_m *Repository) GetCheck(checkID int) (*models.Check, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int) error); ok {
-		r1 = rf(checkID)
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, checkID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -35,13 +52,13 @@ func (_m *Repository) GetCheck(checkID int) (*models.Check, error) {
 	return r0, r1
 }
 
-// GetChecks provides a mock function with given fields: tags
-func (_m *Repository) GetChecks(tags string) ([]models.Check, error) {
-	ret := _m.Called(tags)
+// GetChecks provides a mock function with given fields: ctx, tags
+func (_m *Repository) GetChecks(ctx context.Context, tags string) ([]models.Check, error) {
+	ret := _m.Called(ctx, tags)
 
 	var r0 []models.Check
-	if rf, ok := ret.Get(0).(func(string) []models.Check); ok {
-		r0 = rf(tags)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.Check); ok {
+		r0 = rf(ctx, tags)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Check)
@@ -49,8 +66,8 @@ func (_m *Repository) GetChecks(tags string) ([]models.Check, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(tags)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tags)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -11,12 +11,12 @@ type (
 	}
 )
 
-func (p *CheckGeneratorParams) Validate(_ *uiConfigModels.ConfigVersion) *uiConfigModels.ConfigError {
-	// TODO
+func (p *CheckGeneratorParams) Validate(_ *uiConfigModels.ConfigVersion) []uiConfigModels.ConfigError {
+	var errors []uiConfigModels.ConfigError
 
 	if p.SortBy != "" && p.SortBy != "name" {
-		return &uiConfigModels.ConfigError{}
+		errors = append(errors, uiConfigModels.NewInvalidParamError("sortBy", p.SortBy, `"name"`))
 	}
 
-	return nil
+	return errors
 }
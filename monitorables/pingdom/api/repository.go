@@ -3,12 +3,15 @@
 package api
 
 import (
+	"context"
+
 	"github.com/monitoror/monitoror/monitorables/pingdom/api/models"
 )
 
 type (
 	Repository interface {
-		GetCheck(checkID int) (*models.Check, error)
-		GetChecks(tags string) ([]models.Check, error)
+		CheckConnection(ctx context.Context) error
+		GetCheck(ctx context.Context, checkID int) (*models.Check, error)
+		GetChecks(ctx context.Context, tags string) ([]models.Check, error)
 	}
 )
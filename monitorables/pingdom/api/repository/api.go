@@ -1,15 +1,18 @@
 package repository
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
+	coreModels "github.com/monitoror/monitoror/models"
 	"github.com/monitoror/monitoror/monitorables/pingdom/api"
 	"github.com/monitoror/monitoror/monitorables/pingdom/api/models"
 	"github.com/monitoror/monitoror/monitorables/pingdom/config"
 	"github.com/monitoror/monitoror/pkg/gopingdom"
+	pkgNet "github.com/monitoror/monitoror/pkg/net"
 
 	pingdomAPI "github.com/jsdidierlaurent/go-pingdom/pingdom"
 )
@@ -18,8 +21,8 @@ type (
 	pingdomRepository struct {
 		config *config.Pingdom
 
-		// Pingdom check client
-		pingdomCheckAPI gopingdom.PingdomCheckAPI
+		// Create Pingdom check client sending requests with given context (Pingdom client doesn't support context)
+		pingdomCheckAPI func(ctx context.Context) gopingdom.PingdomCheckAPI
 	}
 )
 
@@ -27,27 +30,49 @@ func NewPingdomRepository(config *config.Pingdom) api.Repository {
 	// Remove last /
 	config.URL = strings.TrimRight(config.URL, "/")
 
-	client, err := pingdomAPI.NewClientWithConfig(pingdomAPI.ClientConfig{
-		BaseURL:  config.URL,
-		APIToken: config.Token,
-		HTTPClient: &http.Client{
-			Timeout: time.Millisecond * time.Duration(config.Timeout),
-		},
-	})
+	newClient := func(httpClient *http.Client) (*pingdomAPI.Client, error) {
+		return pingdomAPI.NewClientWithConfig(pingdomAPI.ClientConfig{
+			BaseURL:    config.URL,
+			APIToken:   config.Token,
+			HTTPClient: httpClient,
+		})
+	}
 
 	// Only if Pingdom URL is not a valid URL
-	if err != nil {
+	if _, err := newClient(http.DefaultClient); err != nil {
 		panic(fmt.Sprintf("unable to initiate connection to Pingdom\n. %v\n", err))
 	}
 
 	return &pingdomRepository{
-		config:          config,
-		pingdomCheckAPI: client.Checks,
+		config: config,
+		pingdomCheckAPI: func(ctx context.Context) gopingdom.PingdomCheckAPI {
+			client, _ := newClient(&http.Client{Transport: pkgNet.NewContextTransport(ctx, http.DefaultTransport)})
+			return client.Checks
+		},
 	}
 }
 
-func (r *pingdomRepository) GetCheck(id int) (result *models.Check, err error) {
-	check, err := r.pingdomCheckAPI.Read(id)
+// CheckConnection list checks to check URL and token
+func (r *pingdomRepository) CheckConnection(ctx context.Context) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	_, err := r.pingdomCheckAPI(ctx).List()
+
+	if pingdomError, ok := err.(*pingdomAPI.PingdomError); ok {
+		if pingdomError.StatusCode == http.StatusUnauthorized || pingdomError.StatusCode == http.StatusForbidden {
+			return fmt.Errorf("%w: %s", coreModels.UnauthorizedError, pingdomError.Message)
+		}
+	}
+
+	return err
+}
+
+func (r *pingdomRepository) GetCheck(ctx context.Context, id int) (result *models.Check, err error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	check, err := r.pingdomCheckAPI(ctx).Read(id)
 	if err != nil {
 		return
 	}
@@ -61,13 +86,16 @@ func (r *pingdomRepository) GetCheck(id int) (result *models.Check, err error) {
 	return
 }
 
-func (r *pingdomRepository) GetChecks(tags string) (results []models.Check, err error) {
+func (r *pingdomRepository) GetChecks(ctx context.Context, tags string) (results []models.Check, err error) {
 	params := make(map[string]string)
 	if tags != "" {
 		params["tags"] = tags
 	}
 
-	checks, err := r.pingdomCheckAPI.List(params)
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	checks, err := r.pingdomCheckAPI(ctx).List(params)
 	if err != nil {
 		return
 	}
@@ -82,3 +110,8 @@ func (r *pingdomRepository) GetChecks(tags string) (results []models.Check, err
 
 	return
 }
+
+// withTimeout add configured timeout to ctx, http client timeout is lost when request context is replaced
+func (r *pingdomRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, time.Millisecond*time.Duration(r.config.Timeout))
+}
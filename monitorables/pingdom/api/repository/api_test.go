@@ -1,9 +1,11 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"testing"
 
+	coreModels "github.com/monitoror/monitoror/models"
 	"github.com/monitoror/monitoror/monitorables/pingdom/config"
 	pkgPingdom "github.com/monitoror/monitoror/pkg/gopingdom"
 	"github.com/monitoror/monitoror/pkg/gopingdom/mocks"
@@ -26,7 +28,7 @@ func initRepository(t *testing.T, checkAPI pkgPingdom.PingdomCheckAPI) *pingdomR
 
 	apiPingdomRepository, ok := repository.(*pingdomRepository)
 	if assert.True(t, ok) {
-		apiPingdomRepository.pingdomCheckAPI = checkAPI
+		apiPingdomRepository.pingdomCheckAPI = func(context.Context) pkgPingdom.PingdomCheckAPI { return checkAPI }
 		return apiPingdomRepository
 	}
 	return nil
@@ -43,12 +45,48 @@ func TestPingdomRepository_NewPingdomRepository_Error(t *testing.T) {
 	assert.Panics(t, func() { _ = NewPingdomRepository(conf) })
 }
 
+func TestPingdomRepository_CheckConnection_Success(t *testing.T) {
+	mock := new(mocks.PingdomCheckAPI)
+	mock.On("List").Return([]pingdom.CheckResponse{}, nil)
+
+	repository := initRepository(t, mock)
+	if repository != nil {
+		assert.NoError(t, repository.CheckConnection(context.Background()))
+		mock.AssertNumberOfCalls(t, "List", 1)
+		mock.AssertExpectations(t)
+	}
+}
+
+func TestPingdomRepository_CheckConnection_Error(t *testing.T) {
+	for _, testcase := range []struct {
+		err          error
+		unauthorized bool
+	}{
+		{err: errors.New("pingdom error")},
+		{err: &pingdom.PingdomError{StatusCode: 500, StatusDesc: "Internal Server Error"}},
+		{err: &pingdom.PingdomError{StatusCode: 401, StatusDesc: "Unauthorized", Message: "Invalid token"}, unauthorized: true},
+	} {
+		mock := new(mocks.PingdomCheckAPI)
+		mock.On("List").Return(nil, testcase.err)
+
+		repository := initRepository(t, mock)
+		if repository != nil {
+			err := repository.CheckConnection(context.Background())
+			if assert.Error(t, err) {
+				assert.Equal(t, testcase.unauthorized, errors.Is(err, coreModels.UnauthorizedError))
+				mock.AssertNumberOfCalls(t, "List", 1)
+				mock.AssertExpectations(t)
+			}
+		}
+	}
+}
+
 func TestPingdomRepository_GetPingdomCheck_Success(t *testing.T) {
 	mock := new(mocks.PingdomCheckAPI)
 	mock.On("Read", Anything).Return(&pingdom.CheckResponse{ID: 1000, Name: "Check 1", Status: "up"}, nil)
 
 	repository := initRepository(t, mock)
-	check, err := repository.GetCheck(1000)
+	check, err := repository.GetCheck(context.Background(), 1000)
 	if assert.NoError(t, err) {
 		assert.Equal(t, "Check 1", check.Name)
 		assert.Equal(t, "up", check.Status)
@@ -63,7 +101,7 @@ func TestPingdomRepository_GetPingdomCheck_Error(t *testing.T) {
 	mock.On("Read", Anything).Return(nil, errors.New("boom"))
 
 	repository := initRepository(t, mock)
-	_, err := repository.GetCheck(1000)
+	_, err := repository.GetCheck(context.Background(), 1000)
 	assert.Error(t, err)
 	mock.AssertNumberOfCalls(t, "Read", 1)
 	mock.AssertExpectations(t)
@@ -78,7 +116,7 @@ func TestPingdomRepository_GetPingdomChecks_Success(t *testing.T) {
 	}, nil)
 
 	repository := initRepository(t, mock)
-	checks, err := repository.GetChecks("tests")
+	checks, err := repository.GetChecks(context.Background(), "tests")
 	if assert.NoError(t, err) {
 		assert.Len(t, checks, 3)
 	}
@@ -92,7 +130,7 @@ func TestPingdomRepository_GetPingdomChecks_Error(t *testing.T) {
 	mock.On("List", Anything).Return(nil, errors.New("boom"))
 
 	repository := initRepository(t, mock)
-	_, err := repository.GetChecks("")
+	_, err := repository.GetChecks(context.Background(), "")
 	assert.Error(t, err)
 	mock.AssertNumberOfCalls(t, "List", 1)
 	mock.AssertExpectations(t)
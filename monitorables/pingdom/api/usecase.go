@@ -3,6 +3,9 @@
 package api
 
 import (
+	"context"
+	"time"
+
 	uiConfigModels "github.com/monitoror/monitoror/api/config/models"
 	coreModels "github.com/monitoror/monitoror/models"
 	"github.com/monitoror/monitoror/monitorables/pingdom/api/models"
@@ -10,11 +13,13 @@ import (
 
 const (
 	PingdomCheckTileType coreModels.TileType = "PINGDOM-CHECK"
+
+	PingdomCheckMinimalRefreshInterval = 30 * time.Second
 )
 
 type (
 	Usecase interface {
-		Check(params *models.CheckParams) (*coreModels.Tile, error)
-		CheckGenerator(params interface{}) ([]uiConfigModels.GeneratedTile, error)
+		Check(ctx context.Context, params *models.CheckParams) (*coreModels.Tile, error)
+		CheckGenerator(ctx context.Context, params interface{}) ([]uiConfigModels.GeneratedTile, error)
 	}
 )
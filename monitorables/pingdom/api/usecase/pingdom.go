@@ -3,6 +3,7 @@
 package usecase
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"sync"
@@ -52,7 +53,7 @@ func NewPingdomUsecase(repository api.Repository, store cache.Store, cacheExpira
 	}
 }
 
-func (pu *pingdomUsecase) Check(params *models.CheckParams) (*coreModels.Tile, error) {
+func (pu *pingdomUsecase) Check(ctx context.Context, params *models.CheckParams) (*coreModels.Tile, error) {
 	tile := coreModels.NewTile(api.PingdomCheckTileType)
 
 	checkID := *params.ID
@@ -61,7 +62,7 @@ func (pu *pingdomUsecase) Check(params *models.CheckParams) (*coreModels.Tile, e
 
 	// Lookup in store for bulk query for this ID, if found, use it
 	if err := pu.store.Get(pu.getTagsByIDStoreKey(checkID), &tags); err == nil {
-		checks, err := pu.loadChecks(tags)
+		checks, err := pu.loadChecks(ctx, tags)
 		if err != nil {
 			return nil, &coreModels.MonitororError{Err: err, Tile: tile, Message: "unable to find checks"}
 		}
@@ -75,7 +76,7 @@ func (pu *pingdomUsecase) Check(params *models.CheckParams) (*coreModels.Tile, e
 		}
 	} else // Bulk not found, request single check
 	{
-		check, err := pu.loadCheck(checkID)
+		check, err := pu.loadCheck(ctx, checkID)
 		if err != nil {
 			return nil, &coreModels.MonitororError{Err: err, Tile: tile, Message: "unable to find check"}
 		}
@@ -89,10 +90,10 @@ func (pu *pingdomUsecase) Check(params *models.CheckParams) (*coreModels.Tile, e
 	return tile, nil
 }
 
-func (pu *pingdomUsecase) CheckGenerator(params interface{}) ([]uiConfigModels.GeneratedTile, error) {
+func (pu *pingdomUsecase) CheckGenerator(ctx context.Context, params interface{}) ([]uiConfigModels.GeneratedTile, error) {
 	lcParams := params.(*models.CheckGeneratorParams)
 
-	checks, err := pu.loadChecks(lcParams.Tags)
+	checks, err := pu.loadChecks(ctx, lcParams.Tags)
 	if err != nil {
 		return nil, &coreModels.MonitororError{Err: err, Message: "unable to list checks"}
 	}
@@ -122,7 +123,7 @@ func (pu *pingdomUsecase) CheckGenerator(params interface{}) ([]uiConfigModels.G
 	return results, err
 }
 
-func (pu *pingdomUsecase) loadCheck(id int) (result *models.Check, err error) {
+func (pu *pingdomUsecase) loadCheck(ctx context.Context, id int) (result *models.Check, err error) {
 	// Synchronize to avoid multi call on pingdom api
 	pu.Lock()
 	defer pu.Unlock()
@@ -135,7 +136,7 @@ func (pu *pingdomUsecase) loadCheck(id int) (result *models.Check, err error) {
 		return
 	}
 
-	result, err = pu.repository.GetCheck(id)
+	result, err = pu.repository.GetCheck(ctx, id)
 	if err != nil {
 		return
 	}
@@ -146,7 +147,7 @@ func (pu *pingdomUsecase) loadCheck(id int) (result *models.Check, err error) {
 	return
 }
 
-func (pu *pingdomUsecase) loadChecks(tags string) (results []models.Check, err error) {
+func (pu *pingdomUsecase) loadChecks(ctx context.Context, tags string) (results []models.Check, err error) {
 	// Synchronize to avoid multi call on pingdom api
 	pu.Lock()
 	defer pu.Unlock()
@@ -158,7 +159,7 @@ func (pu *pingdomUsecase) loadChecks(tags string) (results []models.Check, err e
 		return
 	}
 
-	results, err = pu.repository.GetChecks(tags)
+	results, err = pu.repository.GetChecks(ctx, tags)
 	if err != nil {
 		return
 	}
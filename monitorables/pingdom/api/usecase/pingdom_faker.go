@@ -3,6 +3,7 @@
 package usecase
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -30,7 +31,7 @@ func NewPingdomUsecase() api.Usecase {
 	return &pingdomUsecase{make(map[int]time.Time)}
 }
 
-func (pu *pingdomUsecase) Check(params *pingdomModels.CheckParams) (tile *models.Tile, error error) {
+func (pu *pingdomUsecase) Check(_ context.Context, params *pingdomModels.CheckParams) (tile *models.Tile, error error) {
 	tile = models.NewTile(api.PingdomCheckTileType)
 	tile.Label = fmt.Sprintf(fmt.Sprintf("Check %d", *params.ID))
 
@@ -40,7 +41,7 @@ func (pu *pingdomUsecase) Check(params *pingdomModels.CheckParams) (tile *models
 	return
 }
 
-func (pu *pingdomUsecase) CheckGenerator(params interface{}) ([]uiConfigModels.GeneratedTile, error) {
+func (pu *pingdomUsecase) CheckGenerator(_ context.Context, params interface{}) ([]uiConfigModels.GeneratedTile, error) {
 	panic("unimplemented")
 }
 
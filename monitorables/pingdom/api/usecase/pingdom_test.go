@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -24,12 +25,12 @@ func initUsecase(mockRepository api.Repository) api.Usecase {
 
 func TestPingdomUsecase_Check_NoBulk_Error(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetCheck", AnythingOfType("int")).
+	mockRepository.On("GetCheck", Anything, AnythingOfType("int")).
 		Return(nil, errors.New("boom"))
 
 	pu := initUsecase(mockRepository)
 
-	tile, err := pu.Check(&models.CheckParams{ID: pointer.ToInt(1000)})
+	tile, err := pu.Check(context.Background(), &models.CheckParams{ID: pointer.ToInt(1000)})
 	if assert.Error(t, err) {
 		assert.Nil(t, tile)
 		assert.IsType(t, &coreModels.MonitororError{}, err)
@@ -41,12 +42,12 @@ func TestPingdomUsecase_Check_NoBulk_Error(t *testing.T) {
 
 func TestPingdomUsecase_Check_NoBulk(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetCheck", AnythingOfType("int")).
+	mockRepository.On("GetCheck", Anything, AnythingOfType("int")).
 		Return(&models.Check{ID: 1000, Status: "up", Name: "Check 1"}, nil)
 
 	pu := initUsecase(mockRepository)
 
-	tile, err := pu.Check(&models.CheckParams{ID: pointer.ToInt(1000)})
+	tile, err := pu.Check(context.Background(), &models.CheckParams{ID: pointer.ToInt(1000)})
 	if assert.NoError(t, err) {
 		assert.NotNil(t, tile)
 		assert.Equal(t, coreModels.SuccessStatus, tile.Status)
@@ -69,7 +70,7 @@ func TestPingdomUsecase_Check_NoBulk_WithCache(t *testing.T) {
 		time.Second,
 	)
 
-	tile, err := pu.Check(&models.CheckParams{ID: pointer.ToInt(1000)})
+	tile, err := pu.Check(context.Background(), &models.CheckParams{ID: pointer.ToInt(1000)})
 	if assert.NoError(t, err) {
 		assert.NotNil(t, tile)
 		assert.Equal(t, coreModels.DisabledStatus, tile.Status)
@@ -81,7 +82,7 @@ func TestPingdomUsecase_Check_NoBulk_WithCache(t *testing.T) {
 
 func TestPingdomUsecase_Check_Bulk_Error(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetChecks", AnythingOfType("string")).
+	mockRepository.On("GetChecks", Anything, AnythingOfType("string")).
 		Return(nil, errors.New("boom"))
 
 	pu := initUsecase(mockRepository)
@@ -90,7 +91,7 @@ func TestPingdomUsecase_Check_Bulk_Error(t *testing.T) {
 	// Force cache
 	_ = castedTu.store.Set(castedTu.getTagsByIDStoreKey(1000), "", time.Minute)
 
-	tile, err := pu.Check(&models.CheckParams{ID: pointer.ToInt(1000)})
+	tile, err := pu.Check(context.Background(), &models.CheckParams{ID: pointer.ToInt(1000)})
 	if assert.Error(t, err) {
 		assert.Nil(t, tile)
 		assert.IsType(t, &coreModels.MonitororError{}, err)
@@ -102,7 +103,7 @@ func TestPingdomUsecase_Check_Bulk_Error(t *testing.T) {
 
 func TestPingdomUsecase_Check_Bulk(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetChecks", AnythingOfType("string")).
+	mockRepository.On("GetChecks", Anything, AnythingOfType("string")).
 		Return([]models.Check{
 			{ID: 1000, Status: "up", Name: "Check 1"},
 			{ID: 1100, Status: "down", Name: "Check 2"},
@@ -115,7 +116,7 @@ func TestPingdomUsecase_Check_Bulk(t *testing.T) {
 	// Force cache
 	_ = castedTu.store.Set(castedTu.getTagsByIDStoreKey(1100), "", time.Minute)
 
-	tile, err := pu.Check(&models.CheckParams{ID: pointer.ToInt(1100)})
+	tile, err := pu.Check(context.Background(), &models.CheckParams{ID: pointer.ToInt(1100)})
 	if assert.NoError(t, err) {
 		assert.NotNil(t, tile)
 		assert.Equal(t, coreModels.FailedStatus, tile.Status)
@@ -144,7 +145,7 @@ func TestPingdomUsecase_Check_Bulk_WithCache(t *testing.T) {
 		time.Second,
 	)
 
-	tile, err := pu.Check(&models.CheckParams{ID: pointer.ToInt(1000)})
+	tile, err := pu.Check(context.Background(), &models.CheckParams{ID: pointer.ToInt(1000)})
 	if assert.NoError(t, err) {
 		assert.NotNil(t, tile)
 		assert.Equal(t, coreModels.SuccessStatus, tile.Status)
@@ -156,11 +157,11 @@ func TestPingdomUsecase_Check_Bulk_WithCache(t *testing.T) {
 
 func TestPingdomUsecase_CheckGenerator_Error(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetChecks", AnythingOfType("string")).Return(nil, errors.New("boom"))
+	mockRepository.On("GetChecks", Anything, AnythingOfType("string")).Return(nil, errors.New("boom"))
 
 	pu := initUsecase(mockRepository)
 
-	results, err := pu.CheckGenerator(&models.CheckGeneratorParams{SortBy: "name"})
+	results, err := pu.CheckGenerator(context.Background(), &models.CheckGeneratorParams{SortBy: "name"})
 	if assert.Error(t, err) {
 		assert.Nil(t, results)
 		mockRepository.AssertNumberOfCalls(t, "GetChecks", 1)
@@ -170,7 +171,7 @@ func TestPingdomUsecase_CheckGenerator_Error(t *testing.T) {
 
 func TestPingdomUsecase_CheckGenerator(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetChecks", AnythingOfType("string")).
+	mockRepository.On("GetChecks", Anything, AnythingOfType("string")).
 		Return([]models.Check{
 			{ID: 1000, Status: "up", Name: "Check 2"},
 			{ID: 1100, Status: "down", Name: "Check 1"},
@@ -179,7 +180,7 @@ func TestPingdomUsecase_CheckGenerator(t *testing.T) {
 
 	pu := initUsecase(mockRepository)
 
-	results, err := pu.CheckGenerator(&models.CheckGeneratorParams{SortBy: "name"})
+	results, err := pu.CheckGenerator(context.Background(), &models.CheckGeneratorParams{SortBy: "name"})
 	if assert.NoError(t, err) {
 		assert.NotNil(t, results)
 		assert.Len(t, results, 2)
@@ -3,11 +3,13 @@
 package pingdom
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 
 	"github.com/monitoror/monitoror/api/config/versions"
 	pkgMonitorable "github.com/monitoror/monitoror/internal/pkg/monitorable"
+	"github.com/monitoror/monitoror/service/options"
 	"github.com/monitoror/monitoror/service/registry"
 
 	coreModels "github.com/monitoror/monitoror/models"
@@ -40,8 +42,8 @@ func NewMonitorable(store *store.Store) *Monitorable {
 	pkgMonitorable.LoadConfig(&m.config, pingdomConfig.Default)
 
 	// Register Monitorable Tile in config manager
-	m.checkTileEnabler = store.Registry.RegisterTile(api.PingdomCheckTileType, versions.MinimalVersion, m.GetVariantNames())
-	m.checkGeneratorEnabler = store.Registry.RegisterGenerator(api.PingdomCheckTileType, versions.MinimalVersion, m.GetVariantNames())
+	m.checkTileEnabler = store.Registry.RegisterTile(api.PingdomCheckTileType, versions.MinimalVersion, m.GetVariantNames(), api.PingdomCheckMinimalRefreshInterval, &pingdomModels.CheckParams{})
+	m.checkGeneratorEnabler = store.Registry.RegisterGenerator(api.PingdomCheckTileType, versions.MinimalVersion, m.GetVariantNames(), &pingdomModels.CheckGeneratorParams{})
 
 	return m
 }
@@ -54,6 +56,10 @@ func (m *Monitorable) GetVariantNames() []coreModels.VariantName {
 	return pkgMonitorable.GetVariants(m.config)
 }
 
+func (m *Monitorable) GetTileTypes() []coreModels.TileType {
+	return []coreModels.TileType{api.PingdomCheckTileType, coreModels.NewGeneratorTileType(api.PingdomCheckTileType)}
+}
+
 func (m *Monitorable) Validate(variantName coreModels.VariantName) (bool, error) {
 	conf := m.config[variantName]
 
@@ -84,9 +90,13 @@ func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 
 	// EnableTile route to echo
 	routeGroup := m.store.MonitorableRouter.Group("/pingdom", variantName)
-	route := routeGroup.GET("/pingdom", delivery.GetCheck)
+	route := routeGroup.GET("/pingdom", delivery.GetCheck, options.WithUpstreamURL(conf.URL))
 
 	// EnableTile data for config hydration
-	m.checkTileEnabler.Enable(variantName, &pingdomModels.CheckParams{}, route.Path)
-	m.checkGeneratorEnabler.Enable(variantName, &pingdomModels.CheckGeneratorParams{}, usecase.CheckGenerator)
+	m.checkTileEnabler.Enable(variantName, route.Path)
+	m.checkGeneratorEnabler.Enable(variantName, usecase.CheckGenerator)
+}
+
+func (m *Monitorable) Diagnose(ctx context.Context, variantName coreModels.VariantName) error {
+	return pingdomRepository.NewPingdomRepository(m.config[variantName]).CheckConnection(ctx)
 }
@@ -28,13 +28,17 @@ func NewMonitorable(store *store.Store) *Monitorable {
 	m.store = store
 
 	// Register Monitorable Tile in config manager
-	m.checkTileEnabler = store.Registry.RegisterTile(api.PingdomCheckTileType, versions.MinimalVersion, m.GetVariantNames())
+	m.checkTileEnabler = store.Registry.RegisterTile(api.PingdomCheckTileType, versions.MinimalVersion, m.GetVariantNames(), api.PingdomCheckMinimalRefreshInterval, &pingdomModels.CheckParams{})
 
 	return m
 }
 
 func (m *Monitorable) GetDisplayName() string { return "Pingdom (faker)" }
 
+func (m *Monitorable) GetTileTypes() []coreModels.TileType {
+	return []coreModels.TileType{api.PingdomCheckTileType}
+}
+
 func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	usecase := pingdomUsecase.NewPingdomUsecase()
 	delivery := pingdomDelivery.NewPingdomDelivery(usecase)
@@ -44,5 +48,5 @@ func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	route := routeGroup.GET("/pingdom", delivery.GetCheck)
 
 	// EnableTile data for config hydration
-	m.checkTileEnabler.Enable(variantName, &pingdomModels.CheckParams{}, route.Path)
+	m.checkTileEnabler.Enable(variantName, route.Path)
 }
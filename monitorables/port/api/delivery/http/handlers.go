@@ -25,7 +25,7 @@ func (h *PortDelivery) GetPort(c echo.Context) error {
 		return err
 	}
 
-	tile, err := h.portUsecase.Port(params)
+	tile, err := h.portUsecase.Port(c.Request().Context(), params)
 	if err != nil {
 		return err
 	}
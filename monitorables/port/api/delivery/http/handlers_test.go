@@ -51,7 +51,7 @@ func TestDelivery_PortHandler_Success(t *testing.T) {
 	tile.Status = coreModels.SuccessStatus
 
 	mockUsecase := new(mocks.Usecase)
-	mockUsecase.On("Port", &models.PortParams{Hostname: "monitoror.example.com", Port: 1234}).Return(tile, nil)
+	mockUsecase.On("Port", Anything, &models.PortParams{Hostname: "monitoror.example.com", Port: 1234}).Return(tile, nil)
 	handler := NewPortDelivery(mockUsecase)
 
 	// Expected
@@ -80,7 +80,7 @@ func TestDelivery_PortHandler_Error(t *testing.T) {
 	ctx, _ := initEcho()
 
 	mockUsecase := new(mocks.Usecase)
-	mockUsecase.On("Port", Anything).Return(nil, errors.New("port error"))
+	mockUsecase.On("Port", Anything, Anything).Return(nil, errors.New("port error"))
 	handler := NewPortDelivery(mockUsecase)
 
 	// Test
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	monitorormodels "github.com/monitoror/monitoror/models"
 	models "github.com/monitoror/monitoror/monitorables/port/api/models"
 	mock "github.com/stretchr/testify/mock"
@@ -13,13 +15,13 @@ type Usecase struct {
 	mock.Mock
 }
 
-// Port provides a mock function with given fields: params
-func (_m *Usecase) Port(params *models.PortParams) (*monitorormodels.Tile, error) {
-	ret := _m.Called(params)
+// Port provides a mock function with given fields: ctx, params
+func (_m *Usecase) Port(ctx context.Context, params *models.PortParams) (*monitorormodels.Tile, error) {
+	ret := _m.Called(ctx, params)
 
 	var r0 *monitorormodels.Tile
-	if rf, ok := ret.Get(0).(func(*models.PortParams) *monitorormodels.Tile); ok {
-		r0 = rf(params)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.PortParams) *monitorormodels.Tile); ok {
+		r0 = rf(ctx, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*monitorormodels.Tile)
@@ -27,8 +29,8 @@ func (_m *Usecase) Port(params *models.PortParams) (*monitorormodels.Tile, error
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*models.PortParams) error); ok {
-		r1 = rf(params)
+	if rf, ok := ret.Get(1).(func(context.Context, *models.PortParams) error); ok {
+		r1 = rf(ctx, params)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -2,8 +2,10 @@
 
 package api
 
+import "context"
+
 type (
 	Repository interface {
-		OpenSocket(hostname string, port int) error
+		OpenSocket(ctx context.Context, hostname string, port int) error
 	}
 )
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"time"
@@ -22,10 +23,10 @@ func NewPortRepository(conf *config.Port) api.Repository {
 	return &portRepository{conf, &net.Dialer{Timeout: timeout}}
 }
 
-func (r *portRepository) OpenSocket(hostname string, port int) (err error) {
+func (r *portRepository) OpenSocket(ctx context.Context, hostname string, port int) (err error) {
 	target := fmt.Sprintf("%s:%d", hostname, port)
 
-	conn, err := r.dialer.Dial("tcp", target)
+	conn, err := r.dialer.DialContext(ctx, "tcp", target)
 	if err != nil {
 		return
 	}
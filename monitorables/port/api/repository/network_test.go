@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -30,26 +31,26 @@ func TestRepository_OpenSocket_Success(t *testing.T) {
 	mockConn := new(mocks.Conn)
 	mockConn.On("Close").Return(nil)
 	mockDialer := new(mocks.Dialer)
-	mockDialer.On("Dial", AnythingOfType("string"), AnythingOfType("string")).Return(mockConn, nil)
+	mockDialer.On("DialContext", Anything, AnythingOfType("string"), AnythingOfType("string")).Return(mockConn, nil)
 
 	repository := initRepository(t, mockDialer)
 	if repository != nil {
-		assert.NoError(t, repository.OpenSocket("test", 1234))
+		assert.NoError(t, repository.OpenSocket(context.Background(), "test", 1234))
 		mockConn.AssertNumberOfCalls(t, "Close", 1)
 		mockConn.AssertExpectations(t)
-		mockDialer.AssertNumberOfCalls(t, "Dial", 1)
+		mockDialer.AssertNumberOfCalls(t, "DialContext", 1)
 		mockDialer.AssertExpectations(t)
 	}
 }
 
 func TestRepository_OpenSocket_Failed(t *testing.T) {
 	mockDialer := new(mocks.Dialer)
-	mockDialer.On("Dial", AnythingOfType("string"), AnythingOfType("string")).Return(nil, errors.New("check port failed"))
+	mockDialer.On("DialContext", Anything, AnythingOfType("string"), AnythingOfType("string")).Return(nil, errors.New("check port failed"))
 
 	repository := initRepository(t, mockDialer)
 	if repository != nil {
-		assert.Error(t, repository.OpenSocket("test", 1234))
-		mockDialer.AssertNumberOfCalls(t, "Dial", 1)
+		assert.Error(t, repository.OpenSocket(context.Background(), "test", 1234))
+		mockDialer.AssertNumberOfCalls(t, "DialContext", 1)
 		mockDialer.AssertExpectations(t)
 	}
 }
@@ -3,16 +3,21 @@
 package api
 
 import (
+	"context"
+	"time"
+
 	coreModels "github.com/monitoror/monitoror/models"
 	"github.com/monitoror/monitoror/monitorables/port/api/models"
 )
 
 const (
 	PortTileType coreModels.TileType = "PORT"
+
+	PortMinimalRefreshInterval = 5 * time.Second
 )
 
 type (
 	Usecase interface {
-		Port(params *models.PortParams) (*coreModels.Tile, error)
+		Port(ctx context.Context, params *models.PortParams) (*coreModels.Tile, error)
 	}
 )
@@ -3,6 +3,7 @@
 package usecase
 
 import (
+	"context"
 	"fmt"
 
 	coreModels "github.com/monitoror/monitoror/models"
@@ -20,11 +21,11 @@ func NewPortUsecase(repository api.Repository) api.Usecase {
 	return &portUsecase{repository}
 }
 
-func (pu *portUsecase) Port(params *models.PortParams) (tile *coreModels.Tile, err error) {
+func (pu *portUsecase) Port(ctx context.Context, params *models.PortParams) (tile *coreModels.Tile, err error) {
 	tile = coreModels.NewTile(api.PortTileType)
 	tile.Label = fmt.Sprintf("%s:%d", params.Hostname, params.Port)
 
-	err = pu.repository.OpenSocket(params.Hostname, params.Port)
+	err = pu.repository.OpenSocket(ctx, params.Hostname, params.Port)
 	if err == nil {
 		tile.Status = coreModels.SuccessStatus
 	} else {
@@ -3,6 +3,7 @@
 package usecase
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -29,7 +30,7 @@ func NewPortUsecase() api.Usecase {
 	return &portUsecase{make(map[string]time.Time)}
 }
 
-func (pu *portUsecase) Port(params *models.PortParams) (tile *coreModels.Tile, err error) {
+func (pu *portUsecase) Port(_ context.Context, params *models.PortParams) (tile *coreModels.Tile, err error) {
 	tile = coreModels.NewTile(api.PortTileType)
 	tile.Label = fmt.Sprintf("%s:%d", params.Hostname, params.Port)
 
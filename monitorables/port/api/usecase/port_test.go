@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -17,7 +18,7 @@ import (
 func TestUsecase_CheckPort_Success(t *testing.T) {
 	// Init
 	mockRepo := new(mocks.Repository)
-	mockRepo.On("OpenSocket", AnythingOfType("string"), AnythingOfType("int")).Return(nil)
+	mockRepo.On("OpenSocket", Anything, AnythingOfType("string"), AnythingOfType("int")).Return(nil)
 	usecase := NewPortUsecase(mockRepo)
 
 	// Params
@@ -32,7 +33,7 @@ func TestUsecase_CheckPort_Success(t *testing.T) {
 	eTile.Status = coreModels.SuccessStatus
 
 	// Test
-	rTile, err := usecase.Port(param)
+	rTile, err := usecase.Port(context.Background(), param)
 
 	if assert.NoError(t, err) {
 		assert.Equal(t, eTile, rTile)
@@ -44,7 +45,7 @@ func TestUsecase_CheckPort_Success(t *testing.T) {
 func TestUsecase_CheckPort_Fail(t *testing.T) {
 	// Init
 	mockRepo := new(mocks.Repository)
-	mockRepo.On("OpenSocket", AnythingOfType("string"), AnythingOfType("int")).Return(errors.New("port error"))
+	mockRepo.On("OpenSocket", Anything, AnythingOfType("string"), AnythingOfType("int")).Return(errors.New("port error"))
 	usecase := NewPortUsecase(mockRepo)
 
 	// Params
@@ -59,7 +60,7 @@ func TestUsecase_CheckPort_Fail(t *testing.T) {
 	eTile.Status = coreModels.FailedStatus
 
 	// Test
-	rTile, err := usecase.Port(param)
+	rTile, err := usecase.Port(context.Background(), param)
 
 	if assert.NoError(t, err) {
 		assert.Equal(t, eTile, rTile)
@@ -34,7 +34,7 @@ func NewMonitorable(store *store.Store) *Monitorable {
 	pkgMonitorable.LoadConfig(&m.config, portConfig.Default)
 
 	// Register Monitorable Tile in config manager
-	m.portTileEnabler = store.Registry.RegisterTile(api.PortTileType, versions.MinimalVersion, m.GetVariantNames())
+	m.portTileEnabler = store.Registry.RegisterTile(api.PortTileType, versions.MinimalVersion, m.GetVariantNames(), api.PortMinimalRefreshInterval, &portModels.PortParams{})
 
 	return m
 }
@@ -47,6 +47,10 @@ func (m *Monitorable) GetVariantNames() []coreModels.VariantName {
 	return pkgMonitorable.GetVariants(m.config)
 }
 
+func (m *Monitorable) GetTileTypes() []coreModels.TileType {
+	return []coreModels.TileType{api.PortTileType}
+}
+
 func (m *Monitorable) Validate(_ coreModels.VariantName) (bool, error) {
 	return true, nil
 }
@@ -63,5 +67,5 @@ func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	route := routeGroup.GET("/port", delivery.GetPort)
 
 	// EnableTile data for config hydration
-	m.portTileEnabler.Enable(variantName, &portModels.PortParams{}, route.Path)
+	m.portTileEnabler.Enable(variantName, route.Path)
 }
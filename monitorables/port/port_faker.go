@@ -28,13 +28,17 @@ func NewMonitorable(store *store.Store) *Monitorable {
 	m.store = store
 
 	// Register Monitorable Tile in config manager
-	m.portTileEnabler = store.Registry.RegisterTile(api.PortTileType, versions.MinimalVersion, m.GetVariantNames())
+	m.portTileEnabler = store.Registry.RegisterTile(api.PortTileType, versions.MinimalVersion, m.GetVariantNames(), api.PortMinimalRefreshInterval, &portModels.PortParams{})
 
 	return m
 }
 
 func (m *Monitorable) GetDisplayName() string { return "Port (faker)" }
 
+func (m *Monitorable) GetTileTypes() []coreModels.TileType {
+	return []coreModels.TileType{api.PortTileType}
+}
+
 func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	usecase := portUsecase.NewPortUsecase()
 	delivery := portDelivery.NewPortDelivery(usecase)
@@ -44,5 +48,5 @@ func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	route := routeGroup.GET("/port", delivery.GetPort)
 
 	// EnableTile data for config hydration
-	m.portTileEnabler.Enable(variantName, &portModels.PortParams{}, route.Path)
+	m.portTileEnabler.Enable(variantName, route.Path)
 }
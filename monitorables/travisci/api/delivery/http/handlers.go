@@ -25,7 +25,7 @@ func (h *TravisCIDelivery) GetBuild(c echo.Context) error {
 		return err
 	}
 
-	tile, err := h.travisciUsecase.Build(params)
+	tile, err := h.travisciUsecase.Build(c.Request().Context(), params)
 	if err != nil {
 		return err
 	}
@@ -53,7 +53,7 @@ func TestDelivery_GetBuild_Success(t *testing.T) {
 	tile.Status = coreModels.SuccessStatus
 
 	mockUsecase := new(mocks.Usecase)
-	mockUsecase.On("Build", &models.BuildParams{Owner: "test", Repository: "test", Branch: "master"}).Return(tile, nil)
+	mockUsecase.On("Build", Anything, &models.BuildParams{Owner: "test", Repository: "test", Branch: "master"}).Return(tile, nil)
 	handler := NewTravisCIDelivery(mockUsecase)
 
 	// Expected
@@ -86,7 +86,7 @@ func TestDelivery_GetBuild_Error(t *testing.T) {
 	ctx, _ := initEcho()
 
 	mockUsecase := new(mocks.Usecase)
-	mockUsecase.On("Build", Anything).Return(nil, errors.New("ping error"))
+	mockUsecase.On("Build", Anything, Anything).Return(nil, errors.New("ping error"))
 	handler := NewTravisCIDelivery(mockUsecase)
 
 	// Test
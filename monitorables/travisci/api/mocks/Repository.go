@@ -3,8 +3,11 @@
 package mocks
 
 import (
-	models "github.com/monitoror/monitoror/monitorables/travisci/api/models"
+	context "context"
+
 	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/monitoror/monitoror/monitorables/travisci/api/models"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -12,13 +15,13 @@ type Repository struct {
 	mock.Mock
 }
 
-// GetLastBuildStatus provides a mock function with given fields: owner, repository, branch
-func (_m *Repository) GetLastBuildStatus(owner string, repository string, branch string) (*models.Build, error) {
-	ret := _m.Called(owner, repository, branch)
+// GetLastBuildStatus provides a mock function with given fields: ctx, owner, repository, branch
+func (_m *Repository) GetLastBuildStatus(ctx context.Context, owner string, repository string, branch string) (*models.Build, error) {
+	ret := _m.Called(ctx, owner, repository, branch)
 
 	var r0 *models.Build
-	if rf, ok := ret.Get(0).(func(string, string, string) *models.Build); ok {
-		r0 = rf(owner, repository, branch)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *models.Build); ok {
+		r0 = rf(ctx, owner, repository, branch)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Build)
@@ -26,8 +29,8 @@ func (_m *Repository) GetLastBuildStatus(owner string, repository string, branch
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = rf(owner, repository, branch)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, owner, repository, branch)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,11 +3,13 @@
 package api
 
 import (
+	"context"
+
 	"github.com/monitoror/monitoror/monitorables/travisci/api/models"
 )
 
 type (
 	Repository interface {
-		GetLastBuildStatus(owner, repository, branch string) (*models.Build, error)
+		GetLastBuildStatus(ctx context.Context, owner, repository, branch string) (*models.Build, error)
 	}
 )
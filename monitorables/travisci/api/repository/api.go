@@ -48,7 +48,7 @@ func NewTravisCIRepository(config *config.TravisCI) api.Repository {
 }
 
 // GetBuildStatus fetch build information from travis-ci
-func (r *travisCIRepository) GetLastBuildStatus(owner, repository, branch string) (*models.Build, error) {
+func (r *travisCIRepository) GetLastBuildStatus(ctx context.Context, owner, repository, branch string) (*models.Build, error) {
 	// GetConfig
 	repoSlug := fmt.Sprintf("%s/%s", owner, repository)
 	options := &travis.BuildsByRepoOption{
@@ -57,7 +57,6 @@ func (r *travisCIRepository) GetLastBuildStatus(owner, repository, branch string
 		Include:    []string{"build.commit"},
 	}
 
-	ctx := context.Background()
 	ctx, cancel := context.WithTimeout(ctx, time.Duration(r.config.Timeout)*time.Millisecond)
 	defer cancel()
 
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -59,7 +60,7 @@ func TestRepository_GetLastBuildStatus_Error(t *testing.T) {
 
 	repository := initRepository(t, mockTravis)
 	if repository != nil {
-		_, err := repository.GetLastBuildStatus("test", "test", "test")
+		_, err := repository.GetLastBuildStatus(context.Background(), "test", "test", "test")
 		assert.Error(t, err)
 		assert.Equal(t, travisErr, err)
 		mockTravis.AssertNumberOfCalls(t, "ListByRepoSlug", 1)
@@ -74,7 +75,7 @@ func TestRepository_GetLastBuildStatus_NoBuild(t *testing.T) {
 
 	repository := initRepository(t, mockTravis)
 	if repository != nil {
-		build, err := repository.GetLastBuildStatus("test", "test", "test")
+		build, err := repository.GetLastBuildStatus(context.Background(), "test", "test", "test")
 		assert.NoError(t, err)
 		assert.Nil(t, build)
 		mockTravis.AssertNumberOfCalls(t, "ListByRepoSlug", 1)
@@ -122,7 +123,7 @@ func TestRepository_GetLastBuildStatus_Success(t *testing.T) {
 
 	repository := initRepository(t, mockTravis)
 	if repository != nil {
-		build, err := repository.GetLastBuildStatus("test", "test", "test")
+		build, err := repository.GetLastBuildStatus(context.Background(), "test", "test", "test")
 		assert.NoError(t, err)
 		assert.Equal(t, expectedBuild, build)
 		mockTravis.AssertNumberOfCalls(t, "ListByRepoSlug", 1)
@@ -3,16 +3,21 @@
 package api
 
 import (
+	"context"
+	"time"
+
 	coreModels "github.com/monitoror/monitoror/models"
 	"github.com/monitoror/monitoror/monitorables/travisci/api/models"
 )
 
 const (
 	TravisCIBuildTileType coreModels.TileType = "TRAVISCI-BUILD"
+
+	TravisCIBuildMinimalRefreshInterval = 10 * time.Second
 )
 
 type (
 	Usecase interface {
-		Build(params *models.BuildParams) (*coreModels.Tile, error)
+		Build(ctx context.Context, params *models.BuildParams) (*coreModels.Tile, error)
 	}
 )
@@ -3,6 +3,7 @@
 package usecase
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/monitoror/monitoror/pkg/git"
 
 	"github.com/AlekSi/pointer"
+	echoCache "github.com/jsdidierlaurent/echo-middleware/cache"
 )
 
 type (
@@ -26,17 +28,17 @@ type (
 
 const cacheSize = 5
 
-func NewTravisCIUsecase(repository api.Repository) api.Usecase {
-	return &travisCIUsecase{repository, cache.NewBuildCache(cacheSize)}
+func NewTravisCIUsecase(repository api.Repository, store echoCache.Store, variantName coreModels.VariantName) api.Usecase {
+	return &travisCIUsecase{repository, cache.NewBuildCache(store, fmt.Sprintf("%s:%s", api.TravisCIBuildTileType, variantName), cacheSize)}
 }
 
-func (tu *travisCIUsecase) Build(params *models.BuildParams) (*coreModels.Tile, error) {
+func (tu *travisCIUsecase) Build(ctx context.Context, params *models.BuildParams) (*coreModels.Tile, error) {
 	tile := coreModels.NewTile(api.TravisCIBuildTileType).WithBuild()
 	tile.Label = params.Repository
 	tile.Build.Branch = pointer.ToString(git.HumanizeBranch(params.Branch))
 
 	// Request
-	build, err := tu.repository.GetLastBuildStatus(params.Owner, params.Repository, params.Branch)
+	build, err := tu.repository.GetLastBuildStatus(ctx, params.Owner, params.Repository, params.Branch)
 	if err != nil {
 		return nil, &coreModels.MonitororError{Err: err, Tile: tile, Message: "unable to find build"}
 	}
@@ -3,6 +3,7 @@
 package usecase
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -36,7 +37,7 @@ func NewTravisCIUsecase() api.Usecase {
 	return &travisCIUsecase{cmap.New()}
 }
 
-func (tu *travisCIUsecase) Build(params *models.BuildParams) (tile *coreModels.Tile, err error) {
+func (tu *travisCIUsecase) Build(_ context.Context, params *models.BuildParams) (tile *coreModels.Tile, err error) {
 	tile = coreModels.NewTile(api.TravisCIBuildTileType).WithBuild()
 	tile.Label = params.Repository
 	tile.Build.Branch = pointer.ToString(git.HumanizeBranch(params.Branch))
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/monitoror/monitoror/pkg/git"
 
 	. "github.com/AlekSi/pointer"
+	echoCache "github.com/jsdidierlaurent/echo-middleware/cache"
 	"github.com/stretchr/testify/assert"
 	. "github.com/stretchr/testify/mock"
 )
@@ -21,12 +23,12 @@ var owner, repo, branch = "test", "test", "master"
 
 func TestBuild_Error(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetLastBuildStatus", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetLastBuildStatus", Anything, AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(nil, errors.New("boom"))
 
-	tu := NewTravisCIUsecase(mockRepository)
+	tu := NewTravisCIUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 
-	tile, err := tu.Build(&models.BuildParams{Owner: owner, Repository: repo, Branch: branch})
+	tile, err := tu.Build(context.Background(), &models.BuildParams{Owner: owner, Repository: repo, Branch: branch})
 	if assert.Error(t, err) {
 		assert.Nil(t, tile)
 		assert.IsType(t, &coreModels.MonitororError{}, err)
@@ -38,12 +40,12 @@ func TestBuild_Error(t *testing.T) {
 
 func TestBuild_Error_NoBuild(t *testing.T) {
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetLastBuildStatus", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetLastBuildStatus", Anything, AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(nil, nil)
 
-	tu := NewTravisCIUsecase(mockRepository)
+	tu := NewTravisCIUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 
-	tile, err := tu.Build(&models.BuildParams{Owner: owner, Repository: repo, Branch: branch})
+	tile, err := tu.Build(context.Background(), &models.BuildParams{Owner: owner, Repository: repo, Branch: branch})
 	if assert.Error(t, err) {
 		assert.Nil(t, tile)
 		assert.IsType(t, &coreModels.MonitororError{}, err)
@@ -59,10 +61,10 @@ func TestBuild_Success(t *testing.T) {
 	build := buildResponse(branch, "passed", time.Now(), time.Now(), time.Second*100)
 
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetLastBuildStatus", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetLastBuildStatus", Anything, AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(build, nil)
 
-	tu := NewTravisCIUsecase(mockRepository)
+	tu := NewTravisCIUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 	tUsecase, ok := tu.(*travisCIUsecase)
 	if assert.True(t, ok, "enable to case tu into travisCIUsecase") {
 		// Expected
@@ -79,7 +81,7 @@ func TestBuild_Success(t *testing.T) {
 		// Tests
 		params := &models.BuildParams{Owner: owner, Repository: repo, Branch: branch}
 		tUsecase.buildsCache.Add(params, "0", coreModels.SuccessStatus, time.Second*120)
-		tile, err := tu.Build(params)
+		tile, err := tu.Build(context.Background(), params)
 		if assert.NotNil(t, tile) {
 			assert.NoError(t, err)
 			assert.Equal(t, expected, tile)
@@ -100,10 +102,10 @@ func TestBuild_Failed(t *testing.T) {
 	build := buildResponse(branch, "failed", time.Now(), time.Now(), time.Second*100)
 
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetLastBuildStatus", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetLastBuildStatus", Anything, AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(build, nil)
 
-	tu := NewTravisCIUsecase(mockRepository)
+	tu := NewTravisCIUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 	tUsecase, ok := tu.(*travisCIUsecase)
 	if assert.True(t, ok, "enable to case tu into travisCIUsecase") {
 		// Expected
@@ -123,7 +125,7 @@ func TestBuild_Failed(t *testing.T) {
 
 		params := &models.BuildParams{Owner: owner, Repository: repo, Branch: branch}
 		tUsecase.buildsCache.Add(params, "0", coreModels.SuccessStatus, time.Second*120)
-		tile, err := tu.Build(params)
+		tile, err := tu.Build(context.Background(), params)
 		if assert.NotNil(t, tile) {
 			assert.NoError(t, err)
 			assert.Equal(t, expected, tile)
@@ -144,10 +146,10 @@ func TestBuild_Queued(t *testing.T) {
 	build := buildResponse(branch, "received", time.Now(), time.Time{}, 100)
 
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetLastBuildStatus", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetLastBuildStatus", Anything, AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(build, nil)
 
-	tu := NewTravisCIUsecase(mockRepository)
+	tu := NewTravisCIUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 	tUsecase, ok := tu.(*travisCIUsecase)
 	if assert.True(t, ok) {
 		// Expected
@@ -163,7 +165,7 @@ func TestBuild_Queued(t *testing.T) {
 		// Without Estimated Duration
 		params := &models.BuildParams{Owner: owner, Repository: repo, Branch: branch}
 		tUsecase.buildsCache.Add(params, "0", coreModels.SuccessStatus, time.Second*10)
-		tile, err := tu.Build(params)
+		tile, err := tu.Build(context.Background(), params)
 		if assert.NotNil(t, tile) {
 			assert.NoError(t, err)
 			assert.Equal(t, expected, tile)
@@ -175,10 +177,10 @@ func TestBuild_Running(t *testing.T) {
 	build := buildResponse(branch, "started", time.Now(), time.Time{}, 100)
 
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetLastBuildStatus", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetLastBuildStatus", Anything, AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(build, nil)
 
-	tu := NewTravisCIUsecase(mockRepository)
+	tu := NewTravisCIUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 	tUsecase, ok := tu.(*travisCIUsecase)
 	if assert.True(t, ok, "enable to case tu into travisCIUsecase") {
 		// Expected
@@ -195,7 +197,7 @@ func TestBuild_Running(t *testing.T) {
 
 		// Without Previous Build
 		params := &models.BuildParams{Owner: owner, Repository: repo, Branch: branch}
-		tile, err := tu.Build(params)
+		tile, err := tu.Build(context.Background(), params)
 		if assert.NotNil(t, tile) {
 			assert.NoError(t, err)
 			assert.Equal(t, expected, tile)
@@ -205,7 +207,7 @@ func TestBuild_Running(t *testing.T) {
 		expected.Build.PreviousStatus = coreModels.SuccessStatus
 		expected.Build.EstimatedDuration = ToInt64(int64(120))
 		tUsecase.buildsCache.Add(params, "0", coreModels.SuccessStatus, time.Second*120)
-		tile, err = tu.Build(params)
+		tile, err = tu.Build(context.Background(), params)
 		if assert.NotNil(t, tile) {
 			assert.NoError(t, err)
 			assert.Equal(t, expected, tile)
@@ -221,10 +223,10 @@ func TestBuild_Aborded(t *testing.T) {
 	build := buildResponse(branch, "canceled", time.Now(), time.Time{}, 100)
 
 	mockRepository := new(mocks.Repository)
-	mockRepository.On("GetLastBuildStatus", AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
+	mockRepository.On("GetLastBuildStatus", Anything, AnythingOfType("string"), AnythingOfType("string"), AnythingOfType("string")).
 		Return(build, nil)
 
-	tu := NewTravisCIUsecase(mockRepository)
+	tu := NewTravisCIUsecase(mockRepository, echoCache.NewGoCacheStore(time.Minute, time.Second), coreModels.DefaultVariant)
 	tUsecase, ok := tu.(*travisCIUsecase)
 	if assert.True(t, ok) {
 		// Expected
@@ -240,7 +242,7 @@ func TestBuild_Aborded(t *testing.T) {
 		// Without Estimated Duration
 		params := &models.BuildParams{Owner: owner, Repository: repo, Branch: branch}
 		tUsecase.buildsCache.Add(params, "0", coreModels.SuccessStatus, time.Second*10)
-		tile, err := tu.Build(params)
+		tile, err := tu.Build(context.Background(), params)
 		if assert.NotNil(t, tile) {
 			assert.NoError(t, err)
 			assert.Equal(t, expected, tile)
@@ -17,6 +17,7 @@ import (
 	travisciRepository "github.com/monitoror/monitoror/monitorables/travisci/api/repository"
 	travisciUsecase "github.com/monitoror/monitoror/monitorables/travisci/api/usecase"
 	travisciConfig "github.com/monitoror/monitoror/monitorables/travisci/config"
+	"github.com/monitoror/monitoror/service/options"
 	"github.com/monitoror/monitoror/service/registry"
 	"github.com/monitoror/monitoror/service/store"
 )
@@ -39,7 +40,7 @@ func NewMonitorable(store *store.Store) *Monitorable {
 	pkgMonitorable.LoadConfig(&m.config, travisciConfig.Default)
 
 	// Register Monitorable Tile in config manager
-	m.buildTileEnabler = store.Registry.RegisterTile(api.TravisCIBuildTileType, versions.MinimalVersion, m.GetVariantNames())
+	m.buildTileEnabler = store.Registry.RegisterTile(api.TravisCIBuildTileType, versions.MinimalVersion, m.GetVariantNames(), api.TravisCIBuildMinimalRefreshInterval, &travisciModels.BuildParams{})
 
 	return m
 }
@@ -52,6 +53,10 @@ func (m *Monitorable) GetVariantNames() []coreModels.VariantName {
 	return pkgMonitorable.GetVariants(m.config)
 }
 
+func (m *Monitorable) GetTileTypes() []coreModels.TileType {
+	return []coreModels.TileType{api.TravisCIBuildTileType}
+}
+
 func (m *Monitorable) Validate(variantName coreModels.VariantName) (bool, error) {
 	conf := m.config[variantName]
 	// Error in URL
@@ -66,13 +71,13 @@ func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	conf := m.config[variantName]
 
 	repository := travisciRepository.NewTravisCIRepository(conf)
-	usecase := travisciUsecase.NewTravisCIUsecase(repository)
+	usecase := travisciUsecase.NewTravisCIUsecase(repository, m.store.CacheStore, variantName)
 	delivery := travisciDelivery.NewTravisCIDelivery(usecase)
 
 	// EnableTile route to echo
 	routeGroup := m.store.MonitorableRouter.Group("/travisci", variantName)
-	route := routeGroup.GET("/build", delivery.GetBuild)
+	route := routeGroup.GET("/build", delivery.GetBuild, options.WithUpstreamURL(conf.URL))
 
 	// EnableTile data for config hydration
-	m.buildTileEnabler.Enable(variantName, &travisciModels.BuildParams{}, route.Path)
+	m.buildTileEnabler.Enable(variantName, route.Path)
 }
@@ -28,13 +28,17 @@ func NewMonitorable(store *store.Store) *Monitorable {
 	m.store = store
 
 	// Register Monitorable Tile in config manager
-	m.buildTileEnabler = store.Registry.RegisterTile(api.TravisCIBuildTileType, versions.MinimalVersion, m.GetVariantNames())
+	m.buildTileEnabler = store.Registry.RegisterTile(api.TravisCIBuildTileType, versions.MinimalVersion, m.GetVariantNames(), api.TravisCIBuildMinimalRefreshInterval, &travisciModels.BuildParams{})
 
 	return m
 }
 
 func (m *Monitorable) GetDisplayName() string { return "Travis CI (faker)" }
 
+func (m *Monitorable) GetTileTypes() []coreModels.TileType {
+	return []coreModels.TileType{api.TravisCIBuildTileType}
+}
+
 func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	usecase := travisciUsecase.NewTravisCIUsecase()
 	delivery := travisciDelivery.NewTravisCIDelivery(usecase)
@@ -44,5 +48,5 @@ func (m *Monitorable) Enable(variantName coreModels.VariantName) {
 	route := routeGroup.GET("/build", delivery.GetBuild)
 
 	// EnableTile data for config hydration
-	m.buildTileEnabler.Enable(variantName, &travisciModels.BuildParams{}, route.Path)
+	m.buildTileEnabler.Enable(variantName, route.Path)
 }
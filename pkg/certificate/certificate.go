@@ -0,0 +1,193 @@
+package certificate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultReloadInterval is minimum interval between two checks of certificate files
+	DefaultReloadInterval = time.Second * 10
+
+	selfSignedOrganization = "Monitoror"
+	selfSignedValidity     = time.Hour * 24 * 365 * 2
+)
+
+type (
+	// Reloader provide certificate loaded from files to tls.Config (see GetCertificate).
+	// Files are checked on handshake (at most every ReloadInterval) and reloaded when modified (ex: certificate renewal)
+	Reloader struct {
+		certFile string
+		keyFile  string
+
+		ReloadInterval time.Duration
+
+		lock        sync.RWMutex
+		certificate *tls.Certificate
+		modTime     time.Time
+		lastCheck   time.Time
+	}
+)
+
+// NewReloader load certificate from files. Return error if files are missing or invalid
+func NewReloader(certFile, keyFile string) (*Reloader, error) {
+	r := &Reloader{
+		certFile:       certFile,
+		keyFile:        keyFile,
+		ReloadInterval: DefaultReloadInterval,
+	}
+
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(modTime); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// GetCertificate is used as tls.Config GetCertificate function
+func (r *Reloader) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.reloadIfModified()
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.certificate, nil
+}
+
+// reloadIfModified keep previous certificate when files can't be read (ex: during renewal)
+func (r *Reloader) reloadIfModified() {
+	r.lock.Lock()
+	if time.Since(r.lastCheck) < r.ReloadInterval {
+		r.lock.Unlock()
+		return
+	}
+	r.lastCheck = time.Now()
+	previousModTime := r.modTime
+	r.lock.Unlock()
+
+	modTime, err := r.latestModTime()
+	if err != nil || !modTime.After(previousModTime) {
+		return
+	}
+
+	_ = r.load(modTime)
+}
+
+func (r *Reloader) load(modTime time.Time) error {
+	certificate, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.certificate = &certificate
+	r.modTime = modTime
+	r.lastCheck = time.Now()
+
+	return nil
+}
+
+func (r *Reloader) latestModTime() (time.Time, error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if keyInfo.ModTime().After(certInfo.ModTime()) {
+		return keyInfo.ModTime(), nil
+	}
+	return certInfo.ModTime(), nil
+}
+
+// GenerateSelfSigned create self-signed certificate valid for given hosts (DNS names or IPs). Return PEM encoded certificate and key
+func GenerateSelfSigned(hosts []string) (certPEM []byte, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{Organization: []string{selfSignedOrganization}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(selfSignedValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else if host != "" {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+	if len(template.DNSNames) > 0 {
+		template.Subject.CommonName = template.DNSNames[0]
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return certPEM, keyPEM, nil
+}
+
+// WriteSelfSigned generate self-signed certificate in certFile and keyFile when they don't exist yet,
+// so certificate stay the same between restarts (browsers of walls only trust it once)
+func WriteSelfSigned(certFile, keyFile string, hosts []string) (bool, error) {
+	_, certErr := os.Stat(certFile)
+	_, keyErr := os.Stat(keyFile)
+	if certErr == nil && keyErr == nil {
+		return false, nil
+	}
+
+	certPEM, keyPEM, err := GenerateSelfSigned(hosts)
+	if err != nil {
+		return false, err
+	}
+
+	if err := ioutil.WriteFile(certFile, certPEM, 0644); err != nil {
+		return false, err
+	}
+	if err := ioutil.WriteFile(keyFile, keyPEM, 0600); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
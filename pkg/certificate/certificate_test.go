@@ -0,0 +1,86 @@
+package certificate
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateSelfSigned(t *testing.T) {
+	certPEM, keyPEM, err := GenerateSelfSigned([]string{"localhost", "127.0.0.1", ""})
+	if assert.NoError(t, err) {
+		certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+		if assert.NoError(t, err) {
+			leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+			if assert.NoError(t, err) {
+				assert.NoError(t, leaf.VerifyHostname("localhost"))
+				assert.NoError(t, leaf.VerifyHostname("127.0.0.1"))
+				assert.Error(t, leaf.VerifyHostname("example.com"))
+			}
+		}
+	}
+}
+
+func TestReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "monitoror-certificate")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+
+	// Missing files
+	_, err = NewReloader(certFile, keyFile)
+	assert.Error(t, err)
+
+	generated, err := WriteSelfSigned(certFile, keyFile, []string{"first.example.com"})
+	assert.NoError(t, err)
+	assert.True(t, generated)
+
+	// Existing files are kept
+	generated, err = WriteSelfSigned(certFile, keyFile, []string{"other.example.com"})
+	assert.NoError(t, err)
+	assert.False(t, generated)
+
+	reloader, err := NewReloader(certFile, keyFile)
+	if !assert.NoError(t, err) {
+		return
+	}
+	reloader.ReloadInterval = 0
+	assert.Equal(t, "first.example.com", commonName(t, reloader))
+
+	// Renewal
+	certPEM, keyPEM, _ := GenerateSelfSigned([]string{"second.example.com"})
+	_ = ioutil.WriteFile(certFile, certPEM, 0644)
+	_ = ioutil.WriteFile(keyFile, keyPEM, 0600)
+	future := time.Now().Add(time.Minute)
+	_ = os.Chtimes(certFile, future, future)
+	assert.Equal(t, "second.example.com", commonName(t, reloader))
+
+	// Invalid files, previous certificate is kept
+	_ = ioutil.WriteFile(certFile, []byte("invalid"), 0644)
+	future = future.Add(time.Minute)
+	_ = os.Chtimes(certFile, future, future)
+	assert.Equal(t, "second.example.com", commonName(t, reloader))
+}
+
+func commonName(t *testing.T, reloader *Reloader) string {
+	certificate, err := reloader.GetCertificate(nil)
+	if !assert.NoError(t, err) {
+		return ""
+	}
+
+	leaf, err := x509.ParseCertificate(certificate.Certificate[0])
+	if !assert.NoError(t, err) {
+		return ""
+	}
+	return leaf.Subject.CommonName
+}
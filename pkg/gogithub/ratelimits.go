@@ -0,0 +1,14 @@
+//go:generate mockery -name RateLimitsService
+
+package gogithub
+
+import (
+	"context"
+
+	githubApi "github.com/google/go-github/github"
+)
+
+// RateLimitsService is implemented by github Client. Rate limit endpoint doesn't count against rate limit
+type RateLimitsService interface {
+	RateLimits(ctx context.Context) (*githubApi.RateLimits, *githubApi.Response, error)
+}
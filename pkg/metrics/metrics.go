@@ -0,0 +1,228 @@
+// Package metrics is a minimal implementation of counters and histograms exposed in Prometheus text format (version 0.0.4)
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType of Prometheus text format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets are default histogram buckets (in second), same as Prometheus client
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type (
+	// Registry keep every metric to expose them in Prometheus text format
+	Registry struct {
+		lock    sync.Mutex
+		metrics []metric
+	}
+
+	metric interface {
+		write(w *bufio.Writer)
+	}
+
+	// CounterVec is a counter partitioned by label values
+	CounterVec struct {
+		*vec
+	}
+
+	// HistogramVec is a histogram partitioned by label values
+	HistogramVec struct {
+		*vec
+		buckets []float64
+	}
+
+	vec struct {
+		lock       sync.Mutex
+		name       string
+		help       string
+		metricType string
+		labelNames []string
+		series     map[string]*series
+	}
+
+	series struct {
+		labelValues []string
+		value       float64  // Counter value or histogram sum
+		count       uint64   // Histogram only
+		buckets     []uint64 // Histogram only, not cumulative
+	}
+)
+
+// NewRegistry create an empty registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// NewCounterVec create counter in registry
+func (r *Registry) NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	counter := &CounterVec{newVec(name, help, "counter", labelNames)}
+	r.register(counter)
+	return counter
+}
+
+// NewHistogramVec create histogram in registry. Buckets must be sorted
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	histogram := &HistogramVec{newVec(name, help, "histogram", labelNames), buckets}
+	r.register(histogram)
+	return histogram
+}
+
+func (r *Registry) register(m metric) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.metrics = append(r.metrics, m)
+}
+
+// Write every metric in Prometheus text format
+func (r *Registry) Write(w io.Writer) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	writer := bufio.NewWriter(w)
+	for _, m := range r.metrics {
+		m.write(writer)
+	}
+
+	return writer.Flush()
+}
+
+// Inc increment counter of given label values (in labelNames order)
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add add value to counter of given label values (in labelNames order)
+func (c *CounterVec) Add(value float64, labelValues ...string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.getSeries(labelValues).value += value
+}
+
+func (c *CounterVec) write(w *bufio.Writer) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.writeHeader(w)
+	for _, s := range c.sortedSeries() {
+		writeSample(w, c.name, c.labelNames, s.labelValues, "", "", s.value)
+	}
+}
+
+// Observe add value in histogram of given label values (in labelNames order)
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	s := h.getSeries(labelValues)
+	if s.buckets == nil {
+		s.buckets = make([]uint64, len(h.buckets))
+	}
+
+	s.value += value
+	s.count++
+	if i := sort.SearchFloat64s(h.buckets, value); i < len(h.buckets) {
+		s.buckets[i]++
+	}
+}
+
+func (h *HistogramVec) write(w *bufio.Writer) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.writeHeader(w)
+	for _, s := range h.sortedSeries() {
+		var cumulativeCount uint64
+		for i, upperBound := range h.buckets {
+			cumulativeCount += s.buckets[i]
+			writeSample(w, h.name+"_bucket", h.labelNames, s.labelValues, "le", formatFloat(upperBound), float64(cumulativeCount))
+		}
+		writeSample(w, h.name+"_bucket", h.labelNames, s.labelValues, "le", "+Inf", float64(s.count))
+		writeSample(w, h.name+"_sum", h.labelNames, s.labelValues, "", "", s.value)
+		writeSample(w, h.name+"_count", h.labelNames, s.labelValues, "", "", float64(s.count))
+	}
+}
+
+func newVec(name, help, metricType string, labelNames []string) *vec {
+	return &vec{
+		name:       name,
+		help:       help,
+		metricType: metricType,
+		labelNames: labelNames,
+		series:     make(map[string]*series),
+	}
+}
+
+// getSeries return series of label values, must be called with lock
+func (v *vec) getSeries(labelValues []string) *series {
+	if len(labelValues) != len(v.labelNames) {
+		panic(fmt.Sprintf("metric %s: expected %d label values, got %d", v.name, len(v.labelNames), len(labelValues)))
+	}
+
+	key := strings.Join(labelValues, "\xff")
+	s, exists := v.series[key]
+	if !exists {
+		s = &series{labelValues: append([]string(nil), labelValues...)}
+		v.series[key] = s
+	}
+
+	return s
+}
+
+// sortedSeries return series sorted by label values, must be called with lock
+func (v *vec) sortedSeries() []*series {
+	keys := make([]string, 0, len(v.series))
+	for key := range v.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]*series, len(keys))
+	for i, key := range keys {
+		result[i] = v.series[key]
+	}
+
+	return result
+}
+
+func (v *vec) writeHeader(w *bufio.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", v.name, strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(v.help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", v.name, v.metricType)
+}
+
+func writeSample(w *bufio.Writer, name string, labelNames, labelValues []string, extraLabelName, extraLabelValue string, value float64) {
+	var labels []string
+	for i, labelName := range labelNames {
+		labels = append(labels, fmt.Sprintf(`%s="%s"`, labelName, escapeLabelValue(labelValues[i])))
+	}
+	if extraLabelName != "" {
+		labels = append(labels, fmt.Sprintf(`%s="%s"`, extraLabelName, extraLabelValue))
+	}
+
+	if len(labels) > 0 {
+		fmt.Fprintf(w, "%s{%s} %s\n", name, strings.Join(labels, ","), formatFloat(value))
+	} else {
+		fmt.Fprintf(w, "%s %s\n", name, formatFloat(value))
+	}
+}
+
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func formatFloat(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
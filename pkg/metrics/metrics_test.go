@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Run `go test ./pkg/metrics -update` to rewrite golden files after a deliberate format change
+var update = flag.Bool("update", false, "update golden files")
+
+func TestRegistry_Write(t *testing.T) {
+	for _, testcase := range []struct {
+		golden string
+		fill   func(registry *Registry)
+	}{
+		{
+			golden: "empty.golden",
+			fill:   func(_ *Registry) {},
+		},
+		{
+			golden: "counter.golden",
+			fill: func(registry *Registry) {
+				counter := registry.NewCounterVec("test_requests_total", "Requests count.", "route", "code")
+				counter.Inc("/b", "200")
+				counter.Inc("/a", "500")
+				counter.Add(2, "/a", "500")
+				counter.Add(0.5, "/a", "200")
+
+				noLabel := registry.NewCounterVec("test_total", "Without label.")
+				noLabel.Inc()
+
+				// Metric without series only expose its header
+				registry.NewCounterVec("test_unused_total", "Never incremented.", "label")
+			},
+		},
+		{
+			golden: "histogram.golden",
+			fill: func(registry *Registry) {
+				histogram := registry.NewHistogramVec("test_duration_seconds", "Requests duration.", []float64{0.1, 1}, "route")
+				histogram.Observe(0.05, "/a")
+				histogram.Observe(0.1, "/a") // Upper bound is inclusive
+				histogram.Observe(0.5, "/a")
+				histogram.Observe(3, "/a")
+				histogram.Observe(1, "/b")
+
+				noLabel := registry.NewHistogramVec("test_default_buckets_seconds", "Default buckets.", DefaultBuckets)
+				noLabel.Observe(0.2)
+			},
+		},
+		{
+			golden: "escaping.golden",
+			fill: func(registry *Registry) {
+				counter := registry.NewCounterVec("test_escaping_total", "Help with \\ backslash\nand new line.", "value")
+				counter.Inc(`/"quoted"`)
+				counter.Inc(`C:\path`)
+				counter.Inc("multi\nline")
+			},
+		},
+		{
+			golden: "special_values.golden",
+			fill: func(registry *Registry) {
+				counter := registry.NewCounterVec("test_special_total", "Special float values.", "value")
+				counter.Add(math.Inf(1), "+inf")
+				counter.Add(math.Inf(-1), "-inf")
+				counter.Add(math.NaN(), "nan")
+				counter.Add(1e21, "large")
+				counter.Add(1e-7, "small")
+			},
+		},
+	} {
+		registry := NewRegistry()
+		testcase.fill(registry)
+
+		output := &bytes.Buffer{}
+		if assert.NoError(t, registry.Write(output)) {
+			assertGolden(t, testcase.golden, output.Bytes())
+		}
+	}
+}
+
+func TestCounterVec_WrongLabelValues(t *testing.T) {
+	counter := NewRegistry().NewCounterVec("test_total", "Test.", "label")
+	assert.Panics(t, func() { counter.Inc() })
+}
+
+func assertGolden(t *testing.T, golden string, actual []byte) {
+	path := filepath.Join("testdata", golden)
+	if *update {
+		assert.NoError(t, ioutil.WriteFile(path, actual, 0644))
+	}
+
+	expected, err := ioutil.ReadFile(path)
+	if assert.NoError(t, err) {
+		assert.Equal(t, string(expected), string(actual), golden)
+	}
+}
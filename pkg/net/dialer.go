@@ -2,10 +2,13 @@
 
 package net
 
-import "net"
+import (
+	"context"
+	"net"
+)
 
 // A Dialer is a means to establish a connection.
 type Dialer interface {
-	// Dial connects to the given address via the proxy.
-	Dial(network, addr string) (c net.Conn, err error)
+	// DialContext connects to the given address, connection is aborted when ctx is done.
+	DialContext(ctx context.Context, network, addr string) (c net.Conn, err error)
 }
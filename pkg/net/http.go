@@ -0,0 +1,21 @@
+package net
+
+import (
+	"context"
+	"net/http"
+)
+
+type contextTransport struct {
+	ctx       context.Context
+	transport http.RoundTripper
+}
+
+// NewContextTransport return transport sending every request with ctx. Used by API clients without context support.
+// Request context is replaced, so ctx must carry the request timeout
+func NewContextTransport(ctx context.Context, transport http.RoundTripper) http.RoundTripper {
+	return &contextTransport{ctx: ctx, transport: transport}
+}
+
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.transport.RoundTrip(req.WithContext(t.ctx))
+}
@@ -0,0 +1,28 @@
+package net
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextTransport(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	client := &http.Client{Transport: NewContextTransport(ctx, http.DefaultTransport)}
+
+	resp, err := client.Get(ts.URL)
+	if assert.NoError(t, err) {
+		_ = resp.Body.Close()
+	}
+
+	cancel()
+	_, err = client.Get(ts.URL)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), context.Canceled.Error())
+}
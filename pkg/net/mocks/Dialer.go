@@ -3,9 +3,11 @@
 package mocks
 
 import (
-	net "net"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
+
+	net "net"
 )
 
 // Dialer is an autogenerated mock type for the Dialer type
@@ -13,13 +15,13 @@ type Dialer struct {
 	mock.Mock
 }
 
-// Dial provides a mock function with given fields: network, addr
-func (_m *Dialer) Dial(network string, addr string) (net.Conn, error) {
-	ret := _m.Called(network, addr)
+// DialContext provides a mock function with given fields: ctx, network, addr
+func (_m *Dialer) DialContext(ctx context.Context, network string, addr string) (net.Conn, error) {
+	ret := _m.Called(ctx, network, addr)
 
 	var r0 net.Conn
-	if rf, ok := ret.Get(0).(func(string, string) net.Conn); ok {
-		r0 = rf(network, addr)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) net.Conn); ok {
+		r0 = rf(ctx, network, addr)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(net.Conn)
@@ -27,8 +29,8 @@ func (_m *Dialer) Dial(network string, addr string) (net.Conn, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(network, addr)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, network, addr)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,26 @@
+package singleflight
+
+import (
+	"context"
+	"time"
+)
+
+// detachedContext keep values of ctx (request id, ...) but its deadline and cancellation come from base
+type detachedContext struct {
+	base   context.Context
+	values context.Context
+}
+
+// Detach return a context with values of ctx, only canceled when base is canceled (base nil is never canceled).
+// Used to run a call shared by several callers: it must not fail when the caller who started it is canceled
+func Detach(ctx, base context.Context) context.Context {
+	if base == nil {
+		base = context.Background()
+	}
+	return &detachedContext{base: base, values: ctx}
+}
+
+func (c *detachedContext) Deadline() (deadline time.Time, ok bool) { return c.base.Deadline() }
+func (c *detachedContext) Done() <-chan struct{}                   { return c.base.Done() }
+func (c *detachedContext) Err() error                              { return c.base.Err() }
+func (c *detachedContext) Value(key interface{}) interface{}       { return c.values.Value(key) }
@@ -0,0 +1,34 @@
+package singleflight
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type contextKey string
+
+func TestDetach(t *testing.T) {
+	base, cancelBase := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(context.WithValue(base, contextKey("key"), "value"))
+
+	detached := Detach(ctx, base)
+	assert.Equal(t, "value", detached.Value(contextKey("key")))
+
+	cancel()
+	assert.NoError(t, detached.Err())
+
+	cancelBase()
+	<-detached.Done()
+	assert.Equal(t, context.Canceled, detached.Err())
+}
+
+func TestDetach_WithoutBase(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	detached := Detach(ctx, nil)
+	assert.NoError(t, detached.Err())
+	assert.Nil(t, detached.Done())
+}
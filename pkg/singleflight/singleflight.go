@@ -0,0 +1,62 @@
+package singleflight
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrPanicked is returned to waiting callers when fn panic
+var ErrPanicked = errors.New("singleflight: call panicked")
+
+type (
+	// Group coalesce concurrent calls with the same key: only the first one is executed, others wait and share its result.
+	// Zero value is ready to use
+	Group struct {
+		lock  sync.Mutex
+		calls map[string]*call
+	}
+
+	call struct {
+		wg sync.WaitGroup
+
+		value interface{}
+		err   error
+		dups  int
+	}
+)
+
+// Do execute fn and return its result, unless a call with same key is in progress.
+// In this case, Do wait the end of this call and return its result. shared is true when result is returned to several callers
+func (g *Group) Do(key string, fn func() (interface{}, error)) (value interface{}, err error, shared bool) {
+	g.lock.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*call)
+	}
+	if c, ok := g.calls[key]; ok {
+		c.dups++
+		g.lock.Unlock()
+		c.wg.Wait()
+		return c.value, c.err, true
+	}
+
+	c := &call{err: ErrPanicked}
+	c.wg.Add(1)
+	g.calls[key] = c
+	g.lock.Unlock()
+
+	// Release waiting calls even if fn panic
+	defer func() {
+		g.lock.Lock()
+		delete(g.calls, key)
+		g.lock.Unlock()
+		c.wg.Done()
+	}()
+
+	c.value, c.err = fn()
+
+	g.lock.Lock()
+	shared = c.dups > 0
+	g.lock.Unlock()
+
+	return c.value, c.err, shared
+}
@@ -0,0 +1,106 @@
+package singleflight
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroup_Do(t *testing.T) {
+	var group Group
+
+	value, err, shared := group.Do("key", func() (interface{}, error) { return "value", nil })
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+	assert.False(t, shared)
+
+	_, err, _ = group.Do("key", func() (interface{}, error) { return nil, errors.New("boom") })
+	assert.EqualError(t, err, "boom")
+}
+
+func TestGroup_Do_Concurrent(t *testing.T) {
+	var group Group
+	var calls int32
+	release := make(chan struct{})
+
+	fn := func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return "value", nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]interface{}, 10)
+	shares := make([]bool, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _, shares[i] = group.Do("key", fn)
+		}(i)
+	}
+
+	// Wait all calls are waiting first one
+	for {
+		group.lock.Lock()
+		c := group.calls["key"]
+		ready := c != nil && c.dups == 9
+		group.lock.Unlock()
+		if ready {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for i := range results {
+		assert.Equal(t, "value", results[i])
+		assert.True(t, shares[i])
+	}
+
+	// Other key isn't coalesced
+	value, _, shared := group.Do("other", func() (interface{}, error) { return "other", nil })
+	assert.Equal(t, "other", value)
+	assert.False(t, shared)
+}
+
+func TestGroup_Do_Panic(t *testing.T) {
+	var group Group
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	go func() {
+		defer func() { _ = recover() }()
+		_, _, _ = group.Do("key", func() (interface{}, error) {
+			close(started)
+			<-release
+			panic("boom")
+		})
+	}()
+
+	<-started
+	done := make(chan error)
+	go func() {
+		_, err, _ := group.Do("key", func() (interface{}, error) { return nil, nil })
+		done <- err
+	}()
+
+	for {
+		group.lock.Lock()
+		dups := group.calls["key"].dups
+		group.lock.Unlock()
+		if dups == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+
+	assert.Equal(t, ErrPanicked, <-done)
+}
@@ -13,3 +13,4 @@ go generate ./...
 # Generating mocks for external interfaces
 mockery -name Client -output pkg/goazuredevops/build/mocks  -dir $(go list -m -f '{{ .Dir }}' github.com/jsdidierlaurent/azure-devops-go-api/azuredevops)/build
 mockery -name Client -output pkg/goazuredevops/release/mocks  -dir $(go list -m -f '{{ .Dir }}' github.com/jsdidierlaurent/azure-devops-go-api/azuredevops)/release
+mockery -name Client -output pkg/goazuredevops/location/mocks  -dir $(go list -m -f '{{ .Dir }}' github.com/jsdidierlaurent/azure-devops-go-api/azuredevops)/location
@@ -1,16 +1,41 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+
 	"github.com/jsdidierlaurent/echo-middleware/cache"
 	configDelivery "github.com/monitoror/monitoror/api/config/delivery/http"
+	configModels "github.com/monitoror/monitoror/api/config/models"
 	configRepository "github.com/monitoror/monitoror/api/config/repository"
 	configUsecase "github.com/monitoror/monitoror/api/config/usecase"
+	"github.com/monitoror/monitoror/api/health"
 	"github.com/monitoror/monitoror/api/info"
+	"github.com/monitoror/monitoror/api/stream"
+	streamDelivery "github.com/monitoror/monitoror/api/stream/delivery/http"
+	streamWebSocketDelivery "github.com/monitoror/monitoror/api/stream/delivery/websocket"
+	streamModels "github.com/monitoror/monitoror/api/stream/models"
+	streamUsecase "github.com/monitoror/monitoror/api/stream/usecase"
+	"github.com/monitoror/monitoror/cli"
 	"github.com/monitoror/monitoror/monitorables"
+	"github.com/monitoror/monitoror/service/auth"
+	"github.com/monitoror/monitoror/service/registry"
 	"github.com/monitoror/monitoror/service/router"
 )
 
 func InitApis(s *Server) {
+	// ------------- HEALTH ------------- //
+	healthDelivery := health.NewHTTPHealthDelivery(s.store.Health)
+	s.GET("/health/live", healthDelivery.GetLive)
+	s.GET("/health/ready", healthDelivery.GetReady)
+
+	// ------------- METRICS ------------- //
+	if s.store.Metrics != nil {
+		s.GET("/metrics", s.store.Metrics.Handler)
+	}
+
 	// API group
 	apiGroup := s.Group("/api/v1")
 
@@ -19,17 +44,94 @@ func InitApis(s *Server) {
 	apiGroup.GET("/info", s.store.CacheMiddleware.UpstreamCacheHandlerWithExpiration(cache.NEVER, infoDelivery.GetInfo))
 
 	// ------------- CONFIG ------------- //
-	confRepository := configRepository.NewConfigRepository()
+	confRepository := configRepository.NewConfigRepository(s.store.CoreConfig.ConfigRoot, s.store.CoreConfig.ConfigAllowedExtensions)
 	confUsecase := configUsecase.NewConfigUsecase(confRepository, s.store)
 	confDelivery := configDelivery.NewConfigDelivery(confUsecase)
 	apiGroup.GET("/config", s.store.CacheMiddleware.UpstreamCacheHandler(confDelivery.GetConfig))
+	apiGroup.GET("/configs", confDelivery.GetNamedConfigs)
+	apiGroup.GET("/config/schema", s.store.CacheMiddleware.UpstreamCacheHandlerWithExpiration(cache.NEVER, confDelivery.GetConfigSchema))
+	s.configUsecase = confUsecase
+
+	// ------------- STREAM ------------- //
+	// Tiles are fetched through echo server to reuse monitorables routes and cache
+	strUsecase := streamUsecase.NewStreamUsecase(s.Echo, s.store)
+	strDelivery := streamDelivery.NewStreamDelivery(confDelivery, strUsecase)
+	apiGroup.GET("/stream", strDelivery.GetStream)
+	strWebSocketDelivery := streamWebSocketDelivery.NewWebSocketDelivery(confDelivery, strUsecase, s.store.Registry.(*registry.MetadataRegistry),
+		s.store.CoreConfig.CorsAllowedOrigins, auth.IsEnabled(s.store.CoreConfig))
+	apiGroup.GET("/ws", strWebSocketDelivery.GetWebSocket)
+
+	// Reload config loaded from path when file change and notify stream clients
+	confDelivery.WatchConfigFiles(configRepository.NewFileWatcher(), func(requestURI string, revision string) {
+		reloadConfig(s, strUsecase, requestURI, revision)
+	})
 
 	// ---------------------------------- //
-	s.store.MonitorableRouter = router.NewMonitorableRouter(apiGroup, s.store.CacheMiddleware)
+	s.store.MonitorableRouter = router.NewMonitorableRouter(apiGroup, s.store.CacheMiddleware, s.store.Health)
 	// ---------------------------------- //
 
 	// ------------- MONITORABLES ------------- //
 	monitorableManager := monitorables.NewMonitorableManager(s.store)
 	monitorableManager.RegisterMonitorables()
 	monitorableManager.EnableMonitorables()
+	s.monitorableManager = monitorableManager
+}
+
+// DiagnoseMonitorables check enabled variants against their services and print results.
+// Return number of failed variants. Used by doctor command and startup diagnosis
+func (s *Server) DiagnoseMonitorables() int {
+	return s.monitorableManager.DiagnoseMonitorables(s.ctx)
+}
+
+// DescribeMonitorables return registered monitorables with their variants and tiles. Used by list command
+func (s *Server) DescribeMonitorables() []cli.MonitorableDescription {
+	return s.monitorableManager.DescribeMonitorables()
+}
+
+// PrintConfigSchema write JSON Schema of config for tiles enabled on this server
+func (s *Server) PrintConfigSchema(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s.configUsecase.GetSchema())
+}
+
+// VerifyConfig load config from path or URL, verify it and hydrate it when hydrate is true (tile generators are called).
+// Used by CLI, restrictions of config api (ConfigRoot, ConfigAllowedExtensions, DisableFreeFormConfig) are not applied
+func (s *Server) VerifyConfig(pathOrURL string, hydrate bool) *configModels.ConfigBag {
+	coreConfig := *s.store.CoreConfig
+	coreConfig.DisableFreeFormConfig = false
+	store := *s.store
+	store.CoreConfig = &coreConfig
+
+	confUsecase := configUsecase.NewConfigUsecase(configRepository.NewConfigRepository("", nil), &store)
+
+	params := &configModels.ConfigParams{}
+	if strings.HasPrefix(pathOrURL, "http://") || strings.HasPrefix(pathOrURL, "https://") {
+		params.URL = pathOrURL
+	} else {
+		params.Path = pathOrURL
+	}
+
+	configBag := confUsecase.GetConfig(params)
+	if len(configBag.Errors) == 0 {
+		confUsecase.Verify(configBag)
+	}
+	if len(configBag.Errors) == 0 && hydrate {
+		confUsecase.Hydrate(context.Background(), configBag)
+	}
+
+	return configBag
+}
+
+// reloadConfig remove config from upstream cache and notify stream clients
+func reloadConfig(s *Server, strUsecase stream.Usecase, requestURI string, revision string) {
+	_ = s.store.CacheMiddleware.DeleteUpstreamCache(requestURI)
+
+	strUsecase.Notify(streamModels.ServerEvent{
+		Type:     streamModels.ReloadEventType,
+		Message:  "config changed",
+		URL:      requestURI,
+		Revision: revision,
+	})
 }
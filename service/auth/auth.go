@@ -0,0 +1,289 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/monitoror/monitoror/config"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+const (
+	// FullScope give access to every route
+	FullScope Scope = "full"
+	// DisplayScope is read-only (GET and HEAD requests) and can't access admin routes (ex: /metrics)
+	DisplayScope Scope = "display"
+
+	// DisplayTokenQueryParam is used to give display token in wall URL (ex: https://monitoror.example.com/?token=xxx)
+	DisplayTokenQueryParam = "token"
+
+	SessionCookieName = "monitoror_session"
+	sessionDuration   = time.Hour * 24
+
+	// displayTokenContextKey keep display token removed from request URL by ExtractDisplayToken in echo.Context
+	displayTokenContextKey = "monitoror.auth.displayToken"
+
+	LoginPath    = "/auth/login"
+	CallbackPath = "/auth/callback"
+	LogoutPath   = "/auth/logout"
+)
+
+type (
+	Scope string
+
+	// Identity of authenticated request, available in request context (see IdentityFromContext)
+	Identity struct {
+		Name  string
+		Scope Scope
+	}
+
+	// Authenticator check credentials of every request when at least one authentication method is configured
+	Authenticator struct {
+		bearerTokens     []string
+		basicCredentials map[string]string
+		displayTokens    []string
+
+		cookieCodec *cookieCodec
+		oidc        *oidcProvider
+
+		// Paths reachable without authentication (ex: health checks)
+		publicPaths map[string]bool
+		// Paths forbidden to DisplayScope
+		adminPaths map[string]bool
+	}
+
+	identityContextKey struct{}
+)
+
+// InternalIdentity is used by requests made by server itself
+var InternalIdentity = &Identity{Name: "internal", Scope: FullScope}
+
+// IsEnabled return true when at least one authentication method is configured
+func IsEnabled(conf *config.Config) bool {
+	return len(nonEmpty(conf.AuthBearerTokens)) > 0 || len(nonEmpty(conf.AuthBasicCredentials)) > 0 ||
+		len(nonEmpty(conf.AuthDisplayTokens)) > 0 || conf.AuthOIDCIssuer != ""
+}
+
+// NewAuthenticator create Authenticator from config. Return nil when authentication isn't configured
+func NewAuthenticator(conf *config.Config) *Authenticator {
+	if !IsEnabled(conf) {
+		return nil
+	}
+
+	bearerTokens := nonEmpty(conf.AuthBearerTokens)
+	basicCredentials := nonEmpty(conf.AuthBasicCredentials)
+	displayTokens := nonEmpty(conf.AuthDisplayTokens)
+
+	a := &Authenticator{
+		bearerTokens:     bearerTokens,
+		basicCredentials: make(map[string]string),
+		displayTokens:    displayTokens,
+		cookieCodec:      newCookieCodec(conf.AuthSessionSecret),
+		publicPaths: map[string]bool{
+			"/health/live":  true,
+			"/health/ready": true,
+			LoginPath:       true,
+			CallbackPath:    true,
+			LogoutPath:      true,
+		},
+		adminPaths: map[string]bool{
+			"/metrics": true,
+		},
+	}
+
+	for _, credential := range basicCredentials {
+		// Password can contain ":"
+		parts := strings.SplitN(credential, ":", 2)
+		if len(parts) == 2 {
+			a.basicCredentials[parts[0]] = parts[1]
+		}
+	}
+
+	if conf.AuthOIDCIssuer != "" {
+		allowedEmails := nonEmpty(conf.AuthOIDCAllowedEmails)
+		allowedDomains := nonEmpty(conf.AuthOIDCAllowedDomains)
+		if len(allowedEmails) == 0 && len(allowedDomains) == 0 {
+			log.Warnf("AuthOIDCAllowedEmails and AuthOIDCAllowedDomains are empty, every OIDC login will be refused")
+		}
+
+		a.oidc = newOIDCProvider(conf.AuthOIDCIssuer, conf.AuthOIDCClientID, conf.AuthOIDCClientSecret, conf.AuthOIDCRedirectURL,
+			allowedEmails, allowedDomains)
+	}
+
+	return a
+}
+
+// WithIdentity return context containing identity. Requests made with this context are considered authenticated
+func WithIdentity(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityContextKey{}, identity)
+}
+
+// IdentityFromContext return identity of authenticated request, nil if missing
+func IdentityFromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityContextKey{}).(*Identity)
+	return identity
+}
+
+// ExtractDisplayToken remove display token query param from request URL, so it never appears in logs and cache keys.
+// Token is kept in echo.Context for Middleware. Must be used with echo.Pre to run before every other middleware
+func (a *Authenticator) ExtractDisplayToken() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			request := c.Request()
+			query := request.URL.Query()
+			if token := query.Get(DisplayTokenQueryParam); token != "" {
+				c.Set(displayTokenContextKey, token)
+
+				query.Del(DisplayTokenQueryParam)
+				request.URL.RawQuery = query.Encode()
+				request.RequestURI = request.URL.RequestURI()
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// Middleware reject unauthenticated requests. Identity is added in request context, so requests made by stream
+// usecase with this context (tile refresh) are authenticated too
+func (a *Authenticator) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if a.publicPaths[c.Request().URL.Path] {
+				return next(c)
+			}
+
+			// Display token in wall URL, open session and redirect to URL without token (browser history)
+			if token, ok := c.Get(displayTokenContextKey).(string); ok && containsToken(a.displayTokens, token) &&
+				(c.Request().Method == http.MethodGet || c.Request().Method == http.MethodHead) {
+				a.setSession(c, &Identity{Name: "display", Scope: DisplayScope})
+				return c.Redirect(http.StatusFound, c.Request().URL.RequestURI())
+			}
+
+			identity := IdentityFromContext(c.Request().Context())
+			if identity == nil {
+				identity = a.authenticate(c)
+				if identity == nil {
+					return a.unauthorized(c)
+				}
+				c.SetRequest(c.Request().WithContext(WithIdentity(c.Request().Context(), identity)))
+			}
+
+			if identity.Scope == DisplayScope && !a.isReadOnlyAllowed(c.Request()) {
+				return echo.NewHTTPError(http.StatusForbidden, "display token is read-only")
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// RegisterRoutes add OIDC login flow routes when OIDC is configured
+func (a *Authenticator) RegisterRoutes(e *echo.Echo) {
+	if a.oidc == nil {
+		return
+	}
+
+	e.GET(LoginPath, a.login)
+	e.GET(CallbackPath, a.callback)
+	e.GET(LogoutPath, a.logout)
+}
+
+func (a *Authenticator) authenticate(c echo.Context) *Identity {
+	// Bearer token
+	authorization := c.Request().Header.Get(echo.HeaderAuthorization)
+	if token := strings.TrimPrefix(authorization, "Bearer "); token != authorization {
+		if containsToken(a.bearerTokens, token) {
+			return &Identity{Name: "bearer", Scope: FullScope}
+		}
+		if containsToken(a.displayTokens, token) {
+			return &Identity{Name: "display", Scope: DisplayScope}
+		}
+	}
+
+	// Basic auth
+	if username, password, ok := c.Request().BasicAuth(); ok {
+		if expected, exists := a.basicCredentials[username]; exists && subtle.ConstantTimeCompare([]byte(expected), []byte(password)) == 1 {
+			return &Identity{Name: username, Scope: FullScope}
+		}
+	}
+
+	// Session cookie (OIDC login or display token)
+	if cookie, err := c.Cookie(SessionCookieName); err == nil {
+		s := &session{}
+		if err := a.cookieCodec.decode(cookie.Value, s); err == nil {
+			return &Identity{Name: s.Name, Scope: s.Scope}
+		}
+	}
+
+	return nil
+}
+
+func (a *Authenticator) unauthorized(c echo.Context) error {
+	request := c.Request()
+
+	// Browser navigation, start OIDC login flow
+	if a.oidc != nil && request.Method == http.MethodGet && strings.Contains(request.Header.Get(echo.HeaderAccept), echo.MIMETextHTML) {
+		return c.Redirect(http.StatusFound, LoginPath+"?redirect="+url.QueryEscape(request.URL.RequestURI()))
+	}
+
+	if len(a.basicCredentials) > 0 {
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Basic realm="Monitoror"`)
+	} else {
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer realm="Monitoror"`)
+	}
+
+	return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+}
+
+func (a *Authenticator) isReadOnlyAllowed(request *http.Request) bool {
+	return (request.Method == http.MethodGet || request.Method == http.MethodHead) && !a.adminPaths[request.URL.Path]
+}
+
+func (a *Authenticator) setSession(c echo.Context, identity *Identity) {
+	value, err := a.cookieCodec.encode(&session{
+		Name:   identity.Name,
+		Scope:  identity.Scope,
+		Expire: time.Now().Add(sessionDuration).Unix(),
+	})
+	if err != nil {
+		return
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     SessionCookieName,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   int(sessionDuration.Seconds()),
+		HttpOnly: true,
+		Secure:   c.Scheme() == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// containsToken compare tokens in constant time
+func containsToken(tokens []string, token string) bool {
+	found := false
+	for _, t := range tokens {
+		if subtle.ConstantTimeCompare([]byte(t), []byte(token)) == 1 {
+			found = true
+		}
+	}
+	return found
+}
+
+func nonEmpty(values []string) []string {
+	var result []string
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			result = append(result, value)
+		}
+	}
+	return result
+}
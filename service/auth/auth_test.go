@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/monitoror/monitoror/config"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func initAuthEcho(conf *config.Config) *echo.Echo {
+	authenticator := NewAuthenticator(conf)
+
+	e := echo.New()
+	e.Pre(authenticator.ExtractDisplayToken())
+	e.Use(authenticator.Middleware())
+
+	handler := func(c echo.Context) error {
+		return c.String(http.StatusOK, IdentityFromContext(c.Request().Context()).Name)
+	}
+	e.GET("/api/v1/info", handler)
+	e.POST("/api/v1/info", handler)
+	e.GET("/metrics", handler)
+	e.GET("/health/live", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	return e
+}
+
+func serveAuthEcho(e *echo.Echo, req *http.Request) *httptest.ResponseRecorder {
+	res := httptest.NewRecorder()
+	e.ServeHTTP(res, req)
+	return res
+}
+
+func TestNewAuthenticator_Disabled(t *testing.T) {
+	assert.Nil(t, NewAuthenticator(&config.Config{}))
+	assert.Nil(t, NewAuthenticator(&config.Config{AuthBearerTokens: []string{""}}))
+}
+
+func TestAuthenticator_Bearer(t *testing.T) {
+	e := initAuthEcho(&config.Config{AuthBearerTokens: []string{"token1", "token2"}})
+
+	// Without token
+	res := serveAuthEcho(e, httptest.NewRequest(http.MethodGet, "/api/v1/info", nil))
+	assert.Equal(t, http.StatusUnauthorized, res.Code)
+	assert.Equal(t, `Bearer realm="Monitoror"`, res.Header().Get(echo.HeaderWWWAuthenticate))
+
+	// Wrong token
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/info", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer wrong")
+	assert.Equal(t, http.StatusUnauthorized, serveAuthEcho(e, req).Code)
+
+	// Valid token
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/info", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer token2")
+	res = serveAuthEcho(e, req)
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "bearer", res.Body.String())
+
+	// Public path
+	assert.Equal(t, http.StatusOK, serveAuthEcho(e, httptest.NewRequest(http.MethodGet, "/health/live", nil)).Code)
+
+	// Request with authenticated context (internal request)
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/info", nil)
+	req = req.WithContext(WithIdentity(context.Background(), InternalIdentity))
+	res = serveAuthEcho(e, req)
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "internal", res.Body.String())
+}
+
+func TestAuthenticator_Basic(t *testing.T) {
+	e := initAuthEcho(&config.Config{AuthBasicCredentials: []string{"admin:pass:word"}})
+
+	res := serveAuthEcho(e, httptest.NewRequest(http.MethodGet, "/api/v1/info", nil))
+	assert.Equal(t, http.StatusUnauthorized, res.Code)
+	assert.Equal(t, `Basic realm="Monitoror"`, res.Header().Get(echo.HeaderWWWAuthenticate))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/info", nil)
+	req.SetBasicAuth("admin", "pass")
+	assert.Equal(t, http.StatusUnauthorized, serveAuthEcho(e, req).Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/info", nil)
+	req.SetBasicAuth("admin", "pass:word")
+	res = serveAuthEcho(e, req)
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "admin", res.Body.String())
+}
+
+func TestAuthenticator_DisplayToken(t *testing.T) {
+	e := initAuthEcho(&config.Config{AuthDisplayTokens: []string{"wall"}})
+
+	// Token in query param, session cookie is added and browser is redirected to URL without token
+	res := serveAuthEcho(e, httptest.NewRequest(http.MethodGet, "/api/v1/info?configName=lobby&token=wall", nil))
+	assert.Equal(t, http.StatusFound, res.Code)
+	assert.Equal(t, "/api/v1/info?configName=lobby", res.Header().Get(echo.HeaderLocation))
+
+	cookies := (&http.Response{Header: res.Header()}).Cookies()
+	if assert.Len(t, cookies, 1) {
+		assert.Equal(t, SessionCookieName, cookies[0].Name)
+		assert.True(t, cookies[0].HttpOnly)
+
+		// Next request with cookie only
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/info?configName=lobby", nil)
+		req.AddCookie(cookies[0])
+		res = serveAuthEcho(e, req)
+		assert.Equal(t, http.StatusOK, res.Code)
+		assert.Equal(t, "display", res.Body.String())
+
+		// Read-only
+		req = httptest.NewRequest(http.MethodPost, "/api/v1/info", nil)
+		req.AddCookie(cookies[0])
+		assert.Equal(t, http.StatusForbidden, serveAuthEcho(e, req).Code)
+
+		// Admin route
+		req = httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		req.AddCookie(cookies[0])
+		assert.Equal(t, http.StatusForbidden, serveAuthEcho(e, req).Code)
+	}
+
+	// Wrong token
+	assert.Equal(t, http.StatusUnauthorized, serveAuthEcho(e, httptest.NewRequest(http.MethodGet, "/api/v1/info?token=wrong", nil)).Code)
+
+	// Display token in header
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/info", nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer wall")
+	assert.Equal(t, http.StatusOK, serveAuthEcho(e, req).Code)
+}
+
+func TestAuthenticator_OIDCRedirect(t *testing.T) {
+	e := initAuthEcho(&config.Config{AuthOIDCIssuer: "https://issuer.example.com"})
+
+	// Browser
+	req := httptest.NewRequest(http.MethodGet, "/?configName=lobby", nil)
+	req.Header.Set(echo.HeaderAccept, "text/html,application/xhtml+xml")
+	res := serveAuthEcho(e, req)
+	assert.Equal(t, http.StatusFound, res.Code)
+	assert.Equal(t, "/auth/login?redirect=%2F%3FconfigName%3Dlobby", res.Header().Get(echo.HeaderLocation))
+
+	// API call
+	assert.Equal(t, http.StatusUnauthorized, serveAuthEcho(e, httptest.NewRequest(http.MethodGet, "/api/v1/info", nil)).Code)
+}
+
+func TestAuthenticator_ExtractDisplayToken(t *testing.T) {
+	authenticator := NewAuthenticator(&config.Config{AuthDisplayTokens: []string{"wall"}})
+
+	var requestURIs []string
+	e := echo.New()
+	e.Pre(authenticator.ExtractDisplayToken())
+	// Like loggers and cache middleware, registered before authentication
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			requestURIs = append(requestURIs, c.Request().RequestURI)
+			return next(c)
+		}
+	})
+	e.Use(authenticator.Middleware())
+	e.GET("/api/v1/info", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	assert.Equal(t, http.StatusFound, serveAuthEcho(e, httptest.NewRequest(http.MethodGet, "/api/v1/info?token=wall", nil)).Code)
+	assert.Equal(t, http.StatusUnauthorized, serveAuthEcho(e, httptest.NewRequest(http.MethodGet, "/api/v1/info?token=wrong&configName=lobby", nil)).Code)
+	assert.Equal(t, []string{"/api/v1/info", "/api/v1/info?configName=lobby"}, requestURIs)
+}
@@ -0,0 +1,300 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"golang.org/x/oauth2"
+)
+
+const (
+	oidcStateCookieName = "monitoror_oidc"
+	oidcStateDuration   = time.Minute * 10
+
+	oidcDiscoveryPath = "/.well-known/openid-configuration"
+)
+
+type (
+	// oidcProvider implement OpenID Connect authorization code flow. ID token is received directly from token endpoint
+	// (TLS), so we only check its claims and not its signature (see OpenID Connect Core 1.0, section 3.1.3.7)
+	oidcProvider struct {
+		issuer       string
+		clientID     string
+		clientSecret string
+		redirectURL  string
+
+		// allowedEmails and allowedDomains (lower case) restrict login to these verified emails. Empty refuse everyone
+		allowedEmails  map[string]bool
+		allowedDomains map[string]bool
+
+		httpClient *http.Client
+
+		// oauth2Config is built from discovery document on first login
+		lock         sync.Mutex
+		oauth2Config *oauth2.Config
+	}
+
+	oidcDiscovery struct {
+		Issuer                string `json:"issuer"`
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+	}
+
+	idTokenClaims struct {
+		Issuer            string   `json:"iss"`
+		Subject           string   `json:"sub"`
+		Audience          audience `json:"aud"`
+		Expire            int64    `json:"exp"`
+		Nonce             string   `json:"nonce"`
+		Email             string   `json:"email"`
+		EmailVerified     *bool    `json:"email_verified"`
+		PreferredUsername string   `json:"preferred_username"`
+	}
+
+	// audience can be a string or an array of string
+	audience []string
+)
+
+func newOIDCProvider(issuer, clientID, clientSecret, redirectURL string, allowedEmails, allowedDomains []string) *oidcProvider {
+	p := &oidcProvider{
+		issuer:         strings.TrimSuffix(issuer, "/"),
+		clientID:       clientID,
+		clientSecret:   clientSecret,
+		redirectURL:    redirectURL,
+		allowedEmails:  make(map[string]bool),
+		allowedDomains: make(map[string]bool),
+		httpClient:     &http.Client{Timeout: time.Second * 10},
+	}
+
+	for _, email := range allowedEmails {
+		p.allowedEmails[strings.ToLower(email)] = true
+	}
+	for _, domain := range allowedDomains {
+		p.allowedDomains[strings.ToLower(strings.TrimPrefix(domain, "@"))] = true
+	}
+
+	return p
+}
+
+func (p *oidcProvider) getOAuth2Config() (*oauth2.Config, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.oauth2Config != nil {
+		return p.oauth2Config, nil
+	}
+
+	resp, err := p.httpClient.Get(p.issuer + oidcDiscoveryPath)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to get openid configuration: %s", resp.Status)
+	}
+
+	discovery := &oidcDiscovery{}
+	if err := json.NewDecoder(resp.Body).Decode(discovery); err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != p.issuer {
+		return nil, fmt.Errorf("issuer mismatch, expected %s got %s", p.issuer, discovery.Issuer)
+	}
+
+	p.oauth2Config = &oauth2.Config{
+		ClientID:     p.clientID,
+		ClientSecret: p.clientSecret,
+		RedirectURL:  p.redirectURL,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  discovery.AuthorizationEndpoint,
+			TokenURL: discovery.TokenEndpoint,
+		},
+		Scopes: []string{"openid", "profile", "email"},
+	}
+
+	return p.oauth2Config, nil
+}
+
+// verifyIDToken check claims of ID token and return them
+func (p *oidcProvider) verifyIDToken(rawIDToken, nonce string) (*idTokenClaims, error) {
+	parts := strings.Split(rawIDToken, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed id token")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed id token payload, %v", err)
+	}
+
+	claims := &idTokenClaims{}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, fmt.Errorf("malformed id token payload, %v", err)
+	}
+
+	switch {
+	case strings.TrimSuffix(claims.Issuer, "/") != p.issuer:
+		return nil, fmt.Errorf("unexpected id token issuer: %s", claims.Issuer)
+	case !claims.Audience.contains(p.clientID):
+		return nil, errors.New("id token not issued for this client")
+	case time.Now().Unix() > claims.Expire:
+		return nil, errors.New("id token expired")
+	case claims.Nonce != nonce:
+		return nil, errors.New("id token nonce mismatch")
+	}
+
+	return claims, nil
+}
+
+// isAllowed return true when verified email of identity is in allowed emails or allowed domains
+func (p *oidcProvider) isAllowed(claims *idTokenClaims) bool {
+	// Email not verified by provider can be set to anything by user
+	if claims.Email == "" || (claims.EmailVerified != nil && !*claims.EmailVerified) {
+		return false
+	}
+
+	email := strings.ToLower(claims.Email)
+	if p.allowedEmails[email] {
+		return true
+	}
+
+	at := strings.LastIndex(email, "@")
+	return at >= 0 && p.allowedDomains[email[at+1:]]
+}
+
+// name return identity name from claims (email, preferred username or subject)
+func (c *idTokenClaims) name() string {
+	switch {
+	case c.Email != "":
+		return c.Email
+	case c.PreferredUsername != "":
+		return c.PreferredUsername
+	default:
+		return c.Subject
+	}
+}
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+	return nil
+}
+
+func (a audience) contains(clientID string) bool {
+	for _, aud := range a {
+		if aud == clientID {
+			return true
+		}
+	}
+	return false
+}
+
+// login redirect browser to OIDC provider. State, nonce and redirect URL are kept in signed cookie
+func (a *Authenticator) login(c echo.Context) error {
+	oauth2Config, err := a.oidc.getOAuth2Config()
+	if err != nil {
+		log.Errorf("unable to start oidc login, %v", err)
+		return echo.NewHTTPError(http.StatusBadGateway, "unable to reach identity provider")
+	}
+
+	state := &oidcState{
+		State:    randomString(),
+		Nonce:    randomString(),
+		Redirect: safeRedirect(c.QueryParam("redirect")),
+		Expire:   time.Now().Add(oidcStateDuration).Unix(),
+	}
+	value, err := a.cookieCodec.encode(state)
+	if err != nil {
+		return err
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     oidcStateCookieName,
+		Value:    value,
+		Path:     CallbackPath,
+		MaxAge:   int(oidcStateDuration.Seconds()),
+		HttpOnly: true,
+		Secure:   c.Scheme() == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return c.Redirect(http.StatusFound, oauth2Config.AuthCodeURL(state.State, oauth2.SetAuthURLParam("nonce", state.Nonce)))
+}
+
+// callback exchange authorization code, verify ID token and open session
+func (a *Authenticator) callback(c echo.Context) error {
+	cookie, err := c.Cookie(oidcStateCookieName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "missing login state, retry login")
+	}
+
+	state := &oidcState{}
+	if err := a.cookieCodec.decode(cookie.Value, state); err != nil || state.State != c.QueryParam("state") {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid login state, retry login")
+	}
+	if errorCode := c.QueryParam("error"); errorCode != "" {
+		return echo.NewHTTPError(http.StatusUnauthorized, fmt.Sprintf("login failed: %s", errorCode))
+	}
+
+	oauth2Config, err := a.oidc.getOAuth2Config()
+	if err != nil {
+		log.Errorf("unable to finish oidc login, %v", err)
+		return echo.NewHTTPError(http.StatusBadGateway, "unable to reach identity provider")
+	}
+
+	ctx := c.Request().Context()
+	token, err := oauth2Config.Exchange(ctx, c.QueryParam("code"))
+	if err != nil {
+		log.Warnf("unable to exchange oidc code, %v", err)
+		return echo.NewHTTPError(http.StatusUnauthorized, "login failed")
+	}
+
+	rawIDToken, _ := token.Extra("id_token").(string)
+	claims, err := a.oidc.verifyIDToken(rawIDToken, state.Nonce)
+	if err != nil {
+		log.Warnf("invalid oidc id token, %v", err)
+		return echo.NewHTTPError(http.StatusUnauthorized, "login failed")
+	}
+
+	// Remove state cookie
+	c.SetCookie(&http.Cookie{Name: oidcStateCookieName, Path: CallbackPath, MaxAge: -1})
+
+	if !a.oidc.isAllowed(claims) {
+		log.Warnf("oidc login refused for %s, not in allowed emails or domains", claims.name())
+		return echo.NewHTTPError(http.StatusForbidden, "account not allowed")
+	}
+	a.setSession(c, &Identity{Name: claims.name(), Scope: FullScope})
+
+	return c.Redirect(http.StatusFound, state.Redirect)
+}
+
+func (a *Authenticator) logout(c echo.Context) error {
+	c.SetCookie(&http.Cookie{Name: SessionCookieName, Path: "/", MaxAge: -1})
+	return c.Redirect(http.StatusFound, "/")
+}
+
+// safeRedirect only keep local redirect to avoid open redirect
+func safeRedirect(redirect string) string {
+	if !strings.HasPrefix(redirect, "/") || strings.HasPrefix(redirect, "//") || strings.HasPrefix(redirect, "/\\") {
+		return "/"
+	}
+	return redirect
+}
@@ -0,0 +1,222 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/monitoror/monitoror/config"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildIDToken(claims map[string]interface{}) string {
+	payload, _ := json.Marshal(claims)
+	return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString(payload) + ".signature"
+}
+
+// initFakeProvider start fake OIDC provider returning ID token with given nonce for john@example.com
+func initFakeProvider(t *testing.T, nonce *string) *httptest.Server {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+
+	mux.HandleFunc(oidcDiscoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 server.URL,
+			"authorization_endpoint": server.URL + "/authorize",
+			"token_endpoint":         server.URL + "/token",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		if r.Form.Get("code") != "code" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access",
+			"token_type":   "Bearer",
+			"id_token": buildIDToken(map[string]interface{}{
+				"iss":   server.URL,
+				"aud":   "monitoror",
+				"sub":   "1234",
+				"email": "john@example.com",
+				"exp":   time.Now().Add(time.Hour).Unix(),
+				"nonce": *nonce,
+			}),
+		})
+	})
+
+	return server
+}
+
+func TestOIDCProvider_VerifyIDToken(t *testing.T) {
+	p := newOIDCProvider("https://issuer.example.com/", "monitoror", "", "", nil, nil)
+	exp := time.Now().Add(time.Hour).Unix()
+
+	for _, testcase := range []struct {
+		claims       map[string]interface{}
+		expectedName string
+		expectedErr  bool
+	}{
+		{claims: map[string]interface{}{"iss": "https://issuer.example.com", "aud": "monitoror", "exp": exp, "nonce": "n", "sub": "1234"}, expectedName: "1234"},
+		{claims: map[string]interface{}{"iss": "https://issuer.example.com", "aud": []string{"other", "monitoror"}, "exp": exp, "nonce": "n", "sub": "1234", "preferred_username": "john"}, expectedName: "john"},
+		{claims: map[string]interface{}{"iss": "https://issuer.example.com", "aud": "monitoror", "exp": exp, "nonce": "n", "email": "john@example.com", "preferred_username": "john"}, expectedName: "john@example.com"},
+		{claims: map[string]interface{}{"iss": "https://other.example.com", "aud": "monitoror", "exp": exp, "nonce": "n"}, expectedErr: true},
+		{claims: map[string]interface{}{"iss": "https://issuer.example.com", "aud": "other", "exp": exp, "nonce": "n"}, expectedErr: true},
+		{claims: map[string]interface{}{"iss": "https://issuer.example.com", "aud": "monitoror", "exp": time.Now().Add(-time.Hour).Unix(), "nonce": "n"}, expectedErr: true},
+		{claims: map[string]interface{}{"iss": "https://issuer.example.com", "aud": "monitoror", "exp": exp, "nonce": "other"}, expectedErr: true},
+	} {
+		claims, err := p.verifyIDToken(buildIDToken(testcase.claims), "n")
+		if testcase.expectedErr {
+			assert.Error(t, err)
+		} else if assert.NoError(t, err) {
+			assert.Equal(t, testcase.expectedName, claims.name())
+		}
+	}
+
+	_, err := p.verifyIDToken("malformed", "n")
+	assert.Error(t, err)
+}
+
+func TestOIDCProvider_IsAllowed(t *testing.T) {
+	p := newOIDCProvider("https://issuer.example.com", "monitoror", "", "", []string{"John@Other.com"}, []string{"@example.com"})
+	verified, unverified := true, false
+
+	for _, testcase := range []struct {
+		claims   *idTokenClaims
+		expected bool
+	}{
+		{claims: &idTokenClaims{Email: "jane@example.com"}, expected: true},
+		{claims: &idTokenClaims{Email: "Jane@EXAMPLE.com", EmailVerified: &verified}, expected: true},
+		{claims: &idTokenClaims{Email: "john@other.com"}, expected: true},
+		{claims: &idTokenClaims{Email: "jane@other.com"}, expected: false},
+		{claims: &idTokenClaims{Email: "jane@sub.example.com"}, expected: false},
+		{claims: &idTokenClaims{Email: "jane@example.com.evil.com"}, expected: false},
+		{claims: &idTokenClaims{Email: "jane@example.com", EmailVerified: &unverified}, expected: false},
+		{claims: &idTokenClaims{Subject: "1234", PreferredUsername: "example.com"}, expected: false},
+	} {
+		assert.Equal(t, testcase.expected, p.isAllowed(testcase.claims), testcase.claims.Email)
+	}
+
+	// Nobody is allowed without allowed emails and domains
+	p = newOIDCProvider("https://issuer.example.com", "monitoror", "", "", nil, nil)
+	assert.False(t, p.isAllowed(&idTokenClaims{Email: "john@example.com"}))
+}
+
+// startOIDCLogin follow login route and return state, state cookie and fill nonce used by fake provider
+func startOIDCLogin(t *testing.T, e *echo.Echo, providerURL string, nonce *string) (string, *http.Cookie) {
+	res := httptest.NewRecorder()
+	e.ServeHTTP(res, httptest.NewRequest(http.MethodGet, LoginPath+"?redirect=%2F%3FconfigName%3Dlobby", nil))
+	assert.Equal(t, http.StatusFound, res.Code)
+
+	location, err := url.Parse(res.Header().Get(echo.HeaderLocation))
+	if !assert.NoError(t, err) {
+		return "", nil
+	}
+	assert.Equal(t, providerURL+"/authorize", fmt.Sprintf("%s://%s%s", location.Scheme, location.Host, location.Path))
+	assert.Equal(t, "monitoror", location.Query().Get("client_id"))
+	*nonce = location.Query().Get("nonce")
+
+	stateCookies := (&http.Response{Header: res.Header()}).Cookies()
+	if !assert.Len(t, stateCookies, 1) {
+		return "", nil
+	}
+
+	return location.Query().Get("state"), stateCookies[0]
+}
+
+func TestAuthenticator_OIDCLogin(t *testing.T) {
+	var nonce string
+	provider := initFakeProvider(t, &nonce)
+	defer provider.Close()
+
+	authenticator := NewAuthenticator(&config.Config{
+		AuthOIDCIssuer:         provider.URL,
+		AuthOIDCClientID:       "monitoror",
+		AuthOIDCRedirectURL:    "http://monitoror.example.com" + CallbackPath,
+		AuthOIDCAllowedDomains: []string{"example.com"},
+	})
+	e := echo.New()
+	authenticator.RegisterRoutes(e)
+
+	// Login
+	state, stateCookie := startOIDCLogin(t, e, provider.URL, &nonce)
+	if stateCookie == nil {
+		return
+	}
+
+	// Callback with wrong state
+	req := httptest.NewRequest(http.MethodGet, CallbackPath+"?code=code&state=wrong", nil)
+	req.AddCookie(stateCookie)
+	res := httptest.NewRecorder()
+	e.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+
+	// Callback
+	req = httptest.NewRequest(http.MethodGet, CallbackPath+"?code=code&state="+state, nil)
+	req.AddCookie(stateCookie)
+	res = httptest.NewRecorder()
+	e.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusFound, res.Code)
+	assert.Equal(t, "/?configName=lobby", res.Header().Get(echo.HeaderLocation))
+
+	var sessionCookie *http.Cookie
+	for _, cookie := range (&http.Response{Header: res.Header()}).Cookies() {
+		if cookie.Name == SessionCookieName {
+			sessionCookie = cookie
+		}
+	}
+	if assert.NotNil(t, sessionCookie) {
+		s := &session{}
+		if assert.NoError(t, authenticator.cookieCodec.decode(sessionCookie.Value, s)) {
+			assert.Equal(t, "john@example.com", s.Name)
+			assert.Equal(t, FullScope, s.Scope)
+		}
+	}
+}
+
+func TestAuthenticator_OIDCLogin_NotAllowed(t *testing.T) {
+	var nonce string
+	provider := initFakeProvider(t, &nonce)
+	defer provider.Close()
+
+	authenticator := NewAuthenticator(&config.Config{
+		AuthOIDCIssuer:        provider.URL,
+		AuthOIDCClientID:      "monitoror",
+		AuthOIDCRedirectURL:   "http://monitoror.example.com" + CallbackPath,
+		AuthOIDCAllowedEmails: []string{"jane@example.com"},
+	})
+	e := echo.New()
+	authenticator.RegisterRoutes(e)
+
+	state, stateCookie := startOIDCLogin(t, e, provider.URL, &nonce)
+	if stateCookie == nil {
+		return
+	}
+
+	req := httptest.NewRequest(http.MethodGet, CallbackPath+"?code=code&state="+state, nil)
+	req.AddCookie(stateCookie)
+	res := httptest.NewRecorder()
+	e.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusForbidden, res.Code)
+	for _, cookie := range (&http.Response{Header: res.Header()}).Cookies() {
+		assert.NotEqual(t, SessionCookieName, cookie.Name)
+	}
+}
+
+func TestSafeRedirect(t *testing.T) {
+	assert.Equal(t, "/?configName=lobby", safeRedirect("/?configName=lobby"))
+	assert.Equal(t, "/", safeRedirect(""))
+	assert.Equal(t, "/", safeRedirect("https://evil.example.com"))
+	assert.Equal(t, "/", safeRedirect("//evil.example.com"))
+	assert.Equal(t, "/", safeRedirect("/\\evil.example.com"))
+}
@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCookie = errors.New("invalid or expired cookie")
+
+type (
+	// cookieCodec sign cookie values with HMAC-SHA256. Cookies contain expiration and can't be altered by client
+	cookieCodec struct {
+		secret []byte
+	}
+
+	session struct {
+		Name   string `json:"name"`
+		Scope  Scope  `json:"scope"`
+		Expire int64  `json:"exp"`
+	}
+
+	// oidcState is stored in cookie during OIDC login flow
+	oidcState struct {
+		State    string `json:"state"`
+		Nonce    string `json:"nonce"`
+		Redirect string `json:"redirect"`
+		Expire   int64  `json:"exp"`
+	}
+
+	expirable interface {
+		expired() bool
+	}
+)
+
+// newCookieCodec use secret to sign cookies, random secret is generated when empty (cookies are lost on restart)
+func newCookieCodec(secret string) *cookieCodec {
+	if secret == "" {
+		return &cookieCodec{secret: randomBytes(32)}
+	}
+	return &cookieCodec{secret: []byte(secret)}
+}
+
+func (cc *cookieCodec) encode(value interface{}) (string, error) {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(bytes)
+	return payload + "." + cc.sign(payload), nil
+}
+
+func (cc *cookieCodec) decode(cookie string, value expirable) error {
+	parts := strings.Split(cookie, ".")
+	if len(parts) != 2 || !hmac.Equal([]byte(parts[1]), []byte(cc.sign(parts[0]))) {
+		return ErrInvalidCookie
+	}
+
+	bytes, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return ErrInvalidCookie
+	}
+
+	if err := json.Unmarshal(bytes, value); err != nil || value.expired() {
+		return ErrInvalidCookie
+	}
+
+	return nil
+}
+
+func (cc *cookieCodec) sign(payload string) string {
+	mac := hmac.New(sha256.New, cc.secret)
+	_, _ = mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (s *session) expired() bool {
+	return time.Now().Unix() > s.Expire
+}
+
+func (s *oidcState) expired() bool {
+	return time.Now().Unix() > s.Expire
+}
+
+func randomBytes(n int) []byte {
+	bytes := make([]byte, n)
+	if _, err := rand.Read(bytes); err != nil {
+		panic(err)
+	}
+	return bytes
+}
+
+func randomString() string {
+	return base64.RawURLEncoding.EncodeToString(randomBytes(24))
+}
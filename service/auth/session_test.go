@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCookieCodec(t *testing.T) {
+	codec := newCookieCodec("secret")
+
+	value, err := codec.encode(&session{Name: "test", Scope: DisplayScope, Expire: time.Now().Add(time.Hour).Unix()})
+	if assert.NoError(t, err) {
+		s := &session{}
+		if assert.NoError(t, codec.decode(value, s)) {
+			assert.Equal(t, "test", s.Name)
+			assert.Equal(t, DisplayScope, s.Scope)
+		}
+
+		// Tampered payload
+		assert.Equal(t, ErrInvalidCookie, codec.decode("x"+value, &session{}))
+		// Other secret
+		assert.Equal(t, ErrInvalidCookie, newCookieCodec("other").decode(value, &session{}))
+		// Random secret
+		assert.Equal(t, ErrInvalidCookie, newCookieCodec("").decode(value, &session{}))
+	}
+
+	// Expired
+	value, err = codec.encode(&session{Name: "test", Scope: FullScope, Expire: time.Now().Add(-time.Hour).Unix()})
+	if assert.NoError(t, err) {
+		assert.Equal(t, ErrInvalidCookie, codec.decode(value, &session{}))
+	}
+
+	assert.Equal(t, ErrInvalidCookie, codec.decode("malformed", &session{}))
+}
@@ -0,0 +1,209 @@
+package cachestore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/monitoror/monitoror/config"
+
+	"github.com/jsdidierlaurent/echo-middleware/cache"
+)
+
+const (
+	// DefaultExpiration is used when value is stored with cache.DEFAULT expiration. Every caller set his own expiration
+	DefaultExpiration = time.Minute * 5
+
+	// maxKeyLength is memcached key limit. Longer keys are hashed
+	maxKeyLength = 250
+	// hashedKeyPrefix identify hashed keys in cache server
+	hashedKeyPrefix = "monitoror.hashed.key:"
+
+	pingKey = "monitoror.cachestore.ping"
+)
+
+type (
+	// sharedStore adapt keys and expirations of monitoror to network cache servers (redis, memcached).
+	// Values are serialized with gob by underlying store
+	sharedStore struct {
+		store     cache.Store
+		keyPrefix string
+	}
+
+	// persistentStore store keys starting with one of prefixes in disk store, other keys in main store
+	persistentStore struct {
+		store     cache.Store
+		diskStore cache.Store
+		prefixes  []string
+	}
+)
+
+// NewCacheStore create store of CacheBackend. Upstream / downstream caches and generator results are shared
+// between replicas using same redis or memcached server
+func NewCacheStore(conf *config.Config) (cache.Store, error) {
+	switch conf.CacheBackend {
+	case config.CacheBackendMemory, "":
+		return NewMemoryStore(), nil
+	case config.CacheBackendRedis:
+		if len(conf.CacheBackendAddresses) == 0 {
+			return nil, fmt.Errorf("missing address of %s cache backend", conf.CacheBackend)
+		}
+		return &sharedStore{
+			store:     cache.NewRedisCache(conf.CacheBackendAddresses[0], conf.CacheBackendPassword, DefaultExpiration),
+			keyPrefix: conf.CacheBackendKeyPrefix,
+		}, nil
+	case config.CacheBackendMemcached:
+		if len(conf.CacheBackendAddresses) == 0 {
+			return nil, fmt.Errorf("missing address of %s cache backend", conf.CacheBackend)
+		}
+		return &sharedStore{
+			store:     cache.NewMemcachedStore(conf.CacheBackendAddresses, DefaultExpiration),
+			keyPrefix: conf.CacheBackendKeyPrefix,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown cache backend %q, expected %s, %s or %s",
+			conf.CacheBackend, config.CacheBackendMemory, config.CacheBackendRedis, config.CacheBackendMemcached)
+	}
+}
+
+// NewMemoryStore create in-memory store, caches are local to this instance
+func NewMemoryStore() cache.Store {
+	return cache.NewGoCacheStore(DefaultExpiration, time.Second)
+}
+
+// IsShared return true when store is shared with other instances (network cache server)
+func IsShared(store cache.Store) bool {
+	_, ok := store.(*sharedStore)
+	return ok
+}
+
+// NewPersistentStore keep keys starting with one of prefixes in diskStore (ex: downstream cache, builds history), so they survive restarts
+func NewPersistentStore(store, diskStore cache.Store, prefixes ...string) cache.Store {
+	return &persistentStore{store: store, diskStore: diskStore, prefixes: prefixes}
+}
+
+// IsPersistent return true when caches survive restart of this instance (shared or disk store)
+func IsPersistent(store cache.Store) bool {
+	_, ok := store.(*persistentStore)
+	return ok || IsShared(store)
+}
+
+// Ping check store is reachable by writing and reading a value
+func Ping(store cache.Store) error {
+	now := time.Now().UnixNano()
+	if err := store.Set(pingKey, now, time.Minute); err != nil {
+		return err
+	}
+
+	var value int64
+	return store.Get(pingKey, &value)
+}
+
+func (s *sharedStore) Get(key string, value interface{}) error {
+	return s.store.Get(s.key(key), value)
+}
+
+func (s *sharedStore) Set(key string, value interface{}, expires time.Duration) error {
+	return s.store.Set(s.key(key), value, expiration(expires))
+}
+
+func (s *sharedStore) Add(key string, value interface{}, expires time.Duration) error {
+	return s.store.Add(s.key(key), value, expiration(expires))
+}
+
+func (s *sharedStore) Replace(key string, value interface{}, expires time.Duration) error {
+	return s.store.Replace(s.key(key), value, expiration(expires))
+}
+
+func (s *sharedStore) Delete(key string) error {
+	return s.store.Delete(s.key(key))
+}
+
+func (s *sharedStore) Increment(key string, n uint64) (uint64, error) {
+	return s.store.Increment(s.key(key), n)
+}
+
+func (s *sharedStore) Decrement(key string, n uint64) (uint64, error) {
+	return s.store.Decrement(s.key(key), n)
+}
+
+// Flush isn't supported, server can be shared with other instances or applications
+func (s *sharedStore) Flush() error {
+	return cache.ErrNotSupport
+}
+
+// key add prefix and hash keys unsupported by memcached (too long or with spaces / control characters)
+func (s *sharedStore) key(key string) string {
+	key = s.keyPrefix + key
+
+	if len(key) > maxKeyLength || strings.IndexFunc(key, func(r rune) bool { return r <= ' ' || r == 0x7f }) >= 0 {
+		sum := sha256.Sum256([]byte(key))
+		return s.keyPrefix + hashedKeyPrefix + hex.EncodeToString(sum[:])
+	}
+
+	return key
+}
+
+// expiration round sub-second expirations up to 1 second, cache servers only support seconds
+// (0 would mean default expiration)
+func expiration(expires time.Duration) time.Duration {
+	if expires > 0 && expires < time.Second {
+		return time.Second
+	}
+	return expires
+}
+
+func (s *persistentStore) Get(key string, value interface{}) error {
+	return s.route(key).Get(key, value)
+}
+
+func (s *persistentStore) Set(key string, value interface{}, expires time.Duration) error {
+	return s.route(key).Set(key, value, expires)
+}
+
+func (s *persistentStore) Add(key string, value interface{}, expires time.Duration) error {
+	return s.route(key).Add(key, value, expires)
+}
+
+func (s *persistentStore) Replace(key string, value interface{}, expires time.Duration) error {
+	return s.route(key).Replace(key, value, expires)
+}
+
+func (s *persistentStore) Delete(key string) error {
+	return s.route(key).Delete(key)
+}
+
+func (s *persistentStore) Increment(key string, n uint64) (uint64, error) {
+	return s.route(key).Increment(key, n)
+}
+
+func (s *persistentStore) Decrement(key string, n uint64) (uint64, error) {
+	return s.route(key).Decrement(key, n)
+}
+
+func (s *persistentStore) Flush() error {
+	if err := s.store.Flush(); err != nil {
+		return err
+	}
+	return s.diskStore.Flush()
+}
+
+// Close close disk store (see DiskStore.Close)
+func (s *persistentStore) Close() error {
+	if closer, ok := s.diskStore.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (s *persistentStore) route(key string) cache.Store {
+	for _, prefix := range s.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return s.diskStore
+		}
+	}
+	return s.store
+}
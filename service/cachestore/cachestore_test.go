@@ -0,0 +1,321 @@
+package cachestore
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/monitoror/monitoror/config"
+
+	"github.com/jsdidierlaurent/echo-middleware/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+type (
+	// fakeServer is a local stand-in of redis / memcached, keeping values and expirations (in seconds) in memory
+	fakeServer struct {
+		listener net.Listener
+
+		lock        sync.Mutex
+		values      map[string][]byte
+		expirations map[string]int
+	}
+
+	testValue struct {
+		Status int
+		Data   []byte
+	}
+)
+
+func newFakeServer(t *testing.T, handle func(s *fakeServer, reader *bufio.Reader, writer *bufio.Writer) error) *fakeServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &fakeServer{listener: listener, values: make(map[string][]byte), expirations: make(map[string]int)}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+				reader, writer := bufio.NewReader(conn), bufio.NewWriter(conn)
+				for handle(s, reader, writer) == nil {
+					_ = writer.Flush()
+				}
+			}()
+		}
+	}()
+
+	return s
+}
+
+func (s *fakeServer) set(key string, value []byte, expiration int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.values[key] = value
+	s.expirations[key] = expiration
+}
+
+func (s *fakeServer) get(key string) ([]byte, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	value, ok := s.values[key]
+	return value, ok
+}
+
+func (s *fakeServer) expiration(key string) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.expirations[key]
+}
+
+func (s *fakeServer) delete(key string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	_, ok := s.values[key]
+	delete(s.values, key)
+	return ok
+}
+
+// handleRedis implement PING, AUTH, GET, SET, SETEX, EXISTS and DEL commands of redis protocol (RESP)
+func handleRedis(s *fakeServer, reader *bufio.Reader, writer *bufio.Writer) error {
+	args, err := readRedisCommand(reader)
+	if err != nil {
+		return err
+	}
+
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		_, _ = writer.WriteString("+PONG\r\n")
+	case "AUTH":
+		if args[1] != "password" {
+			_, _ = writer.WriteString("-ERR invalid password\r\n")
+		} else {
+			_, _ = writer.WriteString("+OK\r\n")
+		}
+	case "SET":
+		s.set(args[1], []byte(args[2]), 0)
+		_, _ = writer.WriteString("+OK\r\n")
+	case "SETEX":
+		seconds, _ := strconv.Atoi(args[2])
+		if seconds <= 0 {
+			_, _ = writer.WriteString("-ERR invalid expire time in setex\r\n")
+			break
+		}
+		s.set(args[1], []byte(args[3]), seconds)
+		_, _ = writer.WriteString("+OK\r\n")
+	case "GET":
+		if value, ok := s.get(args[1]); ok {
+			_, _ = fmt.Fprintf(writer, "$%d\r\n%s\r\n", len(value), value)
+		} else {
+			_, _ = writer.WriteString("$-1\r\n")
+		}
+	case "EXISTS":
+		if _, ok := s.get(args[1]); ok {
+			_, _ = writer.WriteString(":1\r\n")
+		} else {
+			_, _ = writer.WriteString(":0\r\n")
+		}
+	case "DEL":
+		if s.delete(args[1]) {
+			_, _ = writer.WriteString(":1\r\n")
+		} else {
+			_, _ = writer.WriteString(":0\r\n")
+		}
+	default:
+		_, _ = fmt.Fprintf(writer, "-ERR unknown command '%s'\r\n", args[0])
+	}
+
+	return nil
+}
+
+func readRedisCommand(reader *bufio.Reader) ([]string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+
+	count, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+	args := make([]string, count)
+	for i := range args {
+		if line, err = reader.ReadString('\n'); err != nil {
+			return nil, err
+		}
+		size, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		args[i] = string(data[:size])
+	}
+
+	return args, nil
+}
+
+// handleMemcached implement gets, set, add, replace and delete commands of memcached text protocol
+func handleMemcached(s *fakeServer, reader *bufio.Reader, writer *bufio.Writer) error {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+
+	fields := strings.Fields(line)
+	switch fields[0] {
+	case "gets":
+		for _, key := range fields[1:] {
+			if value, ok := s.get(key); ok {
+				_, _ = fmt.Fprintf(writer, "VALUE %s 0 %d 1\r\n%s\r\n", key, len(value), value)
+			}
+		}
+		_, _ = writer.WriteString("END\r\n")
+	case "set", "add", "replace":
+		expiration, _ := strconv.Atoi(fields[3])
+		size, _ := strconv.Atoi(fields[4])
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return err
+		}
+
+		_, exists := s.get(fields[1])
+		if (fields[0] == "add" && exists) || (fields[0] == "replace" && !exists) {
+			_, _ = writer.WriteString("NOT_STORED\r\n")
+			break
+		}
+		s.set(fields[1], data[:size], expiration)
+		_, _ = writer.WriteString("STORED\r\n")
+	case "delete":
+		if s.delete(fields[1]) {
+			_, _ = writer.WriteString("DELETED\r\n")
+		} else {
+			_, _ = writer.WriteString("NOT_FOUND\r\n")
+		}
+	default:
+		_, _ = writer.WriteString("ERROR\r\n")
+	}
+
+	return nil
+}
+
+func TestNewCacheStore(t *testing.T) {
+	store, err := NewCacheStore(&config.Config{CacheBackend: config.CacheBackendMemory})
+	assert.NoError(t, err)
+	assert.False(t, IsShared(store))
+	assert.NoError(t, Ping(store))
+
+	store, err = NewCacheStore(&config.Config{CacheBackend: config.CacheBackendRedis, CacheBackendAddresses: []string{"127.0.0.1:6379"}})
+	assert.NoError(t, err)
+	assert.True(t, IsShared(store))
+
+	_, err = NewCacheStore(&config.Config{CacheBackend: config.CacheBackendMemcached})
+	assert.Error(t, err)
+
+	_, err = NewCacheStore(&config.Config{CacheBackend: "unknown"})
+	assert.Error(t, err)
+}
+
+func TestSharedStore_Redis(t *testing.T) {
+	server := newFakeServer(t, handleRedis)
+	defer server.listener.Close()
+
+	conf := &config.Config{
+		CacheBackend:          config.CacheBackendRedis,
+		CacheBackendAddresses: []string{server.listener.Addr().String()},
+		CacheBackendPassword:  "password",
+		CacheBackendKeyPrefix: "prefix:",
+	}
+	store, err := NewCacheStore(conf)
+	assert.NoError(t, err)
+	replicaStore, err := NewCacheStore(conf)
+	if assert.NoError(t, err) {
+		testSharedStore(t, store, replicaStore, server)
+	}
+
+	// Wrong password
+	store, _ = NewCacheStore(&config.Config{
+		CacheBackend:          config.CacheBackendRedis,
+		CacheBackendAddresses: []string{server.listener.Addr().String()},
+		CacheBackendPassword:  "wrong",
+	})
+	assert.Error(t, Ping(store))
+}
+
+func TestSharedStore_Memcached(t *testing.T) {
+	server := newFakeServer(t, handleMemcached)
+	defer server.listener.Close()
+
+	conf := &config.Config{
+		CacheBackend:          config.CacheBackendMemcached,
+		CacheBackendAddresses: []string{server.listener.Addr().String()},
+		CacheBackendKeyPrefix: "prefix:",
+	}
+	store, err := NewCacheStore(conf)
+	assert.NoError(t, err)
+	replicaStore, err := NewCacheStore(conf)
+	if assert.NoError(t, err) {
+		testSharedStore(t, store, replicaStore, server)
+	}
+}
+
+func TestSharedStore_Unreachable(t *testing.T) {
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	address := listener.Addr().String()
+	_ = listener.Close()
+
+	store, _ := NewCacheStore(&config.Config{CacheBackend: config.CacheBackendRedis, CacheBackendAddresses: []string{address}})
+	assert.Error(t, Ping(store))
+
+	// Unreachable server is a cache miss
+	var value testValue
+	assert.Error(t, store.Get("key", &value))
+}
+
+// testSharedStore check two stores (replicas) share values through server
+func testSharedStore(t *testing.T, store, replicaStore cache.Store, server *fakeServer) {
+	assert.NoError(t, Ping(store))
+
+	// Shared value
+	assert.NoError(t, store.Set("monitoror.upstream.key:%2Fapi%2Fv1%2Fping", testValue{Status: 200, Data: []byte("test")}, time.Millisecond*500))
+	_, found := server.get("prefix:monitoror.upstream.key:%2Fapi%2Fv1%2Fping")
+	assert.True(t, found)
+	assert.Equal(t, 1, server.expiration("prefix:monitoror.upstream.key:%2Fapi%2Fv1%2Fping"))
+
+	var value testValue
+	if assert.NoError(t, replicaStore.Get("monitoror.upstream.key:%2Fapi%2Fv1%2Fping", &value)) {
+		assert.Equal(t, 200, value.Status)
+		assert.Equal(t, "test", string(value.Data))
+	}
+
+	// Key with spaces and long key are hashed
+	longKey := "monitoror.config.tileGenerator.key:" + strings.Repeat("x", maxKeyLength)
+	for _, key := range []string{`monitoror.config.tileGenerator.key:{"job": "test"}`, longKey} {
+		assert.NoError(t, store.Set(key, testValue{Status: 201}, time.Minute))
+		_, found = server.get("prefix:" + key)
+		assert.False(t, found)
+		if assert.NoError(t, store.Get(key, &value)) {
+			assert.Equal(t, 201, value.Status)
+		}
+	}
+
+	// Add / Replace
+	assert.Equal(t, cache.ErrNotStored, store.Add("monitoror.upstream.key:%2Fapi%2Fv1%2Fping", testValue{}, time.Minute))
+	assert.Equal(t, cache.ErrNotStored, store.Replace("missing", testValue{}, time.Minute))
+
+	// Delete
+	assert.NoError(t, store.Delete("monitoror.upstream.key:%2Fapi%2Fv1%2Fping"))
+	assert.Equal(t, cache.ErrCacheMiss, store.Get("monitoror.upstream.key:%2Fapi%2Fv1%2Fping", &value))
+
+	// Flush is forbidden on shared server
+	assert.Equal(t, cache.ErrNotSupport, store.Flush())
+}
@@ -0,0 +1,514 @@
+package cachestore
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jsdidierlaurent/echo-middleware/cache"
+)
+
+const (
+	// DefaultCompactInterval is interval between two removals of expired entries
+	DefaultCompactInterval = time.Minute
+
+	recordHeaderSize = 8 // crc32 + body length
+	recordSet        = byte(1)
+	recordDelete     = byte(2)
+
+	// compactRatio trigger compaction when file is this much bigger than live entries
+	compactRatio = 2
+	// evictionRatio is the size kept after eviction of oldest entries (percent of max size)
+	evictionRatio = 90
+)
+
+var (
+	ErrCorruptedRecord = errors.New("corrupted record")
+	ErrStoreLocked     = errors.New("store file is already used by another process")
+)
+
+type (
+	// DiskStore is a cache.Store persisted in one append-only file, so values survive restarts.
+	// Index of entries is kept in memory and values are read from file. Each write append a record (crc32, length, body),
+	// file is compacted when it contains too many expired / overwritten records.
+	// When file exceed max size, oldest written entries are removed first.
+	DiskStore struct {
+		lock sync.Mutex
+
+		path              string
+		file              *os.File
+		lockFile          *os.File // <path>.lock, locked as long as store is open (store file is replaced by compaction)
+		maxSize           int64
+		defaultExpiration time.Duration
+
+		index    map[string]diskEntry
+		fileSize int64
+		liveSize int64
+
+		done chan struct{}
+	}
+
+	diskEntry struct {
+		offset     int64
+		size       int64
+		expiration int64 // in UnixNano, 0 means never
+	}
+)
+
+// NewDiskStore open (or create) store file. maxSize is in bytes, 0 means unlimited.
+// Store file can only be opened by one process, ErrStoreLocked is returned when another process use it
+func NewDiskStore(path string, maxSize int64, defaultExpiration time.Duration) (*DiskStore, error) {
+	s := &DiskStore{
+		path:              path,
+		maxSize:           maxSize,
+		defaultExpiration: defaultExpiration,
+		done:              make(chan struct{}),
+	}
+
+	if err := s.acquireLock(); err != nil {
+		return nil, err
+	}
+
+	if err := s.load(); err != nil {
+		s.closeFiles()
+		return nil, err
+	}
+
+	s.lock.Lock()
+	s.removeExpired()
+	err := s.compactIfNeeded()
+	s.lock.Unlock()
+	if err != nil {
+		s.closeFiles()
+		return nil, err
+	}
+
+	go s.janitor(DefaultCompactInterval)
+
+	return s, nil
+}
+
+// acquireLock lock <path>.lock until store is closed
+func (s *DiskStore) acquireLock() (err error) {
+	if s.lockFile, err = os.OpenFile(s.path+".lock", os.O_RDWR|os.O_CREATE, 0600); err != nil {
+		return err
+	}
+
+	if err = lockFile(s.lockFile); err != nil {
+		_ = s.lockFile.Close()
+	}
+	return err
+}
+
+// closeFiles close store file (when opened) then release lock
+func (s *DiskStore) closeFiles() {
+	if s.file != nil {
+		_ = s.file.Close()
+	}
+	_ = s.lockFile.Close()
+}
+
+// load read every record to build index. Corrupted end of file (ex: crash during write) is truncated
+func (s *DiskStore) load() (err error) {
+	if s.file, err = os.OpenFile(s.path, os.O_RDWR|os.O_CREATE, 0600); err != nil {
+		return err
+	}
+
+	info, err := s.file.Stat()
+	if err != nil {
+		return err
+	}
+
+	s.index = make(map[string]diskEntry)
+	s.fileSize = 0
+	s.liveSize = 0
+
+	reader := io.NewSectionReader(s.file, 0, info.Size())
+	for {
+		op, key, expiration, _, size, err := readRecord(reader, info.Size()-s.fileSize)
+		if err != nil {
+			break
+		}
+
+		s.removeFromIndex(key)
+		if op == recordSet {
+			s.index[key] = diskEntry{offset: s.fileSize, size: size, expiration: expiration}
+			s.liveSize += size
+		}
+		s.fileSize += size
+	}
+
+	return s.file.Truncate(s.fileSize)
+}
+
+func (s *DiskStore) Get(key string, value interface{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	data, err := s.get(key)
+	if err != nil {
+		return err
+	}
+
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(value)
+}
+
+func (s *DiskStore) Set(key string, value interface{}, expires time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.set(key, value, expires)
+}
+
+func (s *DiskStore) Add(key string, value interface{}, expires time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, err := s.get(key); err == nil {
+		return cache.ErrNotStored
+	}
+	return s.set(key, value, expires)
+}
+
+func (s *DiskStore) Replace(key string, value interface{}, expires time.Duration) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, err := s.get(key); err != nil {
+		return cache.ErrNotStored
+	}
+	return s.set(key, value, expires)
+}
+
+func (s *DiskStore) Delete(key string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, err := s.get(key); err != nil {
+		return err
+	}
+	return s.delete(key)
+}
+
+func (s *DiskStore) Increment(key string, n uint64) (uint64, error) {
+	return s.add(key, func(value uint64) uint64 { return value + n })
+}
+
+func (s *DiskStore) Decrement(key string, n uint64) (uint64, error) {
+	return s.add(key, func(value uint64) uint64 {
+		if n > value {
+			return 0
+		}
+		return value - n
+	})
+}
+
+// Flush remove every entry and truncate file
+func (s *DiskStore) Flush() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.index = make(map[string]diskEntry)
+	s.fileSize = 0
+	s.liveSize = 0
+	return s.file.Truncate(0)
+}
+
+// Close stop compaction and close file. Store can't be used after
+func (s *DiskStore) Close() error {
+	close(s.done)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	defer func() { _ = s.lockFile.Close() }()
+
+	if err := s.file.Sync(); err != nil {
+		_ = s.file.Close()
+		return err
+	}
+	return s.file.Close()
+}
+
+// Compact remove expired entries and rewrite file with live entries only
+func (s *DiskStore) Compact() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.removeExpired()
+	return s.compact()
+}
+
+func (s *DiskStore) get(key string) ([]byte, error) {
+	entry, ok := s.index[key]
+	if !ok {
+		return nil, cache.ErrCacheMiss
+	}
+	if entry.expiration != 0 && entry.expiration < time.Now().UnixNano() {
+		s.removeFromIndex(key)
+		return nil, cache.ErrCacheMiss
+	}
+
+	_, _, _, data, _, err := readRecord(io.NewSectionReader(s.file, entry.offset, entry.size), entry.size)
+	return data, err
+}
+
+func (s *DiskStore) set(key string, value interface{}, expires time.Duration) error {
+	var data bytes.Buffer
+	if err := gob.NewEncoder(&data).Encode(value); err != nil {
+		return err
+	}
+
+	switch expires {
+	case cache.DEFAULT:
+		expires = s.defaultExpiration
+	case cache.NEVER:
+		expires = 0
+	}
+	var expiration int64
+	if expires > 0 {
+		expiration = time.Now().Add(expires).UnixNano()
+	}
+
+	record := encodeRecord(recordSet, key, expiration, data.Bytes())
+	if s.maxSize > 0 && int64(len(record)) > s.maxSize {
+		return cache.ErrNotStored
+	}
+
+	offset, err := s.append(record)
+	if err != nil {
+		return err
+	}
+
+	s.removeFromIndex(key)
+	s.index[key] = diskEntry{offset: offset, size: int64(len(record)), expiration: expiration}
+	s.liveSize += int64(len(record))
+
+	return s.compactIfNeeded()
+}
+
+func (s *DiskStore) delete(key string) error {
+	if _, err := s.append(encodeRecord(recordDelete, key, 0, nil)); err != nil {
+		return err
+	}
+
+	s.removeFromIndex(key)
+	return s.compactIfNeeded()
+}
+
+func (s *DiskStore) add(key string, operation func(value uint64) uint64) (uint64, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	data, err := s.get(key)
+	if err != nil {
+		return 0, err
+	}
+
+	var value uint64
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&value); err != nil {
+		return 0, err
+	}
+	value = operation(value)
+
+	// Keep expiration of entry
+	expires := time.Duration(0)
+	if expiration := s.index[key].expiration; expiration != 0 {
+		expires = time.Until(time.Unix(0, expiration))
+	}
+	if expires <= 0 {
+		expires = cache.NEVER
+	}
+
+	return value, s.set(key, value, expires)
+}
+
+func (s *DiskStore) append(record []byte) (int64, error) {
+	offset := s.fileSize
+	if _, err := s.file.WriteAt(record, offset); err != nil {
+		return 0, err
+	}
+	s.fileSize += int64(len(record))
+	return offset, nil
+}
+
+func (s *DiskStore) removeFromIndex(key string) {
+	if entry, ok := s.index[key]; ok {
+		s.liveSize -= entry.size
+		delete(s.index, key)
+	}
+}
+
+func (s *DiskStore) removeExpired() {
+	now := time.Now().UnixNano()
+	for key, entry := range s.index {
+		if entry.expiration != 0 && entry.expiration < now {
+			s.removeFromIndex(key)
+		}
+	}
+}
+
+// compactIfNeeded compact file when it contains too many dead records or when max size is exceeded.
+// Oldest written entries are removed when live entries exceed max size
+func (s *DiskStore) compactIfNeeded() error {
+	overMaxSize := s.maxSize > 0 && s.fileSize > s.maxSize
+	if !overMaxSize && (s.fileSize < int64(os.Getpagesize()) || s.fileSize <= s.liveSize*compactRatio) {
+		return nil
+	}
+
+	s.removeExpired()
+	if overMaxSize {
+		// Keep free space to not compact on every write
+		s.evictOldest(s.maxSize * evictionRatio / 100)
+	}
+
+	return s.compact()
+}
+
+func (s *DiskStore) evictOldest(targetSize int64) {
+	keys := make([]string, 0, len(s.index))
+	for key := range s.index {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return s.index[keys[i]].offset < s.index[keys[j]].offset })
+
+	for _, key := range keys {
+		if s.liveSize <= targetSize {
+			break
+		}
+		s.removeFromIndex(key)
+	}
+}
+
+// compact copy live records in temporary file and replace store file
+func (s *DiskStore) compact() error {
+	tmpPath := s.path + ".compact"
+	tmpFile, err := os.OpenFile(tmpPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	// Keep order of records (used for eviction)
+	keys := make([]string, 0, len(s.index))
+	for key := range s.index {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return s.index[keys[i]].offset < s.index[keys[j]].offset })
+
+	index := make(map[string]diskEntry, len(s.index))
+	var offset int64
+	for _, key := range keys {
+		entry := s.index[key]
+		record := make([]byte, entry.size)
+		if _, err = s.file.ReadAt(record, entry.offset); err == nil {
+			_, err = tmpFile.WriteAt(record, offset)
+		}
+		if err != nil {
+			_ = tmpFile.Close()
+			_ = os.Remove(tmpPath)
+			return err
+		}
+
+		entry.offset = offset
+		index[key] = entry
+		offset += entry.size
+	}
+
+	if err := tmpFile.Sync(); err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		_ = tmpFile.Close()
+		_ = os.Remove(tmpPath)
+		return err
+	}
+
+	_ = s.file.Close()
+	s.file = tmpFile
+	s.index = index
+	s.fileSize = offset
+	s.liveSize = offset
+
+	return nil
+}
+
+func (s *DiskStore) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-ticker.C:
+			s.lock.Lock()
+			s.removeExpired()
+			_ = s.compactIfNeeded()
+			s.lock.Unlock()
+		}
+	}
+}
+
+// encodeRecord return record: crc32 (4 bytes), body length (4 bytes), body.
+// Body: operation (1 byte), expiration (8 bytes), key length (uvarint), key, value
+func encodeRecord(op byte, key string, expiration int64, value []byte) []byte {
+	body := make([]byte, 0, 1+8+binary.MaxVarintLen64+len(key)+len(value))
+	body = append(body, op)
+	body = append(body, make([]byte, 8)...)
+	binary.BigEndian.PutUint64(body[1:9], uint64(expiration))
+
+	keyLength := make([]byte, binary.MaxVarintLen64)
+	body = append(body, keyLength[:binary.PutUvarint(keyLength, uint64(len(key)))]...)
+	body = append(body, key...)
+	body = append(body, value...)
+
+	record := make([]byte, recordHeaderSize, recordHeaderSize+len(body))
+	binary.BigEndian.PutUint32(record[0:4], crc32.ChecksumIEEE(body))
+	binary.BigEndian.PutUint32(record[4:8], uint32(len(body)))
+	return append(record, body...)
+}
+
+// readRecord read next record of reader (at most maxSize bytes). Return ErrCorruptedRecord if checksum doesn't match
+func readRecord(reader io.Reader, maxSize int64) (op byte, key string, expiration int64, value []byte, size int64, err error) {
+	header := make([]byte, recordHeaderSize)
+	if _, err = io.ReadFull(reader, header); err != nil {
+		return
+	}
+
+	bodyLength := int64(binary.BigEndian.Uint32(header[4:8]))
+	if recordHeaderSize+bodyLength > maxSize {
+		err = ErrCorruptedRecord
+		return
+	}
+
+	body := make([]byte, bodyLength)
+	if _, err = io.ReadFull(reader, body); err != nil {
+		return
+	}
+	if crc32.ChecksumIEEE(body) != binary.BigEndian.Uint32(header[0:4]) || len(body) < 10 {
+		err = ErrCorruptedRecord
+		return
+	}
+
+	op = body[0]
+	expiration = int64(binary.BigEndian.Uint64(body[1:9]))
+	keyLength, n := binary.Uvarint(body[9:])
+	if n <= 0 || uint64(len(body)-9-n) < keyLength {
+		err = ErrCorruptedRecord
+		return
+	}
+
+	key = string(body[9+n : 9+n+int(keyLength)])
+	value = body[9+n+int(keyLength):]
+	size = int64(recordHeaderSize + len(body))
+	return
+}
@@ -0,0 +1,19 @@
+//+build !windows
+
+package cachestore
+
+import (
+	"os"
+	"syscall"
+)
+
+// lockFile take an exclusive lock on file, released when file is closed or process exit. Fail when file is already locked
+func lockFile(file *os.File) error {
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		if err == syscall.EWOULDBLOCK {
+			return ErrStoreLocked
+		}
+		return err
+	}
+	return nil
+}
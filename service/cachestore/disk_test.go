@@ -0,0 +1,249 @@
+package cachestore
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jsdidierlaurent/echo-middleware/cache"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestDiskStore(t *testing.T, maxSize int64) (*DiskStore, string, func()) {
+	dir, err := ioutil.TempDir("", "monitoror-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "cache.db")
+	store, err := NewDiskStore(path, maxSize, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return store, path, func() {
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func TestDiskStore(t *testing.T) {
+	store, _, clean := newTestDiskStore(t, 0)
+	defer clean()
+	defer store.Close()
+
+	var value testValue
+	assert.Equal(t, cache.ErrCacheMiss, store.Get("key", &value))
+
+	// Set / Get
+	assert.NoError(t, store.Set("key", testValue{Status: 200, Data: []byte("test")}, cache.DEFAULT))
+	if assert.NoError(t, store.Get("key", &value)) {
+		assert.Equal(t, 200, value.Status)
+		assert.Equal(t, "test", string(value.Data))
+	}
+
+	// Add / Replace
+	assert.Equal(t, cache.ErrNotStored, store.Add("key", testValue{}, cache.DEFAULT))
+	assert.NoError(t, store.Add("key2", testValue{Status: 201}, cache.NEVER))
+	assert.Equal(t, cache.ErrNotStored, store.Replace("missing", testValue{}, cache.DEFAULT))
+	assert.NoError(t, store.Replace("key2", testValue{Status: 202}, cache.NEVER))
+	if assert.NoError(t, store.Get("key2", &value)) {
+		assert.Equal(t, 202, value.Status)
+	}
+
+	// Delete
+	assert.NoError(t, store.Delete("key2"))
+	assert.Equal(t, cache.ErrCacheMiss, store.Delete("key2"))
+	assert.Equal(t, cache.ErrCacheMiss, store.Get("key2", &value))
+
+	// Increment / Decrement
+	assert.NoError(t, store.Set("counter", uint64(10), cache.NEVER))
+	count, err := store.Increment("counter", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(15), count)
+	count, err = store.Decrement("counter", 20)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), count)
+	_, err = store.Increment("missing", 1)
+	assert.Equal(t, cache.ErrCacheMiss, err)
+
+	// Expiration
+	assert.NoError(t, store.Set("expired", testValue{}, time.Millisecond))
+	time.Sleep(time.Millisecond * 5)
+	assert.Equal(t, cache.ErrCacheMiss, store.Get("expired", &value))
+
+	// Flush
+	assert.NoError(t, store.Flush())
+	assert.Equal(t, cache.ErrCacheMiss, store.Get("key", &value))
+}
+
+func TestDiskStore_Reopen(t *testing.T) {
+	store, path, clean := newTestDiskStore(t, 0)
+	defer clean()
+
+	assert.NoError(t, store.Set("key", testValue{Status: 200}, time.Minute))
+	assert.NoError(t, store.Set("overwritten", testValue{Status: 200}, time.Minute))
+	assert.NoError(t, store.Set("overwritten", testValue{Status: 201}, time.Minute))
+	assert.NoError(t, store.Set("deleted", testValue{Status: 200}, time.Minute))
+	assert.NoError(t, store.Delete("deleted"))
+	assert.NoError(t, store.Set("expired", testValue{Status: 200}, time.Millisecond))
+	assert.NoError(t, store.Close())
+
+	time.Sleep(time.Millisecond * 5)
+
+	store, err := NewDiskStore(path, 0, time.Minute)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer store.Close()
+
+	var value testValue
+	if assert.NoError(t, store.Get("key", &value)) {
+		assert.Equal(t, 200, value.Status)
+	}
+	if assert.NoError(t, store.Get("overwritten", &value)) {
+		assert.Equal(t, 201, value.Status)
+	}
+	assert.Equal(t, cache.ErrCacheMiss, store.Get("deleted", &value))
+	assert.Equal(t, cache.ErrCacheMiss, store.Get("expired", &value))
+}
+
+func TestDiskStore_Locked(t *testing.T) {
+	store, path, clean := newTestDiskStore(t, 0)
+	defer clean()
+
+	// Store file is replaced by compaction, lock is kept
+	assert.NoError(t, store.Compact())
+
+	_, err := NewDiskStore(path, 0, time.Minute)
+	assert.Equal(t, ErrStoreLocked, err)
+
+	assert.NoError(t, store.Close())
+
+	store, err = NewDiskStore(path, 0, time.Minute)
+	if assert.NoError(t, err) {
+		assert.NoError(t, store.Close())
+	}
+}
+
+func TestDiskStore_CorruptedFile(t *testing.T) {
+	store, path, clean := newTestDiskStore(t, 0)
+	defer clean()
+
+	assert.NoError(t, store.Set("key", testValue{Status: 200}, time.Minute))
+	assert.NoError(t, store.Set("key2", testValue{Status: 201}, time.Minute))
+	assert.NoError(t, store.Close())
+
+	// Simulate crash during write of last record
+	info, _ := os.Stat(path)
+	assert.NoError(t, os.Truncate(path, info.Size()-3))
+
+	store, err := NewDiskStore(path, 0, time.Minute)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var value testValue
+	assert.NoError(t, store.Get("key", &value))
+	assert.Equal(t, cache.ErrCacheMiss, store.Get("key2", &value))
+
+	// Corrupted end is removed, new records are readable after reopen
+	assert.NoError(t, store.Set("key3", testValue{Status: 202}, time.Minute))
+	assert.NoError(t, store.Close())
+
+	store, err = NewDiskStore(path, 0, time.Minute)
+	if assert.NoError(t, err) {
+		assert.NoError(t, store.Get("key3", &value))
+		assert.Equal(t, 202, value.Status)
+		assert.NoError(t, store.Close())
+	}
+
+	// Garbage file
+	assert.NoError(t, ioutil.WriteFile(path, []byte(strings.Repeat("garbage", 100)), 0600))
+	store, err = NewDiskStore(path, 0, time.Minute)
+	if assert.NoError(t, err) {
+		assert.Equal(t, cache.ErrCacheMiss, store.Get("key", &value))
+		assert.NoError(t, store.Close())
+	}
+}
+
+func TestDiskStore_Compact(t *testing.T) {
+	store, path, clean := newTestDiskStore(t, 0)
+	defer clean()
+	defer store.Close()
+
+	data := []byte(strings.Repeat("x", 100))
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, store.Set("key", testValue{Status: i, Data: data}, time.Minute))
+	}
+
+	// Overwritten records are removed by compaction
+	info, _ := os.Stat(path)
+	assert.True(t, info.Size() < int64(os.Getpagesize())*compactRatio)
+
+	assert.NoError(t, store.Set("expired", testValue{Data: data}, time.Millisecond))
+	time.Sleep(time.Millisecond * 5)
+	assert.NoError(t, store.Compact())
+
+	info, _ = os.Stat(path)
+	assert.Equal(t, store.liveSize, info.Size())
+	assert.Len(t, store.index, 1)
+
+	var value testValue
+	if assert.NoError(t, store.Get("key", &value)) {
+		assert.Equal(t, 99, value.Status)
+	}
+}
+
+func TestDiskStore_MaxSize(t *testing.T) {
+	store, path, clean := newTestDiskStore(t, 4096)
+	defer clean()
+	defer store.Close()
+
+	data := []byte(strings.Repeat("x", 100))
+	for i := 0; i < 100; i++ {
+		assert.NoError(t, store.Set(strings.Repeat("k", i+1), testValue{Status: i, Data: data}, time.Minute))
+	}
+
+	info, _ := os.Stat(path)
+	assert.True(t, info.Size() <= 4096)
+
+	// Oldest entries are removed first
+	var value testValue
+	assert.Equal(t, cache.ErrCacheMiss, store.Get("k", &value))
+	if assert.NoError(t, store.Get(strings.Repeat("k", 100), &value)) {
+		assert.Equal(t, 99, value.Status)
+	}
+
+	// Value bigger than max size
+	assert.Equal(t, cache.ErrNotStored, store.Set("big", testValue{Data: []byte(strings.Repeat("x", 5000))}, time.Minute))
+}
+
+func TestPersistentStore(t *testing.T) {
+	diskStore, _, clean := newTestDiskStore(t, 0)
+	defer clean()
+
+	memoryStore := NewMemoryStore()
+	store := NewPersistentStore(memoryStore, diskStore, "monitoror.downstream.key")
+	assert.True(t, IsPersistent(store))
+	assert.False(t, IsPersistent(memoryStore))
+
+	assert.NoError(t, store.Set("monitoror.downstream.key:%2Fapi%2Fv1%2Fping", testValue{Status: 200}, time.Minute))
+	assert.NoError(t, store.Set("monitoror.upstream.key:%2Fapi%2Fv1%2Fping", testValue{Status: 201}, time.Minute))
+
+	var value testValue
+	assert.NoError(t, diskStore.Get("monitoror.downstream.key:%2Fapi%2Fv1%2Fping", &value))
+	assert.Equal(t, cache.ErrCacheMiss, diskStore.Get("monitoror.upstream.key:%2Fapi%2Fv1%2Fping", &value))
+	assert.NoError(t, memoryStore.Get("monitoror.upstream.key:%2Fapi%2Fv1%2Fping", &value))
+	if assert.NoError(t, store.Get("monitoror.downstream.key:%2Fapi%2Fv1%2Fping", &value)) {
+		assert.Equal(t, 200, value.Status)
+	}
+
+	assert.NoError(t, store.Delete("monitoror.downstream.key:%2Fapi%2Fv1%2Fping"))
+	assert.Equal(t, cache.ErrCacheMiss, diskStore.Get("monitoror.downstream.key:%2Fapi%2Fv1%2Fping", &value))
+
+	assert.NoError(t, store.(io.Closer).Close())
+}
@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/service/metrics"
 
 	"github.com/jsdidierlaurent/echo-middleware/cache"
 	"github.com/labstack/echo/v4"
@@ -14,6 +16,9 @@ type (
 	APIError struct {
 		Code    int    `json:"status"`
 		Message string `json:"message"`
+
+		// RequestID is used to find request in logs (see X-Request-ID header)
+		RequestID string `json:"requestId,omitempty"`
 	}
 )
 
@@ -26,23 +31,37 @@ func HTTPErrorHandler(err error, ctx echo.Context) {
 			if he.Code == http.StatusNotFound {
 				// 404
 				_ = ctx.JSON(he.Code, APIError{
-					Code:    he.Code,
-					Message: "Not Found",
+					Code:      he.Code,
+					Message:   "Not Found",
+					RequestID: requestID(ctx),
 				})
 				return
 			}
+
+			// Other http errors (ex: 401 / 403 returned by authentication)
+			_ = ctx.JSON(he.Code, APIError{
+				Code:      he.Code,
+				Message:   fmt.Sprintf("%v", he.Message),
+				RequestID: requestID(ctx),
+			})
+			return
 		}
 	}
 
 	if err != nil {
 		_ = ctx.JSON(http.StatusInternalServerError, APIError{
-			Code:    http.StatusInternalServerError,
-			Message: err.Error(),
+			Code:      http.StatusInternalServerError,
+			Message:   err.Error(),
+			RequestID: requestID(ctx),
 		})
 	}
 }
 
 func handleMonitororError(me *models.MonitororError, ctx echo.Context) error {
+	if me.RequestID == "" {
+		me.RequestID = requestID(ctx)
+	}
+
 	// No tile set, forward error
 	if me.Tile == nil {
 		return me
@@ -50,8 +69,14 @@ func handleMonitororError(me *models.MonitororError, ctx echo.Context) error {
 
 	// Check if error was timeout and check cache
 	if me.Timeout() {
+		found := cacheMiddleware(ctx)
+
+		m := metrics.FromContext(ctx)
+		m.ObserveCache(metrics.DownstreamCache, found)
+		m.ObserveTimeout(found)
+
 		// If cache found, reply cache and exit
-		if found := cacheMiddleware(ctx); found {
+		if found {
 			return nil
 		}
 
@@ -74,6 +99,11 @@ func handleMonitororError(me *models.MonitororError, ctx echo.Context) error {
 	return nil
 }
 
+// requestID return request ID provided in request context by RequestIDContext middleware
+func requestID(ctx echo.Context) string {
+	return models.RequestIDFromContext(ctx.Request().Context())
+}
+
 // cacheMiddleware look into downstream cache and return cached value to client
 func cacheMiddleware(ctx echo.Context) bool {
 	// Looking for TimeoutCache in echo.context
@@ -11,6 +11,7 @@ import (
 
 	"github.com/monitoror/monitoror/models"
 	"github.com/monitoror/monitoror/monitorables/jenkins/api"
+	"github.com/monitoror/monitoror/service/metrics"
 
 	"github.com/jsdidierlaurent/echo-middleware/cache"
 	"github.com/jsdidierlaurent/echo-middleware/cache/mocks"
@@ -225,3 +226,63 @@ func TestHTTPError_MonitororError_Timeout_Success(t *testing.T) {
 	mockStore.AssertNumberOfCalls(t, "Get", 1)
 	mockStore.AssertExpectations(t)
 }
+
+func TestHTTPError_MonitororError_Timeout_WithMetrics(t *testing.T) {
+	// Init
+	ctx, _ := initErrorEcho()
+	mockStore := new(mocks.Store)
+	mockStore.On("Get", AnythingOfType("string"), Anything).Return(cache.ErrCacheMiss)
+	ctx.Set(models.DownstreamStoreContextKey, mockStore)
+	m := metrics.NewMetrics()
+	ctx.Set(metrics.ContextKey, m)
+
+	// Parameters
+	err := &models.MonitororError{Err: context.DeadlineExceeded, Tile: models.NewTile("TEST")}
+
+	// Test
+	HTTPErrorHandler(err, ctx)
+
+	metricsCtx, res := initErrorEcho()
+	assert.NoError(t, m.Handler(metricsCtx))
+	assert.Contains(t, res.Body.String(), `monitoror_cache_requests_total{cache="downstream",result="miss"} 1`)
+	assert.Contains(t, res.Body.String(), `monitoror_timeouts_total{recovered="false"} 1`)
+}
+
+func TestHTTPError_401(t *testing.T) {
+	// Init
+	ctx, res := initErrorEcho()
+
+	// Parameters
+	err := echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+
+	// Test
+	HTTPErrorHandler(err, ctx)
+
+	assert.Equal(t, http.StatusUnauthorized, res.Code)
+	assert.Equal(t, `{"status":401,"message":"Unauthorized"}`, strings.TrimSpace(res.Body.String()))
+}
+
+func TestHTTPError_MonitororError_WithRequestID(t *testing.T) {
+	// Init
+	ctx, res := initErrorEcho()
+	ctx.SetRequest(ctx.Request().WithContext(models.ContextWithRequestID(ctx.Request().Context(), "request-id")))
+
+	// Parameters
+	err := &models.MonitororError{Err: errors.New("boom")}
+
+	// Expected
+	apiError := APIError{
+		Code:      http.StatusInternalServerError,
+		Message:   err.Error(),
+		RequestID: "request-id",
+	}
+	j, e := json.Marshal(apiError)
+	assert.NoError(t, e, "unable to marshal tile")
+
+	// Test
+	HTTPErrorHandler(err, ctx)
+
+	assert.Equal(t, "request-id", err.RequestID)
+	assert.Equal(t, http.StatusInternalServerError, res.Code)
+	assert.Equal(t, string(j), strings.TrimSpace(res.Body.String()))
+}
@@ -0,0 +1,98 @@
+package health
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	coreModels "github.com/monitoror/monitoror/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+type (
+	// Health keep monitorables state for readiness route
+	Health struct {
+		lock sync.RWMutex
+
+		// nil until monitorables are enabled
+		monitorables []coreModels.MonitorableHealth
+		upstreams    map[string]*coreModels.UpstreamHealth
+	}
+)
+
+func NewHealth() *Health {
+	return &Health{upstreams: make(map[string]*coreModels.UpstreamHealth)}
+}
+
+// SetMonitorables save variants state of every monitorable. Instance is ready once called, unless a variant is errored
+func (h *Health) SetMonitorables(monitorables []coreModels.MonitorableHealth) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.monitorables = append([]coreModels.MonitorableHealth{}, monitorables...)
+}
+
+// UpstreamCallHandler record last success and error of monitorable handler (Decorator Handlers)
+func (h *Health) UpstreamCallHandler(monitorable string, variantName coreModels.VariantName, handle echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		err := handle(c)
+		h.recordUpstreamCall(monitorable, variantName, err)
+		return err
+	}
+}
+
+func (h *Health) recordUpstreamCall(monitorable string, variantName coreModels.VariantName, err error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	key := monitorable + "/" + string(variantName)
+	upstream, exists := h.upstreams[key]
+	if !exists {
+		upstream = &coreModels.UpstreamHealth{Monitorable: monitorable, VariantName: variantName}
+		h.upstreams[key] = upstream
+	}
+
+	now := time.Now()
+	if err == nil {
+		upstream.LastSuccess = &now
+	} else {
+		upstream.LastError = &now
+	}
+}
+
+// GetLiveness return UP while server is able to respond
+func (h *Health) GetLiveness() *coreModels.HealthResponse {
+	return &coreModels.HealthResponse{Status: coreModels.HealthStatusUp}
+}
+
+// GetReadiness return UP when monitorables are enabled without errored variant
+func (h *Health) GetReadiness() *coreModels.HealthResponse {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+
+	response := &coreModels.HealthResponse{
+		Status:       coreModels.HealthStatusUp,
+		Monitorables: h.monitorables,
+	}
+
+	if h.monitorables == nil {
+		response.Status = coreModels.HealthStatusDown
+	}
+	for _, monitorable := range h.monitorables {
+		if len(monitorable.ErroredVariants) > 0 {
+			response.Status = coreModels.HealthStatusDown
+		}
+	}
+
+	var keys []string
+	for key := range h.upstreams {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		response.Upstreams = append(response.Upstreams, *h.upstreams[key])
+	}
+
+	return response
+}
@@ -0,0 +1,60 @@
+package health
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	coreModels "github.com/monitoror/monitoror/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealth_GetLiveness(t *testing.T) {
+	assert.Equal(t, coreModels.HealthStatusUp, NewHealth().GetLiveness().Status)
+}
+
+func TestHealth_GetReadiness(t *testing.T) {
+	health := NewHealth()
+
+	// Monitorables not enabled yet
+	assert.Equal(t, coreModels.HealthStatusDown, health.GetReadiness().Status)
+
+	health.SetMonitorables([]coreModels.MonitorableHealth{
+		{Name: "Jenkins", EnabledVariants: []coreModels.VariantName{coreModels.DefaultVariant}},
+		{Name: "GitHub", DisabledVariants: []coreModels.VariantName{coreModels.DefaultVariant}},
+	})
+	readiness := health.GetReadiness()
+	assert.Equal(t, coreModels.HealthStatusUp, readiness.Status)
+	assert.Len(t, readiness.Monitorables, 2)
+
+	health.SetMonitorables([]coreModels.MonitorableHealth{
+		{Name: "Jenkins", ErroredVariants: []coreModels.ErroredVariant{{VariantName: coreModels.DefaultVariant, Error: "boom"}}},
+	})
+	assert.Equal(t, coreModels.HealthStatusDown, health.GetReadiness().Status)
+}
+
+func TestHealth_UpstreamCallHandler(t *testing.T) {
+	health := NewHealth()
+	ctx := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+
+	success := health.UpstreamCallHandler("jenkins", coreModels.DefaultVariant, func(c echo.Context) error { return nil })
+	failure := health.UpstreamCallHandler("github", "variant1", func(c echo.Context) error { return errors.New("boom") })
+
+	assert.NoError(t, success(ctx))
+	assert.Error(t, failure(ctx))
+
+	upstreams := health.GetReadiness().Upstreams
+	if assert.Len(t, upstreams, 2) {
+		assert.Equal(t, "github", upstreams[0].Monitorable)
+		assert.Equal(t, coreModels.VariantName("variant1"), upstreams[0].VariantName)
+		assert.Nil(t, upstreams[0].LastSuccess)
+		assert.NotNil(t, upstreams[0].LastError)
+
+		assert.Equal(t, "jenkins", upstreams[1].Monitorable)
+		assert.NotNil(t, upstreams[1].LastSuccess)
+		assert.Nil(t, upstreams[1].LastError)
+	}
+}
@@ -0,0 +1,183 @@
+package metrics
+
+import (
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	coreModels "github.com/monitoror/monitoror/models"
+	pkgMetrics "github.com/monitoror/monitoror/pkg/metrics"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// ContextKey is used to provide Metrics to handlers and cache middleware through echo.Context
+	ContextKey = "monitoror.metrics"
+
+	UpstreamCache   = "upstream"
+	DownstreamCache = "downstream"
+
+	GeneratorSuccess          = "success"
+	GeneratorError            = "error"
+	GeneratorTimeout          = "timeout"
+	GeneratorTimeoutRecovered = "timeout_recovered"
+
+	// unmatchedRoute is used as route label for requests without route (404), to avoid one series by unknown url
+	unmatchedRoute = "unmatched"
+)
+
+type (
+	// Metrics collect monitoror metrics and expose them in Prometheus text format.
+	// Every method can be called on nil Metrics (when metrics are disabled) and does nothing
+	Metrics struct {
+		registry *pkgMetrics.Registry
+
+		httpRequests        *pkgMetrics.CounterVec
+		httpRequestDuration *pkgMetrics.HistogramVec
+
+		upstreamCalls        *pkgMetrics.CounterVec
+		upstreamErrors       *pkgMetrics.CounterVec
+		upstreamCallDuration *pkgMetrics.HistogramVec
+
+		cacheRequests *pkgMetrics.CounterVec
+		timeouts      *pkgMetrics.CounterVec
+
+		generatorCalls *pkgMetrics.CounterVec
+	}
+)
+
+func NewMetrics() *Metrics {
+	registry := pkgMetrics.NewRegistry()
+
+	return &Metrics{
+		registry: registry,
+
+		httpRequests: registry.NewCounterVec("monitoror_http_requests_total",
+			"Number of HTTP requests by method, route and status code.", "method", "route", "code"),
+		httpRequestDuration: registry.NewHistogramVec("monitoror_http_request_duration_seconds",
+			"Duration of HTTP requests by method and route.", pkgMetrics.DefaultBuckets, "method", "route"),
+
+		upstreamCalls: registry.NewCounterVec("monitoror_upstream_calls_total",
+			"Number of calls to monitorable services (not served by upstream cache).", "monitorable", "variant"),
+		upstreamErrors: registry.NewCounterVec("monitoror_upstream_errors_total",
+			"Number of calls to monitorable services ending in error (timeout included).", "monitorable", "variant"),
+		upstreamCallDuration: registry.NewHistogramVec("monitoror_upstream_call_duration_seconds",
+			"Duration of calls to monitorable services.", pkgMetrics.DefaultBuckets, "monitorable", "variant"),
+
+		cacheRequests: registry.NewCounterVec("monitoror_cache_requests_total",
+			"Number of cache lookups by cache (upstream or downstream) and result (hit or miss).", "cache", "result"),
+		timeouts: registry.NewCounterVec("monitoror_timeouts_total",
+			"Number of tile timeouts handled by error handler, recovered when downstream cache had a response.", "recovered"),
+
+		generatorCalls: registry.NewCounterVec("monitoror_generator_calls_total",
+			"Number of tile generator invocations by tile type, variant and result.", "type", "variant", "result"),
+	}
+}
+
+// FromContext return Metrics provided by Middleware, nil if metrics are disabled
+func FromContext(c echo.Context) *Metrics {
+	m, _ := c.Get(ContextKey).(*Metrics)
+	return m
+}
+
+// Middleware provide Metrics in echo.Context and record requests count and duration by route
+func (m *Metrics) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set(ContextKey, m)
+
+			start := time.Now()
+			if err := next(c); err != nil {
+				// Call error handler now to record real status code
+				c.Error(err)
+			}
+
+			// Echo use request path as route path when no route match
+			route := c.Path()
+			if c.Response().Status == http.StatusNotFound && route == c.Request().URL.Path {
+				route = unmatchedRoute
+			}
+
+			method := c.Request().Method
+			m.httpRequests.Inc(method, route, strconv.Itoa(c.Response().Status))
+			m.httpRequestDuration.Observe(time.Since(start).Seconds(), method, route)
+
+			return nil
+		}
+	}
+}
+
+// Handler write every metric in Prometheus text format
+func (m *Metrics) Handler(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderContentType, pkgMetrics.ContentType)
+	c.Response().WriteHeader(http.StatusOK)
+	return m.registry.Write(c.Response())
+}
+
+// UpstreamCallHandler record calls of monitorable handler (Decorator Handlers).
+// Must be wrapped by upstream cache handler to only count real calls to services
+func UpstreamCallHandler(monitorable string, variantName coreModels.VariantName, handle echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+		err := handle(c)
+		FromContext(c).ObserveUpstreamCall(monitorable, variantName, time.Since(start), err)
+		return err
+	}
+}
+
+// ObserveUpstreamCall record call to monitorable service
+func (m *Metrics) ObserveUpstreamCall(monitorable string, variantName coreModels.VariantName, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+
+	m.upstreamCalls.Inc(monitorable, string(variantName))
+	m.upstreamCallDuration.Observe(duration.Seconds(), monitorable, string(variantName))
+	if err != nil {
+		m.upstreamErrors.Inc(monitorable, string(variantName))
+	}
+}
+
+// ObserveCache record lookup in upstream or downstream cache
+func (m *Metrics) ObserveCache(cache string, hit bool) {
+	if m == nil {
+		return
+	}
+
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.cacheRequests.Inc(cache, result)
+}
+
+// ObserveTimeout record timeout handled by error handler
+func (m *Metrics) ObserveTimeout(recovered bool) {
+	if m == nil {
+		return
+	}
+
+	m.timeouts.Inc(strconv.FormatBool(recovered))
+}
+
+// ObserveGenerator record tile generator invocation. recovered is used on timeout when previous result was found in cache
+func (m *Metrics) ObserveGenerator(tileType coreModels.TileType, variantName coreModels.VariantName, err error, recovered bool) {
+	if m == nil {
+		return
+	}
+
+	result := GeneratorSuccess
+	if err != nil {
+		switch {
+		case os.IsTimeout(err) && recovered:
+			result = GeneratorTimeoutRecovered
+		case os.IsTimeout(err):
+			result = GeneratorTimeout
+		default:
+			result = GeneratorError
+		}
+	}
+	m.generatorCalls.Inc(string(tileType), string(variantName), result)
+}
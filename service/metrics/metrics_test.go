@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	coreModels "github.com/monitoror/monitoror/models"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics_Middleware(t *testing.T) {
+	m := NewMetrics()
+
+	e := echo.New()
+	e.Use(m.Middleware())
+	e.GET("/metrics", m.Handler)
+	e.GET("/api/v1/test/:id", func(c echo.Context) error {
+		assert.Equal(t, m, FromContext(c))
+		return c.NoContent(http.StatusOK)
+	})
+	e.GET("/api/v1/error", func(c echo.Context) error {
+		return errors.New("boom")
+	})
+
+	for _, url := range []string{"/api/v1/test/1", "/api/v1/test/2", "/api/v1/error", "/unknown"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, url, nil))
+	}
+
+	res := httptest.NewRecorder()
+	e.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", res.Header().Get(echo.HeaderContentType))
+	assert.Contains(t, res.Body.String(), `monitoror_http_requests_total{method="GET",route="/api/v1/test/:id",code="200"} 2`)
+	assert.Contains(t, res.Body.String(), `monitoror_http_requests_total{method="GET",route="/api/v1/error",code="500"} 1`)
+	assert.Contains(t, res.Body.String(), `monitoror_http_requests_total{method="GET",route="unmatched",code="404"} 1`)
+	assert.Contains(t, res.Body.String(), `monitoror_http_request_duration_seconds_count{method="GET",route="/api/v1/test/:id"} 2`)
+}
+
+func TestMetrics_Observe(t *testing.T) {
+	m := NewMetrics()
+
+	m.ObserveUpstreamCall("jenkins", coreModels.DefaultVariant, time.Millisecond, nil)
+	m.ObserveUpstreamCall("jenkins", coreModels.DefaultVariant, time.Millisecond, errors.New("boom"))
+	m.ObserveCache(UpstreamCache, true)
+	m.ObserveCache(UpstreamCache, false)
+	m.ObserveCache(DownstreamCache, false)
+	m.ObserveTimeout(false)
+	m.ObserveGenerator("GENERATOR", coreModels.DefaultVariant, nil, false)
+	m.ObserveGenerator("GENERATOR", coreModels.DefaultVariant, errors.New("boom"), false)
+	m.ObserveGenerator("GENERATOR", coreModels.DefaultVariant, context.DeadlineExceeded, true)
+	m.ObserveGenerator("GENERATOR", coreModels.DefaultVariant, context.DeadlineExceeded, false)
+
+	e := echo.New()
+	res := httptest.NewRecorder()
+	assert.NoError(t, m.Handler(e.NewContext(httptest.NewRequest(http.MethodGet, "/metrics", nil), res)))
+
+	for _, expected := range []string{
+		`monitoror_upstream_calls_total{monitorable="jenkins",variant="default"} 2`,
+		`monitoror_upstream_errors_total{monitorable="jenkins",variant="default"} 1`,
+		`monitoror_upstream_call_duration_seconds_count{monitorable="jenkins",variant="default"} 2`,
+		`monitoror_cache_requests_total{cache="downstream",result="miss"} 1`,
+		`monitoror_cache_requests_total{cache="upstream",result="hit"} 1`,
+		`monitoror_cache_requests_total{cache="upstream",result="miss"} 1`,
+		`monitoror_timeouts_total{recovered="false"} 1`,
+		`monitoror_generator_calls_total{type="GENERATOR",variant="default",result="success"} 1`,
+		`monitoror_generator_calls_total{type="GENERATOR",variant="default",result="error"} 1`,
+		`monitoror_generator_calls_total{type="GENERATOR",variant="default",result="timeout"} 1`,
+		`monitoror_generator_calls_total{type="GENERATOR",variant="default",result="timeout_recovered"} 1`,
+	} {
+		assert.Contains(t, res.Body.String(), expected)
+	}
+}
+
+func TestMetrics_Disabled(t *testing.T) {
+	var m *Metrics
+
+	e := echo.New()
+	ctx := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), httptest.NewRecorder())
+	assert.Nil(t, FromContext(ctx))
+
+	handler := UpstreamCallHandler("jenkins", coreModels.DefaultVariant, func(c echo.Context) error { return nil })
+	assert.NoError(t, handler(ctx))
+
+	assert.NotPanics(t, func() {
+		m.ObserveCache(UpstreamCache, true)
+		m.ObserveTimeout(true)
+		m.ObserveGenerator("GENERATOR", coreModels.DefaultVariant, nil, false)
+	})
+}
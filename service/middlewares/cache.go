@@ -1,9 +1,16 @@
 package middlewares
 
 import (
+	"context"
+	"encoding/gob"
+	"net/http"
+	"os"
+	"sync"
 	"time"
 
 	"github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/pkg/singleflight"
+	"github.com/monitoror/monitoror/service/metrics"
 
 	"github.com/jsdidierlaurent/echo-middleware/cache"
 	"github.com/labstack/echo/v4"
@@ -22,24 +29,49 @@ import (
 * So we look at the cache in the global error handler (see handlers/errors.go)
 *
 * To fill both store at the same time, I implemented a store wrapper that performs every actions on both store
+*
+* When upstream cache is cold, concurrent requests with the same key (ex: many walls opening the same config)
+* share one call of the handler (see coalescedHandle)
  */
+const (
+	// upstreamCacheMissContextKey is set in echo.Context when upstream cache handler call route handler
+	upstreamCacheMissContextKey = "monitoror.upstreamCache.miss"
+	// upstreamCacheHitContextKey contains result of upstream cache lookup (bool). Used by request logger
+	upstreamCacheHitContextKey = "monitoror.upstreamCache.hit"
+)
+
 type (
 	CacheMiddleware struct {
 		store                       cache.Store
 		downstreamDefaultExpiration time.Duration
 		upstreamDefaultExpiration   time.Duration
+
+		// downstreamExpirations contains expiration of every downstream store key. Used by downstream snapshot
+		downstreamExpirations sync.Map
+
+		// upstreamCalls coalesce concurrent calls of handlers with the same upstream cache key
+		upstreamCalls singleflight.Group
+		// baseContext cancel coalesced calls, they aren't canceled with the request starting them (see coalescedHandle)
+		baseContext context.Context
+	}
+
+	// responseRecorder copy response written by handler, used to share it with coalesced requests
+	responseRecorder struct {
+		http.ResponseWriter
+		response cache.ResponseCache
 	}
 
 	// Wrapper for setting value in store with 2 keys for timeout
 	upstreamStore struct {
 		store                       cache.Store
 		downstreamDefaultExpiration time.Duration
+		downstreamExpirations       *sync.Map
 	}
 )
 
-// NewCacheMiddleware used config to instantiate CacheMiddleware
-func NewCacheMiddleware(store cache.Store, downstreamDefaultExpiration, upstreamDefaultExpiration time.Duration) *CacheMiddleware {
-	return &CacheMiddleware{store, downstreamDefaultExpiration, upstreamDefaultExpiration}
+// NewCacheMiddleware used config to instantiate CacheMiddleware. ctx is the server base context, canceled on shutdown
+func NewCacheMiddleware(ctx context.Context, store cache.Store, downstreamDefaultExpiration, upstreamDefaultExpiration time.Duration) *CacheMiddleware {
+	return &CacheMiddleware{store: store, downstreamDefaultExpiration: downstreamDefaultExpiration, upstreamDefaultExpiration: upstreamDefaultExpiration, baseContext: ctx}
 }
 
 //==============================================================================
@@ -48,22 +80,121 @@ func NewCacheMiddleware(store cache.Store, downstreamDefaultExpiration, upstream
 
 //UpstreamCacheHandler return the cached response if he finds it in the store. (Decorator Handlers)
 func (cm *CacheMiddleware) UpstreamCacheHandler(handle echo.HandlerFunc) echo.HandlerFunc {
-	return cache.CacheHandlerWithConfig(cache.CacheMiddlewareConfig{
-		Store:     &upstreamStore{cm.store, cm.downstreamDefaultExpiration},
-		KeyPrefix: "-", // Hack we need to replace this by real key prefix in Store definition
-		Expire:    cm.upstreamDefaultExpiration,
-	}, handle)
+	return cm.upstreamCacheHandler(cm.upstreamDefaultExpiration, handle)
 }
 
 //UpstreamCacheHandlerWithExpiration return the cached response if he finds it in the store. (Decorator Handlers)
 func (cm *CacheMiddleware) UpstreamCacheHandlerWithExpiration(expire time.Duration, handle echo.HandlerFunc) echo.HandlerFunc {
+	return cm.upstreamCacheHandler(expire, handle)
+}
+
+// upstreamCacheHandler use refresh interval of tile URL (see models.RefreshIntervalQueryParam) as expiration
+// when it's longer than route expiration
+func (cm *CacheMiddleware) upstreamCacheHandler(expire time.Duration, handle echo.HandlerFunc) echo.HandlerFunc {
+	// handle is only called by cache handler when response isn't in store
+	missHandle := func(c echo.Context) error {
+		c.Set(upstreamCacheMissContextKey, true)
+		return cm.coalescedHandle(c, handle)
+	}
+	handler := cm.newUpstreamCacheHandler(expire, missHandle)
+
+	return func(c echo.Context) (err error) {
+		if refreshInterval := models.ParseRefreshInterval(c.QueryParams()); expire != cache.NEVER && refreshInterval > expire {
+			err = cm.newUpstreamCacheHandler(refreshInterval, missHandle)(c)
+		} else {
+			err = handler(c)
+		}
+
+		hit := c.Get(upstreamCacheMissContextKey) == nil
+		c.Set(upstreamCacheHitContextKey, hit)
+		metrics.FromContext(c).ObserveCache(metrics.UpstreamCache, hit)
+		return
+	}
+}
+
+func (cm *CacheMiddleware) newUpstreamCacheHandler(expire time.Duration, handle echo.HandlerFunc) echo.HandlerFunc {
 	return cache.CacheHandlerWithConfig(cache.CacheMiddlewareConfig{
-		Store:     &upstreamStore{cm.store, cm.downstreamDefaultExpiration},
+		Store:     &upstreamStore{cm.store, cm.downstreamDefaultExpiration, &cm.downstreamExpirations},
 		KeyPrefix: "-", // Hack we need to replace this by real key prefix in Store definition
 		Expire:    expire,
 	}, handle)
 }
 
+// coalescedHandle call handle once for concurrent requests with the same upstream cache key.
+// Waiting requests respond with the response (or the error) of the first one.
+// handle run with a context detached from the first request: when its client disconnect, waiting requests still get a response.
+// Upstream calls stay bounded by the timeout of their monitorable
+func (cm *CacheMiddleware) coalescedHandle(c echo.Context, handle echo.HandlerFunc) error {
+	key := models.UpstreamStoreKeyPrefix + cache.GetKey("", c.Request())
+
+	called := false
+	value, err, _ := cm.upstreamCalls.Do(key, func() (interface{}, error) {
+		called = true
+		recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+		c.Response().Writer = recorder
+
+		request := c.Request()
+		c.SetRequest(request.WithContext(singleflight.Detach(request.Context(), cm.baseContext)))
+		defer c.SetRequest(request)
+
+		err := handle(c)
+		return &recorder.response, err
+	})
+	if called || err != nil {
+		return copyError(err)
+	}
+
+	response := value.(*cache.ResponseCache)
+	for k, vals := range response.Header {
+		for _, v := range vals {
+			if c.Response().Header().Get(k) == "" {
+				c.Response().Header().Add(k, v)
+			}
+		}
+	}
+	status := response.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	c.Response().WriteHeader(status)
+	_, err = c.Response().Write(response.Data)
+	return err
+}
+
+// copyError give its own error to each coalesced request, error handler modify tile of MonitororError and message of echo.HTTPError
+func copyError(err error) error {
+	switch e := err.(type) {
+	case *models.MonitororError:
+		copied := *e
+		if e.Tile != nil {
+			tile := *e.Tile
+			copied.Tile = &tile
+		}
+		return &copied
+	case *echo.HTTPError:
+		copied := *e
+		return &copied
+	default:
+		return err
+	}
+}
+
+//DeleteUpstreamCache remove cached response of requestURI from upstream store. Used to force refresh of a route
+func (cm *CacheMiddleware) DeleteUpstreamCache(requestURI string) error {
+	return cm.store.Delete(models.UpstreamStoreKeyPrefix + cache.GetKey("", &http.Request{RequestURI: requestURI}))
+}
+
+func (r *responseRecorder) WriteHeader(code int) {
+	r.response.Status = code
+	r.response.Header = r.ResponseWriter.Header().Clone()
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.response.Data = append(r.response.Data, data...)
+	return r.ResponseWriter.Write(data)
+}
+
 //==============================================================================
 // DOWNSTREAM MIDDLEWARE
 //==============================================================================
@@ -77,6 +208,88 @@ func (cm *CacheMiddleware) DownstreamStoreMiddleware() echo.MiddlewareFunc {
 	return cache.StoreMiddlewareWithConfig(config)
 }
 
+//==============================================================================
+// DOWNSTREAM SNAPSHOT
+//==============================================================================
+
+// downstreamSnapshotEntry is a downstream store response saved in snapshot file
+type downstreamSnapshotEntry struct {
+	Key        string
+	Response   cache.ResponseCache
+	Expiration time.Time
+}
+
+//SaveDownstreamSnapshot write every unexpired downstream response in file. Used on shutdown, so restarted instance can answer timeouts
+func (cm *CacheMiddleware) SaveDownstreamSnapshot(path string) (int, error) {
+	var entries []downstreamSnapshotEntry
+
+	now := time.Now()
+	cm.downstreamExpirations.Range(func(key, value interface{}) bool {
+		expiration := value.(time.Time)
+		if expiration.Before(now) {
+			cm.downstreamExpirations.Delete(key)
+			return true
+		}
+
+		var response cache.ResponseCache
+		if err := cm.store.Get(key.(string), &response); err == nil {
+			entries = append(entries, downstreamSnapshotEntry{Key: key.(string), Response: response, Expiration: expiration})
+		}
+		return true
+	})
+
+	// Write in temporary file first to keep previous snapshot if something goes wrong
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := gob.NewEncoder(file).Encode(entries); err != nil {
+		_ = file.Close()
+		_ = os.Remove(tmpPath)
+		return 0, err
+	}
+	if err := file.Close(); err != nil {
+		_ = os.Remove(tmpPath)
+		return 0, err
+	}
+
+	return len(entries), os.Rename(tmpPath, path)
+}
+
+//LoadDownstreamSnapshot restore downstream responses saved by SaveDownstreamSnapshot with their remaining expiration.
+//Missing snapshot file isn't an error
+func (cm *CacheMiddleware) LoadDownstreamSnapshot(path string) (int, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	var entries []downstreamSnapshotEntry
+	if err := gob.NewDecoder(file).Decode(&entries); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, entry := range entries {
+		remaining := time.Until(entry.Expiration)
+		if remaining <= 0 {
+			continue
+		}
+
+		if err := cm.store.Set(entry.Key, entry.Response, remaining); err == nil {
+			cm.downstreamExpirations.Store(entry.Key, entry.Expiration)
+			count++
+		}
+	}
+
+	return count, nil
+}
+
 //==============================================================================
 // ResponsesStore methods (implementation of cache.Store)
 //==============================================================================
@@ -89,7 +302,9 @@ func (c *upstreamStore) Set(key string, val interface{}, expires time.Duration)
 
 	// Don't add response in downstream cache when she come from timeout recover to avoid infinite loop
 	if response, ok := val.(cache.ResponseCache); ok && response.Header.Get(models.DownstreamCacheHeader) == "" {
-		_ = c.store.Set(models.DownstreamStoreKeyPrefix+key[1:], val, c.downstreamDefaultExpiration)
+		if c.store.Set(models.DownstreamStoreKeyPrefix+key[1:], val, c.downstreamDefaultExpiration) == nil {
+			c.downstreamExpirations.Store(models.DownstreamStoreKeyPrefix+key[1:], time.Now().Add(c.downstreamDefaultExpiration))
+		}
 	}
 
 	return
@@ -1,9 +1,21 @@
 package middlewares
 
 import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/service/metrics"
+
 	"github.com/jsdidierlaurent/echo-middleware/cache"
 	"github.com/jsdidierlaurent/echo-middleware/cache/mocks"
 	"github.com/labstack/echo/v4"
@@ -13,7 +25,7 @@ import (
 
 func TestNewCacheMiddleware(t *testing.T) {
 	store := cache.NewGoCacheStore(time.Second, time.Second)
-	middleware := NewCacheMiddleware(store, time.Second, time.Second)
+	middleware := NewCacheMiddleware(context.Background(), store, time.Second, time.Second)
 
 	if assert.NotNil(t, middleware) {
 		assert.NotNil(t, middleware.store)
@@ -38,6 +50,178 @@ func TestUpstreamCacheHandlerWithExpiration(t *testing.T) {
 	assert.NotNil(t, handle)
 }
 
+func TestUpstreamCacheHandler_WithRefreshInterval(t *testing.T) {
+	for _, testcase := range []struct {
+		requestURI     string
+		expire         time.Duration
+		expectedExpire time.Duration
+	}{
+		{requestURI: "/test", expire: time.Second * 10, expectedExpire: time.Second * 10},
+		{requestURI: "/test?refreshInterval=60", expire: time.Second * 10, expectedExpire: time.Minute},
+		{requestURI: "/test?refreshInterval=5", expire: time.Second * 10, expectedExpire: time.Second * 10},
+		{requestURI: "/test?refreshInterval=60", expire: cache.NEVER, expectedExpire: cache.NEVER},
+	} {
+		mockStore := new(mocks.Store)
+		mockStore.On("Get", AnythingOfType("string"), Anything).Return(errors.New("cache miss"))
+		mockStore.On("Set", AnythingOfType("string"), Anything, AnythingOfType("time.Duration")).Return(nil)
+
+		middleware := &CacheMiddleware{store: mockStore, downstreamDefaultExpiration: time.Hour}
+		handle := middleware.UpstreamCacheHandlerWithExpiration(testcase.expire, func(c echo.Context) error {
+			return c.String(http.StatusOK, "test")
+		})
+
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, testcase.requestURI, nil)
+		ctx := e.NewContext(req, httptest.NewRecorder())
+
+		if assert.NoError(t, handle(ctx)) {
+			mockStore.AssertCalled(t, "Set", "monitoror.upstream.key"+cache.GetKey("", req), Anything, testcase.expectedExpire)
+		}
+	}
+}
+
+func TestUpstreamCacheHandler_WithMetrics(t *testing.T) {
+	m := metrics.NewMetrics()
+	middleware := NewCacheMiddleware(context.Background(), cache.NewGoCacheStore(time.Minute, time.Second), time.Minute, time.Minute)
+
+	e := echo.New()
+	e.Use(m.Middleware())
+	e.GET("/metrics", m.Handler)
+	e.GET("/test", middleware.UpstreamCacheHandler(func(c echo.Context) error {
+		return c.String(http.StatusOK, "test")
+	}))
+
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.RequestURI = "/test"
+		e.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	res := httptest.NewRecorder()
+	e.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Contains(t, res.Body.String(), `monitoror_cache_requests_total{cache="upstream",result="hit"} 2`)
+	assert.Contains(t, res.Body.String(), `monitoror_cache_requests_total{cache="upstream",result="miss"} 1`)
+}
+
+func TestUpstreamCacheHandler_Coalesced(t *testing.T) {
+	for _, testcase := range []struct {
+		err            error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{expectedStatus: http.StatusOK, expectedBody: "test"},
+		{err: echo.ErrServiceUnavailable, expectedStatus: http.StatusServiceUnavailable, expectedBody: "Service Unavailable"},
+	} {
+		// Upstream cache is always cold
+		mockStore := new(mocks.Store)
+		mockStore.On("Get", AnythingOfType("string"), Anything).Return(errors.New("cache miss"))
+		mockStore.On("Set", AnythingOfType("string"), Anything, AnythingOfType("time.Duration")).Return(nil)
+
+		var calls int32
+		started := make(chan struct{})
+		release := make(chan struct{})
+		middleware := &CacheMiddleware{store: mockStore, downstreamDefaultExpiration: time.Hour}
+
+		e := echo.New()
+		e.GET("/test", middleware.UpstreamCacheHandler(func(c echo.Context) error {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				close(started)
+			}
+			<-release
+
+			if testcase.err != nil {
+				return testcase.err
+			}
+			c.Response().Header().Set("X-Test", "test")
+			return c.String(http.StatusOK, "test")
+		}))
+
+		responses := make([]*httptest.ResponseRecorder, 5)
+		var wg sync.WaitGroup
+		for i := range responses {
+			responses[i] = httptest.NewRecorder()
+			wg.Add(1)
+			go func(res *httptest.ResponseRecorder) {
+				defer wg.Done()
+				req := httptest.NewRequest(http.MethodGet, "/test", nil)
+				req.RequestURI = "/test"
+				e.ServeHTTP(res, req)
+			}(responses[i])
+
+			// First request call handler, others wait it
+			if i == 0 {
+				<-started
+			}
+		}
+		time.Sleep(time.Millisecond * 100)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		for _, res := range responses {
+			assert.Equal(t, testcase.expectedStatus, res.Code)
+			assert.Contains(t, res.Body.String(), testcase.expectedBody)
+			if testcase.err == nil {
+				assert.Equal(t, "test", res.Header().Get("X-Test"))
+			}
+		}
+	}
+}
+
+func TestUpstreamCacheHandler_Coalesced_FirstRequestCanceled(t *testing.T) {
+	// Upstream cache is always cold
+	mockStore := new(mocks.Store)
+	mockStore.On("Get", AnythingOfType("string"), Anything).Return(errors.New("cache miss"))
+	mockStore.On("Set", AnythingOfType("string"), Anything, AnythingOfType("time.Duration")).Return(nil)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	middleware := NewCacheMiddleware(context.Background(), mockStore, time.Hour, time.Minute)
+
+	e := echo.New()
+	e.GET("/test", middleware.UpstreamCacheHandler(func(c echo.Context) error {
+		close(started)
+		<-release
+
+		// Upstream call fail when its context is canceled
+		if err := c.Request().Context().Err(); err != nil {
+			return err
+		}
+		return c.String(http.StatusOK, "test")
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := httptest.NewRecorder()
+	firstDone := make(chan struct{})
+	go func() {
+		defer close(firstDone)
+		req := httptest.NewRequest(http.MethodGet, "/test", nil).WithContext(ctx)
+		req.RequestURI = "/test"
+		e.ServeHTTP(first, req)
+	}()
+	<-started
+
+	second := httptest.NewRecorder()
+	secondDone := make(chan struct{})
+	go func() {
+		defer close(secondDone)
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.RequestURI = "/test"
+		e.ServeHTTP(second, req)
+	}()
+
+	// Client of first request disconnect while second request wait
+	time.Sleep(time.Millisecond * 100)
+	cancel()
+	close(release)
+	<-firstDone
+	<-secondDone
+
+	assert.Equal(t, http.StatusOK, second.Code)
+	assert.Equal(t, "test", second.Body.String())
+}
+
 func TestDownstreamStoreMiddleware(t *testing.T) {
 	middleware := &CacheMiddleware{store: &upstreamStore{}}
 	handle := middleware.DownstreamStoreMiddleware()
@@ -51,7 +235,8 @@ func TestStore(t *testing.T) {
 	mockStore.On("Set", AnythingOfType("string"), Anything, AnythingOfType("time.Duration")).Return(nil)
 
 	store := &upstreamStore{
-		store: mockStore,
+		store:                 mockStore,
+		downstreamExpirations: &sync.Map{},
 	}
 
 	// Test GET
@@ -62,6 +247,8 @@ func TestStore(t *testing.T) {
 	// Test SET
 	if assert.NoError(t, store.Set("key", cache.ResponseCache{}, time.Hour)) {
 		mockStore.AssertNumberOfCalls(t, "Set", 2)
+		_, tracked := store.downstreamExpirations.Load(models.DownstreamStoreKeyPrefix + "ey")
+		assert.True(t, tracked)
 	}
 
 	// Test Add
@@ -79,3 +266,54 @@ func TestStore(t *testing.T) {
 
 	mockStore.AssertExpectations(t)
 }
+
+func TestDeleteUpstreamCache(t *testing.T) {
+	mockStore := new(mocks.Store)
+	mockStore.On("Delete", "monitoror.upstream.key:%2Fapi%2Fv1%2Fconfig%3Fpath%3Dconfig.json").Return(nil)
+
+	middleware := &CacheMiddleware{store: mockStore}
+
+	assert.NoError(t, middleware.DeleteUpstreamCache("/api/v1/config?path=config.json"))
+	mockStore.AssertExpectations(t)
+}
+
+func TestDownstreamSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "monitoror-snapshot")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "downstream.snapshot")
+
+	middleware := NewCacheMiddleware(context.Background(), cache.NewGoCacheStore(time.Minute, time.Second), time.Minute, time.Second)
+	store := &upstreamStore{middleware.store, middleware.downstreamDefaultExpiration, &middleware.downstreamExpirations}
+	_ = store.Set("-:key", cache.ResponseCache{Status: http.StatusOK, Data: []byte("test")}, time.Second)
+	// Expired entry, ignored
+	middleware.downstreamExpirations.Store(models.DownstreamStoreKeyPrefix+":expired", time.Now().Add(-time.Second))
+
+	count, err := middleware.SaveDownstreamSnapshot(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	restored := NewCacheMiddleware(context.Background(), cache.NewGoCacheStore(time.Minute, time.Second), time.Minute, time.Second)
+	count, err = restored.LoadDownstreamSnapshot(path)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	var response cache.ResponseCache
+	if assert.NoError(t, restored.store.Get(models.DownstreamStoreKeyPrefix+":key", &response)) {
+		assert.Equal(t, "test", string(response.Data))
+	}
+	// Upstream cache isn't restored
+	assert.Error(t, restored.store.Get(models.UpstreamStoreKeyPrefix+":key", &response))
+
+	// Missing file
+	count, err = restored.LoadDownstreamSnapshot(filepath.Join(dir, "missing"))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	// Invalid file
+	_ = ioutil.WriteFile(path, []byte("invalid"), 0644)
+	_, err = restored.LoadDownstreamSnapshot(path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,155 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/service/registry"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+)
+
+// UpstreamHostContextKey contains host of service called by monitorable route. See UpstreamURLHandler
+const UpstreamHostContextKey = "monitoror.upstream.host"
+
+type (
+	// RequestLogger log every monitorable request (identified by tile route path in registry) with structured fields
+	RequestLogger struct {
+		registry   *registry.MetadataRegistry
+		jsonFormat bool
+	}
+
+	logField struct {
+		key   string
+		value interface{}
+	}
+)
+
+// NewRequestLogger create RequestLogger, fields are logged in JSON when jsonFormat is true, else in key=value format
+func NewRequestLogger(registry *registry.MetadataRegistry, jsonFormat bool) *RequestLogger {
+	return &RequestLogger{registry: registry, jsonFormat: jsonFormat}
+}
+
+// UpstreamURLHandler provide host of upstreamURL to request logger. (Decorator Handlers)
+func UpstreamURLHandler(upstreamURL string, handle echo.HandlerFunc) echo.HandlerFunc {
+	host := upstreamURL
+	if u, err := url.Parse(upstreamURL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+
+	return func(c echo.Context) error {
+		c.Set(UpstreamHostContextKey, host)
+		return handle(c)
+	}
+}
+
+// Skipper return true for monitorable requests. Used to skip them in access logs, they are already logged by Middleware
+func (rl *RequestLogger) Skipper(c echo.Context) bool {
+	_, _, found := rl.registry.FindTileByRoutePath(c.Path())
+	return found
+}
+
+// Middleware log monitorable requests. Other requests are ignored
+func (rl *RequestLogger) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			tileType, variantName, found := rl.registry.FindTileByRoutePath(c.Path())
+			if !found {
+				return err
+			}
+
+			if err != nil {
+				// Call error handler now to log real status code
+				c.Error(err)
+			}
+
+			fields := []logField{
+				{"requestId", models.RequestIDFromContext(c.Request().Context())},
+				{"tileType", tileType},
+				{"variant", variantName},
+				{"upstreamHost", upstreamHost(c)},
+				{"cache", cacheResult(c)},
+				{"status", c.Response().Status},
+				{"duration", time.Since(start).Round(time.Microsecond).String()},
+			}
+
+			if err != nil {
+				rl.log(log.WARN, "monitorable request failed", append(fields, logField{"error", err.Error()}))
+			} else {
+				rl.log(log.INFO, "monitorable request", fields)
+			}
+
+			return nil
+		}
+	}
+}
+
+func (rl *RequestLogger) log(level log.Lvl, message string, fields []logField) {
+	if rl.jsonFormat {
+		j := log.JSON{"message": message}
+		for _, field := range fields {
+			j[field.key] = field.value
+		}
+
+		if level == log.WARN {
+			log.Warnj(j)
+		} else {
+			log.Infoj(j)
+		}
+		return
+	}
+
+	var builder strings.Builder
+	builder.WriteString(message)
+	for _, field := range fields {
+		value := fmt.Sprintf("%v", field.value)
+		if value == "" || strings.ContainsAny(value, " \"=") {
+			value = fmt.Sprintf("%q", value)
+		}
+		builder.WriteString(fmt.Sprintf(" %s=%s", field.key, value))
+	}
+
+	if level == log.WARN {
+		log.Warn(builder.String())
+	} else {
+		log.Info(builder.String())
+	}
+}
+
+// upstreamHost return host provided by UpstreamURLHandler, else host given in query params (http, ping, port monitorables)
+func upstreamHost(c echo.Context) string {
+	if host, ok := c.Get(UpstreamHostContextKey).(string); ok {
+		return host
+	}
+
+	if u, err := url.Parse(c.QueryParam("url")); err == nil && u.Host != "" {
+		return u.Host
+	}
+
+	return c.QueryParam("hostname")
+}
+
+// cacheResult return "hit" or "miss" for upstream cache, "downstream" when response come from timeout recover
+// and "none" for routes without upstream cache
+func cacheResult(c echo.Context) string {
+	if c.Response().Header().Get(models.DownstreamCacheHeader) != "" {
+		return "downstream"
+	}
+
+	hit, ok := c.Get(upstreamCacheHitContextKey).(bool)
+	switch {
+	case !ok:
+		return "none"
+	case hit:
+		return "hit"
+	default:
+		return "miss"
+	}
+}
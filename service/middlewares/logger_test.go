@@ -0,0 +1,141 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/service/registry"
+
+	"github.com/jsdidierlaurent/echo-middleware/cache"
+	"github.com/labstack/echo/v4"
+	echoMiddleware "github.com/labstack/echo/v4/middleware"
+	"github.com/labstack/gommon/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func initRequestLoggerEcho(jsonFormat bool) (*echo.Echo, *bytes.Buffer) {
+	output := &bytes.Buffer{}
+	log.SetOutput(output)
+	log.SetHeader(`{"level":"${level}"}`)
+
+	metadataRegistry := registry.NewRegistry()
+	metadataRegistry.RegisterTile("TEST", "2.0", []coreModels.VariantName{coreModels.DefaultVariant}, time.Second, nil).
+		Enable(coreModels.DefaultVariant, "/test/default/test")
+
+	cacheMiddleware := NewCacheMiddleware(context.Background(), cache.NewGoCacheStore(time.Minute, time.Second), time.Minute, time.Minute)
+
+	e := echo.New()
+	e.Use(echoMiddleware.RequestID())
+	e.Use(RequestIDContext())
+	e.Use(NewRequestLogger(metadataRegistry, jsonFormat).Middleware())
+	e.GET("/test/default/test", UpstreamURLHandler("https://test.example.com:8443/path", cacheMiddleware.UpstreamCacheHandler(func(c echo.Context) error {
+		if c.QueryParam("error") != "" {
+			return errors.New("boom")
+		}
+		return c.String(http.StatusOK, "test")
+	})))
+	e.GET("/other", func(c echo.Context) error { return c.String(http.StatusOK, "other") })
+
+	return e, output
+}
+
+func serveRequestLoggerEcho(e *echo.Echo, requestURI string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, requestURI, nil)
+	req.RequestURI = requestURI // Used by cache middleware to build cache key
+	res := httptest.NewRecorder()
+	e.ServeHTTP(res, req)
+	return res
+}
+
+func TestRequestLogger_JSON(t *testing.T) {
+	e, output := initRequestLoggerEcho(true)
+	defer log.SetOutput(os.Stdout)
+
+	res := serveRequestLoggerEcho(e, "/test/default/test")
+	serveRequestLoggerEcho(e, "/test/default/test")
+	serveRequestLoggerEcho(e, "/other")
+
+	decoder := json.NewDecoder(output)
+
+	var fields map[string]interface{}
+	if assert.NoError(t, decoder.Decode(&fields)) {
+		assert.Equal(t, "INFO", fields["level"])
+		assert.Equal(t, "monitorable request", fields["message"])
+		assert.Equal(t, res.Header().Get(echo.HeaderXRequestID), fields["requestId"])
+		assert.Equal(t, "TEST", fields["tileType"])
+		assert.Equal(t, "default", fields["variant"])
+		assert.Equal(t, "test.example.com:8443", fields["upstreamHost"])
+		assert.Equal(t, "miss", fields["cache"])
+		assert.Equal(t, float64(http.StatusOK), fields["status"])
+		assert.NotEmpty(t, fields["duration"])
+	}
+
+	fields = nil
+	if assert.NoError(t, decoder.Decode(&fields)) {
+		assert.Equal(t, "hit", fields["cache"])
+	}
+
+	// Other routes are not logged
+	assert.False(t, decoder.More())
+}
+
+func TestRequestLogger_Text(t *testing.T) {
+	e, output := initRequestLoggerEcho(false)
+	defer log.SetOutput(os.Stdout)
+
+	res := serveRequestLoggerEcho(e, "/test/default/test?error=true")
+
+	assert.Equal(t, http.StatusInternalServerError, res.Code)
+	assert.Contains(t, output.String(), `"level":"WARN"`)
+	assert.Contains(t, output.String(), "monitorable request failed requestId="+res.Header().Get(echo.HeaderXRequestID))
+	assert.Contains(t, output.String(), "tileType=TEST variant=default upstreamHost=test.example.com:8443 cache=miss status=500")
+	assert.Contains(t, output.String(), `error=boom`)
+}
+
+func TestRequestLogger_Skipper(t *testing.T) {
+	metadataRegistry := registry.NewRegistry()
+	metadataRegistry.RegisterTile("TEST", "2.0", []coreModels.VariantName{coreModels.DefaultVariant}, time.Second, nil).
+		Enable(coreModels.DefaultVariant, "/test/default/test")
+	requestLogger := NewRequestLogger(metadataRegistry, true)
+
+	output := &bytes.Buffer{}
+	log.SetOutput(output)
+	defer log.SetOutput(os.Stdout)
+
+	e := echo.New()
+	e.Use(echoMiddleware.LoggerWithConfig(echoMiddleware.LoggerConfig{Format: "access ${uri}\n", Output: output, Skipper: requestLogger.Skipper}))
+	e.Use(requestLogger.Middleware())
+	e.GET("/test/default/test", func(c echo.Context) error { return c.String(http.StatusOK, "test") })
+	e.GET("/other", func(c echo.Context) error { return c.String(http.StatusOK, "other") })
+
+	serveRequestLoggerEcho(e, "/test/default/test")
+	serveRequestLoggerEcho(e, "/other")
+
+	// Monitorable request is only logged once, by request logger
+	assert.Equal(t, 1, strings.Count(output.String(), `"tileType":"TEST"`))
+	assert.NotContains(t, output.String(), "access /test/default/test")
+	assert.Contains(t, output.String(), "access /other")
+}
+
+func TestUpstreamHost(t *testing.T) {
+	for _, testcase := range []struct {
+		requestURI   string
+		expectedHost string
+	}{
+		{requestURI: "/test?url=https://monitoror.example.com/health", expectedHost: "monitoror.example.com"},
+		{requestURI: "/test?hostname=monitoror.example.com", expectedHost: "monitoror.example.com"},
+		{requestURI: "/test", expectedHost: ""},
+	} {
+		ctx := echo.New().NewContext(httptest.NewRequest(http.MethodGet, testcase.requestURI, nil), httptest.NewRecorder())
+		assert.Equal(t, testcase.expectedHost, upstreamHost(ctx))
+	}
+}
@@ -0,0 +1,20 @@
+package middlewares
+
+import (
+	"github.com/monitoror/monitoror/models"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequestIDContext copy request ID generated by echo RequestID middleware into request context,
+// so usecases and repositories can log it. Must be used after echo RequestID middleware
+func RequestIDContext() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if requestID := c.Response().Header().Get(echo.HeaderXRequestID); requestID != "" {
+				c.SetRequest(c.Request().WithContext(models.ContextWithRequestID(c.Request().Context(), requestID)))
+			}
+			return next(c)
+		}
+	}
+}
@@ -14,7 +14,7 @@ type GeneratorEnabler struct {
 	mock.Mock
 }
 
-// Enable provides a mock function with given fields: variantName, generatorParamsValidator, tileGeneratorFunction
-func (_m *GeneratorEnabler) Enable(variantName models.VariantName, generatorParamsValidator configmodels.ParamsValidator, tileGeneratorFunction configmodels.TileGeneratorFunction) {
-	_m.Called(variantName, generatorParamsValidator, tileGeneratorFunction)
+// Enable provides a mock function with given fields: variantName, tileGeneratorFunction
+func (_m *GeneratorEnabler) Enable(variantName models.VariantName, tileGeneratorFunction configmodels.TileGeneratorFunction) {
+	_m.Called(variantName, tileGeneratorFunction)
 }
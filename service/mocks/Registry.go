@@ -9,6 +9,8 @@ import (
 	models "github.com/monitoror/monitoror/models"
 
 	registry "github.com/monitoror/monitoror/service/registry"
+
+	time "time"
 )
 
 // Registry is an autogenerated mock type for the Registry type
@@ -16,13 +18,13 @@ type Registry struct {
 	mock.Mock
 }
 
-// RegisterGenerator provides a mock function with given fields: generatedTileType, minimalVersion, variantNames
-func (_m *Registry) RegisterGenerator(generatedTileType models.TileType, minimalVersion configmodels.RawVersion, variantNames []models.VariantName) registry.GeneratorEnabler {
-	ret := _m.Called(generatedTileType, minimalVersion, variantNames)
+// RegisterGenerator provides a mock function with given fields: generatedTileType, minimalVersion, variantNames, generatorParamsValidator
+func (_m *Registry) RegisterGenerator(generatedTileType models.TileType, minimalVersion configmodels.RawVersion, variantNames []models.VariantName, generatorParamsValidator configmodels.ParamsValidator) registry.GeneratorEnabler {
+	ret := _m.Called(generatedTileType, minimalVersion, variantNames, generatorParamsValidator)
 
 	var r0 registry.GeneratorEnabler
-	if rf, ok := ret.Get(0).(func(models.TileType, configmodels.RawVersion, []models.VariantName) registry.GeneratorEnabler); ok {
-		r0 = rf(generatedTileType, minimalVersion, variantNames)
+	if rf, ok := ret.Get(0).(func(models.TileType, configmodels.RawVersion, []models.VariantName, configmodels.ParamsValidator) registry.GeneratorEnabler); ok {
+		r0 = rf(generatedTileType, minimalVersion, variantNames, generatorParamsValidator)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(registry.GeneratorEnabler)
@@ -32,13 +34,13 @@ func (_m *Registry) RegisterGenerator(generatedTileType models.TileType, minimal
 	return r0
 }
 
-// RegisterTile provides a mock function with given fields: tileType, minimalVersion, variantNames
-func (_m *Registry) RegisterTile(tileType models.TileType, minimalVersion configmodels.RawVersion, variantNames []models.VariantName) registry.TileEnabler {
-	ret := _m.Called(tileType, minimalVersion, variantNames)
+// RegisterTile provides a mock function with given fields: tileType, minimalVersion, variantNames, minimalRefreshInterval, paramsValidator
+func (_m *Registry) RegisterTile(tileType models.TileType, minimalVersion configmodels.RawVersion, variantNames []models.VariantName, minimalRefreshInterval time.Duration, paramsValidator configmodels.ParamsValidator) registry.TileEnabler {
+	ret := _m.Called(tileType, minimalVersion, variantNames, minimalRefreshInterval, paramsValidator)
 
 	var r0 registry.TileEnabler
-	if rf, ok := ret.Get(0).(func(models.TileType, configmodels.RawVersion, []models.VariantName) registry.TileEnabler); ok {
-		r0 = rf(tileType, minimalVersion, variantNames)
+	if rf, ok := ret.Get(0).(func(models.TileType, configmodels.RawVersion, []models.VariantName, time.Duration, configmodels.ParamsValidator) registry.TileEnabler); ok {
+		r0 = rf(tileType, minimalVersion, variantNames, minimalRefreshInterval, paramsValidator)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(registry.TileEnabler)
@@ -3,7 +3,6 @@
 package mocks
 
 import (
-	configmodels "github.com/monitoror/monitoror/api/config/models"
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/monitoror/monitoror/models"
@@ -14,7 +13,7 @@ type TileEnabler struct {
 	mock.Mock
 }
 
-// Enable provides a mock function with given fields: variantName, paramsValidator, routePath
-func (_m *TileEnabler) Enable(variantName models.VariantName, paramsValidator configmodels.ParamsValidator, routePath string) {
-	_m.Called(variantName, paramsValidator, routePath)
+// Enable provides a mock function with given fields: variantName, routePath
+func (_m *TileEnabler) Enable(variantName models.VariantName, routePath string) {
+	_m.Called(variantName, routePath)
 }
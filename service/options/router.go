@@ -17,6 +17,7 @@ type (
 		Middlewares           []echo.MiddlewareFunc
 		CustomCacheExpiration *time.Duration
 		NoCache               bool
+		UpstreamURL           string
 	}
 )
 
@@ -53,6 +54,17 @@ func (w withNoCache) Apply(o *RouterSettings) {
 	o.NoCache = true
 }
 
+// WithUpstreamURL returns a RouterOption that specifies URL of service called by route. Used in request logs
+func WithUpstreamURL(upstreamURL string) RouterOption {
+	return withUpstreamURL{upstreamURL}
+}
+
+type withUpstreamURL struct{ upstreamURL string }
+
+func (w withUpstreamURL) Apply(o *RouterSettings) {
+	o.UpstreamURL = w.upstreamURL
+}
+
 func ApplyOptions(options ...RouterOption) *RouterSettings {
 	rs := &RouterSettings{}
 
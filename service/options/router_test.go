@@ -32,3 +32,9 @@ func TestWithNoCache(t *testing.T) {
 	settings := ApplyOptions(option)
 	assert.True(t, settings.NoCache)
 }
+
+func TestWithUpstreamURL(t *testing.T) {
+	option := WithUpstreamURL("https://jenkins.example.com")
+	settings := ApplyOptions(option)
+	assert.Equal(t, "https://jenkins.example.com", settings.UpstreamURL)
+}
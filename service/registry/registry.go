@@ -4,6 +4,7 @@ package registry
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/monitoror/monitoror/api/config/models"
 	coreModels "github.com/monitoror/monitoror/models"
@@ -12,16 +13,16 @@ import (
 type (
 	// TileManager is used to register Tile and Tile generator in config for verify / hydrate
 	Registry interface {
-		RegisterTile(tileType coreModels.TileType, minimalVersion models.RawVersion, variantNames []coreModels.VariantName) TileEnabler
-		RegisterGenerator(generatedTileType coreModels.TileType, minimalVersion models.RawVersion, variantNames []coreModels.VariantName) GeneratorEnabler
+		RegisterTile(tileType coreModels.TileType, minimalVersion models.RawVersion, variantNames []coreModels.VariantName, minimalRefreshInterval time.Duration, paramsValidator models.ParamsValidator) TileEnabler
+		RegisterGenerator(generatedTileType coreModels.TileType, minimalVersion models.RawVersion, variantNames []coreModels.VariantName, generatorParamsValidator models.ParamsValidator) GeneratorEnabler
 	}
 	// TileEnabler is returned to monitorable after register to enable monitorable tile with this variant if she is "valid"
 	TileEnabler interface {
-		Enable(variantName coreModels.VariantName, paramsValidator models.ParamsValidator, routePath string)
+		Enable(variantName coreModels.VariantName, routePath string)
 	}
 	// GeneratorEnabler is returned to monitorable after register to enable monitorable generator with this variant if she is "valid"
 	GeneratorEnabler interface {
-		Enable(variantName coreModels.VariantName, generatorParamsValidator models.ParamsValidator, tileGeneratorFunction models.TileGeneratorFunction)
+		Enable(variantName coreModels.VariantName, tileGeneratorFunction models.TileGeneratorFunction)
 	}
 
 	// TileAccessor is used in verify. Matching Tile and Generator.
@@ -48,6 +49,10 @@ type (
 		TileType coreModels.TileType
 		// MinimalVersion is the version that makes the tile available
 		MinimalVersion models.RawVersion
+		// MinimalRefreshInterval is the minimal "refreshInterval" allowed in tile config
+		MinimalRefreshInterval time.Duration
+		// ParamsValidator is the params struct of tile, same for every variants
+		ParamsValidator models.ParamsValidator
 		// SettingVariants list all registered variants (can be available or not)
 		VariantsMetadata map[coreModels.VariantName]*tileVariantMetadata
 	}
@@ -72,6 +77,8 @@ type (
 		GeneratedTileType coreModels.TileType
 		// MinimalVersion is the version that makes the tile available
 		MinimalVersion models.RawVersion
+		// GeneratorParamsValidator is the params struct of generator, same for every variants
+		GeneratorParamsValidator models.ParamsValidator
 		// Variants list all registered variants (can be available or not)
 		VariantsMetadata map[coreModels.VariantName]*generatorVariantMetadata
 	}
@@ -99,11 +106,13 @@ func NewRegistry() *MetadataRegistry {
 
 // REGISTRY
 // ----------------------------------------
-func (r *MetadataRegistry) RegisterTile(tileType coreModels.TileType, minimalVersion models.RawVersion, variantNames []coreModels.VariantName) TileEnabler {
+func (r *MetadataRegistry) RegisterTile(tileType coreModels.TileType, minimalVersion models.RawVersion, variantNames []coreModels.VariantName, minimalRefreshInterval time.Duration, paramsValidator models.ParamsValidator) TileEnabler {
 	tileSetting := &tileMetadata{
-		TileType:         tileType,
-		MinimalVersion:   minimalVersion,
-		VariantsMetadata: make(map[coreModels.VariantName]*tileVariantMetadata),
+		TileType:               tileType,
+		MinimalVersion:         minimalVersion,
+		MinimalRefreshInterval: minimalRefreshInterval,
+		ParamsValidator:        paramsValidator,
+		VariantsMetadata:       make(map[coreModels.VariantName]*tileVariantMetadata),
 	}
 
 	// Register Variant with Enabled False
@@ -118,15 +127,16 @@ func (r *MetadataRegistry) RegisterTile(tileType coreModels.TileType, minimalVer
 	return tileSetting
 }
 
-func (r *MetadataRegistry) RegisterGenerator(generatedTileType coreModels.TileType, minimalVersion models.RawVersion, variantNames []coreModels.VariantName) GeneratorEnabler {
+func (r *MetadataRegistry) RegisterGenerator(generatedTileType coreModels.TileType, minimalVersion models.RawVersion, variantNames []coreModels.VariantName, generatorParamsValidator models.ParamsValidator) GeneratorEnabler {
 	// Boxing tile type into generator
 	tileType := coreModels.NewGeneratorTileType(generatedTileType)
 
 	generatorSetting := &generatorMetadata{
-		TileType:          tileType,
-		GeneratedTileType: generatedTileType,
-		MinimalVersion:    minimalVersion,
-		VariantsMetadata:  make(map[coreModels.VariantName]*generatorVariantMetadata),
+		TileType:                 tileType,
+		GeneratedTileType:        generatedTileType,
+		MinimalVersion:           minimalVersion,
+		GeneratorParamsValidator: generatorParamsValidator,
+		VariantsMetadata:         make(map[coreModels.VariantName]*generatorVariantMetadata),
 	}
 
 	// Register Variant with Enabled False
@@ -141,18 +151,31 @@ func (r *MetadataRegistry) RegisterGenerator(generatedTileType coreModels.TileTy
 	return generatorSetting
 }
 
+// FindTileByRoutePath return tile type and variant enabled with this route path. Used to identify monitorable requests
+func (r *MetadataRegistry) FindTileByRoutePath(routePath string) (coreModels.TileType, coreModels.VariantName, bool) {
+	for tileType, tileMetadata := range r.TileMetadata {
+		for variantName, variantMetadata := range tileMetadata.VariantsMetadata {
+			if variantMetadata.Enabled && variantMetadata.RoutePath != nil && *variantMetadata.RoutePath == routePath {
+				return tileType, variantName, true
+			}
+		}
+	}
+
+	return "", "", false
+}
+
 // ----------------------------------------
 
 // TILE METADATA
 // ----------------------------------------
-func (tm *tileMetadata) Enable(variantName coreModels.VariantName, paramsValidator models.ParamsValidator, routePath string) {
+func (tm *tileMetadata) Enable(variantName coreModels.VariantName, routePath string) {
 	variantMetadata, exists := tm.VariantsMetadata[variantName]
 	if !exists {
 		panic(fmt.Sprintf("unable to enable unknown variantName: %s for tile: %s. register it before.", variantName, tm.TileType))
 	}
 
 	variantMetadata.Enabled = true
-	variantMetadata.ParamsValidator = paramsValidator
+	variantMetadata.ParamsValidator = tm.ParamsValidator
 	variantMetadata.RoutePath = &routePath
 }
 
@@ -177,14 +200,14 @@ func (tm *tileMetadata) GetVariantNames() []coreModels.VariantName {
 
 // GENERATOR METADATA
 // ----------------------------------------
-func (gm *generatorMetadata) Enable(variantName coreModels.VariantName, generatorParamsValidator models.ParamsValidator, tileGeneratorFunction models.TileGeneratorFunction) {
+func (gm *generatorMetadata) Enable(variantName coreModels.VariantName, tileGeneratorFunction models.TileGeneratorFunction) {
 	variantMetadata, exists := gm.VariantsMetadata[variantName]
 	if !exists {
 		panic(fmt.Sprintf("unable to enable unknown variantName: %s for tile: %s. register it before.", variantName, gm.TileType))
 	}
 
 	variantMetadata.Enabled = true
-	variantMetadata.GeneratorParamsValidator = generatorParamsValidator
+	variantMetadata.GeneratorParamsValidator = gm.GeneratorParamsValidator
 	variantMetadata.GeneratorFunction = tileGeneratorFunction
 }
 
@@ -2,7 +2,9 @@ package registry
 
 import (
 	"testing"
+	"time"
 
+	configModels "github.com/monitoror/monitoror/api/config/models"
 	"github.com/monitoror/monitoror/api/config/versions"
 	"github.com/monitoror/monitoror/models"
 	coreModels "github.com/monitoror/monitoror/models"
@@ -12,13 +14,14 @@ import (
 
 func TestSettingManager(t *testing.T) {
 	registry := NewRegistry()
-	registry.RegisterTile("TEST", versions.CurrentVersion, []coreModels.VariantName{"test-variant"}).
-		Enable("test-variant", nil, "test-route")
-	registry.RegisterGenerator("TEST", versions.CurrentVersion, []coreModels.VariantName{"test-variant"}).
-		Enable("test-variant", nil, nil)
+	registry.RegisterTile("TEST", versions.CurrentVersion, []coreModels.VariantName{"test-variant"}, time.Second, nil).
+		Enable("test-variant", "test-route")
+	registry.RegisterGenerator("TEST", versions.CurrentVersion, []coreModels.VariantName{"test-variant"}, nil).
+		Enable("test-variant", nil)
 
 	assert.Len(t, registry.TileMetadata, 1)
 	assert.Equal(t, versions.CurrentVersion, registry.TileMetadata["TEST"].GetMinimalVersion())
+	assert.Equal(t, time.Second, registry.TileMetadata["TEST"].MinimalRefreshInterval)
 	assert.Equal(t, []models.VariantName{"test-variant"}, registry.TileMetadata["TEST"].GetVariantNames())
 	assert.Equal(t, coreModels.TileType("TEST"), registry.TileMetadata["TEST"].TileType)
 	variant, exists := registry.TileMetadata["TEST"].GetVariant("test-variant")
@@ -41,13 +44,55 @@ func TestSettingManager(t *testing.T) {
 
 func TestTileSetting_Enable_Panic(t *testing.T) {
 	registry := NewRegistry()
-	tileEnabler := registry.RegisterTile("TEST", versions.CurrentVersion, []coreModels.VariantName{"test-variant"})
+	tileEnabler := registry.RegisterTile("TEST", versions.CurrentVersion, []coreModels.VariantName{"test-variant"}, time.Second, nil)
 	assert.Panics(t, func() {
-		tileEnabler.Enable("wrong-variant", nil, "")
+		tileEnabler.Enable("wrong-variant", "")
 	})
 
-	tileGeneratorEnabler := registry.RegisterGenerator("TEST", versions.CurrentVersion, []coreModels.VariantName{"test-variant"})
+	tileGeneratorEnabler := registry.RegisterGenerator("TEST", versions.CurrentVersion, []coreModels.VariantName{"test-variant"}, nil)
 	assert.Panics(t, func() {
-		tileGeneratorEnabler.Enable("wrong-variant", nil, nil)
+		tileGeneratorEnabler.Enable("wrong-variant", nil)
 	})
 }
+
+func TestMetadataRegistry_FindTileByRoutePath(t *testing.T) {
+	registry := NewRegistry()
+	enabler := registry.RegisterTile("TEST", versions.CurrentVersion, []coreModels.VariantName{coreModels.DefaultVariant, "test-variant", "disabled"}, time.Second, nil)
+	enabler.Enable(coreModels.DefaultVariant, "/test/default/route")
+	enabler.Enable("test-variant", "/test/test-variant/route")
+
+	tileType, variantName, found := registry.FindTileByRoutePath("/test/test-variant/route")
+	if assert.True(t, found) {
+		assert.Equal(t, coreModels.TileType("TEST"), tileType)
+		assert.Equal(t, coreModels.VariantName("test-variant"), variantName)
+	}
+
+	_, _, found = registry.FindTileByRoutePath("/test/disabled/route")
+	assert.False(t, found)
+}
+
+type testParams struct{}
+
+func (p *testParams) Validate(_ *configModels.ConfigVersion) []configModels.ConfigError {
+	return nil
+}
+
+func TestSettingManager_ParamsValidator(t *testing.T) {
+	registry := NewRegistry()
+	registry.RegisterTile("TEST", versions.CurrentVersion, []coreModels.VariantName{"test-variant", "disabled"}, time.Second, &testParams{}).
+		Enable("test-variant", "test-route")
+	registry.RegisterGenerator("TEST", versions.CurrentVersion, []coreModels.VariantName{"test-variant"}, &testParams{}).
+		Enable("test-variant", nil)
+
+	// Params are known from registration, even without enabled variant
+	assert.Equal(t, &testParams{}, registry.TileMetadata["TEST"].ParamsValidator)
+	variant, _ := registry.TileMetadata["TEST"].GetVariant("test-variant")
+	assert.Equal(t, &testParams{}, variant.GetValidator())
+	variant, _ = registry.TileMetadata["TEST"].GetVariant("disabled")
+	assert.False(t, variant.IsEnabled())
+
+	generatorTileType := coreModels.NewGeneratorTileType("TEST")
+	assert.Equal(t, &testParams{}, registry.GeneratorMetadata[generatorTileType].GeneratorParamsValidator)
+	variant, _ = registry.GeneratorMetadata[generatorTileType].GetVariant("test-variant")
+	assert.Equal(t, &testParams{}, variant.GetValidator())
+}
@@ -4,8 +4,11 @@ package router
 
 import (
 	"fmt"
+	"strings"
 
 	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/service/health"
+	"github.com/monitoror/monitoror/service/metrics"
 	"github.com/monitoror/monitoror/service/middlewares"
 	"github.com/monitoror/monitoror/service/options"
 
@@ -23,33 +26,48 @@ type (
 	router struct {
 		apiVersion      *echo.Group
 		cacheMiddleware *middlewares.CacheMiddleware
+		health          *health.Health
 	}
 
 	group struct {
 		router *router
 		group  *echo.Group
+
+		// Used as metrics labels and health upstream name
+		monitorable string
+		variantName coreModels.VariantName
 	}
 )
 
-func NewMonitorableRouter(apiVersion *echo.Group, cacheMiddleware *middlewares.CacheMiddleware) MonitorableRouter {
-	return &router{apiVersion: apiVersion, cacheMiddleware: cacheMiddleware}
+func NewMonitorableRouter(apiVersion *echo.Group, cacheMiddleware *middlewares.CacheMiddleware, health *health.Health) MonitorableRouter {
+	return &router{apiVersion: apiVersion, cacheMiddleware: cacheMiddleware, health: health}
 }
 
 func (r *router) Group(path string, variantName coreModels.VariantName) MonitorableRouterGroup {
-	return &group{router: r, group: r.apiVersion.Group(fmt.Sprintf(`%s/%s`, path, variantName))}
+	return &group{
+		router:      r,
+		group:       r.apiVersion.Group(fmt.Sprintf(`%s/%s`, path, variantName)),
+		monitorable: strings.Trim(path, "/"),
+		variantName: variantName,
+	}
 }
 
 func (g *group) GET(path string, handlerFunc echo.HandlerFunc, opts ...options.RouterOption) *echo.Route {
 	routerSettings := options.ApplyOptions(opts...)
 
-	handler := handlerFunc
+	// Wrapped by upstream cache to only record real calls to services
+	handler := metrics.UpstreamCallHandler(g.monitorable, g.variantName, handlerFunc)
+	handler = g.router.health.UpstreamCallHandler(g.monitorable, g.variantName, handler)
 	if !routerSettings.NoCache {
 		if routerSettings.CustomCacheExpiration != nil {
-			handler = g.router.cacheMiddleware.UpstreamCacheHandlerWithExpiration(*routerSettings.CustomCacheExpiration, handlerFunc)
+			handler = g.router.cacheMiddleware.UpstreamCacheHandlerWithExpiration(*routerSettings.CustomCacheExpiration, handler)
 		} else {
-			handler = g.router.cacheMiddleware.UpstreamCacheHandler(handlerFunc)
+			handler = g.router.cacheMiddleware.UpstreamCacheHandler(handler)
 		}
 	}
+	if routerSettings.UpstreamURL != "" {
+		handler = middlewares.UpstreamURLHandler(routerSettings.UpstreamURL, handler)
+	}
 
 	return g.group.GET(path, handler, routerSettings.Middlewares...)
 }
@@ -1,10 +1,12 @@
 package router
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/service/health"
 	"github.com/monitoror/monitoror/service/middlewares"
 	"github.com/monitoror/monitoror/service/options"
 
@@ -17,8 +19,8 @@ import (
 func TestNewMonitorableRouter(t *testing.T) {
 	// Init
 	g := echo.New().Group("/api/v1")
-	cacheMiddleware := middlewares.NewCacheMiddleware(cache.NewGoCacheStore(time.Minute, time.Second), time.Minute, time.Minute)
-	monitorableRouter := NewMonitorableRouter(g, cacheMiddleware)
+	cacheMiddleware := middlewares.NewCacheMiddleware(context.Background(), cache.NewGoCacheStore(time.Minute, time.Second), time.Minute, time.Minute)
+	monitorableRouter := NewMonitorableRouter(g, cacheMiddleware, health.NewHealth())
 	handler := func(context echo.Context) error { return nil }
 
 	routeGroup := monitorableRouter.Group("/test", coreModels.DefaultVariant)
@@ -1,14 +1,30 @@
 package service
 
 import (
-	"fmt"
+	"context"
+	"io"
 	"math/rand"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
+	configApi "github.com/monitoror/monitoror/api/config"
+	configUsecase "github.com/monitoror/monitoror/api/config/usecase"
 	"github.com/monitoror/monitoror/cli"
 	"github.com/monitoror/monitoror/config"
+	coreModels "github.com/monitoror/monitoror/models"
+	"github.com/monitoror/monitoror/monitorables"
 	"github.com/monitoror/monitoror/pkg/system"
+	"github.com/monitoror/monitoror/service/auth"
+	"github.com/monitoror/monitoror/service/cachestore"
 	"github.com/monitoror/monitoror/service/handlers"
+	"github.com/monitoror/monitoror/service/health"
+	"github.com/monitoror/monitoror/service/metrics"
 	"github.com/monitoror/monitoror/service/middlewares"
 	"github.com/monitoror/monitoror/service/registry"
 	"github.com/monitoror/monitoror/service/store"
@@ -26,37 +42,189 @@ type (
 		*echo.Echo
 
 		store *store.Store
+
+		// Used to export config schema. See PrintConfigSchema
+		configUsecase configApi.Usecase
+		// Used to describe monitorables. See DescribeMonitorables
+		monitorableManager *monitorables.Manager
+
+		// Base context of every request, canceled on shutdown to stop streams and their tile refresh
+		ctx    context.Context
+		cancel context.CancelFunc
+
+		// offline server (CLI commands) is never started, it doesn't open caches of running server. See newCacheStore
+		offline bool
+
+		// HTTP to HTTPS redirect server. See startRedirectServer
+		redirectServer *http.Server
 	}
 )
 
 var colorer = color.New()
 
+var logLevels = map[string]log.Lvl{
+	"debug": log.DEBUG,
+	"info":  log.INFO,
+	"warn":  log.WARN,
+	"error": log.ERROR,
+	"off":   log.OFF,
+}
+
+const (
+	textLogHeader = `${time_rfc3339} ${level}`
+	jsonLogHeader = `{"time":"${time_rfc3339_nano}","level":"${level}"}`
+)
+
 func init() {
 	rand.Seed(time.Now().UnixNano())
 }
 
 // Init create echo server with middlewares, ui, routes
 func Init(config *config.Config, cli cli.CLI) *Server {
+	s := newServer(config, cli, false)
+
+	InitUI(s)
+	InitApis(s)
+
+	if s.isDownstreamSnapshotEnabled() {
+		s.loadDownstreamSnapshot()
+	}
+
+	if config.StartupDiagnosis {
+		s.DiagnoseMonitorables()
+	}
+
+	return s
+}
+
+// InitOffline create echo server with middlewares and routes but without ui.
+// Used by CLI commands to boot monitorables, server is never started. Caches are only kept in memory
+func InitOffline(config *config.Config, cli cli.CLI) *Server {
+	s := newServer(config, cli, true)
+
+	InitApis(s)
+
+	return s
+}
+
+func newServer(config *config.Config, cli cli.CLI, offline bool) *Server {
+	ctx, cancel := context.WithCancel(context.Background())
+
 	s := &Server{
+		ctx:     ctx,
+		cancel:  cancel,
+		offline: offline,
 		store: &store.Store{
 			CoreConfig: config,
+			Context:    ctx,
 			Cli:        cli,
 			Registry:   registry.NewRegistry(),
+			Health:     health.NewHealth(),
 		},
 	}
 
 	s.setupEchoServer()
 	s.setupEchoMiddleware()
 
-	InitUI(s)
-	InitApis(s)
-
 	return s
 }
 
+// Start listen until SIGINT / SIGTERM, then shutdown gracefully (see Shutdown)
 func (s *Server) Start() {
-	s.store.Cli.PrintServerStartup(system.GetNetworkIP(), s.store.CoreConfig.Port)
-	log.Fatal(s.Echo.Start(fmt.Sprintf(":%d", s.store.CoreConfig.Port)))
+	conf := s.store.CoreConfig
+	address := net.JoinHostPort(conf.Address, strconv.Itoa(conf.Port))
+
+	ip := conf.Address
+	if ip == "" {
+		ip = system.GetNetworkIP()
+	}
+
+	tlsConfig, err := s.setupTLS()
+	if err != nil {
+		log.Fatalf("unable to setup HTTPS, %v", err)
+	}
+
+	var serve func() error
+	if tlsConfig == nil {
+		s.store.Cli.PrintServerStartup("http", ip, conf.Port)
+		serve = func() error { return s.Echo.Start(address) }
+	} else {
+		if conf.TLSRedirectPort != 0 {
+			s.startRedirectServer()
+		}
+
+		s.store.Cli.PrintServerStartup("https", ip, conf.Port)
+		s.TLSServer.Addr = address
+		s.TLSServer.TLSConfig = tlsConfig
+		serve = func() error { return s.Echo.StartServer(s.TLSServer) }
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+
+	go func() {
+		if err := serve(); err != nil && err != http.ErrServerClosed {
+			log.Fatal(err)
+		}
+	}()
+
+	sig := <-signals
+	log.Infof("%s received, shutting down", sig)
+	s.Shutdown()
+}
+
+// Shutdown stop listening, cancel streams and wait in-flight requests (at most ShutdownTimeout) before closing connections.
+// Downstream cache is saved when snapshot file is configured
+func (s *Server) Shutdown() {
+	conf := s.store.CoreConfig
+
+	// Streams never end by themselves, cancel them (and their upstream requests) to not wait until timeout
+	s.cancel()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*time.Duration(conf.ShutdownTimeout))
+	defer cancel()
+
+	if s.redirectServer != nil {
+		_ = s.redirectServer.Shutdown(ctx)
+	}
+
+	if err := s.Echo.Shutdown(ctx); err != nil {
+		log.Warnf("in-flight requests not finished before shutdown timeout, closing connections, %v", err)
+		_ = s.Echo.Close()
+	}
+
+	if s.isDownstreamSnapshotEnabled() {
+		s.saveDownstreamSnapshot()
+	}
+
+	if closer, ok := s.store.CacheStore.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			log.Errorf("unable to close cache store, %v", err)
+		}
+	}
+}
+
+// isDownstreamSnapshotEnabled return false with persistent cache store, downstream cache already survive restarts
+func (s *Server) isDownstreamSnapshotEnabled() bool {
+	return s.store.CoreConfig.DownstreamCacheSnapshotFile != "" && !cachestore.IsPersistent(s.store.CacheStore)
+}
+
+func (s *Server) loadDownstreamSnapshot() {
+	count, err := s.store.CacheMiddleware.LoadDownstreamSnapshot(s.store.CoreConfig.DownstreamCacheSnapshotFile)
+	if err != nil {
+		log.Warnf("unable to load downstream cache snapshot, %v", err)
+		return
+	}
+	log.Infof("%d downstream cache response(s) restored from %s", count, s.store.CoreConfig.DownstreamCacheSnapshotFile)
+}
+
+func (s *Server) saveDownstreamSnapshot() {
+	count, err := s.store.CacheMiddleware.SaveDownstreamSnapshot(s.store.CoreConfig.DownstreamCacheSnapshotFile)
+	if err != nil {
+		log.Errorf("unable to save downstream cache snapshot, %v", err)
+		return
+	}
+	log.Infof("%d downstream cache response(s) saved in %s", count, s.store.CoreConfig.DownstreamCacheSnapshotFile)
 }
 
 func (s *Server) setupEchoServer() {
@@ -64,32 +232,144 @@ func (s *Server) setupEchoServer() {
 	s.HideBanner = true
 	s.HidePort = true
 
+	// Requests inherit server context, canceled on shutdown
+	baseContext := func(net.Listener) context.Context { return s.ctx }
+	s.Server.BaseContext = baseContext
+	s.TLSServer.BaseContext = baseContext
+
 	// ----- Errors Handler -----
 	s.HTTPErrorHandler = handlers.HTTPErrorHandler
+
+	s.setupLogger()
+}
+
+// setupLogger apply level and format of config on global logger and echo logger
+func (s *Server) setupLogger() {
+	level, levelExists := logLevels[strings.ToLower(s.store.CoreConfig.LogLevel)]
+	if !levelExists {
+		level = log.INFO
+	}
+	log.SetLevel(level)
+	s.Logger.SetLevel(level)
+
+	header := textLogHeader
+	if s.store.CoreConfig.LogFormat == config.LogFormatJSON {
+		header = jsonLogHeader
+	}
+	log.SetHeader(header)
+	s.Logger.SetHeader(header)
+
+	if !levelExists {
+		log.Warnf("unknown log level %q, using info", s.store.CoreConfig.LogLevel)
+	}
+	if s.store.CoreConfig.LogFormat != config.LogFormatText && s.store.CoreConfig.LogFormat != config.LogFormatJSON {
+		log.Warnf("unknown log format %q, using %s", s.store.CoreConfig.LogFormat, config.LogFormatText)
+	}
+}
+
+// newCacheStore create store of configured cache backend. Fallback on memory store when config is invalid.
+// Offline server use memory store, persistence file and shared backend are used by running server
+func (s *Server) newCacheStore() cache.Store {
+	if s.offline {
+		return cachestore.NewMemoryStore()
+	}
+
+	store, err := cachestore.NewCacheStore(s.store.CoreConfig)
+	if err != nil {
+		log.Warnf("unable to setup cache backend, using %s. %v", config.CacheBackendMemory, err)
+		return cachestore.NewMemoryStore()
+	}
+
+	if cachestore.IsShared(store) {
+		if err := cachestore.Ping(store); err != nil {
+			log.Warnf("%s cache backend unreachable, caches are disabled until it's available. %v", s.store.CoreConfig.CacheBackend, err)
+		}
+		if s.store.CoreConfig.CachePersistenceFile != "" {
+			log.Warnf("cache persistence file ignored, caches are already kept by %s cache backend", s.store.CoreConfig.CacheBackend)
+		}
+		return store
+	}
+
+	if s.store.CoreConfig.CachePersistenceFile != "" {
+		store = s.newPersistentStore(store)
+	}
+
+	return store
+}
+
+// newPersistentStore keep downstream cache, builds history and generator results in CachePersistenceFile.
+// Fallback on store when file can't be opened
+func (s *Server) newPersistentStore(store cache.Store) cache.Store {
+	conf := s.store.CoreConfig
+
+	diskStore, err := cachestore.NewDiskStore(conf.CachePersistenceFile, int64(conf.CachePersistenceMaxSize)*1024*1024, cachestore.DefaultExpiration)
+	if err != nil {
+		log.Warnf("unable to open cache persistence file, caches will be lost on restart. %v", err)
+		return store
+	}
+
+	return cachestore.NewPersistentStore(store, diskStore,
+		coreModels.DownstreamStoreKeyPrefix,
+		coreModels.BuildCacheStoreKeyPrefix,
+		configUsecase.TileGeneratorStoreKeyPrefix,
+	)
 }
 
 func (s *Server) setupEchoMiddleware() {
 	// Recover (don't panic 😎)
 	s.Use(echoMiddleware.Recover())
 
+	// Metrics
+	if s.store.CoreConfig.EnableMetrics {
+		s.store.Metrics = metrics.NewMetrics()
+		s.Use(s.store.Metrics.Middleware())
+	}
+
+	// Request ID (X-Request-ID header), provided in request context for errors and logs
+	s.Use(echoMiddleware.RequestID())
+	s.Use(middlewares.RequestIDContext())
+
+	// Monitorable requests are logged with structured fields by request logger, other requests by echo logger
+	var requestLogger *middlewares.RequestLogger
+	loggerConfig := echoMiddleware.LoggerConfig{Skipper: echoMiddleware.DefaultSkipper}
+	if metadataRegistry, ok := s.store.Registry.(*registry.MetadataRegistry); ok {
+		requestLogger = middlewares.NewRequestLogger(metadataRegistry, s.store.CoreConfig.LogFormat == config.LogFormatJSON)
+		loggerConfig.Skipper = requestLogger.Skipper
+	}
+
 	// Log requests
-	if s.store.CoreConfig.Env != "production" {
-		s.Use(echoMiddleware.LoggerWithConfig(echoMiddleware.LoggerConfig{
-			Format: `[-] ` + colorer.Green("${method}") + ` ${uri} status:${status} latency:` + colorer.Green("${latency_human}") + ` error:"${error}"` + "\n",
-		}))
+	if s.store.CoreConfig.LogFormat != config.LogFormatJSON {
+		loggerConfig.Format = `[-] ` + colorer.Green("${method}") + ` ${uri} status:${status} latency:` + colorer.Green("${latency_human}") + ` id:${id} error:"${error}"` + "\n"
+	}
+	s.Use(echoMiddleware.LoggerWithConfig(loggerConfig))
+
+	// Log monitorable requests with structured fields
+	if requestLogger != nil {
+		s.Use(requestLogger.Middleware())
 	}
 
 	// Cache
-	s.store.CacheStore = cache.NewGoCacheStore(time.Minute*5, time.Second) // Default value, always override
-	s.store.CacheMiddleware = middlewares.NewCacheMiddleware(s.store.CacheStore,
+	s.store.CacheStore = s.newCacheStore()
+	s.store.CacheMiddleware = middlewares.NewCacheMiddleware(s.ctx, s.store.CacheStore,
 		time.Millisecond*time.Duration(s.store.CoreConfig.DownstreamCacheExpiration),
 		time.Millisecond*time.Duration(s.store.CoreConfig.UpstreamCacheExpiration),
 	) // Used as Handler wrapper in routes
 	s.Use(s.store.CacheMiddleware.DownstreamStoreMiddleware())
 
 	// CORS
+	allowOrigins := s.store.CoreConfig.CorsAllowedOrigins
 	s.Use(echoMiddleware.CORSWithConfig(echoMiddleware.CORSConfig{
-		AllowOrigins: []string{"*"},
+		AllowOrigins: allowOrigins,
 		AllowMethods: []string{echo.GET, echo.POST},
+		// Cookies and Authorization header can't be sent to every origin
+		AllowCredentials: len(allowOrigins) > 0 && !(len(allowOrigins) == 1 && allowOrigins[0] == "*"),
 	}))
+
+	// Authentication (after CORS to answer preflight requests), disabled without Auth* config
+	if authenticator := auth.NewAuthenticator(s.store.CoreConfig); authenticator != nil {
+		// Before every middleware, display token is removed from URL before logs and cache keys
+		s.Pre(authenticator.ExtractDisplayToken())
+		s.Use(authenticator.Middleware())
+		authenticator.RegisterRoutes(s.Echo)
+	}
 }
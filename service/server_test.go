@@ -1,11 +1,15 @@
 package service
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/GeertJohan/go.rice/embedded"
 	"github.com/monitoror/monitoror/cli"
 	"github.com/monitoror/monitoror/config"
+	"github.com/monitoror/monitoror/service/cachestore"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -35,3 +39,66 @@ func TestInit_Prod_WithRicebox(t *testing.T) {
 		Init(&config.Config{Env: "production"}, cli.New())
 	})
 }
+
+func TestInitOffline_Prod_WithoutRicebox(t *testing.T) {
+	delete(embedded.EmbeddedBoxes, "../ui/dist")
+	assert.NotPanics(t, func() {
+		InitOffline(&config.Config{Env: "production"}, cli.New())
+	})
+}
+
+func TestInitOffline_MemoryCache(t *testing.T) {
+	dir, err := ioutil.TempDir("", "monitoror-offline")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	// Persistence file of running server isn't opened by CLI commands
+	conf := &config.Config{Env: "develop", CachePersistenceFile: filepath.Join(dir, "cache.db")}
+	server := InitOffline(conf, cli.New())
+
+	assert.False(t, cachestore.IsPersistent(server.store.CacheStore))
+	_, err = os.Stat(conf.CachePersistenceFile)
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestServer_VerifyConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "monitoror-verify")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	validConfig := filepath.Join(dir, "valid.json")
+	_ = ioutil.WriteFile(validConfig, []byte(`{"version": "2.0", "columns": 1, "tiles": [{"type": "EMPTY"}]}`), 0644)
+	invalidConfig := filepath.Join(dir, "invalid.json")
+	_ = ioutil.WriteFile(invalidConfig, []byte(`{"version": "2.0", "columns": 0, "tiles": [{"type": "EMPTY"}]}`), 0644)
+
+	// Path restrictions of config api are not applied
+	server := InitOffline(&config.Config{Env: "develop", DisableFreeFormConfig: true, ConfigRoot: "/nowhere"}, cli.New())
+
+	assert.Len(t, server.VerifyConfig(validConfig, true).Errors, 0)
+	assert.Len(t, server.VerifyConfig(invalidConfig, false).Errors, 1)
+	assert.Len(t, server.VerifyConfig(filepath.Join(dir, "missing.json"), false).Errors, 1)
+}
+
+func TestServer_Shutdown(t *testing.T) {
+	dir, err := ioutil.TempDir("", "monitoror-shutdown")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	conf := &config.Config{Env: "develop", ShutdownTimeout: 1000, DownstreamCacheSnapshotFile: filepath.Join(dir, "downstream.snapshot")}
+	server := Init(conf, cli.New())
+
+	server.Shutdown()
+
+	// Base context of requests is canceled
+	assert.Error(t, server.ctx.Err())
+	assert.FileExists(t, conf.DownstreamCacheSnapshotFile)
+
+	// Snapshot is loaded by next server
+	assert.NotPanics(t, func() { Init(conf, cli.New()) })
+}
@@ -1,8 +1,12 @@
 package store
 
 import (
+	"context"
+
 	"github.com/monitoror/monitoror/cli"
 	coreConfig "github.com/monitoror/monitoror/config"
+	"github.com/monitoror/monitoror/service/health"
+	"github.com/monitoror/monitoror/service/metrics"
 	"github.com/monitoror/monitoror/service/middlewares"
 	"github.com/monitoror/monitoror/service/registry"
 	"github.com/monitoror/monitoror/service/router"
@@ -19,6 +23,9 @@ type (
 		// Global CoreConfig
 		CoreConfig *coreConfig.Config
 
+		// Context is the server base context, canceled on shutdown. Used by calls shared between requests
+		Context context.Context
+
 		// CacheStore for every memory persistent data
 		CacheStore cache.Store
 		// CacheMiddleware using CacheStore to return cached data
@@ -29,5 +36,11 @@ type (
 
 		// Registry used to register Tile for verify / hydrate
 		Registry registry.Registry
+
+		// Health used by readiness route, filled by monitorable manager and router
+		Health *health.Health
+
+		// Metrics exposed on /metrics, nil when disabled
+		Metrics *metrics.Metrics
 	}
 )
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/monitoror/monitoror/pkg/certificate"
+	"github.com/monitoror/monitoror/pkg/system"
+
+	"github.com/labstack/gommon/log"
+)
+
+// setupTLS return tls config of HTTPS server, nil when HTTPS isn't configured
+func (s *Server) setupTLS() (*tls.Config, error) {
+	conf := s.store.CoreConfig
+	hasFiles := conf.TLSCertFile != "" && conf.TLSKeyFile != ""
+
+	if !hasFiles && !conf.TLSSelfSigned {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if !conf.DisableHTTP2 {
+		tlsConfig.NextProtos = []string{"h2", "http/1.1"}
+	}
+
+	// Self-signed certificate without files, regenerated on every start
+	if !hasFiles {
+		certPEM, keyPEM, err := certificate.GenerateSelfSigned(s.certificateHosts())
+		if err != nil {
+			return nil, err
+		}
+
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Warn("HTTPS enabled with generated self-signed certificate, browsers will ask to trust it on every restart")
+		tlsConfig.Certificates = []tls.Certificate{cert}
+		return tlsConfig, nil
+	}
+
+	if conf.TLSSelfSigned {
+		generated, err := certificate.WriteSelfSigned(conf.TLSCertFile, conf.TLSKeyFile, s.certificateHosts())
+		if err != nil {
+			return nil, err
+		}
+		if generated {
+			log.Warnf("self-signed certificate generated in %s", conf.TLSCertFile)
+		}
+	}
+
+	reloader, err := certificate.NewReloader(conf.TLSCertFile, conf.TLSKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig.GetCertificate = reloader.GetCertificate
+
+	return tlsConfig, nil
+}
+
+// certificateHosts list hosts used by walls to reach monitoror
+func (s *Server) certificateHosts() []string {
+	hosts := []string{"localhost", "127.0.0.1", "::1", system.GetNetworkIP()}
+
+	if hostname, err := os.Hostname(); err == nil {
+		hosts = append(hosts, hostname)
+	}
+	if s.store.CoreConfig.Address != "" {
+		hosts = append(hosts, s.store.CoreConfig.Address)
+	}
+
+	return hosts
+}
+
+// startRedirectServer listen on TLSRedirectPort and redirect every request to HTTPS server
+func (s *Server) startRedirectServer() {
+	conf := s.store.CoreConfig
+	s.redirectServer = &http.Server{
+		Addr:     net.JoinHostPort(conf.Address, strconv.Itoa(conf.TLSRedirectPort)),
+		Handler:  httpsRedirectHandler(conf.Port),
+		ErrorLog: s.StdLogger,
+	}
+
+	go func() {
+		if err := s.redirectServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Errorf("unable to start HTTP to HTTPS redirect server, %v", err)
+		}
+	}()
+}
+
+func httpsRedirectHandler(httpsPort int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if hostname, _, err := net.SplitHostPort(host); err == nil {
+			host = hostname
+		}
+
+		if httpsPort != 443 {
+			host = net.JoinHostPort(strings.Trim(host, "[]"), strconv.Itoa(httpsPort))
+		} else if strings.Contains(host, ":") && !strings.HasPrefix(host, "[") {
+			host = "[" + host + "]"
+		}
+
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	})
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/monitoror/monitoror/cli"
+	"github.com/monitoror/monitoror/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer_SetupTLS_Disabled(t *testing.T) {
+	server := InitOffline(&config.Config{Env: "develop"}, cli.New())
+
+	tlsConfig, err := server.setupTLS()
+	assert.NoError(t, err)
+	assert.Nil(t, tlsConfig)
+}
+
+func TestServer_SetupTLS_SelfSigned(t *testing.T) {
+	server := InitOffline(&config.Config{Env: "develop", TLSSelfSigned: true, DisableHTTP2: true}, cli.New())
+
+	tlsConfig, err := server.setupTLS()
+	if assert.NoError(t, err) {
+		assert.Len(t, tlsConfig.Certificates, 1)
+		assert.Empty(t, tlsConfig.NextProtos)
+	}
+}
+
+func TestServer_SetupTLS_Files(t *testing.T) {
+	dir, err := ioutil.TempDir("", "monitoror-tls")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	conf := &config.Config{
+		Env:         "develop",
+		TLSCertFile: filepath.Join(dir, "cert.pem"),
+		TLSKeyFile:  filepath.Join(dir, "key.pem"),
+	}
+
+	// Missing files
+	_, err = InitOffline(conf, cli.New()).setupTLS()
+	assert.Error(t, err)
+
+	// Missing files generated
+	conf.TLSSelfSigned = true
+	tlsConfig, err := InitOffline(conf, cli.New()).setupTLS()
+	if assert.NoError(t, err) {
+		assert.FileExists(t, conf.TLSCertFile)
+		assert.FileExists(t, conf.TLSKeyFile)
+		assert.Equal(t, []string{"h2", "http/1.1"}, tlsConfig.NextProtos)
+
+		cert, err := tlsConfig.GetCertificate(nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, cert)
+	}
+}
+
+func TestHTTPSRedirectHandler(t *testing.T) {
+	for _, testcase := range []struct {
+		port     int
+		host     string
+		expected string
+	}{
+		{port: 443, host: "monitoror.example.com", expected: "https://monitoror.example.com/?configName=lobby"},
+		{port: 443, host: "monitoror.example.com:80", expected: "https://monitoror.example.com/?configName=lobby"},
+		{port: 8443, host: "192.168.1.10:8080", expected: "https://192.168.1.10:8443/?configName=lobby"},
+		{port: 8443, host: "[::1]:8080", expected: "https://[::1]:8443/?configName=lobby"},
+		{port: 443, host: "[::1]:8080", expected: "https://[::1]/?configName=lobby"},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/?configName=lobby", nil)
+		req.Host = testcase.host
+		res := httptest.NewRecorder()
+
+		httpsRedirectHandler(testcase.port).ServeHTTP(res, req)
+
+		assert.Equal(t, http.StatusMovedPermanently, res.Code)
+		assert.Equal(t, testcase.expected, res.Header().Get("Location"))
+	}
+}
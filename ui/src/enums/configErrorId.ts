@@ -2,17 +2,22 @@ export enum ConfigErrorId {
   CannotBeFetched = 'ERROR_CONFIG_CANNOT_BE_FETCHED',
   ConfigNotFound = 'ERROR_CONFIG_NOT_FOUND',
   ConfigVersionTooOld = 'ERROR_FIELD_TYPE_MISMATCH',
+  FieldNotSupportedInThisVersion = 'ERROR_FIELD_NOT_SUPPORTED_IN_THIS_VERSION',
   FieldTypeMismatch = 'ERROR_FIELD_TYPE_MISMATCH',
+  IncludeCycle = 'ERROR_INCLUDE_CYCLE',
   InvalidEscapedCharacter = 'ERROR_INVALID_ESCAPED_CHARACTER',
   InvalidFieldValue = 'ERROR_INVALID_FIELD_VALUE',
   MissingPathOrUrl = 'ERROR_MISSING_PATH_OR_URL',
   MissingRequiredField = 'ERROR_MISSING_REQUIRED_FIELD',
+  UnauthorizedConfigPath = 'ERROR_UNAUTHORIZED_CONFIG_PATH',
   UnauthorizedField = 'ERROR_UNAUTHORIZED_FIELD',
   UnauthorizedSubtileType = 'ERROR_UNAUTHORIZED_SUBTILE_TYPE',
   UnableToHydrate = 'ERROR_UNABLE_TO_HYDRATE',
   UnableToParseConfig = 'ERROR_UNABLE_TO_PARSE_CONFIG',
+  UndefinedVariable = 'ERROR_UNDEFINED_VARIABLE',
   UnexpectedError = 'ERROR_UNEXPECTED',
   UnknownField = 'ERROR_UNKNOWN_FIELD',
+  UnknownTemplate = 'ERROR_UNKNOWN_TEMPLATE',
   UnknownTileType = 'ERROR_UNKNOWN_TILE_TYPE',
   UnknownVariant = 'ERROR_UNKNOWN_VARIANT',
   UnsupportedVersion = 'ERROR_UNSUPPORTED_VERSION',
@@ -5,6 +5,7 @@ const CONFIG_NON_VERIFY_ERRORS = [
   ConfigErrorId.CannotBeFetched,
   ConfigErrorId.ConfigNotFound,
   ConfigErrorId.MissingPathOrUrl,
+  ConfigErrorId.UnauthorizedConfigPath,
 ]
 
 export default function hasConfigVerifyErrors(errors: ConfigError[]): boolean {
@@ -1,3 +1,4 @@
+import PageConfig from '@/interfaces/pageConfig'
 import TileConfig from '@/interfaces/tileConfig'
 
 export default interface Config {
@@ -5,4 +6,5 @@ export default interface Config {
   columns: number,
   zoom?: number,
   tiles: TileConfig[],
+  pages?: PageConfig[],
 }
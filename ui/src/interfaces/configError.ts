@@ -9,5 +9,9 @@ export default interface ConfigError {
     value?: string,
     fieldName?: string,
     expected?: string,
+    line?: number,
+    column?: number,
+    pointer?: string,
+    source?: string,
   },
 }
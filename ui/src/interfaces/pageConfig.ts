@@ -0,0 +1,8 @@
+import TileConfig from '@/interfaces/tileConfig'
+
+export default interface PageConfig {
+  columns: number,
+  zoom?: number,
+  duration: number,
+  tiles: TileConfig[],
+}
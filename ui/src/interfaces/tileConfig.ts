@@ -10,4 +10,5 @@ export default interface TileConfig {
   url?: string,
   tiles?: TileConfig[],
   initialMaxDelay?: number,
+  refreshInterval?: number,
 }
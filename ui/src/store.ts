@@ -19,6 +19,7 @@ import Config from '@/interfaces/config'
 import ConfigBag from '@/interfaces/configBag'
 import ConfigError from '@/interfaces/configError'
 import Info from '@/interfaces/info'
+import PageConfig from '@/interfaces/pageConfig'
 import TaskOptions from '@/interfaces/taskOptions'
 import TileConfig from '@/interfaces/tileConfig'
 import TileState from '@/interfaces/tileState'
@@ -27,6 +28,7 @@ Vue.use(Vuex)
 
 const API_BASE_PATH = '/api/v1'
 const INFO_URL = '/info'
+const DEFAULT_REFRESH_INTERVAL = 10 // In second
 
 export interface RootState {
   appVersion: string | undefined,
@@ -34,6 +36,9 @@ export interface RootState {
   columns: number,
   zoom: number,
   tiles: TileConfig[],
+  pages: PageConfig[],
+  currentPageIndex: number,
+  currentPageDate: Date,
   tilesState: { [key: string]: TileState },
   tasks: Task[],
   errors: ConfigError[],
@@ -42,6 +47,12 @@ export interface RootState {
   lastRefreshDate: Date,
 }
 
+function showPage(state: RootState, page: PageConfig) {
+  state.columns = page.columns
+  state.zoom = page.zoom !== undefined ? page.zoom : 1
+  state.tiles = page.tiles
+}
+
 const store: StoreOptions<RootState> = {
   state: {
     appVersion: undefined,
@@ -49,6 +60,9 @@ const store: StoreOptions<RootState> = {
     columns: 4,
     zoom: 1,
     tiles: [],
+    pages: [],
+    currentPageIndex: 0,
+    currentPageDate: new Date(),
     tilesState: {},
     tasks: [],
     errors: [],
@@ -75,6 +89,11 @@ const store: StoreOptions<RootState> = {
 
       return configUrl
     },
+    configName(): string | undefined {
+      const configName = getQueryParamValue('configName')
+
+      return configName
+    },
     proxyfiedConfigUrl(state, getters): string | undefined {
       const configProxyUrl = `${getters.apiBaseUrl}${API_BASE_PATH}/config`
 
@@ -85,6 +104,10 @@ const store: StoreOptions<RootState> = {
       if (getters.configPath !== undefined) {
         return `${configProxyUrl}?path=${getters.configPath}`
       }
+
+      if (getters.configName !== undefined) {
+        return `${configProxyUrl}?name=${getters.configName}`
+      }
     },
     theme(): Theme {
       let theme = Theme.Default
@@ -96,9 +119,17 @@ const store: StoreOptions<RootState> = {
 
       return theme
     },
-    tileStateKeys(state): string[] {
+    allTiles(state): TileConfig[] {
+      // Tiles of every pages are kept refreshed, to avoid loading on page change
+      if (state.pages.length > 0) {
+        return state.pages.reduce((tiles: TileConfig[], page) => tiles.concat(page.tiles), [])
+      }
+
+      return state.tiles
+    },
+    tileStateKeys(state, getters): string[] {
       const tileStateKeys: string[] = []
-      state.tiles.forEach((tile: TileConfig) => {
+      getters.allTiles.forEach((tile: TileConfig) => {
         tileStateKeys.push(tile.stateKey)
 
         // Add group subTiles stateKeys
@@ -163,12 +194,28 @@ const store: StoreOptions<RootState> = {
     },
     setConfig(state, payload: Config): void {
       state.configVersion = payload.version
+
+      if (payload.pages !== undefined) {
+        state.pages = payload.pages
+        if (state.currentPageIndex >= state.pages.length) {
+          state.currentPageIndex = 0
+        }
+        showPage(state, state.pages[state.currentPageIndex])
+        return
+      }
+
+      state.pages = []
       state.columns = payload.columns
       if (payload.zoom !== undefined) {
         state.zoom = payload.zoom
       }
       state.tiles = payload.tiles
     },
+    setCurrentPageIndex(state, payload: number): void {
+      state.currentPageIndex = payload
+      state.currentPageDate = new Date()
+      showPage(state, state.pages[payload])
+    },
     setErrors(state, payload: ConfigError[]): void {
       state.errors = payload
     },
@@ -217,7 +264,7 @@ const store: StoreOptions<RootState> = {
       if (getters.proxyfiedConfigUrl === undefined) {
         const configPathOrUrlIsMissing: ConfigError = {
           id: ConfigErrorId.MissingPathOrUrl,
-          message: 'configPath or configUrl query param is missing',
+          message: 'configName, configPath or configUrl query param is missing',
           data: {},
         }
 
@@ -262,7 +309,13 @@ const store: StoreOptions<RootState> = {
             commit('setErrors', [])
 
             if (configBag.config !== undefined) {
-              configBag.config.tiles = configBag.config.tiles.map((tile) => hydrateTile(tile))
+              if (configBag.config.pages !== undefined) {
+                configBag.config.pages.forEach((page) => {
+                  page.tiles = page.tiles.map((tile) => hydrateTile(tile))
+                })
+              } else {
+                configBag.config.tiles = configBag.config.tiles.map((tile) => hydrateTile(tile))
+              }
               commit('setConfig', configBag.config)
             }
           }
@@ -279,7 +332,7 @@ const store: StoreOptions<RootState> = {
             await dispatch('refreshGroup', groupTile)
           }
         },
-        interval: 10 * TaskInterval.Second,
+        interval: (tile.refreshInterval || DEFAULT_REFRESH_INTERVAL) * TaskInterval.Second,
         initialDelay: Math.random() * (tile.initialMaxDelay || 0),
       })
     },
@@ -411,6 +464,23 @@ const store: StoreOptions<RootState> = {
         },
       })
 
+      // Show next page when current page duration is over
+      dispatch('addTask', {
+        id: 'rotatePage',
+        type: TaskType.Root,
+        executor: async () => {
+          if (state.pages.length < 2) {
+            return
+          }
+
+          const currentPage = state.pages[state.currentPageIndex]
+          if (now() - state.currentPageDate.getTime() >= currentPage.duration * TaskInterval.Second) {
+            commit('setCurrentPageIndex', (state.currentPageIndex + 1) % state.pages.length)
+          }
+        },
+        interval: 1 * TaskInterval.Second,
+      })
+
       // Update "now" each second
       dispatch('addTask', {
         id: 'updateNow',